@@ -45,6 +45,10 @@ x86_64-w64-mingw32-windres -o main-res.syso $HACKED_BASE/_build/win_temp/hacked.
 echo "Building executables..."
 go build -ldflags "-X main.version=$VERSION" -a -o $HACKED_BASE/_build/linux/$FOLDER_NAME/hacked .
 GOOS=windows GOARCH=amd64 CGO_ENABLED=1 CXX=x86_64-w64-mingw32-g++ CC=x86_64-w64-mingw32-gcc go build -ldflags "-X main.version=$VERSION -H=windowsgui" -a -o $HACKED_BASE/_build/win/$FOLDER_NAME/hacked.exe .
+go build -a -o $HACKED_BASE/_build/linux/$FOLDER_NAME/hacked-patch ./cmd/hacked-patch
+GOOS=windows GOARCH=amd64 go build -a -o $HACKED_BASE/_build/win/$FOLDER_NAME/hacked-patch.exe ./cmd/hacked-patch
+go build -a -o $HACKED_BASE/_build/linux/$FOLDER_NAME/hacked-res ./cmd/hacked-res
+GOOS=windows GOARCH=amd64 go build -a -o $HACKED_BASE/_build/win/$FOLDER_NAME/hacked-res.exe ./cmd/hacked-res
 
 
 echo "Copying distribution resources..."
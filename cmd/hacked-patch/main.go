@@ -0,0 +1,114 @@
+// Command hacked-patch creates and applies mod patches without the graphical editor.
+//
+//	hacked-patch create -base <data dir> [-base <dir> ...] -mod <mod dir> -out <patch file>
+//	hacked-patch verify -base <data dir> [-base <dir> ...] -patch <patch file>
+//	hacked-patch apply  -base <data dir> [-base <dir> ...] -patch <patch file> -out <output dir>
+//
+// Several base directories can be specified. Should they contain the same file, the one of the last is used.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/inkyblackness/hacked/ss1/world/patch"
+)
+
+type dirList []string
+
+func (list *dirList) String() string {
+	return strings.Join(*list, ",")
+}
+
+func (list *dirList) Set(value string) error {
+	*list = append(*list, value)
+	return nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "create":
+		err = create(os.Args[2:])
+	case "verify":
+		err = verify(os.Args[2:])
+	case "apply":
+		err = apply(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s failed: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s create|verify|apply [options]\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Use '%s <command> -h' for the options of a command.\n", os.Args[0])
+}
+
+func create(args []string) error {
+	flags := flag.NewFlagSet("create", flag.ExitOnError)
+	var baseDirs dirList
+	flags.Var(&baseDirs, "base", "directory with the data files the patch is based on. Can be repeated.")
+	modDir := flags.String("mod", "", "directory of the mod")
+	out := flags.String("out", "", "filename of the patch to create")
+	_ = flags.Parse(args)
+	if (len(baseDirs) == 0) || (len(*modDir) == 0) || (len(*out) == 0) {
+		flags.Usage()
+		os.Exit(2)
+	}
+	created, err := patch.CreateFromDirectories(baseDirs, *modDir)
+	if err != nil {
+		return err
+	}
+	return patch.SaveFile(*out, created)
+}
+
+func verify(args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	var baseDirs dirList
+	flags.Var(&baseDirs, "base", "directory with the data files to verify. Can be repeated.")
+	patchFile := flags.String("patch", "", "filename of the patch")
+	_ = flags.Parse(args)
+	if (len(baseDirs) == 0) || (len(*patchFile) == 0) {
+		flags.Usage()
+		os.Exit(2)
+	}
+	loaded, err := patch.LoadFile(*patchFile)
+	if err != nil {
+		return err
+	}
+	err = patch.Verify(loaded, baseDirs)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Patch with %d file(s) can be applied.\n", len(loaded.Files))
+	return nil
+}
+
+func apply(args []string) error {
+	flags := flag.NewFlagSet("apply", flag.ExitOnError)
+	var baseDirs dirList
+	flags.Var(&baseDirs, "base", "directory with the data files to patch. Can be repeated.")
+	patchFile := flags.String("patch", "", "filename of the patch")
+	out := flags.String("out", "", "directory to write the patched files into")
+	_ = flags.Parse(args)
+	if (len(baseDirs) == 0) || (len(*patchFile) == 0) || (len(*out) == 0) {
+		flags.Usage()
+		os.Exit(2)
+	}
+	loaded, err := patch.LoadFile(*patchFile)
+	if err != nil {
+		return err
+	}
+	return patch.ApplyToDirectory(loaded, baseDirs, *out)
+}
@@ -0,0 +1,125 @@
+// Command hacked-res inspects the raw content of resource files without the graphical editor.
+//
+//	hacked-res list <resource file>
+//	hacked-res dump -id <resource ID> [-block <index>] [-hex] [-out <file>] <resource file>
+//
+// The list shows every resource as it is stored in the file, together with what the resource is known as.
+// The dump writes the uncompressed data of one block, either to the given file or to the standard output.
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/inkyblackness/hacked/ss1/edit/inspect"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/resource/lgres"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "list":
+		err = list(os.Args[2:])
+	case "dump":
+		err = dump(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s failed: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s list|dump [options] <resource file>\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Use '%s <command> -h' for the options of a command.\n", os.Args[0])
+}
+
+func openReader(filename string) (*os.File, *lgres.Reader, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader, err := lgres.ReaderFrom(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, err
+	}
+	return file, reader, nil
+}
+
+func list(args []string) error {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	file, reader, err := openReader(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	out := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(out, "ID\tType\tFlags\tBlocks\tRaw\tStored\tKnown as\tRemarks")
+	for _, entry := range inspect.Entries(reader) {
+		var remarks []string
+		if entry.Err != nil {
+			remarks = append(remarks, entry.Err.Error())
+		}
+		remarks = append(remarks, entry.Deviations()...)
+		fmt.Fprintf(out, "0x%v\t%v\t%s\t%d\t%d\t%d\t%s\t%s\n",
+			entry.ID, entry.Properties.ContentType, entry.Flags(), entry.BlockCount,
+			entry.UnpackedLength, entry.PackedLength, entry.Classification(), strings.Join(remarks, "; "))
+	}
+	return out.Flush()
+}
+
+func dump(args []string) error {
+	flags := flag.NewFlagSet("dump", flag.ExitOnError)
+	idText := flags.String("id", "", "identifier of the resource, decimal or hexadecimal with 0x prefix")
+	block := flags.Int("block", 0, "index of the block")
+	asHex := flags.Bool("hex", false, "write a hex dump instead of the raw data")
+	out := flags.String("out", "", "filename to write the data to. Standard output if not specified.")
+	_ = flags.Parse(args)
+	if (len(*idText) == 0) || (flags.NArg() != 1) {
+		flags.Usage()
+		os.Exit(2)
+	}
+	idValue, err := strconv.ParseUint(*idText, 0, 16)
+	if err != nil {
+		return fmt.Errorf("invalid resource ID: %v", err)
+	}
+	file, reader, err := openReader(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := inspect.Block(reader, resource.ID(idValue), *block)
+	if err != nil {
+		return err
+	}
+	if *asHex {
+		data = []byte(hex.Dump(data))
+	}
+	if len(*out) == 0 {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return ioutil.WriteFile(*out, data, 0644)
+}
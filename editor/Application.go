@@ -8,18 +8,35 @@ import (
 	"github.com/inkyblackness/hacked/editor/about"
 	"github.com/inkyblackness/hacked/editor/animations"
 	"github.com/inkyblackness/hacked/editor/archives"
+	"github.com/inkyblackness/hacked/editor/artworks"
 	"github.com/inkyblackness/hacked/editor/bitmaps"
+	"github.com/inkyblackness/hacked/editor/chains"
 	"github.com/inkyblackness/hacked/editor/event"
+	"github.com/inkyblackness/hacked/editor/external"
 	"github.com/inkyblackness/hacked/editor/graphics"
+	"github.com/inkyblackness/hacked/editor/hexedit"
+	"github.com/inkyblackness/hacked/editor/inspector"
 	"github.com/inkyblackness/hacked/editor/levels"
+	"github.com/inkyblackness/hacked/editor/localizations"
 	"github.com/inkyblackness/hacked/editor/messages"
+	"github.com/inkyblackness/hacked/editor/mfd"
+	"github.com/inkyblackness/hacked/editor/movies"
 	"github.com/inkyblackness/hacked/editor/objects"
+	"github.com/inkyblackness/hacked/editor/palettes"
 	"github.com/inkyblackness/hacked/editor/project"
+	"github.com/inkyblackness/hacked/editor/render"
+	"github.com/inkyblackness/hacked/editor/screens"
+	"github.com/inkyblackness/hacked/editor/search"
+	"github.com/inkyblackness/hacked/editor/sounds"
+	"github.com/inkyblackness/hacked/editor/targets"
 	"github.com/inkyblackness/hacked/editor/texts"
 	"github.com/inkyblackness/hacked/editor/textures"
 	"github.com/inkyblackness/hacked/ss1/content/archive"
 	"github.com/inkyblackness/hacked/ss1/content/archive/level"
+	"github.com/inkyblackness/hacked/ss1/content/audio/voc"
 	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/content/descriptions"
+	"github.com/inkyblackness/hacked/ss1/content/font"
 	"github.com/inkyblackness/hacked/ss1/content/movie"
 	"github.com/inkyblackness/hacked/ss1/content/text"
 	"github.com/inkyblackness/hacked/ss1/edit"
@@ -61,7 +78,7 @@ type Application struct {
 
 	cmdStack       *cmd.Stack
 	mod            *world.Mod
-	cp             text.Codepage
+	codepages      text.Codepages
 	textLineCache  *text.Cache
 	textPageCache  *text.Cache
 	messagesCache  *text.ElectronicMessageCache
@@ -69,26 +86,44 @@ type Application struct {
 	textureCache   *graphics.TextureCache
 	animationCache *bitmap.AnimationCache
 	movieCache     *movie.Cache
+	soundCache     *voc.Cache
+	fontCache      *font.Cache
+	interpreters   descriptions.Interpreters
 
 	mapDisplay *levels.MapDisplay
 
 	levels [archive.MaxLevels]*level.Level
 
-	projectView      *project.View
-	archiveView      *archives.View
-	levelControlView *levels.ControlView
-	levelTilesView   *levels.TilesView
-	levelObjectsView *levels.ObjectsView
-	messagesView     *messages.View
-	textsView        *texts.View
-	bitmapsView      *bitmaps.View
-	texturesView     *textures.View
-	animationsView   *animations.View
-	objectsView      *objects.View
-	aboutView        *about.View
-	licensesView     *about.LicensesView
-
-	modalState gui.ModalStateWrapper
+	projectView       *project.View
+	archiveView       *archives.View
+	levelControlView  *levels.ControlView
+	levelTilesView    *levels.TilesView
+	levelObjectsView  *levels.ObjectsView
+	messagesView      *messages.View
+	messageChainsView *chains.View
+	targetCheckView   *targets.View
+	inspectorView     *inspector.View
+	hexEditorView     *hexedit.View
+	moviesView        *movies.View
+	soundsView        *sounds.View
+	textsView         *texts.View
+	bitmapsView       *bitmaps.View
+	texturesView      *textures.View
+	animationsView    *animations.View
+	objectsView       *objects.View
+	palettesView      *palettes.View
+	artworksView      *artworks.View
+	screensView       *screens.View
+	mfdArtView        *mfd.View
+	localizationsView *localizations.View
+	textSearchView    *search.View
+	aboutView         *about.View
+	licensesView      *about.LicensesView
+
+	modalState  gui.ModalStateWrapper
+	audioPlayer *external.AudioPlayer
+
+	fontGlyphsOutdated bool
 
 	failureMessage string
 	failurePending bool
@@ -117,6 +152,9 @@ func (app *Application) InitializeWindow(window opengl.Window) (err error) {
 }
 
 func (app *Application) onWindowClosed() {
+	if app.audioPlayer != nil {
+		app.audioPlayer.Close()
+	}
 	if app.guiContext != nil {
 		app.guiContext.Destroy()
 		app.guiContext = nil
@@ -144,6 +182,7 @@ func (app *Application) initWindowCallbacks() {
 
 func (app *Application) render() {
 	app.dispatchEvents()
+	app.updateFontGlyphs()
 	app.guiContext.NewFrame()
 
 	app.gl.Clear(opengl.COLOR_BUFFER_BIT)
@@ -151,17 +190,29 @@ func (app *Application) render() {
 	app.renderMainMenu()
 
 	app.projectView.Render()
+	app.targetCheckView.Render()
+	app.inspectorView.Render()
+	app.hexEditorView.Render()
 	app.archiveView.Render()
 	activeLevel := app.levels[app.levelControlView.SelectedLevel()]
 	app.levelControlView.Render(activeLevel)
 	app.levelTilesView.Render(activeLevel)
 	app.levelObjectsView.Render(activeLevel)
 	app.messagesView.Render()
+	app.messageChainsView.Render()
+	app.moviesView.Render()
+	app.soundsView.Render()
 	app.textsView.Render()
 	app.bitmapsView.Render()
 	app.texturesView.Render()
 	app.animationsView.Render()
 	app.objectsView.Render()
+	app.palettesView.Render()
+	app.artworksView.Render()
+	app.screensView.Render()
+	app.mfdArtView.Render()
+	app.localizationsView.Render()
+	app.textSearchView.Render()
 
 	paletteTexture, _ := app.paletteCache.Palette(0)
 	app.mapDisplay.Render(app.mod.ObjectProperties(), activeLevel,
@@ -187,8 +238,9 @@ func (app *Application) initOpenGL() {
 func (app *Application) initGui() (err error) {
 	app.initGuiSizes()
 	param := gui.ContextParameters{
-		FontFile: app.FontFile,
-		FontSize: app.FontSize,
+		FontFile:   app.FontFile,
+		FontSize:   app.FontSize,
+		FontGlyphs: codepageGlyphs(),
 	}
 	app.guiContext, err = gui.NewContext(app.window, param)
 	if err != nil {
@@ -209,15 +261,23 @@ func (app *Application) gameTexture(index int) (*graphics.BitmapTexture, error)
 }
 
 func (app *Application) bitmapTextureForUI(textureID imgui.TextureID) (palette uint32, texture uint32) {
-	paletteTexture, _ := app.paletteCache.Palette(0)
-	if paletteTexture == nil {
-		return 0, 0
-	}
-
 	lang := resource.Language((textureID >> 32) & 0xFF)
 	resourceID := resource.ID((textureID >> 16) & 0xFFFF)
 	blockIndex := int(textureID & 0xFFFF)
 	key := resource.KeyOf(resourceID, lang, blockIndex)
+
+	var paletteTexture *graphics.PaletteTexture
+	switch paletteID := render.PaletteIDFromTextureID(textureID); paletteID {
+	case 0:
+		paletteTexture, _ = app.paletteCache.Palette(0)
+	case render.PrivatePalette:
+		paletteTexture, _ = app.paletteCache.BitmapPalette(key)
+	default:
+		paletteTexture, _ = app.paletteCache.PaletteOf(resource.KeyOf(paletteID, lang, 0))
+	}
+	if paletteTexture == nil {
+		return 0, 0
+	}
 	tex, err := app.textureCache.Texture(key)
 	if err != nil {
 		return 0, 0
@@ -364,6 +424,42 @@ func (app *Application) initGuiSizes() {
 	app.FontSize *= app.GuiScale
 }
 
+// codepageGlyphs returns the characters of all named codepages, so that any text can be displayed.
+func codepageGlyphs() []rune {
+	var glyphs []rune
+	for _, name := range text.CodepageNames() {
+		cp, _ := text.CodepageByName(name)
+		glyphs = append(glyphs, text.CodepageRunes(cp)...)
+	}
+	return glyphs
+}
+
+// customCodepageGlyphs returns the characters of the codepages the mod loaded from mapping tables.
+func (app *Application) customCodepageGlyphs() []rune {
+	var glyphs []rune
+	for _, assignment := range app.mod.CodepageAssignments() {
+		if assignment.Table == nil {
+			continue
+		}
+		cp, err := assignment.Codepage()
+		if err == nil {
+			glyphs = append(glyphs, text.CodepageRunes(cp)...)
+		}
+	}
+	return glyphs
+}
+
+func (app *Application) updateFontGlyphs() {
+	if !app.fontGlyphsOutdated {
+		return
+	}
+	app.fontGlyphsOutdated = false
+	err := app.guiContext.AddFontGlyphs(app.customCodepageGlyphs())
+	if err != nil {
+		app.onFailure("Font", "Could not provide the characters of the codepages.", err)
+	}
+}
+
 func (app *Application) initGuiStyle() {
 	if len(app.FontFile) == 0 {
 		imgui.CurrentIO().SetFontGlobalScale(app.GuiScale)
@@ -418,11 +514,13 @@ func (app *Application) initSignalling() {
 func (app *Application) initModel() {
 	app.mod = world.NewMod(app.resourcesChanged, app.modReset)
 
-	app.cp = text.DefaultCodepage()
-	app.textLineCache = text.NewLineCache(app.cp, app.mod)
-	app.textPageCache = text.NewPageCache(app.cp, app.mod)
-	app.messagesCache = text.NewElectronicMessageCache(app.cp, app.mod)
+	app.codepages = app.mod.Codepages()
+	app.textLineCache = text.NewLineCache(app.codepages, app.mod)
+	app.textPageCache = text.NewPageCache(app.codepages, app.mod)
+	app.messagesCache = text.NewElectronicMessageCache(app.codepages, app.mod)
 	app.movieCache = movie.NewCache(app.mod)
+	app.soundCache = voc.NewCache(app.mod)
+	app.fontCache = font.NewCache(app.mod)
 
 	for i := 0; i < archive.MaxLevels; i++ {
 		app.levels[i] = level.NewLevel(ids.LevelResourcesStart, i, app.mod)
@@ -431,6 +529,7 @@ func (app *Application) initModel() {
 	app.paletteCache = graphics.NewPaletteCache(app.gl, app.mod)
 	app.textureCache = graphics.NewTextureCache(app.gl, app.mod)
 	app.animationCache = bitmap.NewAnimationCache(app.mod)
+	app.interpreters = descriptions.Builtin()
 }
 
 func (app *Application) resourcesChanged(modifiedIDs []resource.ID, failedIDs []resource.ID) {
@@ -438,12 +537,15 @@ func (app *Application) resourcesChanged(modifiedIDs []resource.ID, failedIDs []
 	app.textPageCache.InvalidateResources(modifiedIDs)
 	app.messagesCache.InvalidateResources(modifiedIDs)
 	app.movieCache.InvalidateResources(modifiedIDs)
+	app.soundCache.InvalidateResources(modifiedIDs)
+	app.fontCache.InvalidateResources(modifiedIDs)
 	for _, lvl := range app.levels {
 		lvl.InvalidateResources(modifiedIDs)
 	}
 	app.paletteCache.InvalidateResources(modifiedIDs)
 	app.textureCache.InvalidateResources(modifiedIDs)
 	app.animationCache.InvalidateResources(modifiedIDs)
+	app.fontGlyphsOutdated = true
 }
 
 func (app *Application) modReset() {
@@ -453,22 +555,39 @@ func (app *Application) modReset() {
 // nolint: lll
 func (app *Application) initView() {
 	textViewer := media.NewTextViewerService(app.textLineCache, app.textPageCache, app.mod)
-	textSetter := media.NewTextSetterService(app.cp)
+	textSetter := media.NewTextSetterService(app.codepages)
 	audioViewer := media.NewAudioViewerService(app.movieCache, app.mod)
 	audioSetter := media.NewAudioSetterService()
 	augmentedTextService := undoable.NewAugmentedTextService(edit.NewAugmentedTextService(textViewer, textSetter, audioViewer, audioSetter), app)
+	audioService := undoable.NewAudioService(audioViewer, audioSetter, app)
+	app.audioPlayer = external.NewAudioPlayer()
 
-	app.projectView = project.NewView(app.mod, &app.modalState, app.GuiScale, app)
+	app.projectView = project.NewView(app.mod, app.levels[:], &app.interpreters, &app.modalState, app.GuiScale, app)
+	app.targetCheckView = targets.NewTargetCheckView(app.mod, app.levels[:], app.GuiScale, app)
 	app.archiveView = archives.NewArchiveView(app.mod, app.GuiScale, app)
 	app.levelControlView = levels.NewControlView(app.mod, app.GuiScale, app.textLineCache, app.textureCache, app, &app.eventQueue, app.eventDispatcher)
 	app.levelTilesView = levels.NewTilesView(app.mod, app.GuiScale, app.textLineCache, app.textureCache, app, &app.eventQueue, app.eventDispatcher)
-	app.levelObjectsView = levels.NewObjectsView(app.mod, app.GuiScale, app.textLineCache, app.textureCache, app, &app.eventQueue, app.eventDispatcher)
-	app.messagesView = messages.NewMessagesView(app.mod, app.messagesCache, app.cp, app.movieCache, app.textureCache, &app.modalState, app.clipboard, app.GuiScale, app)
-	app.textsView = texts.NewTextsView(augmentedTextService, &app.modalState, app.clipboard, app.GuiScale)
-	app.bitmapsView = bitmaps.NewBitmapsView(app.mod, app.textureCache, app.paletteCache, &app.modalState, app.clipboard, app.GuiScale, app)
-	app.texturesView = textures.NewTexturesView(app.mod, app.textLineCache, app.cp, app.textureCache, app.paletteCache, &app.modalState, app.clipboard, app.GuiScale, app)
+	app.levelObjectsView = levels.NewObjectsView(app.mod, &app.interpreters, app.GuiScale, app.textLineCache, app.textureCache, app, &app.eventQueue, app.eventDispatcher)
+	app.messagesView = messages.NewMessagesView(app.mod, app.messagesCache, app.codepages, audioService, app.textureCache,
+		render.NewTextPreview(app.gl, app.mod, app.fontCache, app.paletteCache, app.GuiScale), &app.modalState, app.clipboard, app.audioPlayer, app.GuiScale, app)
+	app.messageChainsView = chains.NewMessageChainsView(app.mod, &app.interpreters, app.messagesCache, app.levels[:],
+		app.messagesView.ShowMessage, app.showLevelObject, app.GuiScale)
+	app.hexEditorView = hexedit.NewHexEditorView(app.mod, app.GuiScale, app)
+	app.inspectorView = inspector.NewResourceInspectorView(app.mod, app.showResource, app.hexEditorView.ShowBlock, app.GuiScale)
+	app.moviesView = movies.NewMoviesView(app.mod, app.codepages, app.movieCache, &app.modalState, app.clipboard, app.GuiScale, app)
+	app.soundsView = sounds.NewSoundEffectsView(app.mod, app.soundCache, &app.modalState, app.audioPlayer, app.GuiScale, app)
+	app.textsView = texts.NewTextsView(app.mod, augmentedTextService, app.codepages,
+		render.NewTextPreview(app.gl, app.mod, app.fontCache, app.paletteCache, app.GuiScale), &app.modalState, app.clipboard, app.audioPlayer, app.GuiScale)
+	app.bitmapsView = bitmaps.NewBitmapsView(app.mod, app.gl, app.textureCache, app.paletteCache, &app.modalState, app.clipboard, app.GuiScale, app)
+	app.texturesView = textures.NewTexturesView(app.mod, app.gl, app.textLineCache, app.codepages, app.textureCache, app.paletteCache, &app.modalState, app.clipboard, app.GuiScale, app)
 	app.animationsView = animations.NewAnimationsView(app.mod, app.textureCache, app.paletteCache, app.animationCache, &app.modalState, app.GuiScale, app)
-	app.objectsView = objects.NewView(app.mod, app.textLineCache, app.cp, app.textureCache, app.paletteCache, &app.modalState, app.clipboard, app.GuiScale, app)
+	app.objectsView = objects.NewView(app.mod, app.gl, &app.interpreters, app.textLineCache, app.codepages, app.textureCache, app.paletteCache, &app.modalState, app.clipboard, app.GuiScale, app)
+	app.palettesView = palettes.NewPalettesView(app.mod, app.paletteCache, app.GuiScale, app)
+	app.artworksView = artworks.NewArtworksView(app.mod, app.paletteCache, &app.modalState, app.GuiScale, app)
+	app.screensView = screens.NewScreensView(app.mod, app.gl, app.textureCache, app.paletteCache, &app.modalState, app.GuiScale, app)
+	app.mfdArtView = mfd.NewMfdArtView(app.mod, app.gl, app.textureCache, app.paletteCache, &app.modalState, app.GuiScale, app)
+	app.localizationsView = localizations.NewLocalizationsView(app.mod, app.codepages, &app.modalState, app.GuiScale, app)
+	app.textSearchView = search.NewTextSearchView(app.mod, app.codepages, app.showResource, app.GuiScale, app)
 	app.aboutView = about.NewView(app.clipboard, app.GuiScale, app.Version)
 	app.licensesView = about.NewLicensesView(app.GuiScale)
 
@@ -501,6 +620,21 @@ func (app *Application) onLevelObjectRequestCreateEvent(evt levels.ObjectRequest
 	app.levelObjectsView.RequestCreateObject(lvl, evt.Pos)
 }
 
+func (app *Application) showLevelObject(levelID int, id level.ObjectID) {
+	app.eventQueue.Event(levels.NewLevelSelectionSetEvent(levelID))
+	app.eventQueue.Event(levels.NewObjectSelectionSetEvent([]level.ObjectID{id}))
+	*app.levelObjectsView.WindowOpen() = true
+}
+
+func (app *Application) showResource(key resource.Key) bool {
+	return app.messagesView.ShowResource(key) ||
+		app.textsView.ShowResource(key) ||
+		app.bitmapsView.ShowResource(key) ||
+		app.moviesView.ShowResource(key) ||
+		app.objectsView.ShowResource(key) ||
+		app.texturesView.ShowResource(key)
+}
+
 func (app *Application) renderMainMenu() {
 	windowEntry := func(name string, shortcut string, isOpen *bool) {
 		if imgui.MenuItemV(name, shortcut, *isOpen, true) {
@@ -511,6 +645,8 @@ func (app *Application) renderMainMenu() {
 	if imgui.BeginMainMenuBar() {
 		if imgui.BeginMenu("File") {
 			windowEntry("Project", "F1", app.projectView.WindowOpen())
+			windowEntry("Target Check", "", app.targetCheckView.WindowOpen())
+			windowEntry("Resource Inspector", "", app.inspectorView.WindowOpen())
 			imgui.Separator()
 			if imgui.MenuItem("Exit") {
 				app.window.SetCloseRequest(true)
@@ -532,11 +668,21 @@ func (app *Application) renderMainMenu() {
 			windowEntry("Level Tiles", "F3", app.levelTilesView.WindowOpen())
 			windowEntry("Level Objects", "F4", app.levelObjectsView.WindowOpen())
 			windowEntry("Messages", "F5", app.messagesView.WindowOpen())
+			windowEntry("Message Chains", "", app.messageChainsView.WindowOpen())
+			windowEntry("Movies", "", app.moviesView.WindowOpen())
+			windowEntry("Sound Effects", "", app.soundsView.WindowOpen())
 			windowEntry("Texts", "", app.textsView.WindowOpen())
 			windowEntry("Bitmaps", "", app.bitmapsView.WindowOpen())
 			windowEntry("Textures", "", app.texturesView.WindowOpen())
 			windowEntry("Animations", "", app.animationsView.WindowOpen())
 			windowEntry("Game Objects", "", app.objectsView.WindowOpen())
+			windowEntry("Palettes", "", app.palettesView.WindowOpen())
+			windowEntry("Screens", "", app.screensView.WindowOpen())
+			windowEntry("MFD Art", "", app.mfdArtView.WindowOpen())
+			windowEntry("Artwork Exchange", "", app.artworksView.WindowOpen())
+			windowEntry("Localization Exchange", "", app.localizationsView.WindowOpen())
+			windowEntry("Text Search", "", app.textSearchView.WindowOpen())
+			windowEntry("Hex Editor", "", app.hexEditorView.WindowOpen())
 			imgui.EndMenu()
 		}
 		if imgui.BeginMenu("Help") {
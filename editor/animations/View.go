@@ -214,15 +214,6 @@ func (view *View) requestImport() {
 		var frames [][]byte
 		rawPalette := palette.Palette()
 
-		highestBitShift := func(value int16) (result byte) {
-			if value != 0 {
-				for (value >> result) != 1 {
-					result++
-				}
-			}
-			return
-		}
-
 		var prevFrame []byte
 		for index, img := range data.Image {
 			if (img.Bounds().Max.X == data.Config.Width) && (img.Bounds().Max.Y == data.Config.Height) {
@@ -235,8 +226,8 @@ func (view *View) requestImport() {
 				}
 				anim.Entries = append(anim.Entries, entry)
 				bmp.Header.Type = bitmap.TypeCompressed8Bit
-				bmp.Header.WidthFactor = highestBitShift(bmp.Header.Width)
-				bmp.Header.HeightFactor = highestBitShift(bmp.Header.Height)
+				bmp.Header.WidthFactor = bitmap.HighestBitShift(bmp.Header.Width)
+				bmp.Header.HeightFactor = bitmap.HighestBitShift(bmp.Header.Height)
 				bmp.Header.Area = [4]int16{0, 0, anim.Width, anim.Height}
 				bmp.Header.Stride = uint16(bmp.Header.Width)
 
@@ -0,0 +1,62 @@
+package artworks
+
+import (
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+type blockChange struct {
+	key     resource.Key
+	oldData []byte
+	newData []byte
+}
+
+type animationChange struct {
+	key      resource.Key
+	framesID resource.ID
+
+	oldAnimation []byte
+	newAnimation []byte
+
+	oldFrames [][]byte
+	newFrames [][]byte
+}
+
+type setArtworkCommand struct {
+	model *viewModel
+
+	blocks     []blockChange
+	animations []animationChange
+}
+
+func (cmd setArtworkCommand) Do(modder world.Modder) error {
+	for _, change := range cmd.blocks {
+		modder.SetResourceBlock(change.key.Lang, change.key.ID, change.key.Index, change.newData)
+	}
+	for _, change := range cmd.animations {
+		setAnimation(modder, change, change.newAnimation, change.newFrames)
+	}
+	cmd.model.restoreFocus = true
+	return nil
+}
+
+func (cmd setArtworkCommand) Undo(modder world.Modder) error {
+	for _, change := range cmd.blocks {
+		modder.SetResourceBlock(change.key.Lang, change.key.ID, change.key.Index, change.oldData)
+	}
+	for _, change := range cmd.animations {
+		setAnimation(modder, change, change.oldAnimation, change.oldFrames)
+	}
+	cmd.model.restoreFocus = true
+	return nil
+}
+
+func setAnimation(modder world.Modder, change animationChange, animData []byte, frames [][]byte) {
+	if len(frames) == 0 {
+		modder.DelResource(change.key.Lang, change.key.ID)
+		modder.DelResource(change.key.Lang, change.framesID)
+	} else {
+		modder.SetResourceBlock(change.key.Lang, change.key.ID, 0, animData)
+		modder.SetResourceBlocks(change.key.Lang, change.framesID, frames)
+	}
+}
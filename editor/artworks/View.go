@@ -0,0 +1,196 @@
+package artworks
+
+import (
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/editor/external"
+	"github.com/inkyblackness/hacked/editor/graphics"
+	"github.com/inkyblackness/hacked/ss1/edit/artwork"
+	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+// View provides controls to exchange all graphics with a folder of image files.
+type View struct {
+	mod          *world.Mod
+	paletteCache *graphics.PaletteCache
+
+	modalStateMachine gui.ModalStateMachine
+	guiScale          float32
+	commander         cmd.Commander
+
+	model viewModel
+}
+
+// NewArtworksView returns a new instance.
+func NewArtworksView(mod *world.Mod, paletteCache *graphics.PaletteCache,
+	modalStateMachine gui.ModalStateMachine, guiScale float32, commander cmd.Commander) *View {
+	view := &View{
+		mod:          mod,
+		paletteCache: paletteCache,
+
+		modalStateMachine: modalStateMachine,
+		guiScale:          guiScale,
+		commander:         commander,
+
+		model: freshViewModel(),
+	}
+	return view
+}
+
+// WindowOpen returns the flag address, to be used with the main menu.
+func (view *View) WindowOpen() *bool {
+	return &view.model.windowOpen
+}
+
+// Render renders the view.
+func (view *View) Render() {
+	if view.model.restoreFocus {
+		imgui.SetNextWindowFocus()
+		view.model.restoreFocus = false
+		view.model.windowOpen = true
+	}
+	if view.model.windowOpen {
+		imgui.SetNextWindowSizeV(imgui.Vec2{X: 500 * view.guiScale, Y: 400 * view.guiScale}, imgui.ConditionOnce)
+		if imgui.BeginV("Artwork Exchange", view.WindowOpen(), imgui.WindowFlagsNoCollapse) {
+			view.renderContent()
+		}
+		imgui.End()
+	}
+}
+
+func (view *View) renderContent() {
+	imgui.Text("Exchange all bitmaps, textures, object bitmaps, and animations\nwith a folder of PNG and GIF files.")
+	if imgui.Button("Export All...") {
+		view.requestExport()
+	}
+	imgui.SameLine()
+	if imgui.Button("Import All...") {
+		view.requestImport()
+	}
+	if imgui.TreeNode("Conversion of images not using the game palette") {
+		imgui.PushItemWidth(-250 * view.guiScale)
+		view.model.mapSettings.Render()
+		imgui.PopItemWidth()
+		imgui.TreePop()
+	}
+	if view.model.lastExport != nil {
+		imgui.Text(fmt.Sprintf("Exported %d files.", view.model.lastExport.Written))
+		view.renderFailed(view.model.lastExport.Failed, "Not exported")
+	}
+	if view.model.lastApplied > 0 {
+		imgui.Text(fmt.Sprintf("Applied %d changes.", view.model.lastApplied))
+	}
+	imgui.Separator()
+	if view.model.pending != nil {
+		view.renderPending(view.model.pending)
+	}
+}
+
+func (view *View) renderPending(result *artwork.ImportResult) {
+	changeCount := len(result.Bitmaps) + len(result.Animations)
+	imgui.Text(fmt.Sprintf("Changed: %d, unchanged: %d", changeCount, result.Unchanged))
+	view.renderFailed(result.Failed, "Not readable")
+	if changeCount > 0 {
+		if imgui.Button("Apply") {
+			view.requestApply(result)
+		}
+		imgui.SameLine()
+	}
+	if imgui.Button("Discard") {
+		view.model.pending = nil
+		return
+	}
+	if imgui.BeginChildV("Changes", imgui.Vec2{X: -1, Y: 0}, true, 0) {
+		for _, change := range result.Bitmaps {
+			imgui.Text(change.Entry.Path)
+		}
+		for _, change := range result.Animations {
+			imgui.Text(change.Entry.Path)
+		}
+	}
+	imgui.EndChild()
+}
+
+func (view *View) renderFailed(paths []string, title string) {
+	if len(paths) == 0 {
+		return
+	}
+	imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+	imgui.Text(fmt.Sprintf("%s: %d", title, len(paths)))
+	imgui.PopStyleColor()
+	if imgui.IsItemHovered() {
+		text := ""
+		for _, path := range paths {
+			text += path + "\n"
+		}
+		imgui.SetTooltip(text)
+	}
+}
+
+func (view *View) requestExport() {
+	info := "All graphics will be written into sub-folders."
+	var exportTo func(string)
+
+	exportTo = func(dirname string) {
+		palette, err := view.paletteCache.Palette(0)
+		if err != nil {
+			external.Export(view.modalStateMachine, "Could not export. No palette loaded.\n"+info, exportTo, true)
+			return
+		}
+		result := artwork.Export(view.mod, palette.Palette(), artwork.Entries(view.mod), dirname)
+		view.model.lastExport = &result
+		view.model.lastApplied = 0
+	}
+
+	external.Export(view.modalStateMachine, info, exportTo, false)
+}
+
+func (view *View) requestImport() {
+	info := "Folder must have the structure of a previous export.\nOnly files that differ are taken over."
+	var importFrom func(string)
+
+	importFrom = func(dirname string) {
+		palette, err := view.paletteCache.Palette(0)
+		if err != nil {
+			external.ImportFolder(view.modalStateMachine, "Can not import without having a palette loaded.\n"+info, importFrom, true)
+			return
+		}
+		result := artwork.Import(view.mod, palette.Palette(), view.model.mapSettings.Settings(),
+			artwork.Entries(view.mod), dirname)
+		view.model.pending = &result
+		view.model.lastExport = nil
+		view.model.lastApplied = 0
+	}
+
+	external.ImportFolder(view.modalStateMachine, info, importFrom, false)
+}
+
+func (view *View) requestApply(result *artwork.ImportResult) {
+	command := setArtworkCommand{model: &view.model}
+	for _, change := range result.Bitmaps {
+		key := change.Entry.Key
+		command.blocks = append(command.blocks, blockChange{
+			key:     key,
+			oldData: view.mod.ModifiedBlock(key.Lang, key.ID, key.Index),
+			newData: change.Data,
+		})
+	}
+	for _, change := range result.Animations {
+		key := change.Entry.Key
+		command.animations = append(command.animations, animationChange{
+			key:          key,
+			framesID:     change.FramesID,
+			oldAnimation: view.mod.ModifiedBlock(key.Lang, key.ID, 0),
+			newAnimation: change.Data,
+			oldFrames:    view.mod.ModifiedBlocks(key.Lang, change.FramesID),
+			newFrames:    change.Frames,
+		})
+	}
+	view.commander.Queue(command)
+	view.model.pending = nil
+	view.model.lastApplied = len(command.blocks) + len(command.animations)
+}
@@ -0,0 +1,21 @@
+package artworks
+
+import (
+	"github.com/inkyblackness/hacked/editor/render"
+	"github.com/inkyblackness/hacked/ss1/edit/artwork"
+)
+
+type viewModel struct {
+	windowOpen   bool
+	restoreFocus bool
+
+	lastExport  *artwork.ExportResult
+	pending     *artwork.ImportResult
+	lastApplied int
+
+	mapSettings render.MapSettingsEditor
+}
+
+func freshViewModel() viewModel {
+	return viewModel{}
+}
@@ -9,39 +9,19 @@ import (
 	"github.com/inkyblackness/hacked/editor/graphics"
 	"github.com/inkyblackness/hacked/editor/render"
 	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/edit/artwork"
 	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
 	"github.com/inkyblackness/hacked/ss1/resource"
 	"github.com/inkyblackness/hacked/ss1/world"
 	"github.com/inkyblackness/hacked/ss1/world/ids"
 	"github.com/inkyblackness/hacked/ui/gui"
+	"github.com/inkyblackness/hacked/ui/opengl"
 )
 
-type bitmapInfo struct {
-	title            string
-	languageSpecific bool
-	bitmapType       bitmap.Type
-	bitmapFlags      bitmap.Flag
-}
-
-var knownBitmapTypes = map[resource.ID]bitmapInfo{
-	ids.MfdDataBitmaps:        {title: "MFD Data Images", languageSpecific: true, bitmapType: bitmap.TypeCompressed8Bit, bitmapFlags: bitmap.FlagTransparent},
-	ids.ObjectMaterialBitmaps: {title: "Object Materials", languageSpecific: false, bitmapType: bitmap.TypeFlat8Bit, bitmapFlags: 0},
-	ids.ObjectTextureBitmaps:  {title: "Object Textures", languageSpecific: false, bitmapType: bitmap.TypeFlat8Bit, bitmapFlags: 0},
-	ids.IconBitmaps:           {title: "Wall Icons", languageSpecific: false, bitmapType: bitmap.TypeCompressed8Bit, bitmapFlags: bitmap.FlagTransparent},
-	ids.GraffitiBitmaps:       {title: "Graffiti", languageSpecific: false, bitmapType: bitmap.TypeCompressed8Bit, bitmapFlags: bitmap.FlagTransparent},
-}
-
-var knownBitmapTypesOrder = []resource.ID{
-	ids.MfdDataBitmaps,
-	ids.ObjectMaterialBitmaps,
-	ids.ObjectTextureBitmaps,
-	ids.IconBitmaps,
-	ids.GraffitiBitmaps,
-}
-
 // View provides edit controls for bitmaps.
 type View struct {
 	mod          *world.Mod
+	gl           opengl.OpenGL
 	imageCache   *graphics.TextureCache
 	paletteCache *graphics.PaletteCache
 
@@ -54,11 +34,12 @@ type View struct {
 }
 
 // NewBitmapsView returns a new instance.
-func NewBitmapsView(mod *world.Mod, imageCache *graphics.TextureCache, paletteCache *graphics.PaletteCache,
+func NewBitmapsView(mod *world.Mod, gl opengl.OpenGL, imageCache *graphics.TextureCache, paletteCache *graphics.PaletteCache,
 	modalStateMachine gui.ModalStateMachine, clipboard external.Clipboard,
 	guiScale float32, commander cmd.Commander) *View {
 	view := &View{
 		mod:          mod,
+		gl:           gl,
 		imageCache:   imageCache,
 		paletteCache: paletteCache,
 
@@ -77,6 +58,17 @@ func (view *View) WindowOpen() *bool {
 	return &view.model.windowOpen
 }
 
+// ShowResource selects the bitmap with given key and brings the window to front.
+// It returns false if the key does not refer to a known bitmap type.
+func (view *View) ShowResource(key resource.Key) bool {
+	if _, known := artwork.BitmapCategoryOf(key.ID); !known {
+		return false
+	}
+	view.model.currentKey = key
+	view.model.restoreFocus = true
+	return true
+}
+
 // Render renders the view.
 func (view *View) Render() {
 	if view.model.restoreFocus {
@@ -96,21 +88,22 @@ func (view *View) Render() {
 func (view *View) renderContent() {
 	if imgui.BeginChildV("Properties", imgui.Vec2{X: 350 * view.guiScale, Y: 0}, false, 0) {
 		imgui.PushItemWidth(-150 * view.guiScale)
-		if imgui.BeginCombo("Bitmap Type", knownBitmapTypes[view.model.currentKey.ID].title) {
-			for _, id := range knownBitmapTypesOrder {
-				if imgui.SelectableV(knownBitmapTypes[id].title, id == view.model.currentKey.ID, 0, imgui.Vec2{}) {
-					view.model.currentKey.ID = id
+		selectedType, _ := artwork.BitmapCategoryOf(view.model.currentKey.ID)
+		if imgui.BeginCombo("Bitmap Type", selectedType.Title) {
+			for _, category := range artwork.BitmapCategories() {
+				if imgui.SelectableV(category.Title, category.ID == view.model.currentKey.ID, 0, imgui.Vec2{}) {
+					view.model.currentKey.ID = category.ID
 					view.model.currentKey.Index = 0
 				}
 			}
 			imgui.EndCombo()
 		}
-		selectedType := knownBitmapTypes[view.model.currentKey.ID]
-		if selectedType.languageSpecific {
-			if imgui.BeginCombo("Language", view.model.currentKey.Lang.String()) {
-				languages := resource.Languages()
+		selectedType, _ = artwork.BitmapCategoryOf(view.model.currentKey.ID)
+		if selectedType.LanguageSpecific {
+			if imgui.BeginCombo("Language", view.mod.LanguageName(view.model.currentKey.Lang)) {
+				languages := view.mod.Languages()
 				for _, lang := range languages {
-					if imgui.SelectableV(lang.String(), lang == view.model.currentKey.Lang, 0, imgui.Vec2{}) {
+					if imgui.SelectableV(view.mod.LanguageName(lang), lang == view.model.currentKey.Lang, 0, imgui.Vec2{}) {
 						view.model.currentKey.Lang = lang
 					}
 				}
@@ -132,7 +125,7 @@ func (view *View) renderContent() {
 				view.model.currentKey.Index = newValue
 			})
 
-		tex, err := view.imageCache.Texture(view.currentResourceKey())
+		_, err := view.imageCache.Texture(view.currentResourceKey())
 
 		if imgui.Button("Clear") {
 			view.requestClear(selectedType)
@@ -153,9 +146,10 @@ func (view *View) renderContent() {
 				}
 			}
 
-			width, height := tex.Size()
-			imgui.LabelText("Width", fmt.Sprintf("%d", int(width)))
-			imgui.LabelText("Height", fmt.Sprintf("%d", int(height)))
+			if header, hasHeader := graphics.BitmapHeader(view.mod, view.currentResourceKey()); hasHeader {
+				imgui.Separator()
+				render.BitmapHeader(header, false, view.requestSetBitmapHeader)
+			}
 		}
 
 		imgui.PopItemWidth()
@@ -197,7 +191,7 @@ func (view *View) requestExport() {
 		return
 	}
 	rawPalette := palette.Palette()
-	filename := fmt.Sprintf("%05d_%03d_%s.png", key.ID.Value(), key.Index, key.Lang.String())
+	filename := fmt.Sprintf("%05d_%03d_%s.png", key.ID.Value(), key.Index, view.mod.LanguageName(key.Lang))
 	width, height := texture.Size()
 	bmp := bitmap.Bitmap{
 		Header: bitmap.Header{
@@ -211,7 +205,7 @@ func (view *View) requestExport() {
 	external.ExportImage(view.modalStateMachine, filename, bmp)
 }
 
-func (view *View) requestImport(bmpInfo bitmapInfo) {
+func (view *View) requestImport(category artwork.BitmapCategory) {
 	paletteRetriever := func() (bitmap.Palette, error) {
 		palette, err := view.paletteCache.Palette(0)
 		if err != nil {
@@ -219,12 +213,12 @@ func (view *View) requestImport(bmpInfo bitmapInfo) {
 		}
 		return palette.Palette(), nil
 	}
-	external.ImportImage(view.modalStateMachine, paletteRetriever, func(bmp bitmap.Bitmap) {
-		view.requestSetBitmap(bmp, bmpInfo)
+	external.ImportImage(view.modalStateMachine, view.gl, paletteRetriever, func(bmp bitmap.Bitmap) {
+		view.requestSetBitmap(bmp, category)
 	})
 }
 
-func (view *View) requestClear(bmpInfo bitmapInfo) {
+func (view *View) requestClear(category artwork.BitmapCategory) {
 	bmp := bitmap.Bitmap{
 		Header: bitmap.Header{
 			Width:  1,
@@ -232,28 +226,30 @@ func (view *View) requestClear(bmpInfo bitmapInfo) {
 		},
 		Pixels: []byte{0x00},
 	}
-	view.requestSetBitmap(bmp, bmpInfo)
+	view.requestSetBitmap(bmp, category)
 }
 
-func (view *View) requestSetBitmap(bmp bitmap.Bitmap, bmpInfo bitmapInfo) {
-	highestBitShift := func(value int16) (result byte) {
-		if value != 0 {
-			for (value >> result) != 1 {
-				result++
-			}
-		}
-		return
+func (view *View) requestSetBitmap(bmp bitmap.Bitmap, category artwork.BitmapCategory) {
+	if oldHeader, hasOld := graphics.BitmapHeader(view.mod, view.currentResourceKey()); hasOld {
+		bmp.Header.Area = oldHeader.Area.Scaled(oldHeader.Width, oldHeader.Height, bmp.Header.Width, bmp.Header.Height)
 	}
-
-	bmp.Header.Flags = bmpInfo.bitmapFlags
-	bmp.Header.Type = bmpInfo.bitmapType
-	bmp.Header.WidthFactor = highestBitShift(bmp.Header.Width)
-	bmp.Header.HeightFactor = highestBitShift(bmp.Header.Height)
+	bmp.Header.Flags = category.Flags
+	bmp.Header.Type = category.Type
+	bmp.Header.WidthFactor = bitmap.HighestBitShift(bmp.Header.Width)
+	bmp.Header.HeightFactor = bitmap.HighestBitShift(bmp.Header.Height)
 	bmp.Header.Stride = uint16(bmp.Header.Width)
 	data := bitmap.Encode(&bmp, 0)
 	view.requestSetBitmapData(data)
 }
 
+func (view *View) requestSetBitmapHeader(header bitmap.Header) {
+	newData, err := graphics.BitmapDataWithHeader(view.mod, view.currentResourceKey(), header)
+	if err != nil {
+		return
+	}
+	view.requestSetBitmapData(newData)
+}
+
 func (view *View) requestSetBitmapData(newData []byte) {
 	resourceKey := view.currentResourceKey()
 
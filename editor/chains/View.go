@@ -0,0 +1,237 @@
+package chains
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ss1/content/archive/level"
+	"github.com/inkyblackness/hacked/ss1/content/descriptions"
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/edit/chains"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+// MessageShower is called to show the message of given key. The key refers to the first resource of the
+// message group, with the index of the message within the group.
+type MessageShower func(key resource.Key)
+
+// ObjectShower is called to show an object of a level.
+type ObjectShower func(levelID int, id level.ObjectID)
+
+// View shows the chains of all electronic messages and the triggers that send them.
+type View struct {
+	mod          *world.Mod
+	interpreters *descriptions.Interpreters
+	messageCache *text.ElectronicMessageCache
+	levels       []*level.Level
+
+	showMessage MessageShower
+	showObject  ObjectShower
+	guiScale    float32
+
+	model viewModel
+}
+
+// NewMessageChainsView returns a new instance.
+func NewMessageChainsView(mod *world.Mod, interpreters *descriptions.Interpreters, messageCache *text.ElectronicMessageCache, levels []*level.Level,
+	showMessage MessageShower, showObject ObjectShower, guiScale float32) *View {
+	view := &View{
+		mod:          mod,
+		interpreters: interpreters,
+		messageCache: messageCache,
+		levels:       levels,
+
+		showMessage: showMessage,
+		showObject:  showObject,
+		guiScale:    guiScale,
+
+		model: freshViewModel(),
+	}
+	return view
+}
+
+// WindowOpen returns the flag address, to be used with the main menu.
+func (view *View) WindowOpen() *bool {
+	return &view.model.windowOpen
+}
+
+// Render renders the view.
+func (view *View) Render() {
+	if view.model.restoreFocus {
+		imgui.SetNextWindowFocus()
+		view.model.restoreFocus = false
+		view.model.windowOpen = true
+	}
+	if view.model.windowOpen {
+		imgui.SetNextWindowSizeV(imgui.Vec2{X: 640 * view.guiScale, Y: 480 * view.guiScale}, imgui.ConditionOnce)
+		if imgui.BeginV("Message Chains", view.WindowOpen(), imgui.WindowFlagsNoCollapse) {
+			view.renderContent()
+		}
+		imgui.End()
+	}
+}
+
+func (view *View) renderContent() {
+	imgui.PushItemWidth(-150 * view.guiScale)
+	if imgui.BeginCombo("Language", view.mod.LanguageName(view.model.lang)) {
+		for _, lang := range view.mod.Languages() {
+			if imgui.SelectableV(view.mod.LanguageName(lang), lang == view.model.lang, 0, imgui.Vec2{}) {
+				view.model.lang = lang
+			}
+		}
+		imgui.EndCombo()
+	}
+	imgui.PopItemWidth()
+	if imgui.Button("Refresh") {
+		view.model.graph = nil
+	}
+	graph := view.currentGraph()
+
+	imgui.Separator()
+	if (len(graph.Dangling) == 0) && (len(graph.Orphans) == 0) && (len(graph.MissingTriggered) == 0) {
+		imgui.Text("No issues found.")
+	}
+	if len(graph.Dangling) > 0 {
+		imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+		imgui.Text(fmt.Sprintf("%d dangling references to messages that don't exist:", len(graph.Dangling)))
+		imgui.PopStyleColor()
+		for _, link := range graph.Dangling {
+			view.renderMessageLink(fmt.Sprintf("dangling%d", link.From), link.From)
+			imgui.SameLine()
+			imgui.Text(fmt.Sprintf("-> %d (missing)", link.To))
+		}
+	}
+	if len(graph.MissingTriggered) > 0 {
+		imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+		imgui.Text(fmt.Sprintf("%d triggers send messages that don't exist:", len(graph.MissingTriggered)))
+		imgui.PopStyleColor()
+		for triggerIndex, trigger := range graph.MissingTriggered {
+			if imgui.Button(fmt.Sprintf("Level %d, Object %d###missing%d", trigger.Level, trigger.Object, triggerIndex)) {
+				view.showObject(trigger.Level, trigger.Object)
+			}
+			imgui.SameLine()
+			imgui.Text(fmt.Sprintf("-> %d (missing)", trigger.Message))
+		}
+	}
+	if len(graph.Orphans) > 0 {
+		imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 1, Z: 0, W: 1})
+		imgui.Text(fmt.Sprintf("%d interrupt messages no message leads to:", len(graph.Orphans)))
+		imgui.PopStyleColor()
+		for _, index := range graph.Orphans {
+			view.renderMessageLink(fmt.Sprintf("orphan%d", index), index)
+			imgui.SameLine()
+			imgui.Text(view.triggerSummary(graph, index))
+		}
+	}
+
+	imgui.Separator()
+	if imgui.BeginChildV("Chains", imgui.Vec2{X: -1, Y: 0}, true, 0) {
+		for chainIndex, chain := range graph.Chains {
+			view.renderChain(graph, chainIndex, chain)
+		}
+	}
+	imgui.EndChild()
+}
+
+func (view *View) renderChain(graph *chains.Graph, chainIndex int, chain chains.Chain) {
+	titles := make([]string, 0, len(chain.Messages))
+	for _, index := range chain.Messages {
+		titles = append(titles, messageTitle(index))
+	}
+	label := strings.Join(titles, " -> ")
+	if chain.Dangling {
+		label += " -> (missing)"
+	}
+	if chain.Cyclic {
+		label += " -> (cycle)"
+	}
+	if imgui.TreeNodeV(fmt.Sprintf("%s###chain%d", label, chainIndex), 0) {
+		for position, index := range chain.Messages {
+			view.renderMessageLink(fmt.Sprintf("chain%d-%d", chainIndex, position), index)
+			message := view.messageOf(index)
+			imgui.SameLine()
+			imgui.Text(message.Subject)
+			if message.IsInterrupt {
+				imgui.SameLine()
+				imgui.Text("(interrupt)")
+			}
+			for triggerIndex, trigger := range graph.Triggers[index] {
+				imgui.Text("    sent by")
+				imgui.SameLine()
+				if imgui.Button(fmt.Sprintf("Level %d, Object %d###trigger%d-%d-%d",
+					trigger.Level, trigger.Object, chainIndex, position, triggerIndex)) {
+					view.showObject(trigger.Level, trigger.Object)
+				}
+			}
+		}
+		imgui.TreePop()
+	}
+}
+
+func (view *View) renderMessageLink(id string, index int) {
+	if imgui.Button(messageTitle(index) + "###" + id) {
+		group, _ := chains.GroupOf(index)
+		messageID, _ := chains.IDOf(index)
+		view.showMessage(resource.KeyOf(group.Start, view.model.lang, int(messageID.Value()-group.Start.Value())))
+	}
+}
+
+func (view *View) triggerSummary(graph *chains.Graph, index int) string {
+	triggers := graph.Triggers[index]
+	if len(triggers) == 0 {
+		return "(not sent by any trigger)"
+	}
+	return fmt.Sprintf("(sent by %d trigger(s))", len(triggers))
+}
+
+func messageTitle(index int) string {
+	group, isMessage := chains.GroupOf(index)
+	if !isMessage {
+		return fmt.Sprintf("%d", index)
+	}
+	messageID, _ := chains.IDOf(index)
+	return fmt.Sprintf("%s %d", group.Title, messageID.Value()-group.Start.Value())
+}
+
+func (view *View) messageOf(index int) text.ElectronicMessage {
+	group, _ := chains.GroupOf(index)
+	messageID, _ := chains.IDOf(index)
+	msg, err := view.messageCache.Message(resource.KeyOf(group.Start, view.model.lang, int(messageID.Value()-group.Start.Value())))
+	if err != nil {
+		return text.EmptyElectronicMessage()
+	}
+	return msg
+}
+
+// currentGraph returns the analysis of the current language, repeating it if the mod or the descriptions changed.
+func (view *View) currentGraph() *chains.Graph {
+	changeTime := view.mod.LastChangeTime()
+	if (view.model.graph != nil) && (view.model.graphLang == view.model.lang) &&
+		view.model.graphChangeTime.Equal(changeTime) && (view.model.graphInterpreters == view.interpreters.Level) {
+		return view.model.graph
+	}
+	messages := make(map[int]text.ElectronicMessage)
+	for _, group := range chains.Groups() {
+		for offset := 0; offset < group.Count; offset++ {
+			msg, err := view.messageCache.Message(resource.KeyOf(group.Start, view.model.lang, offset))
+			if err != nil {
+				continue
+			}
+			index, _ := chains.IndexOf(group.Start.Plus(offset))
+			messages[index] = msg
+		}
+	}
+	var triggers []chains.Trigger
+	for _, lvl := range view.levels {
+		triggers = append(triggers, chains.TriggersIn(lvl, view.interpreters.Level)...)
+	}
+	graph := chains.Analyze(messages, triggers)
+	view.model.graph = &graph
+	view.model.graphLang = view.model.lang
+	view.model.graphChangeTime = changeTime
+	view.model.graphInterpreters = view.interpreters.Level
+	return view.model.graph
+}
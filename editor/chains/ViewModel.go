@@ -0,0 +1,27 @@
+package chains
+
+import (
+	"time"
+
+	"github.com/inkyblackness/hacked/ss1/content/archive/level/lvlobj"
+	"github.com/inkyblackness/hacked/ss1/edit/chains"
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+type viewModel struct {
+	windowOpen   bool
+	restoreFocus bool
+
+	lang resource.Language
+
+	graph             *chains.Graph
+	graphLang         resource.Language
+	graphChangeTime   time.Time
+	graphInterpreters *lvlobj.Interpreters
+}
+
+func freshViewModel() viewModel {
+	return viewModel{
+		lang: resource.LangDefault,
+	}
+}
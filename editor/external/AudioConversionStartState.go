@@ -0,0 +1,35 @@
+package external
+
+import (
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ss1/content/audio"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type audioConversionStartState struct {
+	machine  gui.ModalStateMachine
+	player   *AudioPlayer
+	guiScale float32
+	callback func(audio.L8)
+	source   audio.F32
+	settings audio.ConversionSettings
+}
+
+func (state audioConversionStartState) Render() {
+	imgui.OpenPopup("Audio Conversion")
+	nextState := &audioConversionState{
+		machine:  state.machine,
+		player:   state.player,
+		guiScale: state.guiScale,
+		callback: state.callback,
+		source:   state.source,
+		settings: state.settings,
+
+		targetRate: state.settings.SampleRate,
+	}
+	state.machine.SetState(nextState)
+}
+
+func (state audioConversionStartState) HandleFiles(names []string) {
+}
@@ -0,0 +1,139 @@
+package external
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/editor/render"
+	"github.com/inkyblackness/hacked/ss1/content/audio"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type audioConversionState struct {
+	machine  gui.ModalStateMachine
+	player   *AudioPlayer
+	guiScale float32
+	callback func(audio.L8)
+	source   audio.F32
+	settings audio.ConversionSettings
+
+	targetRate float32
+
+	preview         audio.L8
+	previewSettings audio.ConversionSettings
+	previewValid    bool
+	playbackFailed  bool
+}
+
+func (state *audioConversionState) Render() {
+	if imgui.BeginPopupModalV("Audio Conversion", nil,
+		imgui.WindowFlagsNoResize|imgui.WindowFlagsNoMove|imgui.WindowFlagsNoSavedSettings|imgui.WindowFlagsAlwaysAutoResize) {
+		imgui.PushItemWidth(200 * state.guiScale)
+		imgui.LabelText("Source", fmt.Sprintf("%.0f Hz, %.2f sec, peak %s",
+			state.source.SampleRate, state.source.Duration(), decibelString(state.source.Peak())))
+		imgui.Separator()
+		state.renderSettings()
+		imgui.Separator()
+
+		preview := state.currentPreview()
+		imgui.LabelText("Result", fmt.Sprintf("%.0f Hz, %.2f sec, %d samples",
+			preview.SampleRate, preview.Duration(), len(preview.Samples)))
+		clipped := clippedSampleCount(preview)
+		if clipped > 0 {
+			imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 1, Z: 0, W: 1})
+			imgui.LabelText("Clipping", fmt.Sprintf("%d samples at full scale", clipped))
+			imgui.PopStyleColor()
+		}
+		imgui.PopItemWidth()
+		render.Waveform("Preview", preview, imgui.Vec2{X: 400 * state.guiScale, Y: 80 * state.guiScale}, 1, 0, 0, func(int, int) {})
+		if imgui.Button("Play") && !preview.Empty() {
+			state.playbackFailed = state.player.Play(preview) != nil
+		}
+		if state.playbackFailed {
+			imgui.SameLine()
+			imgui.Text("Playback failed.")
+		}
+		imgui.Separator()
+
+		if imgui.Button("Import") && !preview.Empty() {
+			state.machine.SetState(nil)
+			imgui.CloseCurrentPopup()
+			state.callback(preview)
+		}
+		imgui.SameLine()
+		if imgui.Button("Cancel") {
+			state.machine.SetState(nil)
+			imgui.CloseCurrentPopup()
+		}
+		imgui.EndPopup()
+	} else {
+		state.machine.SetState(nil)
+	}
+}
+
+func (state *audioConversionState) renderSettings() {
+	rateString := func(rate float32) string {
+		if rate <= 0 {
+			return fmt.Sprintf("Keep (%.0f Hz)", state.source.SampleRate)
+		}
+		return fmt.Sprintf("%.0f Hz", rate)
+	}
+	if imgui.BeginCombo("Sample Rate", rateString(state.settings.SampleRate)) {
+		for _, rate := range state.sampleRates() {
+			if imgui.SelectableV(rateString(rate), rate == state.settings.SampleRate, 0, imgui.Vec2{}) {
+				state.settings.SampleRate = rate
+			}
+		}
+		imgui.EndCombo()
+	}
+	imgui.Checkbox("Dither", &state.settings.Dither)
+	imgui.Checkbox("Normalize", &state.settings.Normalize)
+	imgui.Checkbox("Trim Silence", &state.settings.TrimSilence)
+	if state.settings.TrimSilence {
+		threshold := int32(math.Round(20 * math.Log10(float64(state.settings.SilenceThreshold))))
+		if imgui.SliderIntV("Silence Threshold", &threshold, -80, -10, "%d dB") {
+			state.settings.SilenceThreshold = float32(math.Pow(10, float64(threshold)/20))
+		}
+	}
+}
+
+func (state *audioConversionState) sampleRates() []float32 {
+	rates := []float32{0, 11025, 22050, 44100}
+	for _, rate := range rates {
+		if rate == state.targetRate {
+			return rates
+		}
+	}
+	return append(rates, state.targetRate)
+}
+
+func (state *audioConversionState) currentPreview() audio.L8 {
+	if !state.previewValid || (state.previewSettings != state.settings) {
+		state.preview = state.settings.Convert(state.source)
+		state.previewSettings = state.settings
+		state.previewValid = true
+	}
+	return state.preview
+}
+
+func (state *audioConversionState) HandleFiles(names []string) {
+}
+
+func clippedSampleCount(sound audio.L8) int {
+	count := 0
+	for _, sample := range sound.Samples {
+		if (sample == 0x00) || (sample == 0xFF) {
+			count++
+		}
+	}
+	return count
+}
+
+func decibelString(value float32) string {
+	if value <= 0 {
+		return "-inf dB"
+	}
+	return fmt.Sprintf("%.1f dB", 20*math.Log10(float64(value)))
+}
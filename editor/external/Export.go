@@ -5,10 +5,14 @@ import (
 	"image/png"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/inkyblackness/hacked/ss1/content/audio"
+	"github.com/inkyblackness/hacked/ss1/content/audio/voc"
 	"github.com/inkyblackness/hacked/ss1/content/audio/wav"
 	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/content/movie"
+	"github.com/inkyblackness/hacked/ss1/content/movie/subtitle"
 	"github.com/inkyblackness/hacked/ui/gui"
 )
 
@@ -23,6 +27,7 @@ func Export(machine gui.ModalStateMachine, info string, callback func(string), l
 }
 
 // ExportAudio is a helper wrapper for exporting audio.
+// The format is determined by the extension of the filename: ".voc" for Creative Voice, WAV otherwise.
 func ExportAudio(machine gui.ModalStateMachine, filename string, sound audio.L8) {
 	info := "File to be written: " + filename
 	var dirHandler func(string)
@@ -34,7 +39,11 @@ func ExportAudio(machine gui.ModalStateMachine, filename string, sound audio.L8)
 			return
 		}
 		defer func() { _ = writer.Close() }()
-		err = wav.Save(writer, sound.SampleRate, sound.Samples)
+		saver := wav.Save
+		if strings.ToLower(filepath.Ext(filename)) == ".voc" {
+			saver = voc.Save
+		}
+		err = saver(writer, sound.SampleRate, sound.Samples)
 		if err != nil {
 			Export(machine, info, dirHandler, true)
 		}
@@ -69,3 +78,29 @@ func ExportImage(machine gui.ModalStateMachine, filename string, bmp bitmap.Bitm
 
 	Export(machine, info, exportTo, false)
 }
+
+// ExportSubtitles is a helper wrapper for exporting subtitles.
+// The format is determined by the extension of the filename: ".vtt" for WebVTT, SubRip otherwise.
+func ExportSubtitles(machine gui.ModalStateMachine, filename string, cues []movie.SubtitleCue, duration float32) {
+	info := "File to be written: " + filename
+	var exportTo func(string)
+
+	exportTo = func(dirname string) {
+		writer, err := os.Create(filepath.Join(dirname, filename))
+		if err != nil {
+			Export(machine, "Could not create file.\n"+info, exportTo, true)
+			return
+		}
+		defer func() { _ = writer.Close() }()
+		saver := subtitle.SaveSRT
+		if strings.ToLower(filepath.Ext(filename)) == ".vtt" {
+			saver = subtitle.SaveWebVTT
+		}
+		err = saver(writer, cues, duration)
+		if err != nil {
+			Export(machine, info, exportTo, true)
+		}
+	}
+
+	Export(machine, info, exportTo, false)
+}
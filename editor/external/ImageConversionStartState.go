@@ -0,0 +1,37 @@
+package external
+
+import (
+	"image"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/editor/graphics"
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ui/gui"
+	"github.com/inkyblackness/hacked/ui/opengl"
+)
+
+type imageConversionStartState struct {
+	machine  gui.ModalStateMachine
+	gl       opengl.OpenGL
+	callback func(bitmap.Bitmap)
+	source   image.Image
+	palette  bitmap.Palette
+}
+
+func (state imageConversionStartState) Render() {
+	imgui.OpenPopup("Image Conversion")
+	nextState := &imageConversionState{
+		machine:   state.machine,
+		gl:        state.gl,
+		callback:  state.callback,
+		source:    state.source,
+		palette:   state.palette,
+		bitmapper: bitmap.NewBitmapper(&state.palette),
+	}
+	nextState.sourceTexture = graphics.NewRGBATexture(state.gl, state.source)
+	state.machine.SetState(nextState)
+}
+
+func (state imageConversionStartState) HandleFiles(names []string) {
+}
@@ -0,0 +1,132 @@
+package external
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/editor/graphics"
+	"github.com/inkyblackness/hacked/editor/render"
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ui/gui"
+	"github.com/inkyblackness/hacked/ui/opengl"
+)
+
+// imagePreviewMaxWidth and imagePreviewMaxHeight limit the size of the preview, in pixel.
+// Images up to this size are shown at native scale, larger ones are scaled down.
+const (
+	imagePreviewMaxWidth  = 320
+	imagePreviewMaxHeight = 240
+)
+
+type imageConversionState struct {
+	machine   gui.ModalStateMachine
+	gl        opengl.OpenGL
+	callback  func(bitmap.Bitmap)
+	source    image.Image
+	palette   bitmap.Palette
+	bitmapper *bitmap.Bitmapper
+
+	settingsEditor render.MapSettingsEditor
+
+	preview         bitmap.Bitmap
+	previewSettings bitmap.MapSettings
+	previewValid    bool
+
+	sourceTexture  *graphics.RGBATexture
+	previewTexture *graphics.RGBATexture
+}
+
+func (state *imageConversionState) Render() {
+	if imgui.BeginPopupModalV("Image Conversion", nil,
+		imgui.WindowFlagsNoResize|imgui.WindowFlagsNoMove|imgui.WindowFlagsNoSavedSettings|imgui.WindowFlagsAlwaysAutoResize) {
+		imgui.PushItemWidth(200)
+		bounds := state.source.Bounds()
+		imgui.LabelText("Source", fmt.Sprintf("%dx%d pixel", bounds.Dx(), bounds.Dy()))
+		imgui.Separator()
+		state.settingsEditor.Render()
+		imgui.Separator()
+
+		preview := state.currentPreview()
+		imgui.LabelText("Result", fmt.Sprintf("%d palette entries used", usedIndexCount(preview)))
+		imgui.PopItemWidth()
+		state.renderComparison()
+		imgui.Separator()
+
+		if imgui.Button("Import") {
+			state.close()
+			imgui.CloseCurrentPopup()
+			state.callback(preview)
+		}
+		imgui.SameLine()
+		if imgui.Button("Cancel") {
+			state.close()
+			imgui.CloseCurrentPopup()
+		}
+		imgui.EndPopup()
+	} else {
+		state.close()
+	}
+}
+
+func (state *imageConversionState) close() {
+	state.sourceTexture.Dispose()
+	if state.previewTexture != nil {
+		state.previewTexture.Dispose()
+	}
+	state.machine.SetState(nil)
+}
+
+func (state *imageConversionState) currentPreview() bitmap.Bitmap {
+	settings := state.settingsEditor.Settings()
+	if !state.previewValid || (state.previewSettings != settings) {
+		state.preview = state.bitmapper.MapWith(state.source, settings)
+		state.previewSettings = settings
+		state.previewValid = true
+		if state.previewTexture != nil {
+			state.previewTexture.Dispose()
+		}
+		state.previewTexture = graphics.NewRGBATexture(state.gl, state.previewImage())
+	}
+	return state.preview
+}
+
+func (state *imageConversionState) previewImage() image.Image {
+	width, height := int(state.preview.Header.Width), int(state.preview.Header.Height)
+	img := image.NewPaletted(image.Rect(0, 0, width, height), state.palette.ColorPalette(false))
+	copy(img.Pix, state.preview.Pixels)
+	return img
+}
+
+func (state *imageConversionState) renderComparison() {
+	width, height := state.sourceTexture.Size()
+	scale := float32(math.Min(1, math.Min(float64(imagePreviewMaxWidth/width), float64(imagePreviewMaxHeight/height))))
+	size := imgui.Vec2{X: width * scale, Y: height * scale}
+
+	imgui.BeginGroup()
+	imgui.Text("Source")
+	render.RGBATextureImage("##sourcePreview", state.sourceTexture, size)
+	imgui.EndGroup()
+	imgui.SameLine()
+	imgui.BeginGroup()
+	imgui.Text("Result")
+	render.RGBATextureImage("##resultPreview", state.previewTexture, size)
+	imgui.EndGroup()
+}
+
+func (state *imageConversionState) HandleFiles(names []string) {
+}
+
+func usedIndexCount(bmp bitmap.Bitmap) int {
+	var used [256]bool
+	count := 0
+	for _, pixel := range bmp.Pixels {
+		if !used[pixel] {
+			used[pixel] = true
+			count++
+		}
+	}
+	return count
+}
@@ -5,11 +5,17 @@ import (
 	"image/color"
 	"math"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/inkyblackness/hacked/ss1/content/audio"
+	"github.com/inkyblackness/hacked/ss1/content/audio/voc"
 	"github.com/inkyblackness/hacked/ss1/content/audio/wav"
 	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/content/movie"
+	"github.com/inkyblackness/hacked/ss1/content/movie/subtitle"
 	"github.com/inkyblackness/hacked/ui/gui"
+	"github.com/inkyblackness/hacked/ui/opengl"
 )
 
 // Import starts an import dialog series, calling the given callback with a file name.
@@ -23,10 +29,34 @@ func Import(machine gui.ModalStateMachine, info string, types []TypeInfo, callba
 	})
 }
 
+// ImportFolder starts an import dialog series, calling the given callback with a folder name.
+func ImportFolder(machine gui.ModalStateMachine, info string, callback func(string), lastFailed bool) {
+	machine.SetState(&importFolderStartState{
+		machine:   machine,
+		callback:  callback,
+		info:      info,
+		withError: lastFailed,
+	})
+}
+
+// DefaultSampleRate is the rate audio is imported with if there is no existing sound to take the rate from.
+const DefaultSampleRate = 22050
+
+// ImportSampleRateFor returns the rate of the given existing sound, so that replacements keep the rate of the original.
+func ImportSampleRateFor(existing audio.L8) float32 {
+	if existing.Empty() {
+		return DefaultSampleRate
+	}
+	return existing.SampleRate
+}
+
 // ImportAudio is a helper to handle audio file import. The callback is called with the loaded audio.
-func ImportAudio(machine gui.ModalStateMachine, callback func(l8 audio.L8)) {
-	info := "File must be a WAV file, 22050 Hz, 8-bit or 16-bit, uncompressed."
-	types := []TypeInfo{{Title: "Audio files (*.wav)", Extensions: []string{"wav"}}}
+// Loaded audio is offered for conversion first, which resamples the audio to the given target rate by default.
+// A target rate of zero keeps the rate of the file. The player is used to preview the conversion,
+// and guiScale sizes the dialog.
+func ImportAudio(machine gui.ModalStateMachine, player *AudioPlayer, guiScale, targetRate float32, callback func(l8 audio.L8)) {
+	info := "File must be a WAV file (PCM or float, any rate, any channel count)\nor a Creative Voice (VOC) file."
+	types := []TypeInfo{{Title: "Audio files (*.wav, *.voc)", Extensions: []string{"wav", "voc"}}}
 	var fileHandler func(string)
 
 	fileHandler = func(filename string) {
@@ -36,20 +66,72 @@ func ImportAudio(machine gui.ModalStateMachine, callback func(l8 audio.L8)) {
 			return
 		}
 		defer func() { _ = reader.Close() }()
-		sound, err := wav.Load(reader)
+		var sound audio.F32
+		if strings.ToLower(filepath.Ext(filename)) == ".voc" {
+			var l8 audio.L8
+			l8, err = voc.Load(reader)
+			sound = audio.F32FromL8(l8)
+		} else {
+			sound, err = wav.LoadF32(reader)
+		}
 		if err != nil {
 			Import(machine, info, types, fileHandler, true)
 			return
 		}
-		callback(sound)
+		machine.SetState(&audioConversionStartState{
+			machine:  machine,
+			player:   player,
+			guiScale: guiScale,
+			callback: callback,
+			source:   sound,
+			settings: audio.ConversionSettings{
+				SampleRate:       targetRate,
+				SilenceThreshold: 0.01,
+			},
+		})
+	}
+
+	Import(machine, info, types, fileHandler, false)
+}
+
+// ImportSubtitles is a helper to handle subtitle file import. The callback is called with the loaded cues.
+func ImportSubtitles(machine gui.ModalStateMachine, callback func([]movie.SubtitleCue)) {
+	info := "File must be either a SubRip (SRT) or a WebVTT file.\nText must be encoded as UTF-8."
+	types := []TypeInfo{{Title: "Subtitle files (*.srt, *.vtt)", Extensions: []string{"srt", "vtt"}}}
+	var fileHandler func(string)
+
+	fileHandler = func(filename string) {
+		reader, err := os.Open(filename)
+		if err != nil {
+			Import(machine, "Could not open file.\n"+info, types, fileHandler, true)
+			return
+		}
+		defer func() { _ = reader.Close() }()
+		loader := subtitle.LoadSRT
+		if strings.ToLower(filepath.Ext(filename)) == ".vtt" {
+			loader = subtitle.LoadWebVTT
+		}
+		cues, err := loader(reader)
+		if err != nil {
+			Import(machine, "File not recognized as subtitles.\n"+info, types, fileHandler, true)
+			return
+		}
+		callback(cues)
 	}
 
 	Import(machine, info, types, fileHandler, false)
 }
 
 // ImportImage is a helper to handle image file import. The callback is called with the loaded image.
-func ImportImage(machine gui.ModalStateMachine, paletteRetriever func() (bitmap.Palette, error), callback func(bitmap.Bitmap)) {
-	info := "File should be either a PNG or a GIF file.\nPaletted images matching game palette are taken 1:1,\nothers are mapped closest fitting."
+// Images that need to be mapped to the palette are offered for conversion first, with a preview of the result.
+//
+// If paletteRetriever is nil, the image brings its own palette instead: paletted images keep theirs,
+// for others a palette is generated. This palette is then provided in the Palette field of the bitmap.
+func ImportImage(machine gui.ModalStateMachine, gl opengl.OpenGL, paletteRetriever func() (bitmap.Palette, error), callback func(bitmap.Bitmap)) {
+	info := "File should be either a PNG or a GIF file.\nPaletted images matching game palette are taken 1:1,\nothers are mapped with conversion options."
+	if paletteRetriever == nil {
+		info = "File should be either a PNG or a GIF file.\nPaletted images bring their palette,\nfor others a palette is generated."
+	}
 	types := []TypeInfo{{Title: "Image files (*.gif, *.png)", Extensions: []string{"png", "gif"}}}
 	var fileHandler func(string)
 
@@ -65,9 +147,14 @@ func ImportImage(machine gui.ModalStateMachine, paletteRetriever func() (bitmap.
 			Import(machine, "File not recognized as image.\n"+info, types, fileHandler, true)
 			return
 		}
+		if paletteRetriever == nil {
+			bmp, palette := paletteImage(img)
+			bmp.Palette = &palette
+			callback(bmp)
+			return
+		}
 
 		var bmp bitmap.Bitmap
-		importMapped := true
 		rawPalette, err := paletteRetriever()
 		if err != nil {
 			Import(machine, "Can not import image without having a palette loaded.\n"+info, types, fileHandler, true)
@@ -86,19 +173,47 @@ func ImportImage(machine gui.ModalStateMachine, paletteRetriever func() (bitmap.
 						bmp.Pixels[row*int(bmp.Header.Width)+column] = palettedImg.ColorIndexAt(column, row)
 					}
 				}
-				importMapped = false
+				callback(bmp)
+				return
 			}
 		}
-		if importMapped {
-			bitmapper := bitmap.NewBitmapper(&rawPalette)
-			bmp = bitmapper.Map(img)
-		}
-		callback(bmp)
+		machine.SetState(&imageConversionStartState{
+			machine:  machine,
+			gl:       gl,
+			callback: callback,
+			source:   img,
+			palette:  rawPalette,
+		})
 	}
 
 	Import(machine, info, types, fileHandler, false)
 }
 
+// paletteImage returns the bitmap and the palette of the given image.
+// Paletted images keep their indices, others have a palette generated.
+func paletteImage(img image.Image) (bitmap.Bitmap, bitmap.Palette) {
+	var palette bitmap.Palette
+	if palettedImg, isPaletted := img.(*image.Paletted); isPaletted && (len(palettedImg.Palette) <= len(palette)) {
+		for index, clr := range palettedImg.Palette {
+			rgba := color.NRGBAModel.Convert(clr).(color.NRGBA)
+			palette[index] = bitmap.RGB{Red: rgba.R, Green: rgba.G, Blue: rgba.B}
+		}
+		bounds := img.Bounds()
+		bmp := bitmap.Bitmap{
+			Header: bitmap.Header{Width: int16(bounds.Dx()), Height: int16(bounds.Dy())},
+			Pixels: make([]byte, bounds.Dx()*bounds.Dy()),
+		}
+		for row := 0; row < bounds.Dy(); row++ {
+			copy(bmp.Pixels[row*bounds.Dx():], palettedImg.Pix[row*palettedImg.Stride:row*palettedImg.Stride+bounds.Dx()])
+		}
+		return bmp, palette
+	}
+	palette = bitmap.GeneratePalette(img, len(palette))
+	bitmapper := bitmap.NewBitmapper(&palette)
+	bmp := bitmapper.MapWith(img, bitmap.MapSettings{Dither: bitmap.DitherNone, AllowedIndices: bitmap.FullIndexMask()})
+	return bmp, palette
+}
+
 func paletteMatches(imgPalette color.Palette, rawPalette color.Palette) bool {
 	if len(imgPalette) > len(rawPalette) {
 		return false
@@ -0,0 +1,32 @@
+package external
+
+import (
+	"time"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type importFolderStartState struct {
+	machine   gui.ModalStateMachine
+	callback  func(string)
+	info      string
+	withError bool
+}
+
+func (state importFolderStartState) Render() {
+	imgui.OpenPopup("Import Folder")
+	nextState := &importFolderWaitingState{
+		machine:  state.machine,
+		callback: state.callback,
+		info:     state.info,
+	}
+	if state.withError {
+		nextState.failureTime = time.Now()
+	}
+	state.machine.SetState(nextState)
+}
+
+func (state importFolderStartState) HandleFiles(names []string) {
+}
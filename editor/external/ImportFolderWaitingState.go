@@ -0,0 +1,69 @@
+package external
+
+import (
+	"os"
+	"time"
+
+	"github.com/inkyblackness/imgui-go"
+	"github.com/sqweek/dialog"
+
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type importFolderWaitingState struct {
+	machine  gui.ModalStateMachine
+	callback func(string)
+	info     string
+
+	failureTime time.Time
+}
+
+func (state *importFolderWaitingState) Render() {
+	if imgui.BeginPopupModalV("Import Folder", nil,
+		imgui.WindowFlagsNoResize|imgui.WindowFlagsNoMove|imgui.WindowFlagsNoSavedSettings|imgui.WindowFlagsAlwaysAutoResize) {
+
+		imgui.Text("Waiting for folder.")
+		if !state.failureTime.IsZero() {
+			imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+			imgui.Text("Previous attempt failed, could not read folder.\nPlease check and try again.")
+			imgui.PopStyleColor()
+			if time.Since(state.failureTime).Seconds() > 5 {
+				state.failureTime = time.Time{}
+			}
+		}
+		imgui.Text(`From your file browser drag'n'drop the folder
+to read the data from into the editor window.
+`)
+		imgui.Text(state.info)
+		imgui.Separator()
+		if imgui.Button("Browse...") {
+			dlgBuilder := dialog.Directory()
+			filename, err := dlgBuilder.Browse()
+			if err == nil {
+				state.HandleFiles([]string{filename})
+			}
+		}
+		imgui.SameLine()
+		if imgui.Button("Cancel") {
+			state.machine.SetState(nil)
+			imgui.CloseCurrentPopup()
+		}
+		imgui.EndPopup()
+	} else {
+		state.machine.SetState(nil)
+	}
+}
+
+func (state *importFolderWaitingState) HandleFiles(names []string) {
+	if len(names) != 1 {
+		state.failureTime = time.Now()
+		return
+	}
+	fileInfo, err := os.Stat(names[0])
+	if (err != nil) || !fileInfo.IsDir() {
+		state.failureTime = time.Now()
+		return
+	}
+	state.machine.SetState(nil)
+	state.callback(names[0])
+}
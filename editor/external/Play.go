@@ -0,0 +1,93 @@
+package external
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/inkyblackness/hacked/ss1/content/audio"
+	"github.com/inkyblackness/hacked/ss1/content/audio/wav"
+)
+
+// AudioPlayer plays sounds with a command line player of the system.
+// Each sound is written as a WAV file into the temporary directory. The file is removed once its playback
+// is finished, at the latest when the player is closed.
+type AudioPlayer struct {
+	mutex   sync.Mutex
+	playing map[string]*exec.Cmd
+}
+
+// NewAudioPlayer returns a new instance.
+func NewAudioPlayer() *AudioPlayer {
+	return &AudioPlayer{playing: make(map[string]*exec.Cmd)}
+}
+
+// Play starts to play the given sound, without waiting for the playback to finish.
+func (player *AudioPlayer) Play(sound audio.L8) error {
+	file, err := ioutil.TempFile("", "hacked-*.wav")
+	if err != nil {
+		return err
+	}
+	err = wav.Save(file, sound.SampleRate, sound.Samples)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	var command *exec.Cmd
+	if err == nil {
+		command, err = playerCommand(file.Name())
+	}
+	if err == nil {
+		err = command.Start()
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+	player.mutex.Lock()
+	player.playing[file.Name()] = command
+	player.mutex.Unlock()
+	go func() {
+		_ = command.Wait()
+		player.mutex.Lock()
+		delete(player.playing, file.Name())
+		player.mutex.Unlock()
+		_ = os.Remove(file.Name())
+	}()
+	return nil
+}
+
+// Close stops all playback and removes the remaining files.
+func (player *AudioPlayer) Close() {
+	player.mutex.Lock()
+	defer player.mutex.Unlock()
+	for filename, command := range player.playing {
+		_ = command.Process.Kill()
+		_ = os.Remove(filename)
+	}
+	player.playing = make(map[string]*exec.Cmd)
+}
+
+// playerCommand returns the command to play the given file.
+// The chosen players block until playback is done, which allows to remove the file afterwards.
+// They also avoid handing the file to whatever application the desktop associates with WAV files.
+func playerCommand(filename string) (*exec.Cmd, error) {
+	switch runtime.GOOS {
+	case "windows":
+		script := "(New-Object Media.SoundPlayer '" + strings.ReplaceAll(filename, "'", "''") + "').PlaySync()"
+		return exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script), nil
+	case "darwin":
+		return exec.Command("afplay", filename), nil
+	default:
+		for _, name := range []string{"paplay", "aplay"} {
+			if path, err := exec.LookPath(name); err == nil {
+				return exec.Command(path, filename), nil
+			}
+		}
+		return nil, errors.New("no audio player found, neither paplay nor aplay")
+	}
+}
@@ -0,0 +1,45 @@
+package graphics
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+// BitmapData returns the serialized bitmap of given key. It returns nil if the block is not available.
+func BitmapData(localizer resource.Localizer, key resource.Key) []byte {
+	resourceView, err := localizer.LocalizedResources(key.Lang).Select(key.ID)
+	if err != nil {
+		return nil
+	}
+	reader, err := resourceView.Block(key.Index)
+	if err != nil {
+		return nil
+	}
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// BitmapHeader returns the header of the bitmap of given key.
+// The returned boolean is false if there is no bitmap available.
+func BitmapHeader(localizer resource.Localizer, key resource.Key) (bitmap.Header, bool) {
+	var header bitmap.Header
+	data := BitmapData(localizer, key)
+	if len(data) < bitmap.HeaderSize {
+		return header, false
+	}
+	err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &header)
+	return header, err == nil
+}
+
+// BitmapDataWithHeader returns the serialized bitmap of given key, with its header replaced.
+// See bitmap.ModifyHeader for which properties of the header can be changed.
+func BitmapDataWithHeader(localizer resource.Localizer, key resource.Key, header bitmap.Header) ([]byte, error) {
+	return bitmap.ModifyHeader(BitmapData(localizer, key), func(newHeader *bitmap.Header) { *newHeader = header })
+}
@@ -15,7 +15,8 @@ type PaletteCache struct {
 	gl        opengl.OpenGL
 	localizer resource.Localizer
 
-	palettes map[resource.Key]*PaletteTexture
+	palettes       map[resource.Key]*PaletteTexture
+	bitmapPalettes map[resource.Key]*PaletteTexture
 }
 
 // NewPaletteCache returns a new instance.
@@ -24,6 +25,8 @@ func NewPaletteCache(gl opengl.OpenGL, localizer resource.Localizer) *PaletteCac
 		gl:        gl,
 		localizer: localizer,
 		palettes:  make(map[resource.Key]*PaletteTexture),
+
+		bitmapPalettes: make(map[resource.Key]*PaletteTexture),
 	}
 	return cache
 }
@@ -37,12 +40,22 @@ func (cache *PaletteCache) InvalidateResources(ids []resource.ID) {
 				delete(cache.palettes, key)
 			}
 		}
+		for key, texture := range cache.bitmapPalettes {
+			if key.ID == id {
+				texture.Dispose()
+				delete(cache.bitmapPalettes, key)
+			}
+		}
 	}
 }
 
 // Palette returns the palette with given index - if available.
 func (cache *PaletteCache) Palette(index int) (*PaletteTexture, error) {
-	key := resource.KeyOf(ids.GamePalettesStart.Plus(index), resource.LangAny, 0)
+	return cache.PaletteOf(resource.KeyOf(ids.GamePalettesStart.Plus(index), resource.LangAny, 0))
+}
+
+// PaletteOf returns the palette stored in the resource block with given key - if available.
+func (cache *PaletteCache) PaletteOf(key resource.Key) (*PaletteTexture, error) {
 	pal, existing := cache.palettes[key]
 	if existing {
 		return pal, nil
@@ -70,3 +83,34 @@ func (cache *PaletteCache) Palette(index int) (*PaletteTexture, error) {
 
 	return pal, nil
 }
+
+// BitmapPalette returns the private palette of the bitmap with given key - if available.
+func (cache *PaletteCache) BitmapPalette(key resource.Key) (*PaletteTexture, error) {
+	pal, existing := cache.bitmapPalettes[key]
+	if existing {
+		return pal, nil
+	}
+	view, err := cache.localizer.LocalizedResources(key.Lang).Select(key.ID)
+	if err != nil {
+		return nil, err
+	}
+	if view.ContentType() != resource.Bitmap {
+		return nil, errors.New("resource not a bitmap")
+	}
+	reader, err := view.Block(key.Index)
+	if err != nil {
+		return nil, err
+	}
+	bmp, err := bitmap.Decode(reader)
+	if err != nil {
+		return nil, err
+	}
+	if bmp.Palette == nil {
+		return nil, errors.New("bitmap has no private palette")
+	}
+
+	pal = NewPaletteTexture(cache.gl, bmp.Palette)
+	cache.bitmapPalettes[key] = pal
+
+	return pal, nil
+}
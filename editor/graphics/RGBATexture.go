@@ -0,0 +1,81 @@
+package graphics
+
+import (
+	"image"
+
+	"github.com/inkyblackness/hacked/ui/opengl"
+)
+
+// RGBATexture wraps an OpenGL handle for a downloaded image with full color.
+type RGBATexture struct {
+	gl     opengl.OpenGL
+	handle uint32
+
+	width, height float32
+	u, v          float32
+}
+
+// NewRGBATexture downloads the provided image to OpenGL and returns a RGBATexture instance.
+func NewRGBATexture(gl opengl.OpenGL, img image.Image) *RGBATexture {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	textureWidth := powerOfTwo(width)
+	textureHeight := powerOfTwo(height)
+	tex := &RGBATexture{
+		gl:     gl,
+		handle: gl.GenTextures(1)[0],
+
+		width:  float32(width),
+		height: float32(height),
+	}
+	tex.u = tex.width / float32(textureWidth)
+	tex.v = tex.height / float32(textureHeight)
+
+	const bytesPerRGBA = 4
+	paddedData := make([]byte, textureWidth*textureHeight*bytesPerRGBA)
+	for y := 0; y < height; y++ {
+		outOffset := y * textureWidth * bytesPerRGBA
+		for x := 0; x < width; x++ {
+			r, g, b, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			paddedData[outOffset+0] = byte(r >> 8)
+			paddedData[outOffset+1] = byte(g >> 8)
+			paddedData[outOffset+2] = byte(b >> 8)
+			paddedData[outOffset+3] = byte(a >> 8)
+			outOffset += bytesPerRGBA
+		}
+	}
+
+	gl.BindTexture(opengl.TEXTURE_2D, tex.handle)
+	gl.TexImage2D(opengl.TEXTURE_2D, 0, opengl.RGBA, int32(textureWidth), int32(textureHeight),
+		0, opengl.RGBA, opengl.UNSIGNED_BYTE, paddedData)
+	gl.TexParameteri(opengl.TEXTURE_2D, opengl.TEXTURE_MAG_FILTER, opengl.NEAREST)
+	gl.TexParameteri(opengl.TEXTURE_2D, opengl.TEXTURE_MIN_FILTER, opengl.NEAREST)
+	gl.GenerateMipmap(opengl.TEXTURE_2D)
+	gl.BindTexture(opengl.TEXTURE_2D, 0)
+
+	return tex
+}
+
+// Dispose releases the OpenGL texture.
+func (tex *RGBATexture) Dispose() {
+	if tex.handle != 0 {
+		tex.gl.DeleteTextures([]uint32{tex.handle})
+		tex.handle = 0
+	}
+}
+
+// Handle returns the texture handle.
+func (tex *RGBATexture) Handle() uint32 {
+	return tex.handle
+}
+
+// Size returns the dimensions of the image, in pixels.
+func (tex *RGBATexture) Size() (width, height float32) {
+	return tex.width, tex.height
+}
+
+// UV returns the maximum U and V values for the image. The image will be
+// stored in a power-of-two texture, which may be larger than the image.
+func (tex *RGBATexture) UV() (u, v float32) {
+	return tex.u, tex.v
+}
@@ -0,0 +1,45 @@
+package hexedit
+
+import (
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+type setBlockDataCommand struct {
+	model *viewModel
+
+	key  resource.Key
+	list bool
+
+	oldData [][]byte
+	newData [][]byte
+}
+
+func (cmd setBlockDataCommand) Do(modder world.Modder) error {
+	return cmd.perform(modder, cmd.newData)
+}
+
+func (cmd setBlockDataCommand) Undo(modder world.Modder) error {
+	return cmd.perform(modder, cmd.oldData)
+}
+
+func (cmd setBlockDataCommand) perform(modder world.Modder, data [][]byte) error {
+	switch {
+	case cmd.list:
+		var blockData []byte
+		if len(data) > 0 {
+			blockData = data[0]
+		}
+		modder.SetResourceBlock(cmd.key.Lang, cmd.key.ID, cmd.key.Index, blockData)
+	case len(data) > 0:
+		modder.SetResourceBlocks(cmd.key.Lang, cmd.key.ID, data)
+	default:
+		modder.DelResource(cmd.key.Lang, cmd.key.ID)
+	}
+
+	cmd.model.restoreFocus = true
+	cmd.model.key = cmd.key
+	cmd.model.idText = cmd.key.ID.String()
+	cmd.model.cacheValid = false
+	return nil
+}
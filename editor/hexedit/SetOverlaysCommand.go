@@ -0,0 +1,30 @@
+package hexedit
+
+import (
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/overlay"
+)
+
+type overlaysSetter interface {
+	SetOverlays(set overlay.Set)
+}
+
+type setOverlaysCommand struct {
+	model  *viewModel
+	setter overlaysSetter
+
+	oldSet overlay.Set
+	newSet overlay.Set
+}
+
+func (cmd setOverlaysCommand) Do(modder world.Modder) error {
+	cmd.setter.SetOverlays(cmd.newSet)
+	cmd.model.restoreFocus = true
+	return nil
+}
+
+func (cmd setOverlaysCommand) Undo(modder world.Modder) error {
+	cmd.setter.SetOverlays(cmd.oldSet)
+	cmd.model.restoreFocus = true
+	return nil
+}
@@ -0,0 +1,457 @@
+package hexedit
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+	"github.com/inkyblackness/hacked/ss1/world/overlay"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+const (
+	bytesPerRow  = 16
+	rowsPerPage  = 16
+	bytesPerPage = bytesPerRow * rowsPerPage
+)
+
+// View shows the raw bytes of any resource block, with the user-defined fields of the resource.
+type View struct {
+	mod *world.Mod
+
+	guiScale  float32
+	commander cmd.Commander
+
+	model viewModel
+}
+
+// NewHexEditorView returns a new instance.
+func NewHexEditorView(mod *world.Mod, guiScale float32, commander cmd.Commander) *View {
+	view := &View{
+		mod: mod,
+
+		guiScale:  guiScale,
+		commander: commander,
+
+		model: freshViewModel(),
+	}
+	return view
+}
+
+// WindowOpen returns the flag address, to be used with the main menu.
+func (view *View) WindowOpen() *bool {
+	return &view.model.windowOpen
+}
+
+// ShowBlock selects the block of given key and brings the window to front.
+// The key refers to the resource itself, with the index of the block.
+func (view *View) ShowBlock(key resource.Key) {
+	view.model.key = key
+	view.model.idText = key.ID.String()
+	view.model.page = 0
+	view.model.selectedOffset = 0
+	view.model.restoreFocus = true
+}
+
+// Render renders the view.
+func (view *View) Render() {
+	if view.model.restoreFocus {
+		imgui.SetNextWindowFocus()
+		view.model.restoreFocus = false
+		view.model.windowOpen = true
+	}
+	if view.model.windowOpen {
+		imgui.SetNextWindowSizeV(imgui.Vec2{X: 900 * view.guiScale, Y: 520 * view.guiScale}, imgui.ConditionOnce)
+		if imgui.BeginV("Hex Editor", view.WindowOpen(), imgui.WindowFlagsNoCollapse) {
+			view.renderContent()
+		}
+		imgui.End()
+	}
+}
+
+func (view *View) renderContent() {
+	data, blockCount, err := view.currentBlock()
+
+	if imgui.BeginChildV("Properties", imgui.Vec2{X: 350 * view.guiScale, Y: 0}, false, 0) {
+		imgui.PushItemWidth(-100 * view.guiScale)
+		view.renderSelection(blockCount)
+		imgui.Separator()
+		if err != nil {
+			gui.TextColored(fmt.Sprintf("Block not available: %v", err), imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+		} else {
+			view.renderByteEdit(data)
+			imgui.Separator()
+			view.renderFields(data)
+		}
+		imgui.PopItemWidth()
+	}
+	imgui.EndChild()
+	imgui.SameLine()
+	if imgui.BeginChildV("Bytes", imgui.Vec2{X: -1, Y: 0}, true, 0) && (err == nil) {
+		view.renderBytes(data)
+	}
+	imgui.EndChild()
+}
+
+func (view *View) renderSelection(blockCount int) {
+	if imgui.InputTextV("Resource ID", &view.model.idText,
+		imgui.InputTextFlagsCharsHexadecimal|imgui.InputTextFlagsCharsUppercase|imgui.InputTextFlagsEnterReturnsTrue, nil) {
+		value, err := strconv.ParseUint(view.model.idText, 16, 16)
+		if err == nil {
+			view.ShowBlock(resource.KeyOf(resource.ID(value), defaultLanguageOf(resource.ID(value)), 0))
+		}
+	}
+	if imgui.BeginCombo("Language", view.mod.LanguageName(view.model.key.Lang)) {
+		languages := append([]resource.Language{resource.LangAny}, view.mod.Languages()...)
+		for _, lang := range languages {
+			if imgui.SelectableV(view.mod.LanguageName(lang), lang == view.model.key.Lang, 0, imgui.Vec2{}) {
+				view.model.key.Lang = lang
+			}
+		}
+		imgui.EndCombo()
+	}
+	if blockCount > 1 {
+		if gui.StepSliderInt("Block", &view.model.key.Index, 0, blockCount-1) {
+			view.model.page = 0
+		}
+	}
+	if info, known := ids.Info(view.model.key.ID); known {
+		imgui.Text(fmt.Sprintf("Known as %v in %s", info.ContentType, info.ResFile.For(resource.LangDefault)))
+	} else {
+		imgui.Text("Unknown resource")
+	}
+}
+
+func (view *View) renderByteEdit(data []byte) {
+	pageCount := (len(data) + bytesPerPage - 1) / bytesPerPage
+	if pageCount > 1 {
+		gui.StepSliderIntV("Page", &view.model.page, 0, pageCount-1, "%d")
+	}
+	imgui.Text(fmt.Sprintf("%d bytes, offset %d (0x%04X) selected", len(data), view.model.selectedOffset, view.model.selectedOffset))
+	if imgui.InputTextV("Write Hex", &view.model.bytesText,
+		imgui.InputTextFlagsCharsHexadecimal|imgui.InputTextFlagsCharsUppercase|imgui.InputTextFlagsEnterReturnsTrue, nil) {
+		view.requestWriteBytes(data, view.model.selectedOffset, view.model.bytesText)
+	}
+	if imgui.IsItemHovered() {
+		imgui.SetTooltip("Hexadecimal bytes to write at the selected offset.\nPress Enter to write.")
+	}
+	if len(view.model.errorInfo) > 0 {
+		gui.TextColored(view.model.errorInfo, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+	}
+}
+
+func (view *View) renderFields(data []byte) {
+	set := view.mod.Overlays()
+	id := view.model.key.ID
+	fields := set.Fields(id)
+	covered := 0
+	for offset := range data {
+		if fieldIndexAt(fields, offset) >= 0 {
+			covered++
+		}
+	}
+	imgui.Text(fmt.Sprintf("Fields (%d of %d bytes described)", covered, len(data)))
+	for keyIndex, key := range set.KeysFor(id) {
+		imgui.Text(scopeText(key) + ":")
+		for index, field := range set.FieldsOf(key) {
+			if imgui.Button(fmt.Sprintf("X###removeField%d-%d", keyIndex, index)) {
+				view.requestSetOverlays(set.Without(key, index))
+			}
+			imgui.SameLine()
+			if imgui.SelectableV(fmt.Sprintf("%s: %s###field%d-%d", field.Name, field.Format(data), keyIndex, index),
+				field.Covers(view.model.selectedOffset), 0, imgui.Vec2{}) {
+				view.selectOffset(field.Offset)
+			}
+			if imgui.IsItemHovered() {
+				imgui.SetTooltip(fmt.Sprintf("%v at offset %d, %d bytes", field.Type, field.Offset, field.Length))
+			}
+		}
+	}
+
+	imgui.Separator()
+	imgui.Text(fmt.Sprintf("New field at offset %d:", view.model.selectedOffset))
+	imgui.InputText("Name", &view.model.newField.Name)
+	if imgui.BeginCombo("Type", view.model.newField.Type.String()) {
+		for _, fieldType := range overlay.FieldTypes() {
+			if imgui.SelectableV(fieldType.String(), fieldType == view.model.newField.Type, 0, imgui.Vec2{}) {
+				view.model.newField.Type = fieldType
+				view.model.lengthText = fmt.Sprintf("%d", fieldType.ElementSize())
+			}
+		}
+		imgui.EndCombo()
+	}
+	imgui.InputTextV("Length", &view.model.lengthText, imgui.InputTextFlagsCharsDecimal, nil)
+	view.renderNewFieldScope(id)
+	if imgui.Button("Add Field") {
+		view.requestAddField()
+	}
+}
+
+func (view *View) renderNewFieldScope(id resource.ID) {
+	_, isLevelResource := overlay.LevelOffsetOf(id)
+	if (view.model.newFieldScope == levelScope) && !isLevelResource {
+		view.model.newFieldScope = resourceScope
+	}
+	scopeNames := map[int]string{
+		resourceScope: "This resource",
+		rangeScope:    "Range of resources",
+		levelScope:    "Same resource of every level",
+	}
+	if imgui.BeginCombo("Applies to", scopeNames[view.model.newFieldScope]) {
+		for _, scope := range []int{resourceScope, rangeScope, levelScope} {
+			if (scope == levelScope) && !isLevelResource {
+				continue
+			}
+			if imgui.SelectableV(scopeNames[scope], scope == view.model.newFieldScope, 0, imgui.Vec2{}) {
+				view.model.newFieldScope = scope
+			}
+		}
+		imgui.EndCombo()
+	}
+	if view.model.newFieldScope == rangeScope {
+		imgui.InputTextV("Last ID", &view.model.rangeEndText,
+			imgui.InputTextFlagsCharsHexadecimal|imgui.InputTextFlagsCharsUppercase, nil)
+	}
+}
+
+// newFieldKey returns the key the new field shall be registered for.
+func (view *View) newFieldKey() (overlay.Key, error) {
+	id := view.model.key.ID
+	switch view.model.newFieldScope {
+	case rangeScope:
+		last, err := strconv.ParseUint(view.model.rangeEndText, 16, 16)
+		if err != nil {
+			return overlay.Key{}, errors.New("invalid last ID of range")
+		}
+		return overlay.KeyOfRange(id, resource.ID(last)), nil
+	case levelScope:
+		offset, isLevelResource := overlay.LevelOffsetOf(id)
+		if !isLevelResource {
+			return overlay.Key{}, errors.New("resource does not belong to a level")
+		}
+		return overlay.KeyOfLevelResource(offset), nil
+	default:
+		return overlay.KeyOfID(id), nil
+	}
+}
+
+func (view *View) renderBytes(data []byte) {
+	fields := view.mod.Overlays().Fields(view.model.key.ID)
+	start := view.model.page * bytesPerPage
+	for rowStart := start; (rowStart < len(data)) && (rowStart < start+bytesPerPage); rowStart += bytesPerRow {
+		imgui.Text(fmt.Sprintf("%06X:", rowStart))
+		for offset := rowStart; (offset < len(data)) && (offset < rowStart+bytesPerRow); offset++ {
+			imgui.SameLine()
+			fieldIndex := fieldIndexAt(fields, offset)
+			if fieldIndex >= 0 {
+				imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 0.4, Y: 0.8, Z: 1, W: 1})
+			}
+			if imgui.SelectableV(fmt.Sprintf("%02X###byte%d", data[offset], offset), offset == view.model.selectedOffset,
+				0, imgui.Vec2{X: 18 * view.guiScale, Y: 0}) {
+				view.selectOffset(offset)
+			}
+			if fieldIndex >= 0 {
+				imgui.PopStyleColor()
+			}
+			if imgui.IsItemHovered() {
+				tooltip := fmt.Sprintf("Offset %d (0x%04X)", offset, offset)
+				if fieldIndex >= 0 {
+					tooltip += "\n" + fields[fieldIndex].Name
+				}
+				imgui.SetTooltip(tooltip)
+			}
+		}
+		imgui.SameLine()
+		end := rowStart + bytesPerRow
+		if end > len(data) {
+			end = len(data)
+		}
+		imgui.Text(printable(data[rowStart:end]))
+	}
+}
+
+func (view *View) selectOffset(offset int) {
+	view.model.selectedOffset = offset
+	view.model.page = offset / bytesPerPage
+	view.model.errorInfo = ""
+}
+
+func (view *View) currentBlock() ([]byte, int, error) {
+	if view.model.cacheValid && (view.model.cachedKey == view.model.key) &&
+		(view.model.cachedTime == view.mod.LastChangeTime()) {
+		return view.model.cachedData, view.model.cachedBlocks, view.model.cachedErr
+	}
+	view.model.cachedKey = view.model.key
+	view.model.cachedTime = view.mod.LastChangeTime()
+	view.model.cachedData, view.model.cachedBlocks, view.model.cachedErr = view.readBlock(view.model.key)
+	view.model.cacheValid = true
+	if view.model.selectedOffset >= len(view.model.cachedData) {
+		view.model.selectedOffset = 0
+		view.model.page = 0
+	}
+	return view.model.cachedData, view.model.cachedBlocks, view.model.cachedErr
+}
+
+func (view *View) readBlock(key resource.Key) ([]byte, int, error) {
+	resourceView, err := view.mod.LocalizedResources(key.Lang).Select(key.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	blockCount := resourceView.BlockCount()
+	if (key.Index < 0) || (key.Index >= blockCount) {
+		return nil, blockCount, fmt.Errorf("resource has %d block(s)", blockCount)
+	}
+	reader, err := resourceView.Block(key.Index)
+	if err != nil {
+		return nil, blockCount, err
+	}
+	data, err := ioutil.ReadAll(reader)
+	return data, blockCount, err
+}
+
+func (view *View) requestWriteBytes(data []byte, offset int, text string) {
+	newBytes, err := hex.DecodeString(strings.Join(strings.Fields(text), ""))
+	if err != nil {
+		view.model.errorInfo = "Invalid hex data: " + err.Error()
+		return
+	}
+	if offset+len(newBytes) > len(data) {
+		view.model.errorInfo = "Data exceeds the block. The block size can not be changed here."
+		return
+	}
+	view.model.errorInfo = ""
+	newData := make([]byte, len(data))
+	copy(newData, data)
+	copy(newData[offset:], newBytes)
+
+	key := view.model.key
+	command := setBlockDataCommand{
+		model: &view.model,
+		key:   key,
+	}
+	if info, known := ids.Info(key.ID); known && info.List {
+		command.list = true
+		if oldBlock := view.mod.ModifiedBlock(key.Lang, key.ID, key.Index); len(oldBlock) > 0 {
+			command.oldData = [][]byte{oldBlock}
+		}
+		command.newData = [][]byte{newData}
+	} else {
+		command.oldData = view.mod.ModifiedBlocks(key.Lang, key.ID)
+		command.newData, err = view.allBlocksWith(key, newData)
+		if err != nil {
+			view.model.errorInfo = err.Error()
+			return
+		}
+	}
+	view.commander.Queue(command)
+}
+
+func (view *View) allBlocksWith(key resource.Key, blockData []byte) ([][]byte, error) {
+	resourceView, err := view.mod.LocalizedResources(key.Lang).Select(key.ID)
+	if err != nil {
+		return nil, err
+	}
+	blocks := make([][]byte, resourceView.BlockCount())
+	for index := range blocks {
+		if index == key.Index {
+			blocks[index] = blockData
+			continue
+		}
+		reader, err := resourceView.Block(index)
+		if err != nil {
+			return nil, err
+		}
+		blocks[index], err = ioutil.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return blocks, nil
+}
+
+func (view *View) requestAddField() {
+	field := view.model.newField
+	field.Offset = view.model.selectedOffset
+	field.Name = strings.TrimSpace(field.Name)
+	length, err := strconv.Atoi(view.model.lengthText)
+	if err != nil {
+		view.model.errorInfo = "Invalid length"
+		return
+	}
+	field.Length = length
+	err = field.Validate()
+	if err != nil {
+		view.model.errorInfo = "Invalid field: " + err.Error()
+		return
+	}
+	key, err := view.newFieldKey()
+	if err != nil {
+		view.model.errorInfo = "Invalid scope: " + err.Error()
+		return
+	}
+	view.model.errorInfo = ""
+	view.model.newField.Name = ""
+	view.requestSetOverlays(view.mod.Overlays().With(key, field))
+}
+
+func (view *View) requestSetOverlays(set overlay.Set) {
+	command := setOverlaysCommand{
+		model:  &view.model,
+		setter: view.mod,
+		oldSet: view.mod.Overlays(),
+		newSet: set,
+	}
+	view.commander.Queue(command)
+}
+
+func defaultLanguageOf(id resource.ID) resource.Language {
+	info, known := ids.Info(id)
+	if !known {
+		return resource.LangAny
+	}
+	if _, isAgnostic := info.ResFile.(resource.AnyLanguage); isAgnostic {
+		return resource.LangAny
+	}
+	return resource.LangDefault
+}
+
+func scopeText(key overlay.Key) string {
+	if offset, isLevelResource := key.LevelOffset(); isLevelResource {
+		return fmt.Sprintf("Offset %d of every level", offset)
+	}
+	first, last := key.Range()
+	if first == last {
+		return "Resource " + first.String()
+	}
+	return "Resources " + first.String() + " to " + last.String()
+}
+
+func fieldIndexAt(fields []overlay.Field, offset int) int {
+	for index, field := range fields {
+		if field.Covers(offset) {
+			return index
+		}
+	}
+	return -1
+}
+
+func printable(data []byte) string {
+	text := make([]byte, len(data))
+	for index, b := range data {
+		if (b >= 0x20) && (b < 0x7F) {
+			text[index] = b
+		} else {
+			text[index] = '.'
+		}
+	}
+	return string(text)
+}
@@ -0,0 +1,49 @@
+package hexedit
+
+import (
+	"time"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/overlay"
+)
+
+// Scopes of new fields.
+const (
+	resourceScope = iota
+	rangeScope
+	levelScope
+)
+
+type viewModel struct {
+	windowOpen   bool
+	restoreFocus bool
+
+	key    resource.Key
+	idText string
+	page   int
+
+	selectedOffset int
+	bytesText      string
+
+	newField      overlay.Field
+	lengthText    string
+	newFieldScope int
+	rangeEndText  string
+	errorInfo     string
+
+	cacheValid   bool
+	cachedKey    resource.Key
+	cachedTime   time.Time
+	cachedBlocks int
+	cachedData   []byte
+	cachedErr    error
+}
+
+func freshViewModel() viewModel {
+	return viewModel{
+		key:        resource.KeyOf(resource.ID(0x0FA1), resource.LangAny, 0),
+		idText:     "0FA1",
+		newField:   overlay.Field{Length: 1, Type: overlay.Uint8},
+		lengthText: "1",
+	}
+}
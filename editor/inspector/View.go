@@ -0,0 +1,206 @@
+package inspector
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/inkyblackness/imgui-go"
+	"github.com/sqweek/dialog"
+
+	"github.com/inkyblackness/hacked/editor/render"
+	"github.com/inkyblackness/hacked/ss1/edit/inspect"
+	"github.com/inkyblackness/hacked/ss1/resource/lgres"
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+// View shows the raw content of any resource file, independent of the loaded mod.
+// Only the languages of resources are derived considering the mod.
+type View struct {
+	mod *world.Mod
+
+	showResource render.ResourceShower
+	showBlock    render.BlockShower
+	guiScale     float32
+
+	model viewModel
+}
+
+// NewResourceInspectorView returns a new instance.
+func NewResourceInspectorView(mod *world.Mod, showResource render.ResourceShower, showBlock render.BlockShower, guiScale float32) *View {
+	view := &View{
+		mod: mod,
+
+		showResource: showResource,
+		showBlock:    showBlock,
+		guiScale:     guiScale,
+
+		model: freshViewModel(),
+	}
+	return view
+}
+
+// WindowOpen returns the flag address, to be used with the main menu.
+func (view *View) WindowOpen() *bool {
+	return &view.model.windowOpen
+}
+
+// Render renders the view.
+func (view *View) Render() {
+	if view.model.restoreFocus {
+		imgui.SetNextWindowFocus()
+		view.model.restoreFocus = false
+		view.model.windowOpen = true
+	}
+	if view.model.windowOpen {
+		imgui.SetNextWindowSizeV(imgui.Vec2{X: 800 * view.guiScale, Y: 480 * view.guiScale}, imgui.ConditionOnce)
+		if imgui.BeginV("Resource Inspector", view.WindowOpen(), imgui.WindowFlagsNoCollapse) {
+			view.renderContent()
+		}
+		imgui.End()
+	}
+}
+
+func (view *View) renderContent() {
+	if imgui.Button("Open...") {
+		filename, err := dialog.File().Filter("Resource files (*.res)", "res").Filter("All files (*.*)", "*").Load()
+		if err == nil {
+			view.load(filename)
+		}
+	}
+	if len(view.model.filename) > 0 {
+		imgui.SameLine()
+		if imgui.Button("Reload") {
+			view.load(view.model.filename)
+		}
+		imgui.SameLine()
+		imgui.Text(view.model.filename)
+	}
+	if view.model.loadErr != nil {
+		gui.TextColored(fmt.Sprintf("Could not read file: %v", view.model.loadErr), imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+	}
+	if view.model.reader == nil {
+		return
+	}
+
+	imgui.Separator()
+	if imgui.BeginChildV("Entries", imgui.Vec2{X: -1, Y: -180 * view.guiScale}, true, 0) {
+		view.renderEntries()
+	}
+	imgui.EndChild()
+	view.renderSelected()
+}
+
+func (view *View) renderEntries() {
+	imgui.ColumnsV(7, "entries", imgui.ColumnsFlagsNone)
+	for _, title := range []string{"ID", "Type", "Flags", "Blocks", "Raw", "Stored", "Known as"} {
+		imgui.Text(title)
+		imgui.NextColumn()
+	}
+	imgui.Separator()
+	for index, entry := range view.model.entries {
+		if imgui.SelectableV(fmt.Sprintf("0x%v", entry.ID), index == view.model.selectedIndex, 0, imgui.Vec2{}) {
+			view.model.selectedIndex = index
+			view.model.selectedBlock = 0
+			view.model.resultInfo = ""
+		}
+		imgui.NextColumn()
+		imgui.Text(entry.Properties.ContentType.String())
+		imgui.NextColumn()
+		imgui.Text(entry.Flags())
+		imgui.NextColumn()
+		imgui.Text(fmt.Sprintf("%d", entry.BlockCount))
+		imgui.NextColumn()
+		imgui.Text(fmt.Sprintf("%d", entry.UnpackedLength))
+		imgui.NextColumn()
+		imgui.Text(fmt.Sprintf("%d", entry.PackedLength))
+		imgui.NextColumn()
+		if (entry.Err != nil) || (len(entry.Deviations()) > 0) {
+			gui.TextColored(entry.Classification(), imgui.Vec4{X: 1, Y: 1, Z: 0, W: 1})
+		} else {
+			imgui.Text(entry.Classification())
+		}
+		imgui.NextColumn()
+	}
+	imgui.Columns(1, "")
+}
+
+func (view *View) renderSelected() {
+	if (view.model.selectedIndex < 0) || (view.model.selectedIndex >= len(view.model.entries)) {
+		imgui.Text("Select a resource to see its details.")
+		return
+	}
+	entry := view.model.entries[view.model.selectedIndex]
+	imgui.Text(fmt.Sprintf("Resource 0x%v at offset %d: %s", entry.ID, entry.Offset, entry.Classification()))
+	if entry.Err != nil {
+		gui.TextColored(fmt.Sprintf("Could not read resource: %v", entry.Err), imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+		return
+	}
+	for _, deviation := range entry.Deviations() {
+		gui.TextColored("Differs from known resource: "+deviation, imgui.Vec4{X: 1, Y: 1, Z: 0, W: 1})
+	}
+	if entry.BlockCount > 0 {
+		imgui.PushItemWidth(-150 * view.guiScale)
+		gui.StepSliderInt("Block", &view.model.selectedBlock, 0, entry.BlockCount-1)
+		imgui.PopItemWidth()
+		if imgui.Button("Dump Block...") {
+			view.dumpBlock(entry)
+		}
+		imgui.SameLine()
+		if imgui.Button("Show in Hex Editor") {
+			view.showBlock(entry.BlockKey(view.model.filename, view.mod.AdditionalLanguages(), view.model.selectedBlock))
+		}
+		imgui.SameLine()
+	}
+	if entry.Known && imgui.Button("Show in Editor") {
+		if view.showResource(entry.Key(view.model.filename, view.mod.AdditionalLanguages(), view.model.selectedBlock)) {
+			view.model.resultInfo = ""
+		} else {
+			view.model.resultInfo = "There is no editor for this resource."
+		}
+	}
+	if len(view.model.resultInfo) > 0 {
+		imgui.Text(view.model.resultInfo)
+	}
+}
+
+func (view *View) load(filename string) {
+	view.model.filename = filename
+	view.model.reader = nil
+	view.model.entries = nil
+	view.model.loadErr = nil
+	view.model.selectedIndex = -1
+	view.model.resultInfo = ""
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		view.model.loadErr = err
+		return
+	}
+	reader, err := lgres.ReaderFrom(bytes.NewReader(data))
+	if err != nil {
+		view.model.loadErr = err
+		return
+	}
+	view.model.reader = reader
+	view.model.entries = inspect.Entries(reader)
+}
+
+func (view *View) dumpBlock(entry inspect.Entry) {
+	data, err := inspect.Block(view.model.reader, entry.ID, view.model.selectedBlock)
+	if err != nil {
+		view.model.resultInfo = fmt.Sprintf("Could not read block: %v", err)
+		return
+	}
+	filename, err := dialog.File().Filter("All files (*.*)", "*").Title("Dump block").Save()
+	if err != nil {
+		return
+	}
+	err = ioutil.WriteFile(filename, data, 0644)
+	if err != nil {
+		view.model.resultInfo = fmt.Sprintf("Could not write file: %v", err)
+		return
+	}
+	view.model.resultInfo = fmt.Sprintf("Dumped %d bytes.", len(data))
+}
@@ -0,0 +1,26 @@
+package inspector
+
+import (
+	"github.com/inkyblackness/hacked/ss1/edit/inspect"
+	"github.com/inkyblackness/hacked/ss1/resource/lgres"
+)
+
+type viewModel struct {
+	windowOpen   bool
+	restoreFocus bool
+
+	filename string
+	reader   *lgres.Reader
+	entries  []inspect.Entry
+	loadErr  error
+
+	selectedIndex int
+	selectedBlock int
+	resultInfo    string
+}
+
+func freshViewModel() viewModel {
+	return viewModel{
+		selectedIndex: -1,
+	}
+}
@@ -4,3 +4,8 @@ package levels
 type LevelSelectionSetEvent struct {
 	id int
 }
+
+// NewLevelSelectionSetEvent returns an event that selects the given level.
+func NewLevelSelectionSetEvent(id int) LevelSelectionSetEvent {
+	return LevelSelectionSetEvent{id: id}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/inkyblackness/hacked/editor/render"
 	"github.com/inkyblackness/hacked/ss1/content/archive/level"
 	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/edit/objtypes"
 	"github.com/inkyblackness/hacked/ss1/resource"
 	"github.com/inkyblackness/hacked/ss1/world/ids"
 	"github.com/inkyblackness/hacked/ui/input"
@@ -198,21 +199,11 @@ func (display *MapDisplay) Render(properties object.PropertiesTable, lvl *level.
 		display.highlighter.Render(objects, fineCoordinatesPerTileSide/4, [4]float32{1.0, 1.0, 1.0, 0.3})
 	}
 	if paletteTexture != nil {
-		tripleOffsets := make(map[object.Triple]int)
-
-		{
-			offset := 0
-			properties.Iterate(func(triple object.Triple, prop *object.Properties) bool {
-				numExtra := int(prop.Common.Bitmap3D.FrameNumber())
-
-				if triple.Class != object.ClassTrap {
-					tripleOffsets[triple] = offset + 2
-				} else {
-					tripleOffsets[triple] = offset
-				}
-				offset += 3 + numExtra
-				return true
-			})
+		tripleOffsets := objtypes.BitmapOffsets(properties)
+		for triple := range tripleOffsets {
+			if triple.Class != object.ClassTrap {
+				tripleOffsets[triple] += 2
+			}
 		}
 		var icons []iconData
 		var highlightIcon iconData
@@ -228,7 +219,7 @@ func (display *MapDisplay) Render(properties object.PropertiesTable, lvl *level.
 			triple := entry.Triple()
 			index, cached := tripleOffsets[triple]
 			if cached {
-				key := resource.KeyOf(ids.ObjectBitmaps, resource.LangAny, index+1)
+				key := resource.KeyOf(ids.ObjectBitmaps, resource.LangAny, index)
 				texture, err := textureRetriever(key)
 				if err == nil {
 					icon := iconData{pos: MapPosition{X: entry.X, Y: entry.Y}, texture: texture}
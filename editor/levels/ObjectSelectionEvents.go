@@ -16,3 +16,8 @@ type ObjectSelectionAddEvent struct {
 type ObjectSelectionRemoveEvent struct {
 	objects []level.ObjectID
 }
+
+// NewObjectSelectionSetEvent returns an event that sets the given objects as current selection.
+func NewObjectSelectionSetEvent(objects []level.ObjectID) ObjectSelectionSetEvent {
+	return ObjectSelectionSetEvent{objects: objects}
+}
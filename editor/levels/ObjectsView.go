@@ -14,6 +14,7 @@ import (
 	"github.com/inkyblackness/hacked/ss1/content/archive/level"
 	"github.com/inkyblackness/hacked/ss1/content/archive/level/lvlids"
 	"github.com/inkyblackness/hacked/ss1/content/archive/level/lvlobj"
+	"github.com/inkyblackness/hacked/ss1/content/descriptions"
 	"github.com/inkyblackness/hacked/ss1/content/interpreters"
 	"github.com/inkyblackness/hacked/ss1/content/object"
 	"github.com/inkyblackness/hacked/ss1/content/text"
@@ -26,6 +27,7 @@ import (
 // ObjectsView is for object properties.
 type ObjectsView struct {
 	mod          *world.Mod
+	interpreters *descriptions.Interpreters
 	textCache    *text.Cache
 	textureCache *graphics.TextureCache
 
@@ -37,10 +39,12 @@ type ObjectsView struct {
 }
 
 // NewObjectsView returns a new instance.
-func NewObjectsView(mod *world.Mod, guiScale float32, textCache *text.Cache, textureCache *graphics.TextureCache,
+func NewObjectsView(mod *world.Mod, interpreters *descriptions.Interpreters, guiScale float32,
+	textCache *text.Cache, textureCache *graphics.TextureCache,
 	commander cmd.Commander, eventListener event.Listener, eventRegistry event.Registry) *ObjectsView {
 	view := &ObjectsView{
 		mod:          mod,
+		interpreters: interpreters,
 		textCache:    textCache,
 		textureCache: textureCache,
 
@@ -696,17 +700,17 @@ func (view *ObjectsView) requestBaseChange(lvl *level.Level, modifier func(*leve
 }
 
 func (view *ObjectsView) extraInterpreterFactory(lvl *level.Level) lvlobj.InterpreterFactory {
-	interpreterFactory := lvlobj.RealWorldExtra
+	interpreterFactory := view.interpreters.Level.RealWorldExtra
 	if lvl.IsCyberspace() {
-		interpreterFactory = lvlobj.CyberspaceExtra
+		interpreterFactory = view.interpreters.Level.CyberspaceExtra
 	}
 	return interpreterFactory
 }
 
 func (view *ObjectsView) classInterpreterFactory(lvl *level.Level) lvlobj.InterpreterFactory {
-	interpreterFactory := lvlobj.ForRealWorld
+	interpreterFactory := view.interpreters.Level.ForRealWorld
 	if lvl.IsCyberspace() {
-		interpreterFactory = lvlobj.ForCyberspace
+		interpreterFactory = view.interpreters.Level.ForCyberspace
 	}
 	return interpreterFactory
 }
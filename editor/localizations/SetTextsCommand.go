@@ -0,0 +1,49 @@
+package localizations
+
+import (
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+type textChange struct {
+	id    resource.ID
+	index int
+
+	oldData [][]byte
+	newData [][]byte
+}
+
+type setTextsCommand struct {
+	model *viewModel
+
+	lang    resource.Language
+	changes []textChange
+}
+
+func (cmd setTextsCommand) Do(modder world.Modder) error {
+	return cmd.perform(modder, func(change textChange) [][]byte { return change.newData })
+}
+
+func (cmd setTextsCommand) Undo(modder world.Modder) error {
+	return cmd.perform(modder, func(change textChange) [][]byte { return change.oldData })
+}
+
+func (cmd setTextsCommand) perform(modder world.Modder, dataResolver func(textChange) [][]byte) error {
+	for _, change := range cmd.changes {
+		data := dataResolver(change)
+		switch {
+		case change.index >= 0:
+			var block []byte
+			if len(data) > 0 {
+				block = data[0]
+			}
+			modder.SetResourceBlock(cmd.lang, change.id, change.index, block)
+		case len(data) > 0:
+			modder.SetResourceBlocks(cmd.lang, change.id, data)
+		default:
+			modder.DelResource(cmd.lang, change.id)
+		}
+	}
+	cmd.model.restoreFocus = true
+	return nil
+}
@@ -0,0 +1,254 @@
+package localizations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/editor/external"
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/edit/localization"
+	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+// View provides controls to exchange all texts with translation files.
+type View struct {
+	mod       *world.Mod
+	codepages text.Codepages
+
+	modalStateMachine gui.ModalStateMachine
+	guiScale          float32
+	commander         cmd.Commander
+
+	model viewModel
+}
+
+// NewLocalizationsView returns a new instance.
+func NewLocalizationsView(mod *world.Mod, codepages text.Codepages,
+	modalStateMachine gui.ModalStateMachine, guiScale float32, commander cmd.Commander) *View {
+	view := &View{
+		mod:       mod,
+		codepages: codepages,
+
+		modalStateMachine: modalStateMachine,
+		guiScale:          guiScale,
+		commander:         commander,
+
+		model: freshViewModel(),
+	}
+	return view
+}
+
+// WindowOpen returns the flag address, to be used with the main menu.
+func (view *View) WindowOpen() *bool {
+	return &view.model.windowOpen
+}
+
+// Render renders the view.
+func (view *View) Render() {
+	if view.model.restoreFocus {
+		imgui.SetNextWindowFocus()
+		view.model.restoreFocus = false
+		view.model.windowOpen = true
+	}
+	if view.model.windowOpen {
+		imgui.SetNextWindowSizeV(imgui.Vec2{X: 500 * view.guiScale, Y: 400 * view.guiScale}, imgui.ConditionOnce)
+		if imgui.BeginV("Localization Exchange", view.WindowOpen(), imgui.WindowFlagsNoCollapse) {
+			view.renderContent()
+		}
+		imgui.End()
+	}
+}
+
+func (view *View) renderContent() {
+	imgui.Text("Exchange all texts, messages, and names with\ntranslation files in gettext PO or XLIFF format.")
+	imgui.PushItemWidth(-150 * view.guiScale)
+	view.renderLanguageCombo("Source Language", &view.model.sourceLang)
+	view.renderLanguageCombo("Target Language", &view.model.targetLang)
+	formats := []string{"gettext PO", "XLIFF 1.2"}
+	formatIndex := 0
+	if view.model.xliff {
+		formatIndex = 1
+	}
+	if imgui.BeginCombo("Format", formats[formatIndex]) {
+		for index, format := range formats {
+			if imgui.SelectableV(format, index == formatIndex, 0, imgui.Vec2{}) {
+				view.model.xliff = index == 1
+			}
+		}
+		imgui.EndCombo()
+	}
+	imgui.PopItemWidth()
+
+	if imgui.Button("Export...") {
+		view.requestExport()
+	}
+	imgui.SameLine()
+	if imgui.Button("Import...") {
+		view.requestImport()
+	}
+	if view.model.lastExported > 0 {
+		imgui.Text(fmt.Sprintf("Exported %d texts.", view.model.lastExported))
+	}
+	if view.model.lastApplied > 0 {
+		imgui.Text(fmt.Sprintf("Applied %d changes.", view.model.lastApplied))
+	}
+	imgui.Separator()
+	if view.model.pending != nil {
+		view.renderPending(view.model.pending)
+	}
+}
+
+func (view *View) renderLanguageCombo(label string, lang *resource.Language) {
+	if imgui.BeginCombo(label, view.mod.LanguageName(*lang)) {
+		for _, other := range view.mod.Languages() {
+			if imgui.SelectableV(view.mod.LanguageName(other), other == *lang, 0, imgui.Vec2{}) {
+				*lang = other
+			}
+		}
+		imgui.EndCombo()
+	}
+}
+
+func (view *View) renderPending(result *localization.ImportResult) {
+	imgui.Text(fmt.Sprintf("Import into %s - changed: %d, unchanged: %d",
+		view.mod.LanguageName(view.model.pendingLang), len(result.Changed), result.Unchanged))
+	view.renderIDs(result.Missing, "Missing", imgui.Vec4{X: 1, Y: 1, Z: 0, W: 1})
+	view.renderIDs(result.OverLength, "Too long (not taken over)", imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+	view.renderIDs(result.Unmappable, "Not in codepage (not taken over)", imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+	view.renderIDs(result.Unknown, "Unknown", imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+	if len(result.Changes) > 0 {
+		if imgui.Button("Apply") {
+			view.requestApply(result)
+		}
+		imgui.SameLine()
+	}
+	if imgui.Button("Discard") {
+		view.model.pending = nil
+		return
+	}
+	if imgui.BeginChildV("Changes", imgui.Vec2{X: -1, Y: 0}, true, 0) {
+		for _, id := range result.Changed {
+			imgui.Text(id)
+		}
+	}
+	imgui.EndChild()
+}
+
+func (view *View) renderIDs(ids []string, title string, color imgui.Vec4) {
+	if len(ids) == 0 {
+		return
+	}
+	imgui.PushStyleColor(imgui.StyleColorText, color)
+	imgui.Text(fmt.Sprintf("%s: %d", title, len(ids)))
+	imgui.PopStyleColor()
+	if imgui.IsItemHovered() {
+		const maxShown = 30
+		text := ""
+		for index, id := range ids {
+			if index == maxShown {
+				text += fmt.Sprintf("... and %d more", len(ids)-maxShown)
+				break
+			}
+			text += id + "\n"
+		}
+		imgui.SetTooltip(text)
+	}
+}
+
+func (view *View) exportFilename() string {
+	extension := "po"
+	if view.model.xliff {
+		extension = "xlf"
+	}
+	return fmt.Sprintf("texts-%s.%s", strings.ToLower(view.mod.LanguageName(view.model.targetLang)), extension)
+}
+
+func (view *View) requestExport() {
+	filename := view.exportFilename()
+	info := "File to be written: " + filename
+	var exportTo func(string)
+
+	exportTo = func(dirname string) {
+		writer, err := os.Create(filepath.Join(dirname, filename))
+		if err != nil {
+			external.Export(view.modalStateMachine, "Could not create file.\n"+info, exportTo, true)
+			return
+		}
+		defer func() { _ = writer.Close() }()
+		units := localization.Units(view.mod, view.codepages, view.model.sourceLang, view.model.targetLang)
+		if view.model.xliff {
+			err = localization.SaveXLIFF(writer, units, view.mod.AdditionalLanguages().Code(view.model.sourceLang), view.mod.AdditionalLanguages().Code(view.model.targetLang))
+		} else {
+			err = localization.SavePO(writer, units, view.mod.AdditionalLanguages().Code(view.model.targetLang))
+		}
+		if err != nil {
+			external.Export(view.modalStateMachine, info, exportTo, true)
+			return
+		}
+		view.model.lastExported = len(units)
+		view.model.lastApplied = 0
+	}
+
+	external.Export(view.modalStateMachine, info, exportTo, false)
+}
+
+func (view *View) requestImport() {
+	info := fmt.Sprintf("Translations are imported into the %s language.\nOnly texts that differ are taken over.",
+		view.mod.LanguageName(view.model.targetLang))
+	types := []external.TypeInfo{
+		{Title: "Gettext files (*.po)", Extensions: []string{"po"}},
+		{Title: "XLIFF files (*.xlf, *.xliff)", Extensions: []string{"xlf", "xliff"}},
+	}
+	var fileHandler func(string)
+
+	fileHandler = func(filename string) {
+		reader, err := os.Open(filename)
+		if err != nil {
+			external.Import(view.modalStateMachine, "Could not open file.\n"+info, types, fileHandler, true)
+			return
+		}
+		defer func() { _ = reader.Close() }()
+		loader := localization.LoadPO
+		if strings.ToLower(filepath.Ext(filename)) != ".po" {
+			loader = localization.LoadXLIFF
+		}
+		units, err := loader(reader)
+		if err != nil {
+			external.Import(view.modalStateMachine, "File not recognized.\n"+info, types, fileHandler, true)
+			return
+		}
+		result := localization.Import(view.mod, view.codepages, view.model.sourceLang, view.model.targetLang, units)
+		view.model.pending = &result
+		view.model.pendingLang = view.model.targetLang
+		view.model.lastExported = 0
+		view.model.lastApplied = 0
+	}
+
+	external.Import(view.modalStateMachine, info, types, fileHandler, false)
+}
+
+func (view *View) requestApply(result *localization.ImportResult) {
+	lang := view.model.pendingLang
+	command := setTextsCommand{model: &view.model, lang: lang}
+	for _, change := range result.Changes {
+		entry := textChange{id: change.ID, index: change.Index, newData: change.Data}
+		if change.Index >= 0 {
+			if oldData := view.mod.ModifiedBlock(lang, change.ID, change.Index); oldData != nil {
+				entry.oldData = [][]byte{oldData}
+			}
+		} else {
+			entry.oldData = view.mod.ModifiedBlocks(lang, change.ID)
+		}
+		command.changes = append(command.changes, entry)
+	}
+	view.commander.Queue(command)
+	view.model.pending = nil
+	view.model.lastApplied = len(command.changes)
+}
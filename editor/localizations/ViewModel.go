@@ -0,0 +1,27 @@
+package localizations
+
+import (
+	"github.com/inkyblackness/hacked/ss1/edit/localization"
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+type viewModel struct {
+	windowOpen   bool
+	restoreFocus bool
+
+	sourceLang resource.Language
+	targetLang resource.Language
+	xliff      bool
+
+	lastExported int
+	pending      *localization.ImportResult
+	pendingLang  resource.Language
+	lastApplied  int
+}
+
+func freshViewModel() viewModel {
+	return viewModel{
+		sourceLang: resource.LangDefault,
+		targetLang: resource.LangGerman,
+	}
+}
@@ -10,8 +10,10 @@ import (
 	"github.com/inkyblackness/hacked/editor/render"
 	"github.com/inkyblackness/hacked/editor/values"
 	"github.com/inkyblackness/hacked/ss1/content/audio"
+	"github.com/inkyblackness/hacked/ss1/content/font"
 	"github.com/inkyblackness/hacked/ss1/content/movie"
 	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/edit/undoable"
 	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
 	"github.com/inkyblackness/hacked/ss1/resource"
 	"github.com/inkyblackness/hacked/ss1/world"
@@ -46,12 +48,14 @@ const videoMailDisplayBase = 256
 type View struct {
 	mod          *world.Mod
 	messageCache *text.ElectronicMessageCache
-	cp           text.Codepage
-	movieCache   *movie.Cache
+	codepages    text.Codepages
+	audioService undoable.AudioService
 	imageCache   *graphics.TextureCache
+	textPreview  *render.TextPreview
 
 	modalStateMachine gui.ModalStateMachine
 	clipboard         external.Clipboard
+	audioPlayer       *external.AudioPlayer
 	guiScale          float32
 	commander         cmd.Commander
 
@@ -59,19 +63,21 @@ type View struct {
 }
 
 // NewMessagesView returns a new instance.
-func NewMessagesView(mod *world.Mod, messageCache *text.ElectronicMessageCache, cp text.Codepage,
-	movieCache *movie.Cache, imageCache *graphics.TextureCache,
-	modalStateMachine gui.ModalStateMachine, clipboard external.Clipboard,
+func NewMessagesView(mod *world.Mod, messageCache *text.ElectronicMessageCache, codepages text.Codepages,
+	audioService undoable.AudioService, imageCache *graphics.TextureCache, textPreview *render.TextPreview,
+	modalStateMachine gui.ModalStateMachine, clipboard external.Clipboard, audioPlayer *external.AudioPlayer,
 	guiScale float32, commander cmd.Commander) *View {
 	view := &View{
 		mod:          mod,
 		messageCache: messageCache,
-		cp:           cp,
-		movieCache:   movieCache,
+		codepages:    codepages,
+		audioService: audioService,
 		imageCache:   imageCache,
+		textPreview:  textPreview,
 
 		modalStateMachine: modalStateMachine,
 		clipboard:         clipboard,
+		audioPlayer:       audioPlayer,
 		guiScale:          guiScale,
 		commander:         commander,
 
@@ -85,6 +91,22 @@ func (view *View) WindowOpen() *bool {
 	return &view.model.windowOpen
 }
 
+// ShowMessage selects the message with given key and brings the window to front.
+// The key refers to the first resource of the message type, with the index of the message.
+func (view *View) ShowMessage(key resource.Key) {
+	view.model.currentKey = key
+	view.model.restoreFocus = true
+}
+
+// ShowResource shows the message with given key if it refers to a known message type.
+func (view *View) ShowResource(key resource.Key) bool {
+	if _, known := knownMessageTypes[key.ID]; !known {
+		return false
+	}
+	view.ShowMessage(key)
+	return true
+}
+
 // Render renders the view.
 func (view *View) Render() {
 	if view.model.restoreFocus {
@@ -118,10 +140,10 @@ func (view *View) renderContent() {
 		if gui.StepSliderInt("Index", &index, 0, info.MaxCount-1) {
 			view.model.currentKey.Index = index
 		}
-		if imgui.BeginCombo("Language", view.model.currentKey.Lang.String()) {
-			languages := resource.Languages()
+		if imgui.BeginCombo("Language", view.mod.LanguageName(view.model.currentKey.Lang)) {
+			languages := view.mod.Languages()
 			for _, lang := range languages {
-				if imgui.SelectableV(lang.String(), lang == view.model.currentKey.Lang, 0, imgui.Vec2{}) {
+				if imgui.SelectableV(view.mod.LanguageName(lang), lang == view.model.currentKey.Lang, 0, imgui.Vec2{}) {
 					view.model.currentKey.Lang = lang
 				}
 			}
@@ -157,9 +179,13 @@ func (view *View) renderContent() {
 			if imgui.Button("Import") {
 				view.requestImportAudio()
 			}
+			view.model.audioEditor.Render("Audio", sound, false, 330*view.guiScale, view.guiScale, view.requestSoundEdit)
 		}
 		imgui.Separator()
 
+		if view.model.unmappableKey == view.model.currentKey {
+			render.UnmappableWarning(view.model.unmappable)
+		}
 		textModes := map[bool]string{true: "Verbose", false: "Terse"}
 		if imgui.BeginCombo("Text Mode", textModes[view.model.showVerboseText]) {
 			for _, setting := range []bool{true, false} {
@@ -243,10 +269,36 @@ func (view *View) renderContent() {
 		imgui.PopItemWidth()
 	}
 	imgui.EndChild()
+	imgui.SameLine()
+	if imgui.BeginChildV("Preview", imgui.Vec2{X: -1, Y: -200 * view.guiScale}, false, 0) {
+		view.renderPreview()
+	}
+	imgui.EndChild()
 
 	view.renderMFDs()
 }
 
+// renderPreview shows the current text as the game would lay it out.
+// Verbose texts are shown in the main view area, terse texts in an MFD.
+func (view *View) renderPreview() {
+	message, _ := view.currentMessage()
+	cp := view.codepages.ForLanguage(view.model.currentKey.Lang)
+	senderColor := render.DefaultTextColor
+	if message.ColorIndex >= 0 {
+		senderColor = byte(message.ColorIndex)
+	}
+	display := font.MessageDisplay
+	body := message.VerboseText
+	if !view.model.showVerboseText {
+		display = font.MFDDisplay
+		body = message.TerseText
+	}
+	view.textPreview.Render("MessagePreview", display,
+		font.Paragraph{Text: cp.Encode(message.Sender), Color: senderColor},
+		font.Paragraph{Text: cp.Encode(message.Subject), Color: senderColor},
+		font.Paragraph{Text: cp.Encode(body), Color: render.DefaultTextColor})
+}
+
 func (view *View) renderMFDs() {
 	message, readOnly := view.currentMessage()
 
@@ -294,13 +346,16 @@ func (view View) hasAudio() bool {
 
 func (view *View) currentSound() (sound audio.L8) {
 	if view.hasAudio() {
-		key := view.model.currentKey
-		key.ID = key.ID.Plus(300)
-		sound, _ = view.movieCache.Audio(key)
+		sound = view.audioService.Sound(view.currentSoundKey())
 	}
 	return
 }
 
+func (view View) currentSoundKey() resource.Key {
+	key := view.model.currentKey
+	return resource.KeyOf(key.ID.Plus(key.Index).Plus(300), key.Lang, 0)
+}
+
 func (view View) messageOf(key resource.Key) text.ElectronicMessage {
 	msg, cacheErr := view.messageCache.Message(key)
 	if cacheErr != nil {
@@ -372,20 +427,35 @@ func (view *View) renderSideImage(label string, index int) {
 func (view *View) requestExportAudio(sound audio.L8) {
 	filename := fmt.Sprintf("%05d_%s.wav",
 		view.model.currentKey.ID.Plus(view.model.currentKey.Index).Plus(300).Value(),
-		view.model.currentKey.Lang.String())
+		view.mod.LanguageName(view.model.currentKey.Lang))
 
 	external.ExportAudio(view.modalStateMachine, filename, sound)
 }
 
 func (view *View) requestImportAudio() {
-	external.ImportAudio(view.modalStateMachine, func(sound audio.L8) {
+	external.ImportAudio(view.modalStateMachine, view.audioPlayer, view.guiScale, external.ImportSampleRateFor(view.currentSound()), func(sound audio.L8) {
 		movieData := movie.ContainSoundData(sound)
 		view.requestAudioChange(movieData)
 	})
 }
 
+func (view *View) requestSoundEdit(sound audio.L8) {
+	view.audioService.RequestSetSound(view.currentSoundKey(), sound, view.restoreFunc())
+}
+
+func (view *View) restoreFunc() func() {
+	oldKey := view.model.currentKey
+	oldShowVerboseText := view.model.showVerboseText
+
+	return func() {
+		view.model.restoreFocus = true
+		view.model.currentKey = oldKey
+		view.model.showVerboseText = oldShowVerboseText
+	}
+}
+
 func (view *View) requestClear() {
-	view.requestWipe(text.EmptyElectronicMessage().Encode(view.cp))
+	view.requestWipe(text.EmptyElectronicMessage().Encode(view.codepages.ForLanguage(view.model.currentKey.Lang)))
 }
 
 func (view *View) requestRemove() {
@@ -396,7 +466,7 @@ func (view *View) requestWipe(newTextData [][]byte) {
 	textEntries := make(map[resource.Language]messageDataEntry)
 	audioEntries := make(map[resource.Language]messageDataEntry)
 	textID := view.model.currentKey.ID.Plus(view.model.currentKey.Index)
-	for lang := resource.Language(0); lang < resource.LanguageCount; lang++ {
+	for _, lang := range view.mod.Languages() {
 		textEntries[lang] = messageDataEntry{
 			oldData: view.mod.ModifiedBlocks(lang, textID),
 			newData: newTextData,
@@ -416,16 +486,19 @@ func (view *View) requestTextChange(modifier func(*text.ElectronicMessage)) {
 	entries := make(map[resource.Language]messageDataEntry)
 	modifier(&msg)
 
+	cp := view.codepages.ForLanguage(view.model.currentKey.Lang)
+	view.model.unmappableKey = view.model.currentKey
+	view.model.unmappable = text.UnmappableRunes(cp, msg.Title+msg.Sender+msg.Subject+msg.VerboseText+msg.TerseText)
 	entries[view.model.currentKey.Lang] = messageDataEntry{
 		oldData: view.mod.ModifiedBlocks(view.model.currentKey.Lang, view.model.currentKey.ID.Plus(view.model.currentKey.Index)),
-		newData: msg.Encode(view.cp),
+		newData: msg.Encode(cp),
 	}
 	view.requestSetMessageData(entries, nil)
 }
 
 func (view *View) requestPropertyChange(modifier func(*text.ElectronicMessage)) {
 	entries := make(map[resource.Language]messageDataEntry)
-	for lang := resource.Language(0); lang < resource.LanguageCount; lang++ {
+	for _, lang := range view.mod.Languages() {
 		key := view.model.currentKey
 		key.Lang = lang
 		msg := view.messageOf(key)
@@ -433,7 +506,7 @@ func (view *View) requestPropertyChange(modifier func(*text.ElectronicMessage))
 
 		entries[lang] = messageDataEntry{
 			oldData: view.mod.ModifiedBlocks(lang, key.ID.Plus(key.Index)),
-			newData: msg.Encode(view.cp),
+			newData: msg.Encode(view.codepages.ForLanguage(lang)),
 		}
 	}
 	view.requestSetMessageData(entries, nil)
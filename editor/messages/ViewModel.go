@@ -1,6 +1,7 @@
 package messages
 
 import (
+	"github.com/inkyblackness/hacked/editor/render"
 	"github.com/inkyblackness/hacked/ss1/resource"
 	"github.com/inkyblackness/hacked/ss1/world/ids"
 )
@@ -11,11 +12,17 @@ type viewModel struct {
 
 	currentKey      resource.Key
 	showVerboseText bool
+
+	unmappable    []rune
+	unmappableKey resource.Key
+
+	audioEditor render.AudioEditor
 }
 
 func freshViewModel() viewModel {
 	return viewModel{
 		currentKey:      resource.KeyOf(ids.MailsStart, resource.LangDefault, 0),
 		showVerboseText: true,
+		audioEditor:     render.NewAudioEditor(),
 	}
 }
@@ -0,0 +1,30 @@
+package mfd
+
+import (
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+type setArtCommand struct {
+	model *viewModel
+
+	key     resource.Key
+	oldData []byte
+	newData []byte
+}
+
+func (cmd setArtCommand) Do(modder world.Modder) error {
+	return cmd.perform(modder, cmd.newData)
+}
+
+func (cmd setArtCommand) Undo(modder world.Modder) error {
+	return cmd.perform(modder, cmd.oldData)
+}
+
+func (cmd setArtCommand) perform(modder world.Modder, data []byte) error {
+	modder.SetResourceBlock(cmd.key.Lang, cmd.key.ID, cmd.key.Index, data)
+
+	cmd.model.restoreFocus = true
+	cmd.model.currentKey = cmd.key
+	return nil
+}
@@ -0,0 +1,272 @@
+package mfd
+
+import (
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/editor/external"
+	"github.com/inkyblackness/hacked/editor/graphics"
+	"github.com/inkyblackness/hacked/editor/render"
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+	"github.com/inkyblackness/hacked/ui/gui"
+	"github.com/inkyblackness/hacked/ui/opengl"
+)
+
+// View provides edit controls for all the bitmaps of the MFD art files.
+type View struct {
+	mod          *world.Mod
+	gl           opengl.OpenGL
+	imageCache   *graphics.TextureCache
+	paletteCache *graphics.PaletteCache
+
+	modalStateMachine gui.ModalStateMachine
+	guiScale          float32
+	commander         cmd.Commander
+
+	model viewModel
+}
+
+// NewMfdArtView returns a new instance.
+func NewMfdArtView(mod *world.Mod, gl opengl.OpenGL, imageCache *graphics.TextureCache, paletteCache *graphics.PaletteCache,
+	modalStateMachine gui.ModalStateMachine, guiScale float32, commander cmd.Commander) *View {
+	view := &View{
+		mod:          mod,
+		gl:           gl,
+		imageCache:   imageCache,
+		paletteCache: paletteCache,
+
+		modalStateMachine: modalStateMachine,
+		guiScale:          guiScale,
+		commander:         commander,
+
+		model: freshViewModel(),
+	}
+	return view
+}
+
+// WindowOpen returns the flag address, to be used with the main menu.
+func (view *View) WindowOpen() *bool {
+	return &view.model.windowOpen
+}
+
+// Render renders the view.
+func (view *View) Render() {
+	if view.model.restoreFocus {
+		imgui.SetNextWindowFocus()
+		view.model.restoreFocus = false
+		view.model.windowOpen = true
+	}
+	if view.model.windowOpen {
+		imgui.SetNextWindowSizeV(imgui.Vec2{X: 800 * view.guiScale, Y: 440 * view.guiScale}, imgui.ConditionOnce)
+		if imgui.BeginV("MFD Art", view.WindowOpen(), imgui.WindowFlagsNoCollapse) {
+			view.renderContent()
+		}
+		imgui.End()
+	}
+}
+
+func (view *View) renderContent() {
+	artIDs := view.artResourceIDs()
+	if imgui.BeginChildV("Properties", imgui.Vec2{X: 350 * view.guiScale, Y: 0}, false, 0) {
+		imgui.PushItemWidth(-150 * view.guiScale)
+		if imgui.BeginCombo("Language", view.mod.LanguageName(view.model.currentKey.Lang)) {
+			for _, lang := range view.mod.Languages() {
+				if imgui.SelectableV(view.mod.LanguageName(lang), lang == view.model.currentKey.Lang, 0, imgui.Vec2{}) {
+					view.model.currentKey.Lang = lang
+				}
+			}
+			imgui.EndCombo()
+		}
+		if imgui.BeginCombo("Resource", view.resourceLabel(view.model.currentKey.ID)) {
+			for _, id := range artIDs {
+				if imgui.SelectableV(view.resourceLabel(id), id == view.model.currentKey.ID, 0, imgui.Vec2{}) {
+					view.model.currentKey.ID = id
+					view.model.currentKey.Index = 0
+				}
+			}
+			imgui.EndCombo()
+		}
+		count := view.blockCount(view.model.currentKey.ID)
+		if count > 0 {
+			gui.StepSliderInt("Index", &view.model.currentKey.Index, 0, count-1)
+			render.TextureSelector("###"+"IndexBitmap", -1, view.guiScale, count,
+				view.model.currentKey.Index, view.imageCache,
+				view.indexedKey,
+				func(index int) string { return fmt.Sprintf("%d", index) },
+				func(newValue int) {
+					view.model.currentKey.Index = newValue
+				})
+		}
+		view.renderBitmapControls(count)
+		imgui.PopItemWidth()
+	}
+	imgui.EndChild()
+	imgui.SameLine()
+	render.TextureImage("Big texture", view.imageCache, view.model.currentKey, imgui.Vec2{X: 320 * view.guiScale, Y: 240 * view.guiScale})
+}
+
+func (view *View) renderBitmapControls(count int) {
+	if imgui.Button("Add Entry") {
+		view.requestImport(count)
+	}
+	if count == 0 {
+		return
+	}
+	imgui.SameLine()
+	if imgui.Button("Import") {
+		view.requestImport(view.model.currentKey.Index)
+	}
+	_, err := view.imageCache.Texture(view.model.currentKey)
+	if err == nil {
+		imgui.SameLine()
+		if imgui.Button("Export") {
+			view.requestExport()
+		}
+	}
+	if view.hasModCurrentBitmap() {
+		imgui.SameLine()
+		if imgui.Button("Remove") {
+			view.requestSetBitmapData(view.model.currentKey, nil)
+		}
+	}
+	if header, hasHeader := graphics.BitmapHeader(view.mod, view.model.currentKey); hasHeader {
+		imgui.Separator()
+		render.BitmapHeader(header, false, view.requestSetBitmapHeader)
+	}
+}
+
+// artResourceIDs returns all compound bitmap resources stored in the MFD art files.
+func (view *View) artResourceIDs() []resource.ID {
+	var result []resource.ID
+	selector := view.mod.LocalizedResources(view.model.currentKey.Lang)
+	for _, id := range view.mod.ResourceIDsIn(ids.MfdArt) {
+		resourceView, err := selector.Select(id)
+		if (err == nil) && resourceView.Compound() && (resourceView.ContentType() == resource.Bitmap) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func (view *View) resourceLabel(id resource.ID) string {
+	label := fmt.Sprintf("%04X", id.Value())
+	if id == ids.MfdDataBitmaps {
+		label += " (MFD Data)"
+	}
+	return label
+}
+
+func (view *View) blockCount(id resource.ID) int {
+	resourceView, err := view.mod.LocalizedResources(view.model.currentKey.Lang).Select(id)
+	if err != nil {
+		return 0
+	}
+	return resourceView.BlockCount()
+}
+
+func (view *View) indexedKey(index int) resource.Key {
+	key := view.model.currentKey
+	key.Index = index
+	return key
+}
+
+func (view *View) hasModCurrentBitmap() bool {
+	key := view.model.currentKey
+	return len(view.mod.ModifiedBlock(key.Lang, key.ID, key.Index)) > 0
+}
+
+// templateHeader returns the header to use for new bitmaps of the current resource.
+// This is the header of the first bitmap in the resource, so that type and transparency match the other entries.
+func (view *View) templateHeader() bitmap.Header {
+	key := view.model.currentKey
+	for index := 0; index < view.blockCount(key.ID); index++ {
+		key.Index = index
+		if header, hasHeader := graphics.BitmapHeader(view.mod, key); hasHeader {
+			return header
+		}
+	}
+	return bitmap.Header{Type: bitmap.TypeCompressed8Bit, Flags: bitmap.FlagTransparent}
+}
+
+func (view *View) requestExport() {
+	key := view.model.currentKey
+	texture, err := view.imageCache.Texture(key)
+	if err != nil {
+		return
+	}
+	palette, err := view.paletteCache.Palette(0)
+	if err != nil {
+		return
+	}
+	rawPalette := palette.Palette()
+	filename := fmt.Sprintf("%05d_%03d_%s.png", key.ID.Value(), key.Index, view.mod.LanguageName(key.Lang))
+	width, height := texture.Size()
+	bmp := bitmap.Bitmap{
+		Header: bitmap.Header{
+			Width:  int16(width),
+			Height: int16(height),
+		},
+		Pixels:  texture.PixelData(),
+		Palette: &rawPalette,
+	}
+
+	external.ExportImage(view.modalStateMachine, filename, bmp)
+}
+
+func (view *View) requestImport(index int) {
+	key := view.model.currentKey
+	key.Index = index
+	paletteRetriever := func() (bitmap.Palette, error) {
+		palette, err := view.paletteCache.Palette(0)
+		if err != nil {
+			return bitmap.Palette{}, err
+		}
+		return palette.Palette(), nil
+	}
+	external.ImportImage(view.modalStateMachine, view.gl, paletteRetriever, func(bmp bitmap.Bitmap) {
+		view.requestSetBitmap(key, bmp)
+	})
+}
+
+func (view *View) requestSetBitmap(key resource.Key, bmp bitmap.Bitmap) {
+	template, hasOld := graphics.BitmapHeader(view.mod, key)
+	if hasOld {
+		bmp.Header.Area = template.Area.Scaled(template.Width, template.Height, bmp.Header.Width, bmp.Header.Height)
+	} else {
+		template = view.templateHeader()
+	}
+	bmp.Header.Type = bitmap.TypeCompressed8Bit
+	if template.Type.Is8Bit() {
+		bmp.Header.Type = template.Type
+	}
+	bmp.Header.Flags = template.Flags
+	bmp.Header.WidthFactor = bitmap.HighestBitShift(bmp.Header.Width)
+	bmp.Header.HeightFactor = bitmap.HighestBitShift(bmp.Header.Height)
+	bmp.Header.Stride = uint16(bmp.Header.Width)
+	view.requestSetBitmapData(key, bitmap.Encode(&bmp, 0))
+}
+
+func (view *View) requestSetBitmapHeader(header bitmap.Header) {
+	key := view.model.currentKey
+	newData, err := graphics.BitmapDataWithHeader(view.mod, key, header)
+	if err != nil {
+		return
+	}
+	view.requestSetBitmapData(key, newData)
+}
+
+func (view *View) requestSetBitmapData(key resource.Key, newData []byte) {
+	command := setArtCommand{
+		model: &view.model,
+
+		key:     key,
+		oldData: view.mod.ModifiedBlock(key.Lang, key.ID, key.Index),
+		newData: newData,
+	}
+	view.commander.Queue(command)
+}
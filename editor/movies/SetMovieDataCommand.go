@@ -0,0 +1,40 @@
+package movies
+
+import (
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+type setMovieDataCommand struct {
+	model *viewModel
+
+	displayKey   resource.Key
+	subtitleLang resource.Language
+	selectedCue  int
+
+	resourceKey resource.Key
+	oldData     [][]byte
+	newData     [][]byte
+}
+
+func (cmd setMovieDataCommand) Do(modder world.Modder) error {
+	return cmd.perform(modder, cmd.newData)
+}
+
+func (cmd setMovieDataCommand) Undo(modder world.Modder) error {
+	return cmd.perform(modder, cmd.oldData)
+}
+
+func (cmd setMovieDataCommand) perform(modder world.Modder, data [][]byte) error {
+	if len(data) > 0 {
+		modder.SetResourceBlocks(cmd.resourceKey.Lang, cmd.resourceKey.ID, data)
+	} else {
+		modder.DelResource(cmd.resourceKey.Lang, cmd.resourceKey.ID)
+	}
+
+	cmd.model.restoreFocus = true
+	cmd.model.currentKey = cmd.displayKey
+	cmd.model.subtitleLang = cmd.subtitleLang
+	cmd.model.selectedCue = cmd.selectedCue
+	return nil
+}
@@ -0,0 +1,385 @@
+package movies
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/editor/external"
+	"github.com/inkyblackness/hacked/editor/render"
+	"github.com/inkyblackness/hacked/ss1/content/movie"
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type movieInfo struct {
+	title            string
+	languageSpecific bool
+}
+
+var knownMovieTypes = map[resource.ID]movieInfo{
+	ids.MovieIntro:             {title: "Intro", languageSpecific: true},
+	ids.MovieDeath:             {title: "Death", languageSpecific: false},
+	ids.MovieEnd:               {title: "End", languageSpecific: false},
+	ids.MailsAudioStart:        {title: "Mail Audio", languageSpecific: true},
+	ids.LogsAudioStart:         {title: "Log Audio", languageSpecific: true},
+	ids.TrapMessagesAudioStart: {title: "Trap Audio", languageSpecific: true},
+}
+
+var knownMovieTypesOrder = []resource.ID{
+	ids.MovieIntro,
+	ids.MovieDeath,
+	ids.MovieEnd,
+	ids.MailsAudioStart,
+	ids.LogsAudioStart,
+	ids.TrapMessagesAudioStart,
+}
+
+// View provides edit controls for movies and their subtitles.
+type View struct {
+	mod        *world.Mod
+	codepages  text.Codepages
+	movieCache *movie.Cache
+
+	modalStateMachine gui.ModalStateMachine
+	clipboard         external.Clipboard
+	guiScale          float32
+	commander         cmd.Commander
+
+	model viewModel
+}
+
+// NewMoviesView returns a new instance.
+func NewMoviesView(mod *world.Mod, codepages text.Codepages, movieCache *movie.Cache,
+	modalStateMachine gui.ModalStateMachine, clipboard external.Clipboard,
+	guiScale float32, commander cmd.Commander) *View {
+	view := &View{
+		mod:        mod,
+		codepages:  codepages,
+		movieCache: movieCache,
+
+		modalStateMachine: modalStateMachine,
+		clipboard:         clipboard,
+		guiScale:          guiScale,
+		commander:         commander,
+
+		model: freshViewModel(),
+	}
+	return view
+}
+
+// WindowOpen returns the flag address, to be used with the main menu.
+func (view *View) WindowOpen() *bool {
+	return &view.model.windowOpen
+}
+
+// ShowResource selects the movie with given key and brings the window to front.
+// It returns false if the key does not refer to a known movie type.
+func (view *View) ShowResource(key resource.Key) bool {
+	if _, known := knownMovieTypes[key.ID]; !known {
+		return false
+	}
+	view.model.currentKey = key
+	view.model.restoreFocus = true
+	return true
+}
+
+// Render renders the view.
+func (view *View) Render() {
+	if view.model.restoreFocus {
+		imgui.SetNextWindowFocus()
+		view.model.restoreFocus = false
+		view.model.windowOpen = true
+	}
+	if view.model.windowOpen {
+		imgui.SetNextWindowSizeV(imgui.Vec2{X: 800 * view.guiScale, Y: 400 * view.guiScale}, imgui.ConditionOnce)
+		if imgui.BeginV("Movies", view.WindowOpen(), imgui.WindowFlagsNoCollapse) {
+			view.renderContent()
+		}
+		imgui.End()
+	}
+}
+
+func (view *View) renderContent() {
+	container, containerErr := view.movieCache.Movie(view.currentResourceKey())
+	cues := view.currentCues(container)
+	if view.model.selectedCue >= len(cues) {
+		view.model.selectedCue = len(cues) - 1
+	}
+
+	if imgui.BeginChildV("Properties", imgui.Vec2{X: 350 * view.guiScale, Y: 0}, false, 0) {
+		imgui.PushItemWidth(-150 * view.guiScale)
+		if imgui.BeginCombo("Movie Type", knownMovieTypes[view.model.currentKey.ID].title) {
+			for _, id := range knownMovieTypesOrder {
+				if imgui.SelectableV(knownMovieTypes[id].title, id == view.model.currentKey.ID, 0, imgui.Vec2{}) {
+					view.model.currentKey.ID = id
+					view.model.currentKey.Index = 0
+					view.model.selectedCue = -1
+				}
+			}
+			imgui.EndCombo()
+		}
+		selectedType := knownMovieTypes[view.model.currentKey.ID]
+		info, _ := ids.Info(view.model.currentKey.ID)
+		if info.MaxCount > 1 {
+			if gui.StepSliderInt("Index", &view.model.currentKey.Index, 0, info.MaxCount-1) {
+				view.model.selectedCue = -1
+			}
+		}
+		if selectedType.languageSpecific {
+			if view.model.currentKey.Lang == resource.LangAny {
+				view.model.currentKey.Lang = resource.LangDefault
+			}
+			view.renderLanguageCombo("Language", view.mod.Languages(), &view.model.currentKey.Lang)
+		} else {
+			view.model.currentKey.Lang = resource.LangAny
+		}
+
+		if containerErr == nil {
+			imgui.LabelText("Duration", fmt.Sprintf("%.2f sec", container.MediaDuration()))
+			if container.VideoWidth() != 0 {
+				imgui.LabelText("Video", fmt.Sprintf("%dx%d", container.VideoWidth(), container.VideoHeight()))
+			}
+			imgui.LabelText("Audio", fmt.Sprintf("%d Hz", container.AudioSampleRate()))
+			if view.hasModCurrentMovie() {
+				if imgui.Button("Remove") {
+					view.requestSetMovieData(nil, view.model.selectedCue)
+				}
+			} else {
+				imgui.Text("(read-only)")
+			}
+
+			imgui.Separator()
+			view.renderLanguageCombo("Subtitles", movie.SubtitleLanguages(), &view.model.subtitleLang)
+			imgui.LabelText("Cues", fmt.Sprintf("%d", len(cues)))
+			if imgui.Button("Add") {
+				view.requestAddCue(container, cues)
+			}
+			if view.model.selectedCue >= 0 {
+				imgui.SameLine()
+				if imgui.Button("Delete") {
+					view.requestRemoveCue(container, cues)
+				}
+			}
+			imgui.SameLine()
+			if imgui.Button("Import") {
+				view.requestImport(container)
+			}
+			if len(cues) > 0 {
+				if imgui.Button("Export SRT") {
+					view.requestExport(container, cues, "srt")
+				}
+				imgui.SameLine()
+				if imgui.Button("Export WebVTT") {
+					view.requestExport(container, cues, "vtt")
+				}
+			}
+			if (view.model.unmappableKey == view.model.currentKey) && (view.model.unmappableLang == view.model.subtitleLang) {
+				render.UnmappableWarning(view.model.unmappable)
+			}
+			if view.model.selectedCue >= 0 {
+				imgui.Separator()
+				view.renderSelectedCue(container, cues)
+			}
+		} else {
+			imgui.Text("(no movie available)")
+		}
+
+		imgui.PopItemWidth()
+	}
+	imgui.EndChild()
+	imgui.SameLine()
+	if imgui.BeginChildV("Cues", imgui.Vec2{X: -1, Y: 0}, true, 0) {
+		for index, cue := range cues {
+			label := strings.Split(cue.Text, "\n")[0]
+			if len(cue.Text) == 0 {
+				label = "(clear)"
+			}
+			if imgui.SelectableV(fmt.Sprintf("%8.3f  %s###%d", cue.Timestamp, label, index),
+				index == view.model.selectedCue, 0, imgui.Vec2{}) {
+				view.model.selectedCue = index
+			}
+		}
+	}
+	imgui.EndChild()
+}
+
+func (view *View) renderLanguageCombo(label string, languages []resource.Language, lang *resource.Language) {
+	if imgui.BeginCombo(label, view.mod.LanguageName(*lang)) {
+		for _, entry := range languages {
+			if imgui.SelectableV(entry.String(), entry == *lang, 0, imgui.Vec2{}) {
+				*lang = entry
+				view.model.selectedCue = -1
+			}
+		}
+		imgui.EndCombo()
+	}
+}
+
+func (view *View) renderSelectedCue(container movie.Container, cues []movie.SubtitleCue) {
+	cue := cues[view.model.selectedCue]
+	if (view.model.timestampCue != view.model.selectedCue) || (view.model.timestampBase != cue.Timestamp) {
+		view.model.timestampText = fmt.Sprintf("%.3f", cue.Timestamp)
+		view.model.timestampCue = view.model.selectedCue
+		view.model.timestampBase = cue.Timestamp
+	}
+	// The change is only taken over on Enter, as every change re-encodes the movie.
+	if imgui.InputTextV("Timestamp (sec)", &view.model.timestampText,
+		imgui.InputTextFlagsCharsDecimal|imgui.InputTextFlagsEnterReturnsTrue, nil) {
+		value, err := strconv.ParseFloat(view.model.timestampText, 32)
+		timestamp := float32(value)
+		if timestamp > container.MediaDuration() {
+			timestamp = container.MediaDuration()
+		}
+		if (err == nil) && (timestamp >= 0) && (timestamp != cue.Timestamp) {
+			view.requestCueChange(container, cues, func(cue *movie.SubtitleCue) { cue.Timestamp = timestamp })
+		}
+		view.model.timestampCue = -1
+	}
+	imgui.PushTextWrapPos()
+	if len(cue.Text) == 0 {
+		imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1.0, Y: 1.0, Z: 1.0, W: 0.5})
+		imgui.Text("(empty - clears subtitle)")
+		imgui.PopStyleColor()
+	} else {
+		imgui.Text(cue.Text)
+	}
+	imgui.PopTextWrapPos()
+	if imgui.BeginPopupContextItemV("Text-Popup", 1) {
+		if imgui.Selectable("Copy to Clipboard") {
+			view.clipboard.SetString(cue.Text)
+		}
+		if imgui.Selectable("Copy from Clipboard") {
+			newValue, err := view.clipboard.String()
+			if err == nil {
+				view.requestCueChange(container, cues, func(cue *movie.SubtitleCue) { cue.Text = newValue })
+			}
+		}
+		imgui.EndPopup()
+	}
+}
+
+func (view *View) currentResourceKey() resource.Key {
+	return resource.KeyOf(view.model.currentKey.ID.Plus(view.model.currentKey.Index), view.model.currentKey.Lang, 0)
+}
+
+func (view *View) currentCues(container movie.Container) []movie.SubtitleCue {
+	control, hasTrack := movie.SubtitleControlForLanguage(view.model.subtitleLang)
+	if (container == nil) || !hasTrack {
+		return nil
+	}
+	return movie.SubtitlesOf(container, control, view.codepages.ForLanguage(view.model.subtitleLang))
+}
+
+func (view *View) hasModCurrentMovie() bool {
+	key := view.currentResourceKey()
+	return len(view.mod.ModifiedBlocks(key.Lang, key.ID)) > 0
+}
+
+func (view *View) requestExport(container movie.Container, cues []movie.SubtitleCue, extension string) {
+	key := view.currentResourceKey()
+	filename := fmt.Sprintf("%05d_%s_%s.%s", key.ID.Value(), view.mod.LanguageName(key.Lang), view.mod.LanguageName(view.model.subtitleLang), extension)
+
+	external.ExportSubtitles(view.modalStateMachine, filename, cues, container.MediaDuration())
+}
+
+func (view *View) requestImport(container movie.Container) {
+	external.ImportSubtitles(view.modalStateMachine, func(cues []movie.SubtitleCue) {
+		view.requestSetCues(container, cues, -1)
+	})
+}
+
+func (view *View) requestAddCue(container movie.Container, cues []movie.SubtitleCue) {
+	newCue := movie.SubtitleCue{}
+	if view.model.selectedCue >= 0 {
+		newCue.Timestamp = cues[view.model.selectedCue].Timestamp + 1.0
+		if newCue.Timestamp > container.MediaDuration() {
+			newCue.Timestamp = container.MediaDuration()
+		}
+	}
+	newCues := make([]movie.SubtitleCue, 0, len(cues)+1)
+	newCues = append(newCues, cues...)
+	newCues = append(newCues, newCue)
+	view.requestSetCues(container, newCues, len(cues))
+}
+
+func (view *View) requestRemoveCue(container movie.Container, cues []movie.SubtitleCue) {
+	newCues := make([]movie.SubtitleCue, 0, len(cues))
+	newCues = append(newCues, cues[:view.model.selectedCue]...)
+	newCues = append(newCues, cues[view.model.selectedCue+1:]...)
+	view.requestSetCues(container, newCues, -1)
+}
+
+func (view *View) requestCueChange(container movie.Container, cues []movie.SubtitleCue, modifier func(*movie.SubtitleCue)) {
+	newCues := make([]movie.SubtitleCue, len(cues))
+	copy(newCues, cues)
+	modifier(&newCues[view.model.selectedCue])
+	view.requestSetCues(container, newCues, view.model.selectedCue)
+}
+
+// requestSetCues replaces the subtitles of the current language.
+// The cue at index trackedCue, if not negative, will be selected after the change.
+func (view *View) requestSetCues(container movie.Container, cues []movie.SubtitleCue, trackedCue int) {
+	control, hasTrack := movie.SubtitleControlForLanguage(view.model.subtitleLang)
+	if !hasTrack {
+		return
+	}
+	type indexedCue struct {
+		cue     movie.SubtitleCue
+		tracked bool
+	}
+	sortedCues := make([]indexedCue, len(cues))
+	for index, cue := range cues {
+		sortedCues[index] = indexedCue{cue: cue, tracked: index == trackedCue}
+	}
+	sort.SliceStable(sortedCues, func(a, b int) bool { return sortedCues[a].cue.Timestamp < sortedCues[b].cue.Timestamp })
+	newCues := make([]movie.SubtitleCue, len(sortedCues))
+	selectedCue := -1
+	for index, entry := range sortedCues {
+		newCues[index] = entry.cue
+		if entry.tracked {
+			selectedCue = index
+		}
+	}
+
+	cp := view.codepages.ForLanguage(view.model.subtitleLang)
+	var allText strings.Builder
+	for _, cue := range newCues {
+		allText.WriteString(cue.Text)
+	}
+	view.model.unmappable = text.UnmappableRunes(cp, allText.String())
+	view.model.unmappableKey = view.model.currentKey
+	view.model.unmappableLang = view.model.subtitleLang
+
+	newContainer := movie.WithSubtitles(container, control, newCues, cp)
+	buffer := bytes.NewBuffer(nil)
+	err := movie.Write(buffer, newContainer)
+	if err != nil {
+		return
+	}
+	view.requestSetMovieData([][]byte{buffer.Bytes()}, selectedCue)
+}
+
+func (view *View) requestSetMovieData(newData [][]byte, selectedCue int) {
+	resourceKey := view.currentResourceKey()
+	command := setMovieDataCommand{
+		model: &view.model,
+
+		displayKey:   view.model.currentKey,
+		subtitleLang: view.model.subtitleLang,
+		selectedCue:  selectedCue,
+
+		resourceKey: resourceKey,
+		oldData:     view.mod.ModifiedBlocks(resourceKey.Lang, resourceKey.ID),
+		newData:     newData,
+	}
+	view.commander.Queue(command)
+}
@@ -0,0 +1,32 @@
+package movies
+
+import (
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+)
+
+type viewModel struct {
+	windowOpen   bool
+	restoreFocus bool
+
+	currentKey   resource.Key
+	subtitleLang resource.Language
+	selectedCue  int
+
+	timestampText string
+	timestampCue  int
+	timestampBase float32
+
+	unmappable     []rune
+	unmappableKey  resource.Key
+	unmappableLang resource.Language
+}
+
+func freshViewModel() viewModel {
+	return viewModel{
+		currentKey:   resource.KeyOf(ids.MovieIntro, resource.LangDefault, 0),
+		subtitleLang: resource.LangDefault,
+		selectedCue:  -1,
+		timestampCue: -1,
+	}
+}
@@ -0,0 +1,56 @@
+package objects
+
+import (
+	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+type objectTypeListChange struct {
+	lang resource.Language
+	id   resource.ID
+
+	oldData [][]byte
+	newData [][]byte
+}
+
+type addObjectTypeCommand struct {
+	model *viewModel
+
+	oldTriple object.Triple
+	oldBitmap int
+	newTriple object.Triple
+
+	oldProperties object.PropertiesTable
+	newProperties object.PropertiesTable
+
+	lists []objectTypeListChange
+}
+
+func (command addObjectTypeCommand) Do(modder world.Modder) error {
+	modder.SetObjectPropertiesTable(command.newProperties)
+	for _, list := range command.lists {
+		modder.SetResourceBlocks(list.lang, list.id, list.newData)
+	}
+	return command.restoreFocus(command.newTriple, 0)
+}
+
+func (command addObjectTypeCommand) Undo(modder world.Modder) error {
+	for index := len(command.lists) - 1; index >= 0; index-- {
+		list := command.lists[index]
+		if list.oldData != nil {
+			modder.SetResourceBlocks(list.lang, list.id, list.oldData)
+		} else {
+			modder.DelResource(list.lang, list.id)
+		}
+	}
+	modder.SetObjectPropertiesTable(command.oldProperties)
+	return command.restoreFocus(command.oldTriple, command.oldBitmap)
+}
+
+func (command addObjectTypeCommand) restoreFocus(triple object.Triple, bitmap int) error {
+	command.model.restoreFocus = true
+	command.model.currentObject = triple
+	command.model.currentBitmap = bitmap
+	return nil
+}
@@ -0,0 +1,26 @@
+package objects
+
+import (
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ss1/content/object/objsheet"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type importPropertiesStartState struct {
+	machine gui.ModalStateMachine
+	view    *View
+	updates []objsheet.Update
+}
+
+func (state importPropertiesStartState) Render() {
+	imgui.OpenPopup("Import properties")
+	state.machine.SetState(&importPropertiesWaitingState{
+		machine: state.machine,
+		view:    state.view,
+		updates: state.updates,
+	})
+}
+
+func (state importPropertiesStartState) HandleFiles(names []string) {
+}
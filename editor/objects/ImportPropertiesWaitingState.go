@@ -0,0 +1,56 @@
+package objects
+
+import (
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ss1/content/object/objsheet"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type importPropertiesWaitingState struct {
+	machine gui.ModalStateMachine
+	view    *View
+	updates []objsheet.Update
+}
+
+func (state *importPropertiesWaitingState) Render() {
+	if imgui.BeginPopupModalV("Import properties", nil,
+		imgui.WindowFlagsNoResize|imgui.WindowFlagsNoMove|imgui.WindowFlagsNoSavedSettings|imgui.WindowFlagsAlwaysAutoResize) {
+
+		differenceCount := 0
+		for _, update := range state.updates {
+			differenceCount += len(update.Differences)
+		}
+		imgui.Text(fmt.Sprintf("Changed objects: %d, changed values: %d", len(state.updates), differenceCount))
+		guiScale := state.view.guiScale
+		if imgui.BeginChildV("Differences", imgui.Vec2{X: 600 * guiScale, Y: 300 * guiScale}, true, 0) {
+			for _, update := range state.updates {
+				imgui.Text(update.Triple.String() + ": " + state.view.objectName(update.Triple, resource.LangDefault, true))
+				for _, diff := range update.Differences {
+					imgui.Text(fmt.Sprintf("    %s: %s -> %s", diff.Column, diff.OldValue, diff.NewValue))
+				}
+			}
+		}
+		imgui.EndChild()
+		imgui.Separator()
+		if (len(state.updates) > 0) && imgui.Button("Apply") {
+			state.view.requestSetObjectPropertiesBatch(state.updates)
+			state.machine.SetState(nil)
+			imgui.CloseCurrentPopup()
+		}
+		imgui.SameLine()
+		if imgui.Button("Cancel") {
+			state.machine.SetState(nil)
+			imgui.CloseCurrentPopup()
+		}
+		imgui.EndPopup()
+	} else {
+		state.machine.SetState(nil)
+	}
+}
+
+func (state *importPropertiesWaitingState) HandleFiles(names []string) {
+}
@@ -5,27 +5,38 @@ import (
 	"github.com/inkyblackness/hacked/ss1/world"
 )
 
+type objectPropertiesChange struct {
+	triple object.Triple
+
+	oldProperties object.Properties
+	newProperties object.Properties
+}
+
 type setObjectPropertiesCommand struct {
 	model *viewModel
 
 	triple object.Triple
 	bitmap int
 
-	oldProperties object.Properties
-	newProperties object.Properties
+	changes []objectPropertiesChange
 }
 
 func (command setObjectPropertiesCommand) Do(modder world.Modder) error {
-	return command.perform(modder, command.newProperties)
+	for _, change := range command.changes {
+		modder.SetObjectProperties(change.triple, change.newProperties)
+	}
+	return command.restoreFocus()
 }
 
 func (command setObjectPropertiesCommand) Undo(modder world.Modder) error {
-	return command.perform(modder, command.oldProperties)
+	for index := len(command.changes) - 1; index >= 0; index-- {
+		change := command.changes[index]
+		modder.SetObjectProperties(change.triple, change.oldProperties)
+	}
+	return command.restoreFocus()
 }
 
-func (command setObjectPropertiesCommand) perform(modder world.Modder, properties object.Properties) error {
-	modder.SetObjectProperties(command.triple, properties)
-
+func (command setObjectPropertiesCommand) restoreFocus() error {
 	command.model.restoreFocus = true
 	command.model.currentObject = command.triple
 	command.model.currentBitmap = command.bitmap
@@ -2,6 +2,9 @@ package objects
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/inkyblackness/imgui-go"
@@ -11,22 +14,28 @@ import (
 	"github.com/inkyblackness/hacked/editor/render"
 	"github.com/inkyblackness/hacked/editor/values"
 	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/content/descriptions"
 	"github.com/inkyblackness/hacked/ss1/content/interpreters"
 	"github.com/inkyblackness/hacked/ss1/content/object"
-	"github.com/inkyblackness/hacked/ss1/content/object/objprop"
+	"github.com/inkyblackness/hacked/ss1/content/object/objsheet"
 	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/edit/objtypes"
 	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
 	"github.com/inkyblackness/hacked/ss1/resource"
 	"github.com/inkyblackness/hacked/ss1/world"
 	"github.com/inkyblackness/hacked/ss1/world/ids"
+	"github.com/inkyblackness/hacked/ss1/world/target"
 	"github.com/inkyblackness/hacked/ui/gui"
+	"github.com/inkyblackness/hacked/ui/opengl"
 )
 
 // View provides edit controls for game objects.
 type View struct {
 	mod          *world.Mod
+	gl           opengl.OpenGL
+	interpreters *descriptions.Interpreters
 	textCache    *text.Cache
-	cp           text.Codepage
+	codepages    text.Codepages
 	imageCache   *graphics.TextureCache
 	paletteCache *graphics.PaletteCache
 
@@ -39,14 +48,16 @@ type View struct {
 }
 
 // NewView returns a new instance.
-func NewView(mod *world.Mod, textCache *text.Cache, cp text.Codepage,
+func NewView(mod *world.Mod, gl opengl.OpenGL, interpreters *descriptions.Interpreters, textCache *text.Cache, codepages text.Codepages,
 	imageCache *graphics.TextureCache, paletteCache *graphics.PaletteCache,
 	modalStateMachine gui.ModalStateMachine,
 	clipboard external.Clipboard, guiScale float32, commander cmd.Commander) *View {
 	view := &View{
 		mod:          mod,
+		gl:           gl,
+		interpreters: interpreters,
 		textCache:    textCache,
-		cp:           cp,
+		codepages:    codepages,
 		imageCache:   imageCache,
 		paletteCache: paletteCache,
 
@@ -65,6 +76,31 @@ func (view *View) WindowOpen() *bool {
 	return &view.model.windowOpen
 }
 
+// ShowResource selects the object of the given name text and brings the window to front.
+// It returns false if the key does not refer to an object name.
+func (view *View) ShowResource(key resource.Key) bool {
+	if (key.ID != ids.ObjectLongNames) && (key.ID != ids.ObjectShortNames) {
+		return false
+	}
+	found := false
+	linearIndex := 0
+	view.mod.ObjectProperties().Iterate(func(triple object.Triple, _ *object.Properties) bool {
+		if linearIndex == key.Index {
+			view.model.currentObject = triple
+			found = true
+		}
+		linearIndex++
+		return !found
+	})
+	if !found {
+		return false
+	}
+	view.model.currentBitmap = 0
+	view.model.currentLang = key.Lang
+	view.model.restoreFocus = true
+	return true
+}
+
 // Render renders the view.
 func (view *View) Render() {
 	if view.model.restoreFocus {
@@ -123,10 +159,10 @@ func (view *View) renderContent() {
 
 		imgui.Separator()
 
-		if imgui.BeginCombo("Language", view.model.currentLang.String()) {
-			languages := resource.Languages()
+		if imgui.BeginCombo("Language", view.mod.LanguageName(view.model.currentLang)) {
+			languages := view.mod.Languages()
 			for _, lang := range languages {
-				if imgui.SelectableV(lang.String(), lang == view.model.currentLang, 0, imgui.Vec2{}) {
+				if imgui.SelectableV(view.mod.LanguageName(lang), lang == view.model.currentLang, 0, imgui.Vec2{}) {
 					view.model.currentLang = lang
 				}
 			}
@@ -142,6 +178,9 @@ func (view *View) renderContent() {
 			func(newValue string) {
 				view.requestSetObjectName(view.model.currentObject, false, newValue)
 			})
+		if (view.model.unmappableObject == view.model.currentObject) && (view.model.unmappableLang == view.model.currentLang) {
+			render.UnmappableWarning(view.model.unmappable)
+		}
 
 		if propErr == nil {
 			if imgui.TreeNodeV("Common Properties", imgui.TreeNodeFlagsDefaultOpen|imgui.TreeNodeFlagsFramed) {
@@ -167,6 +206,31 @@ func (view *View) renderContent() {
 
 	imgui.BeginGroup()
 	view.renderObjectBitmap()
+	imgui.Separator()
+	imgui.Text("Properties of all objects as CSV tables:")
+	if imgui.Button("Export Properties...") {
+		view.requestExportProperties()
+	}
+	if view.mod.HasModifyableObjectProperties() {
+		imgui.SameLine()
+		if imgui.Button("Import Properties...") {
+			view.requestImportProperties()
+		}
+		imgui.Separator()
+		subclassTypes := 0
+		for _, triple := range view.mod.ObjectProperties().TriplesInClass(view.model.currentObject.Class) {
+			if triple.Subclass == view.model.currentObject.Subclass {
+				subclassTypes++
+			}
+		}
+		imgui.Text(fmt.Sprintf("Subclass has %d types (maximum %d).", subclassTypes, object.MaxTypesPerSubclass))
+		profile, _ := target.ProfileByKey(view.mod.TargetProfile())
+		if !profile.AdditionalObjectTypes {
+			imgui.Text(fmt.Sprintf("The target %s supports no additional types.", profile.Title))
+		} else if (subclassTypes < object.MaxTypesPerSubclass) && imgui.Button("Add Type to Subclass") {
+			view.requestAddObjectType()
+		}
+	}
 	imgui.EndGroup()
 }
 
@@ -222,13 +286,17 @@ func (view *View) requestSetObjectName(triple object.Triple, longName bool, newV
 		oldValue, _ := view.textCache.Text(key)
 
 		if oldValue != newValue {
+			cp := view.codepages.ForLanguage(key.Lang)
+			view.model.unmappable = text.UnmappableRunes(cp, newValue)
+			view.model.unmappableObject = triple
+			view.model.unmappableLang = key.Lang
 			command := setObjectTextCommand{
 				model:   &view.model,
 				triple:  view.model.currentObject,
 				bitmap:  view.model.currentBitmap,
 				key:     key,
-				oldData: view.cp.Encode(oldValue),
-				newData: view.cp.Encode(text.Blocked(newValue)[0]),
+				oldData: cp.Encode(oldValue),
+				newData: cp.Encode(text.Blocked(newValue)[0]),
 			}
 			view.commander.Queue(command)
 		}
@@ -245,12 +313,138 @@ func (view *View) requestSetObjectProperties(modifier func(*object.Properties))
 	if err != nil {
 		return
 	}
-	command.oldProperties = currentProp.Clone()
-	command.newProperties = currentProp.Clone()
-	modifier(&command.newProperties)
+	change := objectPropertiesChange{
+		triple:        command.triple,
+		oldProperties: currentProp.Clone(),
+		newProperties: currentProp.Clone(),
+	}
+	modifier(&change.newProperties)
+	command.changes = append(command.changes, change)
 	view.commander.Queue(command)
 }
 
+func (view *View) requestSetObjectPropertiesBatch(updates []objsheet.Update) {
+	if len(updates) == 0 {
+		return
+	}
+	command := setObjectPropertiesCommand{
+		model:  &view.model,
+		triple: updates[0].Triple,
+	}
+	for _, update := range updates {
+		command.changes = append(command.changes, objectPropertiesChange{
+			triple:        update.Triple,
+			oldProperties: update.OldProperties,
+			newProperties: update.NewProperties,
+		})
+	}
+	view.commander.Queue(command)
+}
+
+func (view *View) requestAddObjectType() {
+	current := view.model.currentObject
+	oldProperties := view.mod.ObjectProperties()
+	addition, err := objtypes.Add(view.mod, view.codepages, view.mod.Languages(), oldProperties, current.Class, current.Subclass)
+	if err != nil {
+		return
+	}
+	command := addObjectTypeCommand{
+		model: &view.model,
+
+		oldTriple: current,
+		oldBitmap: view.model.currentBitmap,
+		newTriple: addition.Triple,
+
+		oldProperties: oldProperties.Clone(),
+		newProperties: addition.Properties,
+	}
+	for _, list := range addition.Lists {
+		command.lists = append(command.lists, objectTypeListChange{
+			lang:    list.Lang,
+			id:      list.ID,
+			oldData: view.mod.ModifiedBlocks(list.Lang, list.ID),
+			newData: list.Data,
+		})
+	}
+	view.commander.Queue(command)
+}
+
+func (view *View) sheetFilename(class object.Class) string {
+	return fmt.Sprintf("properties-%02d-%s.csv", int(class), strings.ToLower(class.String()))
+}
+
+func (view *View) requestExportProperties() {
+	info := "One CSV file per object class will be written, such as properties-00-gun.csv."
+	var exportTo func(string)
+
+	exportTo = func(dirname string) {
+		sheets := objsheet.Export(view.mod.ObjectProperties(), view.interpreters.Properties, func(triple object.Triple) string {
+			return view.objectName(triple, resource.LangDefault, true)
+		})
+		for _, sheet := range sheets {
+			err := view.writeSheet(filepath.Join(dirname, view.sheetFilename(sheet.Class)), sheet)
+			if err != nil {
+				external.Export(view.modalStateMachine, "Could not write file.\n"+info, exportTo, true)
+				return
+			}
+		}
+	}
+
+	external.Export(view.modalStateMachine, info, exportTo, false)
+}
+
+func (view *View) writeSheet(filename string, sheet objsheet.Sheet) error {
+	writer, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = writer.Close() }()
+	return objsheet.Write(writer, sheet)
+}
+
+func (view *View) requestImportProperties() {
+	info := "All CSV files of the folder are read, as written by an export.\nEmpty cells keep their value."
+	var importFrom func(string)
+
+	importFrom = func(dirname string) {
+		filenames, err := filepath.Glob(filepath.Join(dirname, "*.csv"))
+		if err != nil || (len(filenames) == 0) {
+			external.ImportFolder(view.modalStateMachine, "Folder has no CSV files.\n"+info, importFrom, true)
+			return
+		}
+		sort.Strings(filenames)
+		comparison := objsheet.NewComparison(view.mod.ObjectProperties(), view.interpreters.Properties)
+		for _, filename := range filenames {
+			err := compareSheet(comparison, filename)
+			if err != nil {
+				external.ImportFolder(view.modalStateMachine,
+					fmt.Sprintf("%s: %v\n%s", filepath.Base(filename), err, info), importFrom, true)
+				return
+			}
+		}
+		view.modalStateMachine.SetState(&importPropertiesStartState{
+			machine: view.modalStateMachine,
+			view:    view,
+			updates: comparison.Updates(),
+		})
+	}
+
+	external.ImportFolder(view.modalStateMachine, info, importFrom, false)
+}
+
+func compareSheet(comparison *objsheet.Comparison, filename string) error {
+	reader, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = reader.Close() }()
+	sheet, err := objsheet.Read(reader)
+	if err != nil {
+		return err
+	}
+	return comparison.Add(sheet)
+}
+
 func (view *View) renderCommonProperties(readOnly bool, properties *object.Properties) {
 	intIdentity := func(u values.Unifier) int { return u.Unified().(int) }
 	intFormat := func(value int) string { return "%d" }
@@ -513,20 +707,20 @@ func (view *View) renderCommonProperties(readOnly bool, properties *object.Prope
 }
 
 func (view *View) renderGenericProperties(readOnly bool, properties *object.Properties) {
-	readInterpreter := objprop.GenericProperties(view.model.currentObject.Class, properties.Generic)
+	readInterpreter := view.interpreters.Properties.GenericProperties(view.model.currentObject.Class, properties.Generic)
 	view.createPropertyControls(readOnly, readInterpreter, func(key string, modifier func(uint32) uint32) {
 		view.requestSetObjectProperties(func(prop *object.Properties) {
-			writeInterpreter := objprop.GenericProperties(view.model.currentObject.Class, prop.Generic)
+			writeInterpreter := view.interpreters.Properties.GenericProperties(view.model.currentObject.Class, prop.Generic)
 			view.setInterpreterValueKeyed(writeInterpreter, key, modifier)
 		})
 	})
 }
 
 func (view *View) renderSpecificProperties(readOnly bool, properties *object.Properties) {
-	readInterpreter := objprop.SpecificProperties(view.model.currentObject, properties.Specific)
+	readInterpreter := view.interpreters.Properties.SpecificProperties(view.model.currentObject, properties.Specific)
 	view.createPropertyControls(readOnly, readInterpreter, func(key string, modifier func(uint32) uint32) {
 		view.requestSetObjectProperties(func(prop *object.Properties) {
-			writeInterpreter := objprop.SpecificProperties(view.model.currentObject, prop.Specific)
+			writeInterpreter := view.interpreters.Properties.SpecificProperties(view.model.currentObject, prop.Specific)
 			view.setInterpreterValueKeyed(writeInterpreter, key, modifier)
 		})
 	})
@@ -596,6 +790,9 @@ func (view *View) renderObjectBitmap() {
 			view.requestSetBitmapData(nil)
 		}
 	}
+	if header, hasHeader := graphics.BitmapHeader(view.mod, view.currentBitmapKey()); hasHeader {
+		render.BitmapHeader(header, false, view.requestSetBitmapHeader)
+	}
 }
 
 func (view *View) requestClearBitmap() {
@@ -617,7 +814,7 @@ func (view *View) requestImportBitmap() {
 		}
 		return palette.Palette(), nil
 	}
-	external.ImportImage(view.modalStateMachine, paletteRetriever, func(bmp bitmap.Bitmap) {
+	external.ImportImage(view.modalStateMachine, view.gl, paletteRetriever, func(bmp bitmap.Bitmap) {
 		view.requestSetBitmap(bmp)
 	})
 }
@@ -654,24 +851,26 @@ func (view *View) hasModCurrentBitmap() bool {
 }
 
 func (view *View) requestSetBitmap(bmp bitmap.Bitmap) {
-	highestBitShift := func(value int16) (result byte) {
-		if value != 0 {
-			for (value >> result) != 1 {
-				result++
-			}
-		}
-		return
+	if oldHeader, hasOld := graphics.BitmapHeader(view.mod, view.currentBitmapKey()); hasOld {
+		bmp.Header.Area = oldHeader.Area.Scaled(oldHeader.Width, oldHeader.Height, bmp.Header.Width, bmp.Header.Height)
 	}
-
 	bmp.Header.Flags = bitmap.FlagTransparent
 	bmp.Header.Type = bitmap.TypeFlat8Bit
-	bmp.Header.WidthFactor = highestBitShift(bmp.Header.Width)
-	bmp.Header.HeightFactor = highestBitShift(bmp.Header.Height)
+	bmp.Header.WidthFactor = bitmap.HighestBitShift(bmp.Header.Width)
+	bmp.Header.HeightFactor = bitmap.HighestBitShift(bmp.Header.Height)
 	bmp.Header.Stride = uint16(bmp.Header.Width)
 	data := bitmap.Encode(&bmp, 0)
 	view.requestSetBitmapData(data)
 }
 
+func (view *View) requestSetBitmapHeader(header bitmap.Header) {
+	newData, err := graphics.BitmapDataWithHeader(view.mod, view.currentBitmapKey(), header)
+	if err != nil {
+		return
+	}
+	view.requestSetBitmapData(newData)
+}
+
 func (view *View) requestSetBitmapData(newData []byte) {
 	resourceKey := view.currentBitmapKey()
 
@@ -692,19 +891,10 @@ func (view *View) currentBitmapKey() resource.Key {
 }
 
 func (view *View) currentBitmapKeyFor(offset int) resource.Key {
-	baseOffset := 1
-	done := false
-	view.mod.ObjectProperties().Iterate(func(triple object.Triple, prop *object.Properties) bool {
-		if !done && triple == view.model.currentObject {
-			done = true
-		}
-		if done {
-			return false
-		}
-		numExtra := int(prop.Common.Bitmap3D.FrameNumber())
-		baseOffset += 3 + numExtra
-		return true
-	})
-
+	baseOffset := objtypes.BitmapOffset(view.mod.ObjectProperties(), view.model.currentObject)
+	if baseOffset < 0 {
+		// Types that are not in the table have no bitmaps. The invalid index selects none.
+		return resource.KeyOf(ids.ObjectBitmaps, resource.LangAny, -1)
+	}
 	return resource.KeyOf(ids.ObjectBitmaps, resource.LangAny, baseOffset+offset)
 }
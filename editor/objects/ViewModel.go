@@ -12,6 +12,10 @@ type viewModel struct {
 	currentObject object.Triple
 	currentBitmap int
 	currentLang   resource.Language
+
+	unmappable       []rune
+	unmappableObject object.Triple
+	unmappableLang   resource.Language
 }
 
 func freshViewModel() viewModel {
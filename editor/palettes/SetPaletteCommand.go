@@ -0,0 +1,70 @@
+package palettes
+
+import (
+	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+type resourceChange struct {
+	lang    resource.Language
+	id      resource.ID
+	oldData [][]byte
+	newData [][]byte
+}
+
+type setPaletteCommand struct {
+	model *viewModel
+
+	paletteIndex  int
+	selectedIndex int
+
+	changes []resourceChange
+
+	// mergeKey identifies a continuous change. Commands with the same, non-empty key are merged.
+	mergeKey string
+}
+
+func (cmd setPaletteCommand) Do(modder world.Modder) error {
+	return cmd.perform(modder, func(change resourceChange) [][]byte { return change.newData })
+}
+
+func (cmd setPaletteCommand) Undo(modder world.Modder) error {
+	return cmd.perform(modder, func(change resourceChange) [][]byte { return change.oldData })
+}
+
+func (cmd setPaletteCommand) MergedWith(previous cmd.Command) (cmd.Command, bool) {
+	prev, isPalette := previous.(setPaletteCommand)
+	if !isPalette || (cmd.mergeKey == "") || (prev.mergeKey != cmd.mergeKey) ||
+		(prev.paletteIndex != cmd.paletteIndex) || (prev.selectedIndex != cmd.selectedIndex) ||
+		(len(prev.changes) != len(cmd.changes)) {
+		return nil, false
+	}
+	merged := cmd
+	merged.changes = make([]resourceChange, len(cmd.changes))
+	for index, change := range cmd.changes {
+		prevChange := prev.changes[index]
+		if (prevChange.lang != change.lang) || (prevChange.id != change.id) {
+			return nil, false
+		}
+		change.oldData = prevChange.oldData
+		merged.changes[index] = change
+	}
+	return merged, true
+}
+
+func (cmd setPaletteCommand) perform(modder world.Modder, dataResolver func(resourceChange) [][]byte) error {
+	for _, change := range cmd.changes {
+		data := dataResolver(change)
+		if len(data) > 0 {
+			modder.SetResourceBlocks(change.lang, change.id, data)
+		} else {
+			modder.DelResource(change.lang, change.id)
+		}
+	}
+
+	cmd.model.restoreFocus = true
+	cmd.model.paletteIndex = cmd.paletteIndex
+	cmd.model.selectedIndex = cmd.selectedIndex
+	return nil
+}
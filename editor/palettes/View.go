@@ -0,0 +1,330 @@
+package palettes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/editor/graphics"
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+const entriesPerRow = 16
+
+// View provides edit controls for the game palettes.
+type View struct {
+	mod          *world.Mod
+	paletteCache *graphics.PaletteCache
+
+	guiScale  float32
+	commander cmd.Commander
+
+	reservedRanges bitmap.PaletteRanges
+
+	model viewModel
+}
+
+// NewPalettesView returns a new instance.
+func NewPalettesView(mod *world.Mod, paletteCache *graphics.PaletteCache, guiScale float32, commander cmd.Commander) *View {
+	view := &View{
+		mod:          mod,
+		paletteCache: paletteCache,
+
+		guiScale:  guiScale,
+		commander: commander,
+
+		reservedRanges: bitmap.ReservedPaletteRanges(),
+
+		model: freshViewModel(),
+	}
+	return view
+}
+
+// WindowOpen returns the flag address, to be used with the main menu.
+func (view *View) WindowOpen() *bool {
+	return &view.model.windowOpen
+}
+
+// Render renders the view.
+func (view *View) Render() {
+	if view.model.restoreFocus {
+		imgui.SetNextWindowFocus()
+		view.model.restoreFocus = false
+		view.model.windowOpen = true
+	}
+	if view.model.windowOpen {
+		imgui.SetNextWindowSizeV(imgui.Vec2{X: 800 * view.guiScale, Y: 440 * view.guiScale}, imgui.ConditionOnce)
+		if imgui.BeginV("Palettes", view.WindowOpen(), imgui.WindowFlagsNoCollapse) {
+			view.renderContent()
+		}
+		imgui.End()
+	}
+}
+
+func (view *View) renderContent() {
+	info, _ := ids.Info(ids.GamePalettesStart)
+	palette, paletteErr := view.currentPalette()
+
+	if imgui.BeginChildV("Properties", imgui.Vec2{X: 350 * view.guiScale, Y: 0}, false, 0) {
+		imgui.PushItemWidth(-150 * view.guiScale)
+		gui.StepSliderInt("Palette", &view.model.paletteIndex, 0, info.MaxCount-1)
+		if paletteErr != nil {
+			imgui.Text("(palette not available)")
+		} else {
+			if view.hasModCurrentPalette() {
+				if imgui.Button("Remove") {
+					view.requestRemove()
+				}
+			} else {
+				imgui.Text("(read-only)")
+			}
+			imgui.Separator()
+			view.renderEntryControls(palette)
+			imgui.Separator()
+			view.renderRemapControls(palette)
+		}
+		imgui.PopItemWidth()
+	}
+	imgui.EndChild()
+	imgui.SameLine()
+	if imgui.BeginChildV("Entries", imgui.Vec2{X: -1, Y: 0}, true, 0) && (paletteErr == nil) {
+		view.renderEntries(palette)
+	}
+	imgui.EndChild()
+}
+
+func (view *View) renderEntryControls(palette bitmap.Palette) {
+	gui.StepSliderInt("Index", &view.model.selectedIndex, 0, len(palette)-1)
+	imgui.LabelText("Reserved", view.reservedText(view.model.selectedIndex))
+	entry := palette[view.model.selectedIndex]
+	colorSlider := func(label string, value uint8, modifier func(*bitmap.RGB, uint8)) {
+		intValue := int(value)
+		if gui.StepSliderInt(label, &intValue, 0, 255) {
+			newPalette := palette
+			modifier(&newPalette[view.model.selectedIndex], uint8(intValue))
+			view.requestSetPalette(newPalette, bitmap.IdentityMapping(), "color-"+label)
+		}
+	}
+	colorSlider("Red", entry.Red, func(col *bitmap.RGB, value uint8) { col.Red = value })
+	colorSlider("Green", entry.Green, func(col *bitmap.RGB, value uint8) { col.Green = value })
+	colorSlider("Blue", entry.Blue, func(col *bitmap.RGB, value uint8) { col.Blue = value })
+	imgui.Text("Shading tables are derived from the whole palette.")
+}
+
+func (view *View) renderRemapControls(palette bitmap.Palette) {
+	gui.StepSliderInt("Target Index", &view.model.targetIndex, 0, len(palette)-1)
+	imgui.LabelText("Target Reserved", view.reservedText(view.model.targetIndex))
+	canRemap := view.model.paletteIndex == 0
+	if canRemap {
+		imgui.Checkbox("Remap Bitmaps", &view.model.remapBitmaps)
+	}
+	remap := canRemap && view.model.remapBitmaps
+	selected := view.model.selectedIndex
+	target := view.model.targetIndex
+	touchesTransparency := (selected == 0) || (target == 0)
+	if selected == target {
+		return
+	}
+	if remap && touchesTransparency {
+		imgui.Text("Transparency entry can not be remapped.")
+		return
+	}
+	if imgui.Button("Swap with Target") {
+		newPalette := palette
+		newPalette[selected], newPalette[target] = palette[target], palette[selected]
+		mapping := bitmap.IdentityMapping()
+		if remap {
+			mapping[selected] = byte(target)
+			mapping[target] = byte(selected)
+		}
+		view.requestSetPalette(newPalette, mapping, "")
+	}
+	if remap {
+		imgui.SameLine()
+		if imgui.Button("Merge into Target") {
+			mapping := bitmap.IdentityMapping()
+			mapping[selected] = byte(target)
+			view.requestSetPalette(palette, mapping, "")
+		}
+	}
+	if !remap {
+		imgui.Text("Without remapping, bitmaps will show the swapped colors.")
+	}
+}
+
+func (view *View) renderEntries(palette bitmap.Palette) {
+	size := imgui.Vec2{X: 24 * view.guiScale, Y: 24 * view.guiScale}
+	for index, entry := range palette {
+		if (index % entriesPerRow) != 0 {
+			imgui.SameLine()
+		}
+		color := imgui.Vec4{X: float32(entry.Red) / 255, Y: float32(entry.Green) / 255, Z: float32(entry.Blue) / 255, W: 1}
+		marker := ""
+		if r, reserved := view.reservedRanges.RangeOf(index); reserved {
+			marker = r.Purpose.String()[:1]
+		}
+		imgui.PushStyleColor(imgui.StyleColorButton, color)
+		imgui.PushStyleColor(imgui.StyleColorButtonHovered, color)
+		imgui.PushStyleColor(imgui.StyleColorButtonActive, color)
+		if index == view.model.selectedIndex {
+			marker = "[" + marker + "]"
+		}
+		if imgui.ButtonV(fmt.Sprintf("%s##%d", marker, index), size) {
+			view.model.selectedIndex = index
+		}
+		imgui.PopStyleColorV(3)
+		if imgui.IsItemHovered() {
+			imgui.SetTooltip(fmt.Sprintf("%3d (0x%02X): R%3d G%3d B%3d\n%s",
+				index, index, entry.Red, entry.Green, entry.Blue, view.reservedText(index)))
+		}
+	}
+}
+
+func (view *View) reservedText(index int) string {
+	r, reserved := view.reservedRanges.RangeOf(index)
+	if !reserved {
+		return "No"
+	}
+	return fmt.Sprintf("%s (%d..%d)", r.Purpose.String(), r.Start, r.Start+r.Count-1)
+}
+
+func (view *View) currentPaletteID() resource.ID {
+	return ids.GamePalettesStart.Plus(view.model.paletteIndex)
+}
+
+func (view *View) currentPalette() (bitmap.Palette, error) {
+	texture, err := view.paletteCache.Palette(view.model.paletteIndex)
+	if err != nil {
+		return bitmap.Palette{}, err
+	}
+	return texture.Palette(), nil
+}
+
+func (view *View) hasModCurrentPalette() bool {
+	return len(view.mod.ModifiedBlocks(resource.LangAny, view.currentPaletteID())) > 0
+}
+
+func (view *View) requestRemove() {
+	view.requestChanges([]resourceChange{{
+		lang:    resource.LangAny,
+		id:      view.currentPaletteID(),
+		oldData: view.mod.ModifiedBlocks(resource.LangAny, view.currentPaletteID()),
+		newData: nil,
+	}}, "")
+}
+
+func (view *View) requestSetPalette(palette bitmap.Palette, mapping bitmap.IndexMapping, mergeKey string) {
+	buf := bytes.NewBuffer(nil)
+	_ = binary.Write(buf, binary.LittleEndian, &palette)
+	changes := []resourceChange{{
+		lang:    resource.LangAny,
+		id:      view.currentPaletteID(),
+		oldData: view.mod.ModifiedBlocks(resource.LangAny, view.currentPaletteID()),
+		newData: [][]byte{buf.Bytes()},
+	}}
+	if !mapping.IsIdentity() {
+		changes = append(changes, view.remappedBitmaps(mapping)...)
+	}
+	view.requestChanges(changes, mergeKey)
+}
+
+// requestChanges queues the changes as one command. Consecutive changes with the same,
+// non-empty merge key are undone in one step, such as all the changes while dragging a slider.
+func (view *View) requestChanges(changes []resourceChange, mergeKey string) {
+	command := setPaletteCommand{
+		model:         &view.model,
+		paletteIndex:  view.model.paletteIndex,
+		selectedIndex: view.model.selectedIndex,
+		changes:       changes,
+		mergeKey:      mergeKey,
+	}
+	view.commander.Queue(command)
+}
+
+// remappedBitmaps collects all bitmap resources of the world and the mod, which change with the given mapping.
+func (view *View) remappedBitmaps(mapping bitmap.IndexMapping) []resourceChange {
+	var changes []resourceChange
+	for _, key := range view.bitmapResources() {
+		resourceView, err := view.mod.LocalizedResources(key.Lang).Select(key.ID)
+		if err != nil {
+			continue
+		}
+		newData := make([][]byte, resourceView.BlockCount())
+		changed := false
+		for index := 0; index < len(newData); index++ {
+			data := view.blockData(resourceView, index)
+			newData[index] = data
+			if len(data) == 0 {
+				continue
+			}
+			remapped, blockChanged, remapErr := bitmap.Remap(data, mapping)
+			if (remapErr == nil) && blockChanged {
+				newData[index] = remapped
+				changed = true
+			}
+		}
+		if changed {
+			changes = append(changes, resourceChange{
+				lang:    key.Lang,
+				id:      key.ID,
+				oldData: view.mod.ModifiedBlocks(key.Lang, key.ID),
+				newData: newData,
+			})
+		}
+	}
+	return changes
+}
+
+func (view *View) blockData(resourceView resource.View, index int) []byte {
+	reader, err := resourceView.Block(index)
+	if err != nil {
+		return nil
+	}
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// bitmapResources returns the keys of all known bitmap resources, per language, from both the world and the mod.
+// The index of the returned keys is not used.
+func (view *View) bitmapResources() []resource.Key {
+	var keys []resource.Key
+	known := make(map[resource.Key]bool)
+	add := func(lang resource.Language, id resource.ID) {
+		key := resource.KeyOf(id, lang, 0)
+		info, isKnown := ids.Info(id)
+		if known[key] || !isKnown || (info.ContentType != resource.Bitmap) {
+			return
+		}
+		known[key] = true
+		keys = append(keys, key)
+	}
+	manifest := view.mod.World()
+	for entryIndex := 0; entryIndex < manifest.EntryCount(); entryIndex++ {
+		entry, err := manifest.Entry(entryIndex)
+		if err != nil {
+			continue
+		}
+		for _, loc := range entry.Resources {
+			for _, id := range loc.Viewer.IDs() {
+				add(loc.Language, id)
+			}
+		}
+	}
+	for _, loc := range view.mod.ModifiedResources() {
+		for _, id := range loc.Store.IDs() {
+			add(loc.Language, id)
+		}
+	}
+	return keys
+}
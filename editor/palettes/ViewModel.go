@@ -0,0 +1,19 @@
+package palettes
+
+type viewModel struct {
+	windowOpen   bool
+	restoreFocus bool
+
+	paletteIndex  int
+	selectedIndex int
+	targetIndex   int
+	remapBitmaps  bool
+}
+
+func freshViewModel() viewModel {
+	return viewModel{
+		selectedIndex: 1,
+		targetIndex:   1,
+		remapBitmaps:  true,
+	}
+}
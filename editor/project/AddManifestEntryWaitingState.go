@@ -1,6 +1,8 @@
 package project
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/inkyblackness/imgui-go"
@@ -16,6 +18,7 @@ type addManifestEntryWaitingState struct {
 	machine     gui.ModalStateMachine
 	view        *View
 	failureTime time.Time
+	failureText string
 }
 
 func (state *addManifestEntryWaitingState) Render() {
@@ -25,7 +28,7 @@ func (state *addManifestEntryWaitingState) Render() {
 		imgui.Text("Waiting for folders/files.")
 		if !state.failureTime.IsZero() {
 			imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
-			imgui.Text("Previous attempt failed, no usable data detected.\nPlease check and try again.")
+			imgui.Text(state.failureText)
 			imgui.PopStyleColor()
 			if time.Since(state.failureTime).Seconds() > 5 {
 				state.failureTime = time.Time{}
@@ -56,11 +59,21 @@ Typically, you would use the main "data" directory of the game
 }
 
 func (state *addManifestEntryWaitingState) HandleFiles(names []string) {
-	staging := newFileStaging()
+	staging := newFileStaging(true)
 
 	staging.stageAll(names)
+	if staging.languagesErr != nil {
+		state.fail(fmt.Sprintf("Could not read %s: %v", world.LanguagesFilename, staging.languagesErr))
+		return
+	}
+	currentLanguages := state.view.mod.AdditionalLanguages()
+	languages := mergedLanguages(currentLanguages, staging.languages)
+	staging.takeOverUnlisted(languages)
 
 	if len(staging.resources) > 0 {
+		if len(languages) != len(currentLanguages) {
+			state.view.requestSetLanguages(languages)
+		}
 		entry := &world.ManifestEntry{
 			ID: names[0],
 		}
@@ -68,17 +81,39 @@ func (state *addManifestEntryWaitingState) HandleFiles(names []string) {
 		for filename, viewer := range staging.resources {
 			localized := resource.LocalizedResources{
 				ID:       filename,
-				Language: ids.LocalizeFilename(filename),
+				Language: ids.LocalizeFilenameWith(filename, languages),
 				Viewer:   viewer,
 			}
 			entry.Resources = append(entry.Resources, localized)
 		}
 		entry.ObjectProperties = staging.objectProperties
 		entry.TextureProperties = staging.textureProperties
+		entry.Fingerprint = staging.fingerprint()
+		state.view.sources.adopt(worldSources, staging.sources)
 
 		state.view.requestAddManifestEntry(entry)
 		state.machine.SetState(nil)
 	} else {
-		state.failureTime = time.Now()
+		state.fail("Previous attempt failed, no usable data detected.\nPlease check and try again.")
+	}
+}
+
+func (state *addManifestEntryWaitingState) fail(text string) {
+	state.failureTime = time.Now()
+	state.failureText = text
+}
+
+// mergedLanguages returns the current languages, followed by those staged languages that are not yet known by name.
+func mergedLanguages(current, staged []resource.LanguageSpec) []resource.LanguageSpec {
+	result := append([]resource.LanguageSpec{}, current...)
+	for _, spec := range staged {
+		known := false
+		for _, existing := range result {
+			known = known || strings.EqualFold(existing.Name, spec.Name)
+		}
+		if !known && (len(result) < resource.MaxAdditionalLanguages) {
+			result = append(result, spec)
+		}
 	}
+	return result
 }
@@ -0,0 +1,23 @@
+package project
+
+import (
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type applyPatchStartState struct {
+	machine gui.ModalStateMachine
+	view    *View
+}
+
+func (state applyPatchStartState) Render() {
+	imgui.OpenPopup("Apply patch")
+	state.machine.SetState(&applyPatchWaitingState{
+		machine: state.machine,
+		view:    state.view,
+	})
+}
+
+func (state applyPatchStartState) HandleFiles(names []string) {
+}
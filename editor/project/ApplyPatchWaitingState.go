@@ -0,0 +1,113 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/inkyblackness/imgui-go"
+	"github.com/sqweek/dialog"
+
+	"github.com/inkyblackness/hacked/ss1/world/patch"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type applyPatchWaitingState struct {
+	machine gui.ModalStateMachine
+	view    *View
+
+	patch         *patch.Patch
+	patchFilename string
+	errorInfo     string
+	resultInfo    string
+}
+
+func (state *applyPatchWaitingState) Render() {
+	if imgui.BeginPopupModalV("Apply patch", nil,
+		imgui.WindowFlagsNoResize|imgui.WindowFlagsNoMove|imgui.WindowFlagsNoSavedSettings|imgui.WindowFlagsAlwaysAutoResize) {
+
+		baseDirs := state.view.baseDirectories()
+		imgui.Text("Static world data: " + strings.Join(baseDirs, ", "))
+		if state.patch == nil {
+			imgui.Text(`From your file browser drag'n'drop the patch file
+into the editor window. The patch is verified against
+the static world data before it can be applied.`)
+		} else {
+			imgui.Text("Patch: " + state.patchFilename)
+			imgui.Text(`The patch matches the static world data.
+Drag'n'drop the folder to write the patched files
+into the editor window. You can then load it as mod.`)
+			imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+			imgui.Text("It is recommended to use an empty folder.\nApplying will overwrite existing files.")
+			imgui.PopStyleColor()
+		}
+		if len(state.errorInfo) > 0 {
+			imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+			imgui.Text(state.errorInfo)
+			imgui.PopStyleColor()
+		}
+		if len(state.resultInfo) > 0 {
+			imgui.Text(state.resultInfo)
+		}
+		imgui.Separator()
+		if imgui.Button("Browse...") {
+			var filename string
+			var err error
+			if state.patch == nil {
+				filename, err = dialog.File().Filter("Mod patches (*.hkpatch)", "hkpatch").Filter("All files (*.*)", "*").Load()
+			} else {
+				filename, err = dialog.Directory().Browse()
+			}
+			if err == nil {
+				state.HandleFiles([]string{filename})
+			}
+		}
+		imgui.SameLine()
+		if imgui.Button("Close") {
+			state.machine.SetState(nil)
+			imgui.CloseCurrentPopup()
+		}
+		imgui.EndPopup()
+	} else {
+		state.machine.SetState(nil)
+	}
+}
+
+func (state *applyPatchWaitingState) HandleFiles(names []string) {
+	if len(names) != 1 {
+		return
+	}
+	state.errorInfo = ""
+	state.resultInfo = ""
+	if state.patch == nil {
+		loaded, err := patch.LoadFile(names[0])
+		if err == nil {
+			err = patch.Verify(loaded, state.view.baseDirectories())
+		}
+		if err != nil {
+			state.errorInfo = err.Error()
+			return
+		}
+		state.patch = &loaded
+		state.patchFilename = names[0]
+		return
+	}
+	fileInfo, err := os.Stat(names[0])
+	if (err != nil) || !fileInfo.IsDir() {
+		state.errorInfo = "Not a folder: " + names[0]
+		return
+	}
+	for _, file := range state.patch.Files {
+		err = state.view.sources.detach(filepath.Join(names[0], file.Name))
+		if err != nil {
+			state.errorInfo = err.Error()
+			return
+		}
+	}
+	err = patch.ApplyToDirectory(*state.patch, state.view.baseDirectories(), names[0])
+	if err != nil {
+		state.errorInfo = err.Error()
+		return
+	}
+	state.resultInfo = "Patched " + pluralFiles(len(state.patch.Files)) + " into:\n" + names[0]
+}
@@ -0,0 +1,23 @@
+package project
+
+import (
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type createPatchStartState struct {
+	machine gui.ModalStateMachine
+	view    *View
+}
+
+func (state createPatchStartState) Render() {
+	imgui.OpenPopup("Create patch")
+	state.machine.SetState(&createPatchWaitingState{
+		machine: state.machine,
+		view:    state.view,
+	})
+}
+
+func (state createPatchStartState) HandleFiles(names []string) {
+}
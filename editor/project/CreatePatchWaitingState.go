@@ -0,0 +1,94 @@
+package project
+
+import (
+	"strings"
+
+	"github.com/inkyblackness/imgui-go"
+	"github.com/sqweek/dialog"
+
+	"github.com/inkyblackness/hacked/ss1/world/patch"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type createPatchWaitingState struct {
+	machine gui.ModalStateMachine
+	view    *View
+
+	errorInfo  string
+	resultInfo string
+}
+
+func (state *createPatchWaitingState) Render() {
+	if imgui.BeginPopupModalV("Create patch", nil,
+		imgui.WindowFlagsNoResize|imgui.WindowFlagsNoMove|imgui.WindowFlagsNoSavedSettings|imgui.WindowFlagsAlwaysAutoResize) {
+
+		modPath := state.view.mod.Path()
+		baseDirs := state.view.baseDirectories()
+		imgui.Text(`A patch contains the differences of the saved mod
+to the static world data. It can only be applied
+to the same static world data it was created from.`)
+		imgui.Separator()
+		imgui.Text("Static world data: " + strings.Join(baseDirs, ", "))
+		imgui.Text("Mod: " + modPath)
+		ready := true
+		if len(baseDirs) == 0 {
+			state.renderError("There is no static world data to compare against.")
+			ready = false
+		}
+		if len(modPath) == 0 {
+			state.renderError("The mod has not been saved yet.")
+			ready = false
+		} else if len(state.view.mod.ModifiedFilenames()) > 0 {
+			state.renderError("The mod has unsaved changes. Please save first.")
+			ready = false
+		}
+		if len(state.errorInfo) > 0 {
+			state.renderError(state.errorInfo)
+		}
+		if len(state.resultInfo) > 0 {
+			imgui.Text(state.resultInfo)
+		}
+		imgui.Separator()
+		if ready && imgui.Button("Save As...") {
+			filename, err := dialog.File().Filter("Mod patches (*.hkpatch)", "hkpatch").Title("Save patch").Save()
+			if err == nil {
+				state.HandleFiles([]string{filename})
+			}
+		}
+		imgui.SameLine()
+		if imgui.Button("Close") {
+			state.machine.SetState(nil)
+			imgui.CloseCurrentPopup()
+		}
+		imgui.EndPopup()
+	} else {
+		state.machine.SetState(nil)
+	}
+}
+
+func (state *createPatchWaitingState) renderError(text string) {
+	imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+	imgui.Text(text)
+	imgui.PopStyleColor()
+}
+
+func (state *createPatchWaitingState) HandleFiles(names []string) {
+	if len(names) != 1 {
+		return
+	}
+	state.errorInfo = ""
+	state.resultInfo = ""
+	filename := names[0]
+	if !strings.HasSuffix(strings.ToLower(filename), ".hkpatch") {
+		filename += ".hkpatch"
+	}
+	created, err := patch.CreateFromDirectories(state.view.baseDirectories(), state.view.mod.Path())
+	if err == nil {
+		err = patch.SaveFile(filename, created)
+	}
+	if err != nil {
+		state.errorInfo = err.Error()
+		return
+	}
+	state.resultInfo = "Patch saved with changes of " + pluralFiles(len(created.Files)) + ":\n" + filename
+}
@@ -0,0 +1,59 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/inkyblackness/hacked/ss1/content/descriptions"
+)
+
+// descriptionsFilename is the name of the file with object descriptions, located next to the executable.
+const descriptionsFilename = "descriptions.json"
+
+func descriptionsPath() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return descriptionsFilename
+	}
+	return filepath.Join(filepath.Dir(exe), descriptionsFilename)
+}
+
+// loadDescriptions returns the interpreters based on the object descriptions of the given file, and a status text.
+// Without the file, the built-in descriptions are used.
+func loadDescriptions(absFilename string) (descriptions.Interpreters, string, error) {
+	file, err := os.Open(absFilename)
+	if os.IsNotExist(err) {
+		return descriptions.Builtin(), "Using built-in descriptions (no file)", nil
+	}
+	if err != nil {
+		return descriptions.Interpreters{}, "", err
+	}
+	defer func() {
+		_ = file.Close() // nolint: gas
+	}()
+	loaded, err := descriptions.Load(file)
+	if err != nil {
+		return descriptions.Interpreters{}, "", err
+	}
+	interpreters, err := descriptions.Build(loaded)
+	if err != nil {
+		return descriptions.Interpreters{}, "", err
+	}
+	return interpreters, fmt.Sprintf("Using %d description(s) from file", len(loaded.Entries)), nil
+}
+
+func saveDefaultDescriptions(absFilename string) error {
+	defaults, err := descriptions.Defaults()
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(absFilename)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close() // nolint: gas
+	}()
+	return descriptions.Save(file, defaults)
+}
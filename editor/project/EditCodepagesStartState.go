@@ -0,0 +1,25 @@
+package project
+
+import (
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type editCodepagesStartState struct {
+	machine gui.ModalStateMachine
+	view    *View
+}
+
+func (state editCodepagesStartState) Render() {
+	imgui.OpenPopup("Codepages")
+	state.machine.SetState(&editCodepagesWaitingState{
+		machine:   state.machine,
+		view:      state.view,
+		tableLang: resource.LangDefault,
+	})
+}
+
+func (state editCodepagesStartState) HandleFiles(names []string) {
+}
@@ -0,0 +1,131 @@
+package project
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/inkyblackness/imgui-go"
+	"github.com/sqweek/dialog"
+
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type editCodepagesWaitingState struct {
+	machine gui.ModalStateMachine
+	view    *View
+
+	tableLang   resource.Language
+	failureTime time.Time
+}
+
+func (state *editCodepagesWaitingState) Render() {
+	if imgui.BeginPopupModalV("Codepages", nil,
+		imgui.WindowFlagsNoResize|imgui.WindowFlagsNoMove|imgui.WindowFlagsNoSavedSettings|imgui.WindowFlagsAlwaysAutoResize) {
+
+		imgui.Text(`The texts of each language are stored in a codepage.
+The fonts of the game need to provide their characters
+in the layout of the selected codepage.
+Characters that are not in the codepage are stored as '?'.`)
+		imgui.Separator()
+
+		current := state.view.mod.CodepageAssignments()
+		imgui.PushItemWidth(-150 * state.view.guiScale)
+		for _, lang := range state.view.mod.Languages() {
+			selected := text.CP437
+			for _, assignment := range current {
+				if assignment.Language == lang {
+					selected = assignment.Name
+				}
+			}
+			if imgui.BeginCombo(state.view.mod.LanguageName(lang), selected) {
+				for _, name := range text.CodepageNames() {
+					if imgui.SelectableV(name, strings.EqualFold(name, selected), 0, imgui.Vec2{}) {
+						state.requestAssign(current, world.CodepageAssignment{Language: lang, Name: name})
+					}
+				}
+				imgui.EndCombo()
+			}
+		}
+		imgui.Separator()
+
+		if !state.failureTime.IsZero() {
+			imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+			imgui.Text("File not a valid mapping table.")
+			imgui.PopStyleColor()
+			if time.Since(state.failureTime).Seconds() > 5 {
+				state.failureTime = time.Time{}
+			}
+		}
+		imgui.Text(`A custom mapping table lists one "0xNN 0xUUUU" pair per line,
+mapping a byte value to a Unicode character.
+Drag'n'drop a table onto this window, or browse for it,
+to assign it to the following language:`)
+		if imgui.BeginCombo("Custom Table For", state.view.mod.LanguageName(state.tableLang)) {
+			for _, lang := range state.view.mod.Languages() {
+				if imgui.SelectableV(state.view.mod.LanguageName(lang), lang == state.tableLang, 0, imgui.Vec2{}) {
+					state.tableLang = lang
+				}
+			}
+			imgui.EndCombo()
+		}
+		imgui.PopItemWidth()
+		if imgui.Button("Browse...") {
+			filename, err := dialog.File().Filter("Mapping tables (*.txt)", "txt").Filter("All files (*.*)", "*").Load()
+			if err == nil {
+				state.HandleFiles([]string{filename})
+			}
+		}
+		imgui.SameLine()
+		if imgui.Button("Close") {
+			state.machine.SetState(nil)
+			imgui.CloseCurrentPopup()
+		}
+		imgui.EndPopup()
+	} else {
+		state.machine.SetState(nil)
+	}
+}
+
+func (state *editCodepagesWaitingState) requestAssign(current []world.CodepageAssignment, assignment world.CodepageAssignment) {
+	var newAssignments []world.CodepageAssignment
+	for _, other := range current {
+		if other.Language != assignment.Language {
+			newAssignments = append(newAssignments, other)
+		}
+	}
+	if (assignment.Table != nil) || !strings.EqualFold(assignment.Name, text.CP437) {
+		newAssignments = append(newAssignments, assignment)
+	}
+	state.view.requestSetCodepages(newAssignments)
+}
+
+func (state *editCodepagesWaitingState) HandleFiles(names []string) {
+	if len(names) != 1 {
+		state.failureTime = time.Now()
+		return
+	}
+	table, err := ioutil.ReadFile(names[0])
+	if err != nil {
+		state.failureTime = time.Now()
+		return
+	}
+	assignment := world.CodepageAssignment{
+		Language: state.tableLang,
+		Name:     strings.ReplaceAll(filepath.Base(names[0]), " ", "_"),
+		Table:    table,
+	}
+	_, isNamed := text.CodepageByName(assignment.Name)
+	isModFile := strings.EqualFold(assignment.Name, world.CodepagesFilename) ||
+		strings.EqualFold(assignment.Name, world.LanguagesFilename)
+	if _, err = assignment.Codepage(); (err != nil) || isNamed || isModFile {
+		state.failureTime = time.Now()
+		return
+	}
+	state.failureTime = time.Time{}
+	state.requestAssign(state.view.mod.CodepageAssignments(), assignment)
+}
@@ -0,0 +1,23 @@
+package project
+
+import (
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type editDescriptionsStartState struct {
+	machine gui.ModalStateMachine
+	view    *View
+}
+
+func (state editDescriptionsStartState) Render() {
+	imgui.OpenPopup("Object descriptions")
+	state.machine.SetState(&editDescriptionsWaitingState{
+		machine: state.machine,
+		view:    state.view,
+	})
+}
+
+func (state editDescriptionsStartState) HandleFiles(names []string) {
+}
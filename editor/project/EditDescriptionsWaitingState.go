@@ -0,0 +1,89 @@
+package project
+
+import (
+	"github.com/inkyblackness/imgui-go"
+	"github.com/sqweek/dialog"
+
+	"github.com/inkyblackness/hacked/ss1/content/descriptions"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type editDescriptionsWaitingState struct {
+	machine gui.ModalStateMachine
+	view    *View
+
+	errorInfo string
+}
+
+func (state *editDescriptionsWaitingState) Render() {
+	if imgui.BeginPopupModalV("Object descriptions", nil,
+		imgui.WindowFlagsNoResize|imgui.WindowFlagsNoMove|imgui.WindowFlagsNoSavedSettings|imgui.WindowFlagsAlwaysAutoResize) {
+
+		imgui.Text(`Object descriptions specify the fields of object data.
+They are read from a JSON file, which takes precedence over the built-in ones.
+Write the built-in descriptions to a file as a starting point for own findings.`)
+		imgui.Separator()
+		imgui.Text("File: " + state.view.descriptionsPath)
+		imgui.Text("Status: " + state.view.descriptionsInfo)
+		if len(state.errorInfo) > 0 {
+			imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+			imgui.Text(state.errorInfo)
+			imgui.PopStyleColor()
+		}
+		imgui.Separator()
+		if imgui.Button("Reload") {
+			state.reload()
+		}
+		imgui.SameLine()
+		if imgui.Button("Load...") {
+			filename, err := dialog.File().Filter("Object descriptions (*.json)", "json").Title("Load object descriptions").Load()
+			if err == nil {
+				state.HandleFiles([]string{filename})
+			}
+		}
+		imgui.SameLine()
+		if imgui.Button("Write Built-in...") {
+			filename, err := dialog.File().Filter("Object descriptions (*.json)", "json").Title("Save built-in descriptions").Save()
+			if err == nil {
+				state.errorInfo = ""
+				err = saveDefaultDescriptions(filename)
+				if err != nil {
+					state.errorInfo = err.Error()
+				}
+			}
+		}
+		imgui.SameLine()
+		if imgui.Button("Use Built-in") {
+			state.errorInfo = ""
+			*state.view.interpreters = descriptions.Builtin()
+			state.view.descriptionsInfo = "Using built-in descriptions"
+		}
+		imgui.SameLine()
+		if imgui.Button("Close") {
+			state.machine.SetState(nil)
+			imgui.CloseCurrentPopup()
+		}
+		imgui.EndPopup()
+	} else {
+		state.machine.SetState(nil)
+	}
+}
+
+func (state *editDescriptionsWaitingState) reload() {
+	state.errorInfo = ""
+	interpreters, info, err := loadDescriptions(state.view.descriptionsPath)
+	if err != nil {
+		state.errorInfo = err.Error()
+		return
+	}
+	*state.view.interpreters = interpreters
+	state.view.descriptionsInfo = info
+}
+
+func (state *editDescriptionsWaitingState) HandleFiles(names []string) {
+	if len(names) != 1 {
+		return
+	}
+	state.view.descriptionsPath = names[0]
+	state.reload()
+}
@@ -0,0 +1,25 @@
+package project
+
+import (
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type editLanguagesStartState struct {
+	machine gui.ModalStateMachine
+	view    *View
+}
+
+func (state editLanguagesStartState) Render() {
+	imgui.OpenPopup("Languages")
+	state.machine.SetState(&editLanguagesWaitingState{
+		machine: state.machine,
+		view:    state.view,
+		newSpec: resource.LanguageSpec{FilenamePattern: resource.FilenamePlaceholder + "_xx"},
+	})
+}
+
+func (state editLanguagesStartState) HandleFiles(names []string) {
+}
@@ -0,0 +1,80 @@
+package project
+
+import (
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type editLanguagesWaitingState struct {
+	machine gui.ModalStateMachine
+	view    *View
+
+	newSpec resource.LanguageSpec
+}
+
+func (state *editLanguagesWaitingState) Render() {
+	if imgui.BeginPopupModalV("Languages", nil,
+		imgui.WindowFlagsNoResize|imgui.WindowFlagsNoMove|imgui.WindowFlagsNoSavedSettings|imgui.WindowFlagsAlwaysAutoResize) {
+
+		imgui.Text(`Besides Default, French, and German, the mod can have
+further languages, as supported by source ports.
+Their files are named after the pattern, with
+` + resource.FilenamePlaceholder + ` replaced by the name of the default file.
+Add languages before adding the static world data.`)
+		imgui.Separator()
+
+		current := state.view.mod.AdditionalLanguages()
+		for index, spec := range current {
+			imgui.PushID(fmt.Sprintf("lang%d", index))
+			if imgui.Button("Remove") {
+				newSpecs := append(append([]resource.LanguageSpec{}, current[:index]...), current[index+1:]...)
+				state.view.requestSetLanguages(newSpecs)
+			}
+			imgui.SameLine()
+			imgui.Text(fmt.Sprintf("%s (%s): %s", spec.Name, spec.Code, spec.Filename(ids.CybStrng.For(resource.LangDefault))))
+			imgui.PopID()
+		}
+		if len(current) == 0 {
+			imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1.0, Y: 1.0, Z: 1.0, W: 0.5})
+			imgui.Text("(no additional languages)")
+			imgui.PopStyleColor()
+		}
+		imgui.Separator()
+
+		imgui.InputText("Name", &state.newSpec.Name)
+		imgui.InputText("Code", &state.newSpec.Code)
+		imgui.InputText("Filename Pattern", &state.newSpec.FilenamePattern)
+		err := state.newSpec.Validate()
+		if (err == nil) && (len(current) >= resource.MaxAdditionalLanguages) {
+			err = fmt.Errorf("no more than %d additional languages possible", resource.MaxAdditionalLanguages)
+		}
+		if err != nil {
+			imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+			imgui.Text(err.Error())
+			imgui.PopStyleColor()
+		} else {
+			imgui.Text("Strings file: " + state.newSpec.Filename(ids.CybStrng.For(resource.LangDefault)))
+			if imgui.Button("Add") {
+				state.view.requestSetLanguages(append(current, state.newSpec))
+				state.newSpec.Name = ""
+				state.newSpec.Code = ""
+			}
+			imgui.SameLine()
+		}
+		if imgui.Button("Close") {
+			state.machine.SetState(nil)
+			imgui.CloseCurrentPopup()
+		}
+		imgui.EndPopup()
+	} else {
+		state.machine.SetState(nil)
+	}
+}
+
+func (state *editLanguagesWaitingState) HandleFiles(names []string) {
+}
@@ -0,0 +1,46 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/inkyblackness/hacked/ss1/world/edition"
+)
+
+// editionsFilename is the name of the database of fingerprints recorded by the user, located next to the executable.
+const editionsFilename = "editions.txt"
+
+func editionsPath() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return editionsFilename
+	}
+	return filepath.Join(filepath.Dir(exe), editionsFilename)
+}
+
+// loadEditions reads the database of recorded editions. A missing or invalid file results in an empty database.
+func loadEditions(absFilename string) *edition.Database {
+	file, err := os.Open(absFilename)
+	if err != nil {
+		return &edition.Database{}
+	}
+	defer func() {
+		_ = file.Close() // nolint: gas
+	}()
+	db, err := edition.LoadDatabase(file)
+	if err != nil {
+		return &edition.Database{}
+	}
+	return db
+}
+
+func saveEditions(db *edition.Database, absFilename string) error {
+	file, err := os.Create(absFilename)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close() // nolint: gas
+	}()
+	return edition.SaveDatabase(file, db)
+}
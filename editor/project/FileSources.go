@@ -0,0 +1,142 @@
+package project
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// fileSource provides random access to the data of a file, without reading it as a whole.
+// Resource files are read through such sources, so that only the accessed data is loaded.
+//
+// As the source keeps the file open, it has to be detached before the file is overwritten.
+// Detaching reads the remaining data into memory and closes the file.
+type fileSource struct {
+	mutex sync.RWMutex
+	path  string
+	file  *os.File
+	data  io.ReaderAt
+}
+
+func openFileSource(path string) (*fileSource, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSource{path: path, file: file, data: file}, nil
+}
+
+// ReadAt implements the io.ReaderAt interface.
+func (source *fileSource) ReadAt(p []byte, off int64) (int, error) {
+	source.mutex.RLock()
+	defer source.mutex.RUnlock()
+	return source.data.ReadAt(p, off)
+}
+
+func (source *fileSource) close() error {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	if source.file == nil {
+		return nil
+	}
+	err := source.file.Close()
+	source.file = nil
+	source.data = bytes.NewReader(nil)
+	return err
+}
+
+func (source *fileSource) detach() error {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	if source.file == nil {
+		return nil
+	}
+	info, err := source.file.Stat()
+	if err != nil {
+		return err
+	}
+	data := make([]byte, info.Size())
+	_, err = io.ReadFull(io.NewSectionReader(source.file, 0, info.Size()), data)
+	if err != nil {
+		return err
+	}
+	source.data = bytes.NewReader(data)
+	err = source.file.Close()
+	source.file = nil
+	return err
+}
+
+// sourceOwner identifies who uses a set of file sources.
+type sourceOwner int
+
+const (
+	worldSources sourceOwner = iota
+	modSources
+)
+
+// fileSources keeps track of all the file sources that are in use.
+//
+// Sources of the static world data are kept for as long as the editor runs, as entries may return via undo.
+// Sources of the mod are replaced, and their files closed, when another mod is loaded.
+type fileSources struct {
+	mutex  sync.Mutex
+	owners map[sourceOwner][]*fileSource
+}
+
+func newFileSources() *fileSources {
+	return &fileSources{owners: make(map[sourceOwner][]*fileSource)}
+}
+
+// adopt registers the given sources. Sources of the mod replace any previous ones, which are closed
+// unless they are also in use by the world.
+func (sources *fileSources) adopt(owner sourceOwner, list []*fileSource) {
+	sources.mutex.Lock()
+	defer sources.mutex.Unlock()
+	if owner == modSources {
+		for _, replaced := range sources.owners[modSources] {
+			if !containsSource(sources.owners[worldSources], replaced) && !containsSource(list, replaced) {
+				_ = replaced.close() // nolint: gas
+			}
+		}
+		sources.owners[owner] = nil
+	}
+	sources.owners[owner] = append(sources.owners[owner], list...)
+}
+
+func containsSource(list []*fileSource, source *fileSource) bool {
+	for _, entry := range list {
+		if entry == source {
+			return true
+		}
+	}
+	return false
+}
+
+// detach detaches all sources of the given file, so that it can be overwritten.
+func (sources *fileSources) detach(path string) error {
+	sources.mutex.Lock()
+	defer sources.mutex.Unlock()
+	for _, list := range sources.owners {
+		for _, source := range list {
+			if samePath(source.path, path) {
+				err := source.detach()
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if (errA != nil) || (errB != nil) {
+		return a == b
+	}
+	return strings.EqualFold(absA, absB)
+}
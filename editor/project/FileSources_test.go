@@ -0,0 +1,79 @@
+package project
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSourceReadsFromFile(t *testing.T) {
+	path := aTemporaryFile(t, []byte{0x01, 0x02, 0x03})
+	defer os.RemoveAll(filepath.Dir(path)) // nolint: errcheck
+
+	source, err := openFileSource(path)
+	require.Nil(t, err)
+	defer source.close() // nolint: errcheck
+	buf := make([]byte, 2)
+	_, err = source.ReadAt(buf, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []byte{0x02, 0x03}, buf)
+}
+
+func TestFileSourcesKeepDataOfDetachedFiles(t *testing.T) {
+	path := aTemporaryFile(t, []byte{0x01, 0x02, 0x03})
+	defer os.RemoveAll(filepath.Dir(path)) // nolint: errcheck
+
+	source, err := openFileSource(path)
+	require.Nil(t, err)
+	sources := newFileSources()
+	sources.adopt(modSources, []*fileSource{source})
+
+	err = sources.detach(path)
+	require.Nil(t, err)
+	err = ioutil.WriteFile(path, []byte{0xAA}, 0644)
+	require.Nil(t, err)
+
+	buf := make([]byte, 3)
+	_, err = source.ReadAt(buf, 0)
+	require.Nil(t, err)
+	assert.Equal(t, []byte{0x01, 0x02, 0x03}, buf)
+}
+
+func TestFileSourcesReplaceSourcesOfMod(t *testing.T) {
+	sources := newFileSources()
+	first := &fileSource{path: "first"}
+	second := &fileSource{path: "second"}
+	sources.adopt(modSources, []*fileSource{first})
+	sources.adopt(worldSources, []*fileSource{first})
+	sources.adopt(modSources, []*fileSource{second})
+	assert.Equal(t, []*fileSource{second}, sources.owners[modSources])
+	assert.Equal(t, []*fileSource{first}, sources.owners[worldSources])
+}
+
+func TestFileSourcesCloseReplacedSourcesOfMod(t *testing.T) {
+	path := aTemporaryFile(t, []byte{0x01, 0x02, 0x03})
+	defer os.RemoveAll(filepath.Dir(path)) // nolint: errcheck
+
+	source, err := openFileSource(path)
+	require.Nil(t, err)
+	sources := newFileSources()
+	sources.adopt(modSources, []*fileSource{source})
+	sources.adopt(modSources, nil)
+
+	assert.Nil(t, source.file)
+	_, err = source.ReadAt(make([]byte, 1), 0)
+	assert.NotNil(t, err)
+}
+
+func aTemporaryFile(t *testing.T, data []byte) string {
+	dir, err := ioutil.TempDir("", "hacked-sources")
+	require.Nil(t, err)
+	path := filepath.Join(dir, "test.res")
+	err = ioutil.WriteFile(path, data, 0644)
+	require.Nil(t, err)
+	return path
+}
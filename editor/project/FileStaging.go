@@ -2,6 +2,7 @@ package project
 
 import (
 	"bytes"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -14,6 +15,8 @@ import (
 	"github.com/inkyblackness/hacked/ss1/resource/lgres"
 	"github.com/inkyblackness/hacked/ss1/serial"
 	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/edition"
+	"github.com/inkyblackness/hacked/ss1/world/overlay"
 )
 
 type fileStaging struct {
@@ -22,20 +25,46 @@ type fileStaging struct {
 	failedFiles int
 	savegames   map[string]resource.Viewer
 	resources   map[string]resource.Viewer
+	unlisted    map[string]resource.Viewer
+
+	languages    []resource.LanguageSpec
+	languagesErr error
+
+	codepagesData []byte
+	codepagesPath string
+
+	objectPropertiesData     []byte
+	objectPropertiesFilename string
+	objectTypes              object.Descriptors
 
 	objectProperties  object.PropertiesTable
 	textureProperties texture.PropertiesList
+
+	sources   []*fileSource
+	checksums edition.Fingerprint
+
+	base          edition.Fingerprint
+	targetProfile string
+	overlays      overlay.Set
 }
 
-func newFileStaging() *fileStaging {
-	return &fileStaging{
+// newFileStaging returns a new staging. If withChecksums is set, the checksums of all staged files are
+// calculated for a fingerprint.
+func newFileStaging(withChecksums bool) *fileStaging {
+	staging := &fileStaging{
 		resources: make(map[string]resource.Viewer),
 		savegames: make(map[string]resource.Viewer),
+		unlisted:  make(map[string]resource.Viewer),
 	}
+	if withChecksums {
+		staging.checksums = make(edition.Fingerprint)
+	}
+	return staging
 }
 
 func (staging *fileStaging) stageAll(names []string) {
 	staging.stageList(names, len(names) == 1)
+	staging.decodeObjectProperties()
 }
 
 func (staging *fileStaging) stageList(names []string, isOnlyStagedFile bool) {
@@ -57,64 +86,235 @@ func (staging *fileStaging) stage(name string, isOnlyStagedFile bool) {
 		staging.markFailedFile()
 		return
 	}
-	file, err := os.Open(name)
-	if err != nil {
-		staging.markFailedFile()
-		return
-	}
-	defer file.Close() // nolint: errcheck
 
 	if fileInfo.IsDir() {
 		if isOnlyStagedFile {
-			subNames, _ := file.Readdirnames(0)
+			dir, err := os.Open(name)
+			if err != nil {
+				staging.markFailedFile()
+				return
+			}
+			subNames, _ := dir.Readdirnames(0)
+			_ = dir.Close()
 			joinedSubNames := make([]string, len(subNames))
 			for index, subName := range subNames {
 				joinedSubNames[index] = filepath.Join(name, subName)
 			}
 			staging.stageList(joinedSubNames, false)
 		}
-	} else {
-		fileData, err := ioutil.ReadAll(file)
+		return
+	}
+
+	filename := filepath.Base(name)
+	lowercase := strings.ToLower(filename)
+	source, err := openFileSource(name)
+	if err != nil {
+		staging.markFailedFile()
+		return
+	}
+	// Resource files are kept open and only read when accessed.
+	reader, err := lgres.ReaderFrom(source)
+	if err == nil {
+		err = staging.stageResources(filename, source, reader, fileInfo.Size(), isOnlyStagedFile)
 		if err != nil {
 			staging.markFailedFile()
-			return
 		}
+		return
+	}
+	_ = source.close() // nolint: gas
 
-		reader, err := lgres.ReaderFrom(bytes.NewReader(fileData))
-		filename := filepath.Base(name)
-		if (err == nil) && (isOnlyStagedFile || fileWhitelist.Matches(filename)) {
+	if !isStagedDataFile(lowercase) {
+		return
+	}
+	fileData, err := ioutil.ReadFile(name)
+	if err != nil {
+		staging.markFailedFile()
+		return
+	}
+	if lowercase == world.ObjectPropertiesFilename {
+		staging.modify(func() {
+			staging.objectPropertiesData = fileData
+			staging.objectPropertiesFilename = filename
+		})
+	}
+	if lowercase == world.ObjectTypesFilename {
+		var desc object.Descriptors
+		desc, err = world.LoadObjectTypes(bytes.NewReader(fileData))
+		if err == nil {
+			staging.modify(func() { staging.objectTypes = desc })
+		}
+	}
+	if lowercase == world.LanguagesFilename {
+		var languages []resource.LanguageSpec
+		languages, err = world.LoadLanguages(bytes.NewReader(fileData))
+		if err == nil {
+			staging.modify(func() { staging.languages = languages })
+		} else {
+			staging.modify(func() { staging.languagesErr = err })
+		}
+	}
+	if lowercase == world.BaseFilename {
+		var base edition.Fingerprint
+		base, err = edition.LoadFingerprint(bytes.NewReader(fileData))
+		if err == nil {
+			staging.modify(func() { staging.base = base })
+		}
+	}
+	if lowercase == world.TargetFilename {
+		var key string
+		key, err = world.LoadTargetProfile(bytes.NewReader(fileData))
+		if err == nil {
+			staging.modify(func() { staging.targetProfile = key })
+		}
+	}
+	if lowercase == world.OverlaysFilename {
+		var set overlay.Set
+		set, err = overlay.Load(bytes.NewReader(fileData))
+		if err == nil {
+			staging.modify(func() { staging.overlays = set })
+		}
+	}
+	if lowercase == world.CodepagesFilename {
+		staging.modify(func() {
+			staging.codepagesData = fileData
+			staging.codepagesPath = filepath.Dir(name)
+		})
+	}
+	if (lowercase == world.TexturePropertiesFilename) && (len(fileData) > 4) {
+		decoder := serial.NewDecoder(bytes.NewReader(fileData))
+		entryCount := (len(fileData) - 4) / texture.PropertiesSize
+		properties := make(texture.PropertiesList, entryCount)
+		properties.Code(decoder)
+		err = decoder.FirstError()
+		if err == nil {
 			staging.modify(func() {
-				if world.IsSavegame(reader) {
-					staging.savegames[filename] = reader
-				} else {
-					staging.resources[filename] = reader
-				}
+				staging.textureProperties = properties
+				staging.addChecksum(filename, fileData)
 			})
 		}
-		if strings.ToLower(filename) == world.ObjectPropertiesFilename {
-			decoder := serial.NewDecoder(bytes.NewReader(fileData))
-			properties := object.StandardPropertiesTable()
-			properties.Code(decoder)
-			err = decoder.FirstError()
-			if err == nil {
-				staging.modify(func() { staging.objectProperties = properties })
-			}
+	}
+
+	if err != nil {
+		staging.markFailedFile()
+	}
+}
+
+func isStagedDataFile(lowercase string) bool {
+	switch lowercase {
+	case world.ObjectPropertiesFilename, world.ObjectTypesFilename, world.TexturePropertiesFilename,
+		world.LanguagesFilename, world.CodepagesFilename, world.BaseFilename, world.TargetFilename,
+		world.OverlaysFilename:
+		return true
+	default:
+		return false
+	}
+}
+
+func (staging *fileStaging) stageResources(filename string, source *fileSource, reader *lgres.Reader,
+	size int64, isOnlyStagedFile bool) error {
+	var sum edition.Checksum
+	if staging.checksums != nil {
+		var err error
+		sum, err = edition.ChecksumFrom(io.NewSectionReader(source, 0, size))
+		if err != nil {
+			return err
 		}
-		if strings.ToLower(filename) == world.TexturePropertiesFilename && (len(fileData) > 4) {
-			decoder := serial.NewDecoder(bytes.NewReader(fileData))
-			entryCount := (len(fileData) - 4) / texture.PropertiesSize
-			properties := make(texture.PropertiesList, entryCount)
-			properties.Code(decoder)
-			err = decoder.FirstError()
-			if err == nil {
-				staging.modify(func() { staging.textureProperties = properties })
+	}
+	staging.modify(func() {
+		staging.sources = append(staging.sources, source)
+		if staging.checksums != nil {
+			staging.checksums[strings.ToLower(filename)] = sum
+		}
+		if isOnlyStagedFile || isWhitelisted(filename, nil) {
+			if world.IsSavegame(reader) {
+				staging.savegames[filename] = reader
+			} else {
+				staging.resources[filename] = reader
 			}
+		} else {
+			staging.unlisted[filename] = reader
 		}
+	})
+	return nil
+}
 
-		if err != nil {
-			staging.markFailedFile()
+// decodeObjectProperties decodes the staged object properties.
+// As their layout depends on the catalogue of object types, this has to happen after all files were staged.
+func (staging *fileStaging) decodeObjectProperties() {
+	if staging.objectPropertiesData == nil {
+		return
+	}
+	desc := staging.objectTypes
+	if desc == nil {
+		desc = object.StandardDescriptors()
+	}
+	decoder := serial.NewDecoder(bytes.NewReader(staging.objectPropertiesData))
+	properties := object.NewPropertiesTable(desc)
+	properties.Code(decoder)
+	if decoder.FirstError() != nil {
+		staging.markFailedFile()
+		return
+	}
+	staging.objectProperties = properties
+	staging.addChecksum(staging.objectPropertiesFilename, staging.objectPropertiesData)
+}
+
+func (staging *fileStaging) addChecksum(filename string, data []byte) {
+	if staging.checksums != nil {
+		staging.checksums.Add(filename, data)
+	}
+}
+
+// takeOverUnlisted adds all resource files that were not whitelisted while staging, yet are files of one
+// of the given additional languages. These languages are only known after the files were staged.
+func (staging *fileStaging) takeOverUnlisted(languages []resource.LanguageSpec) {
+	for filename, reader := range staging.unlisted {
+		if isWhitelisted(filename, languages) && !world.IsSavegame(reader) {
+			staging.resources[filename] = reader
 		}
 	}
+	staging.unlisted = make(map[string]resource.Viewer)
+}
+
+// codepageAssignments parses the staged codepage assignments.
+// As they refer to languages by name, this has to happen after the additional languages are known.
+// Custom mapping tables are read from the folder of the assignments file.
+func (staging *fileStaging) codepageAssignments() []world.CodepageAssignment {
+	if staging.codepagesData == nil {
+		return nil
+	}
+	assignments, err := world.LoadCodepageAssignments(bytes.NewReader(staging.codepagesData), staging.languages,
+		func(filename string) ([]byte, error) {
+			return ioutil.ReadFile(filepath.Join(staging.codepagesPath, filepath.Base(filename)))
+		})
+	if err != nil {
+		staging.markFailedFile()
+		return nil
+	}
+	return assignments
+}
+
+// fingerprint returns the checksums of the staged resource files and properties.
+// Other files, such as the configuration files of a mod, are not part of the static world data and
+// are intentionally not considered.
+func (staging *fileStaging) fingerprint() edition.Fingerprint {
+	fp := make(edition.Fingerprint)
+	take := func(filename string) {
+		lowercase := strings.ToLower(filename)
+		if sum, known := staging.checksums[lowercase]; known {
+			fp[lowercase] = sum
+		}
+	}
+	for filename := range staging.resources {
+		take(filename)
+	}
+	if staging.objectProperties != nil {
+		take(world.ObjectPropertiesFilename)
+	}
+	if staging.textureProperties != nil {
+		take(world.TexturePropertiesFilename)
+	}
+	return fp
 }
 
 func (staging *fileStaging) markFailedFile() {
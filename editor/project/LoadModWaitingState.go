@@ -1,6 +1,7 @@
 package project
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/inkyblackness/imgui-go"
@@ -15,6 +16,7 @@ type loadModWaitingState struct {
 	machine     gui.ModalStateMachine
 	view        *View
 	failureTime time.Time
+	failureText string
 }
 
 func (state *loadModWaitingState) Render() {
@@ -24,7 +26,7 @@ func (state *loadModWaitingState) Render() {
 		imgui.Text("Waiting for folder.")
 		if !state.failureTime.IsZero() {
 			imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
-			imgui.Text("Previous attempt failed, no usable data detected.\nPlease check and try again.")
+			imgui.Text(state.failureText)
 			imgui.PopStyleColor()
 			if time.Since(state.failureTime).Seconds() > 5 {
 				state.failureTime = time.Time{}
@@ -56,15 +58,20 @@ use the main "data" directory of the game.
 }
 
 func (state *loadModWaitingState) HandleFiles(names []string) {
-	staging := newFileStaging()
+	staging := newFileStaging(false)
 
 	staging.stageAll(names)
+	if staging.languagesErr != nil {
+		state.fail(fmt.Sprintf("Could not read %s: %v", world.LanguagesFilename, staging.languagesErr))
+		return
+	}
+	staging.takeOverUnlisted(staging.languages)
 
 	if len(staging.resources) > 0 {
 		var locs []*world.LocalizedResources
 
 		for filename, viewer := range staging.resources {
-			lang := ids.LocalizeFilename(filename)
+			lang := ids.LocalizeFilenameWith(filename, staging.languages)
 			loc := &world.LocalizedResources{
 				Filename: filename,
 				Language: lang,
@@ -72,7 +79,7 @@ func (state *loadModWaitingState) HandleFiles(names []string) {
 			for _, id := range viewer.IDs() {
 				view, err := viewer.View(id)
 				if err == nil {
-					_ = loc.Store.Put(id, view)
+					loc.Store.Reference(id, view)
 				}
 				// TODO: handle error?
 			}
@@ -80,8 +87,15 @@ func (state *loadModWaitingState) HandleFiles(names []string) {
 		}
 
 		state.machine.SetState(nil)
-		state.view.requestLoadMod(names[0], locs, staging.objectProperties, staging.textureProperties)
+		state.view.sources.adopt(modSources, staging.sources)
+		state.view.requestLoadMod(names[0], staging.languages, staging.codepageAssignments(), staging.base,
+			staging.targetProfile, staging.overlays, locs, staging.objectProperties, staging.textureProperties)
 	} else {
-		state.failureTime = time.Now()
+		state.fail("Previous attempt failed, no usable data detected.\nPlease check and try again.")
 	}
 }
+
+func (state *loadModWaitingState) fail(text string) {
+	state.failureTime = time.Now()
+	state.failureText = text
+}
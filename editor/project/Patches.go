@@ -0,0 +1,10 @@
+package project
+
+import "fmt"
+
+func pluralFiles(count int) string {
+	if count == 1 {
+		return "1 file"
+	}
+	return fmt.Sprintf("%d files", count)
+}
@@ -0,0 +1,23 @@
+package project
+
+import (
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type recordEditionStartState struct {
+	machine gui.ModalStateMachine
+	view    *View
+}
+
+func (state recordEditionStartState) Render() {
+	imgui.OpenPopup("Record Fingerprint")
+	state.machine.SetState(&recordEditionWaitingState{
+		machine: state.machine,
+		view:    state.view,
+	})
+}
+
+func (state recordEditionStartState) HandleFiles(names []string) {
+}
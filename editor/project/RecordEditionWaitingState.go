@@ -0,0 +1,63 @@
+package project
+
+import (
+	"strings"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type recordEditionWaitingState struct {
+	machine gui.ModalStateMachine
+	view    *View
+
+	name      string
+	errorInfo string
+}
+
+func (state *recordEditionWaitingState) Render() {
+	if imgui.BeginPopupModalV("Record Fingerprint", nil,
+		imgui.WindowFlagsNoResize|imgui.WindowFlagsNoMove|imgui.WindowFlagsNoSavedSettings|imgui.WindowFlagsAlwaysAutoResize) {
+
+		fp := state.view.mod.World().Fingerprint()
+		imgui.Text(`Record the fingerprint of the current static world data
+under the name of an edition, so that the data is recognized later.
+Only record data of unmodified, original releases.`)
+		imgui.Text("Database: " + state.view.editionsPath)
+		imgui.Separator()
+		imgui.Text("Currently recognized as: " + state.view.editions.Detect(fp).String())
+		imgui.Text("Files: " + strings.Join(fp.Filenames(), ", "))
+		imgui.InputText("Edition", &state.name)
+		name := strings.TrimSpace(state.name)
+		if len(state.errorInfo) > 0 {
+			imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+			imgui.Text(state.errorInfo)
+			imgui.PopStyleColor()
+		}
+		imgui.Separator()
+		if (len(name) > 0) && (len(fp) > 0) {
+			if imgui.Button("Record") {
+				state.view.editions.Record(name, fp)
+				err := saveEditions(state.view.editions, state.view.editionsPath)
+				if err != nil {
+					state.errorInfo = err.Error()
+				} else {
+					state.machine.SetState(nil)
+					imgui.CloseCurrentPopup()
+				}
+			}
+			imgui.SameLine()
+		}
+		if imgui.Button("Cancel") {
+			state.machine.SetState(nil)
+			imgui.CloseCurrentPopup()
+		}
+		imgui.EndPopup()
+	} else {
+		state.machine.SetState(nil)
+	}
+}
+
+func (state *recordEditionWaitingState) HandleFiles(names []string) {
+}
@@ -2,6 +2,8 @@ package project
 
 import (
 	"bytes"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -11,9 +13,11 @@ import (
 	"github.com/inkyblackness/hacked/ss1/resource/lgres"
 	"github.com/inkyblackness/hacked/ss1/serial"
 	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/edition"
+	"github.com/inkyblackness/hacked/ss1/world/overlay"
 )
 
-func saveModResourcesTo(mod *world.Mod, modPath string) error {
+func saveModResourcesTo(mod *world.Mod, modPath string, sources *fileSources) error {
 	localized := mod.ModifiedResources()
 	filenamesToSave := mod.ModifiedFilenames()
 
@@ -28,7 +32,7 @@ func saveModResourcesTo(mod *world.Mod, modPath string) error {
 
 	for _, loc := range localized {
 		if shallBeSaved(loc.Filename) {
-			err := saveResourcesTo(loc.Store, filepath.Join(modPath, loc.Filename))
+			err := saveResourcesTo(loc.Store, filepath.Join(modPath, loc.Filename), sources)
 			if err != nil {
 				return err
 			}
@@ -47,11 +51,118 @@ func saveModResourcesTo(mod *world.Mod, modPath string) error {
 			return err
 		}
 	}
+	if shallBeSaved(world.ObjectTypesFilename) {
+		err := saveObjectTypesTo(mod.ObjectProperties().Descriptors(), filepath.Join(modPath, world.ObjectTypesFilename))
+		if err != nil {
+			return err
+		}
+	}
+
+	if shallBeSaved(world.LanguagesFilename) {
+		err := saveLanguagesTo(mod.AdditionalLanguages(), filepath.Join(modPath, world.LanguagesFilename))
+		if err != nil {
+			return err
+		}
+	}
+	if shallBeSaved(world.BaseFilename) {
+		err := saveBaseTo(mod.Base(), filepath.Join(modPath, world.BaseFilename))
+		if err != nil {
+			return err
+		}
+	}
+	if shallBeSaved(world.TargetFilename) {
+		err := saveTargetProfileTo(mod.TargetProfile(), filepath.Join(modPath, world.TargetFilename))
+		if err != nil {
+			return err
+		}
+	}
+	if shallBeSaved(world.OverlaysFilename) {
+		err := saveOverlaysTo(mod.Overlays(), filepath.Join(modPath, world.OverlaysFilename))
+		if err != nil {
+			return err
+		}
+	}
+	if shallBeSaved(world.CodepagesFilename) {
+		err := saveCodepagesTo(mod.CodepageAssignments(), mod.AdditionalLanguages(), modPath, shallBeSaved)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
-func saveResourcesTo(viewer resource.Viewer, absFilename string) error {
+func saveLanguagesTo(specs []resource.LanguageSpec, absFilename string) error {
+	return saveOptionalTo(absFilename, len(specs) == 0, func(writer io.Writer) error {
+		return world.SaveLanguages(writer, specs)
+	})
+}
+
+func saveBaseTo(fp edition.Fingerprint, absFilename string) error {
+	return saveOptionalTo(absFilename, len(fp) == 0, func(writer io.Writer) error {
+		return edition.SaveFingerprint(writer, fp, "data files the mod was built against")
+	})
+}
+
+func saveTargetProfileTo(key string, absFilename string) error {
+	return saveOptionalTo(absFilename, len(key) == 0, func(writer io.Writer) error {
+		return world.SaveTargetProfile(writer, key)
+	})
+}
+
+func saveObjectTypesTo(desc object.Descriptors, absFilename string) error {
+	return saveOptionalTo(absFilename, world.HasStandardObjectTypes(desc), func(writer io.Writer) error {
+		return world.SaveObjectTypes(writer, desc)
+	})
+}
+
+func saveOverlaysTo(set overlay.Set, absFilename string) error {
+	return saveOptionalTo(absFilename, len(set) == 0, func(writer io.Writer) error {
+		return overlay.Save(writer, set)
+	})
+}
+
+func saveCodepagesTo(assignments []world.CodepageAssignment, languages resource.LanguageSpecs, modPath string, shallBeSaved func(string) bool) error {
+	for _, assignment := range assignments {
+		if (assignment.Table != nil) && shallBeSaved(assignment.Name) {
+			err := ioutil.WriteFile(filepath.Join(modPath, assignment.Name), assignment.Table, 0644)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return saveOptionalTo(filepath.Join(modPath, world.CodepagesFilename), len(assignments) == 0, func(writer io.Writer) error {
+		return world.SaveCodepageAssignments(writer, assignments, languages)
+	})
+}
+
+// saveOptionalTo writes a file that only exists if it has content. If empty is set,
+// the file is removed instead, if it exists.
+func saveOptionalTo(absFilename string, empty bool, write func(io.Writer) error) error {
+	if empty {
+		err := os.Remove(absFilename)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	file, err := os.Create(absFilename)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close() // nolint: gas
+	}()
+	return write(file)
+}
+
+// saveResourcesTo writes the resources to given file. As the resources may still be read from
+// the same file, the file sources are detached from it before.
+func saveResourcesTo(viewer resource.Viewer, absFilename string, sources *fileSources) error {
+	err := sources.detach(absFilename)
+	if err != nil {
+		return err
+	}
 	file, err := os.Create(absFilename)
 	if err != nil {
 		return err
@@ -0,0 +1,31 @@
+package project
+
+import (
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ss1/world/target"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+type saveModViolationsState struct {
+	machine gui.ModalStateMachine
+	view    *View
+
+	modPath    string
+	profile    target.Profile
+	violations []target.Violation
+}
+
+func (state saveModViolationsState) Render() {
+	imgui.OpenPopup("Save mod")
+	state.machine.SetState(&saveModViolationsWaitingState{
+		machine:    state.machine,
+		view:       state.view,
+		modPath:    state.modPath,
+		profile:    state.profile,
+		violations: state.violations,
+	})
+}
+
+func (state saveModViolationsState) HandleFiles(names []string) {
+}
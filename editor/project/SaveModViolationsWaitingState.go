@@ -0,0 +1,56 @@
+package project
+
+import (
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ss1/world/target"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+// maxListedViolations limits how many violations are listed in the dialog.
+const maxListedViolations = 10
+
+type saveModViolationsWaitingState struct {
+	machine gui.ModalStateMachine
+	view    *View
+
+	modPath    string
+	profile    target.Profile
+	violations []target.Violation
+}
+
+func (state *saveModViolationsWaitingState) Render() {
+	if imgui.BeginPopupModalV("Save mod", nil,
+		imgui.WindowFlagsNoResize|imgui.WindowFlagsNoMove|imgui.WindowFlagsNoSavedSettings|imgui.WindowFlagsAlwaysAutoResize) {
+
+		imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+		imgui.Text(fmt.Sprintf("The mod will not run correctly on the target engine %s:", state.profile.Title))
+		imgui.PopStyleColor()
+		for index, violation := range state.violations {
+			if index >= maxListedViolations {
+				imgui.Text(fmt.Sprintf("... and %d more, see the Target Check window.", len(state.violations)-index))
+				break
+			}
+			imgui.Text(violation.Area + ": " + violation.Message)
+		}
+		imgui.Separator()
+		if imgui.Button("Save Anyway") {
+			state.machine.SetState(nil)
+			imgui.CloseCurrentPopup()
+			state.view.saveModTo(state.modPath)
+		}
+		imgui.SameLine()
+		if imgui.Button("Cancel") {
+			state.machine.SetState(nil)
+			imgui.CloseCurrentPopup()
+		}
+		imgui.EndPopup()
+	} else {
+		state.machine.SetState(nil)
+	}
+}
+
+func (state *saveModViolationsWaitingState) HandleFiles(names []string) {
+}
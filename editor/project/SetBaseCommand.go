@@ -0,0 +1,27 @@
+package project
+
+import (
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/edition"
+)
+
+type baseSetter interface {
+	SetBase(fp edition.Fingerprint)
+}
+
+type setBaseCommand struct {
+	setter baseSetter
+
+	oldBase edition.Fingerprint
+	newBase edition.Fingerprint
+}
+
+func (cmd setBaseCommand) Do(modder world.Modder) error {
+	cmd.setter.SetBase(cmd.newBase)
+	return nil
+}
+
+func (cmd setBaseCommand) Undo(modder world.Modder) error {
+	cmd.setter.SetBase(cmd.oldBase)
+	return nil
+}
@@ -0,0 +1,32 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inkyblackness/hacked/ss1/world/edition"
+)
+
+type testingBaseSetter struct {
+	base edition.Fingerprint
+}
+
+func (setter *testingBaseSetter) SetBase(fp edition.Fingerprint) {
+	setter.base = fp
+}
+
+func TestSetBaseCommandSetsNewBaseOnDoAndOldOnUndo(t *testing.T) {
+	oldBase := edition.Fingerprint{"archive.dat": edition.ChecksumOf([]byte{1})}
+	newBase := edition.Fingerprint{"archive.dat": edition.ChecksumOf([]byte{2})}
+	var setter testingBaseSetter
+	command := setBaseCommand{setter: &setter, oldBase: oldBase, newBase: newBase}
+
+	err := command.Do(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, newBase, setter.base)
+
+	err = command.Undo(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, oldBase, setter.base)
+}
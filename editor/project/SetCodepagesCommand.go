@@ -0,0 +1,26 @@
+package project
+
+import (
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+type codepagesSetter interface {
+	SetCodepageAssignments(assignments []world.CodepageAssignment)
+}
+
+type setCodepagesCommand struct {
+	setter codepagesSetter
+
+	oldAssignments []world.CodepageAssignment
+	newAssignments []world.CodepageAssignment
+}
+
+func (cmd setCodepagesCommand) Do(modder world.Modder) error {
+	cmd.setter.SetCodepageAssignments(cmd.newAssignments)
+	return nil
+}
+
+func (cmd setCodepagesCommand) Undo(modder world.Modder) error {
+	cmd.setter.SetCodepageAssignments(cmd.oldAssignments)
+	return nil
+}
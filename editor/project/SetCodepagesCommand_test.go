@@ -0,0 +1,34 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+type testingCodepagesSetter struct {
+	assignments []world.CodepageAssignment
+}
+
+func (setter *testingCodepagesSetter) SetCodepageAssignments(assignments []world.CodepageAssignment) {
+	setter.assignments = assignments
+}
+
+func TestSetCodepagesCommandSetsNewAssignmentsOnDoAndOldOnUndo(t *testing.T) {
+	oldAssignments := []world.CodepageAssignment{{Language: resource.LangGerman, Name: text.CP850}}
+	newAssignments := []world.CodepageAssignment{{Language: resource.LangGerman, Name: "custom.txt", Table: []byte{}}}
+	var setter testingCodepagesSetter
+	command := setCodepagesCommand{setter: &setter, oldAssignments: oldAssignments, newAssignments: newAssignments}
+
+	err := command.Do(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, newAssignments, setter.assignments)
+
+	err = command.Undo(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, oldAssignments, setter.assignments)
+}
@@ -0,0 +1,27 @@
+package project
+
+import (
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+type languagesSetter interface {
+	SetAdditionalLanguages(specs []resource.LanguageSpec)
+}
+
+type setLanguagesCommand struct {
+	setter languagesSetter
+
+	oldSpecs []resource.LanguageSpec
+	newSpecs []resource.LanguageSpec
+}
+
+func (cmd setLanguagesCommand) Do(modder world.Modder) error {
+	cmd.setter.SetAdditionalLanguages(cmd.newSpecs)
+	return nil
+}
+
+func (cmd setLanguagesCommand) Undo(modder world.Modder) error {
+	cmd.setter.SetAdditionalLanguages(cmd.oldSpecs)
+	return nil
+}
@@ -0,0 +1,32 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+type testingLanguagesSetter struct {
+	specs []resource.LanguageSpec
+}
+
+func (setter *testingLanguagesSetter) SetAdditionalLanguages(specs []resource.LanguageSpec) {
+	setter.specs = specs
+}
+
+func TestSetLanguagesCommandSetsNewSpecsOnDoAndOldOnUndo(t *testing.T) {
+	oldSpecs := []resource.LanguageSpec{{Name: "Spanish", Code: "es", FilenamePattern: "{name}_es"}}
+	newSpecs := append(oldSpecs, resource.LanguageSpec{Name: "Polish", Code: "pl", FilenamePattern: "{name}_pl"})
+	var setter testingLanguagesSetter
+	command := setLanguagesCommand{setter: &setter, oldSpecs: oldSpecs, newSpecs: newSpecs}
+
+	err := command.Do(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, newSpecs, setter.specs)
+
+	err = command.Undo(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, oldSpecs, setter.specs)
+}
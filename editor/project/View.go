@@ -3,38 +3,74 @@ package project
 import (
 	"fmt"
 	"math"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/inkyblackness/imgui-go"
 
+	"github.com/inkyblackness/hacked/ss1/content/archive/level"
+	"github.com/inkyblackness/hacked/ss1/content/descriptions"
 	"github.com/inkyblackness/hacked/ss1/content/object"
 	"github.com/inkyblackness/hacked/ss1/content/texture"
 	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
+	"github.com/inkyblackness/hacked/ss1/resource"
 	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/edition"
+	"github.com/inkyblackness/hacked/ss1/world/overlay"
+	"github.com/inkyblackness/hacked/ss1/world/target"
 	"github.com/inkyblackness/hacked/ui/gui"
 )
 
 // View handles the project display.
 type View struct {
-	mod *world.Mod
+	mod    *world.Mod
+	levels []*level.Level
 
 	modalStateMachine gui.ModalStateMachine
 	guiScale          float32
 	commander         cmd.Commander
 
+	editions     *edition.Database
+	editionsPath string
+	sources      *fileSources
+
+	interpreters     *descriptions.Interpreters
+	descriptionsPath string
+	descriptionsInfo string
+
 	model viewModel
 }
 
 // NewView creates a new instance for the project display.
-func NewView(mod *world.Mod, modalStateMachine gui.ModalStateMachine,
-	guiScale float32, commander cmd.Commander) *View {
+// The view sets the given interpreters according to the object descriptions it loads.
+func NewView(mod *world.Mod, levels []*level.Level, interpreters *descriptions.Interpreters,
+	modalStateMachine gui.ModalStateMachine, guiScale float32, commander cmd.Commander) *View {
+	path := editionsPath()
+	descPath := descriptionsPath()
+	loaded, descInfo, err := loadDescriptions(descPath)
+	if err != nil {
+		loaded = descriptions.Builtin()
+		descInfo = "Using built-in descriptions, file is invalid: " + err.Error()
+	}
+	*interpreters = loaded
 	return &View{
-		mod: mod,
+		mod:    mod,
+		levels: levels,
 
 		modalStateMachine: modalStateMachine,
 		guiScale:          guiScale,
 		commander:         commander,
 
+		editions:     loadEditions(path),
+		editionsPath: path,
+		sources:      newFileSources(),
+
+		interpreters:     interpreters,
+		descriptionsPath: descPath,
+		descriptionsInfo: descInfo,
+
 		model: freshViewModel(),
 	}
 }
@@ -103,8 +139,14 @@ func (view *View) renderContent() {
 		view.startLoadingMod()
 	}
 	imgui.EndGroup()
+	view.renderBaseInfo()
 
-	imgui.Text("Static World Data")
+	fp := view.mod.World().Fingerprint()
+	detection := view.editions.Detect(fp)
+	imgui.Text("Static World Data - " + detection.String())
+	if imgui.IsItemHovered() && (len(detection.Unrecognized) > 0) {
+		imgui.SetTooltip("Unrecognized files:\n" + strings.Join(detection.Unrecognized, "\n"))
+	}
 	imgui.BeginChildV("ManifestEntries", imgui.Vec2{X: -100 * view.guiScale, Y: 0}, true, 0)
 	manifest := view.mod.World()
 	entries := manifest.EntryCount()
@@ -129,9 +171,52 @@ func (view *View) renderContent() {
 	if imgui.ButtonV("Remove", imgui.Vec2{X: -1, Y: 0}) {
 		view.requestRemoveManifestEntry()
 	}
+	imgui.Separator()
+	if imgui.ButtonV("Languages...", imgui.Vec2{X: -1, Y: 0}) {
+		view.startEditingLanguages()
+	}
+	if imgui.ButtonV("Codepages...", imgui.Vec2{X: -1, Y: 0}) {
+		view.startEditingCodepages()
+	}
+	if imgui.ButtonV("Fingerprints...", imgui.Vec2{X: -1, Y: 0}) {
+		view.startRecordingEdition()
+	}
+	if imgui.ButtonV("Descriptions...", imgui.Vec2{X: -1, Y: 0}) {
+		view.startEditingDescriptions()
+	}
+	imgui.Separator()
+	if imgui.ButtonV("Create Patch...", imgui.Vec2{X: -1, Y: 0}) {
+		view.startCreatingPatch()
+	}
+	if imgui.ButtonV("Apply Patch...", imgui.Vec2{X: -1, Y: 0}) {
+		view.startApplyingPatch()
+	}
 	imgui.EndGroup()
 }
 
+func (view *View) renderBaseInfo() {
+	base := view.mod.Base()
+	if len(base) == 0 {
+		return
+	}
+	differences := view.mod.BaseDifferences()
+	if len(differences) == 0 {
+		imgui.Text("Built against: " + view.editions.Detect(base).String())
+		return
+	}
+	imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+	imgui.Text(fmt.Sprintf("Built against different data: %s, %d file(s) differ",
+		view.editions.Detect(base).String(), len(differences)))
+	imgui.PopStyleColor()
+	if imgui.IsItemHovered() {
+		imgui.SetTooltip("Missing or different files:\n" + strings.Join(differences, "\n"))
+	}
+	imgui.SameLine()
+	if imgui.Button("Adopt Current Data") {
+		view.requestSetBase(view.mod.World().Fingerprint())
+	}
+}
+
 func (view *View) startLoadingMod() {
 	view.modalStateMachine.SetState(&loadModStartState{
 		machine: view.modalStateMachine,
@@ -160,6 +245,94 @@ func (view *View) startAddingManifestEntry() {
 	})
 }
 
+func (view *View) startEditingLanguages() {
+	view.modalStateMachine.SetState(&editLanguagesStartState{
+		machine: view.modalStateMachine,
+		view:    view,
+	})
+}
+
+func (view *View) requestSetLanguages(specs []resource.LanguageSpec) {
+	command := setLanguagesCommand{
+		setter:   view.mod,
+		oldSpecs: view.mod.AdditionalLanguages(),
+		newSpecs: specs,
+	}
+	view.commander.Queue(command)
+}
+
+func (view *View) startEditingCodepages() {
+	view.modalStateMachine.SetState(&editCodepagesStartState{
+		machine: view.modalStateMachine,
+		view:    view,
+	})
+}
+
+func (view *View) requestSetCodepages(assignments []world.CodepageAssignment) {
+	command := setCodepagesCommand{
+		setter:         view.mod,
+		oldAssignments: view.mod.CodepageAssignments(),
+		newAssignments: assignments,
+	}
+	view.commander.Queue(command)
+}
+
+func (view *View) startRecordingEdition() {
+	view.modalStateMachine.SetState(&recordEditionStartState{
+		machine: view.modalStateMachine,
+		view:    view,
+	})
+}
+
+func (view *View) startEditingDescriptions() {
+	view.modalStateMachine.SetState(&editDescriptionsStartState{
+		machine: view.modalStateMachine,
+		view:    view,
+	})
+}
+
+func (view *View) requestSetBase(fp edition.Fingerprint) {
+	command := setBaseCommand{
+		setter:  view.mod,
+		oldBase: view.mod.Base(),
+		newBase: fp,
+	}
+	view.commander.Queue(command)
+}
+
+func (view *View) startCreatingPatch() {
+	view.modalStateMachine.SetState(&createPatchStartState{
+		machine: view.modalStateMachine,
+		view:    view,
+	})
+}
+
+func (view *View) startApplyingPatch() {
+	view.modalStateMachine.SetState(&applyPatchStartState{
+		machine: view.modalStateMachine,
+		view:    view,
+	})
+}
+
+// baseDirectories returns the folders of the static world data, in order of the manifest.
+// Entries that were added from files refer to the folder of the first file.
+func (view *View) baseDirectories() []string {
+	var dirs []string
+	manifest := view.mod.World()
+	for index := 0; index < manifest.EntryCount(); index++ {
+		entry, err := manifest.Entry(index)
+		if err != nil {
+			continue
+		}
+		dir := entry.ID
+		if info, err := os.Stat(dir); (err != nil) || !info.IsDir() {
+			dir = filepath.Dir(dir)
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
 func (view *View) requestMoveManifestEntryUp() {
 	manifest := view.mod.World()
 	entries := manifest.EntryCount()
@@ -218,17 +391,52 @@ func (view *View) requestRemoveManifestEntry() {
 	view.commander.Queue(command)
 }
 
-func (view *View) requestLoadMod(modPath string, resources []*world.LocalizedResources,
+func (view *View) requestLoadMod(modPath string, languages []resource.LanguageSpec, codepages []world.CodepageAssignment,
+	base edition.Fingerprint, targetProfile string, overlays overlay.Set, resources []*world.LocalizedResources,
 	objectProperties object.PropertiesTable, textureProperties texture.PropertiesList) {
 	view.mod.SetPath(modPath)
+	view.mod.SetAdditionalLanguages(languages)
+	view.mod.SetCodepageAssignments(codepages)
+	view.mod.SetBase(base)
+	view.mod.SetTargetProfile(targetProfile)
+	view.mod.SetOverlays(overlays)
 	view.mod.Reset(resources, objectProperties, textureProperties)
 	// fix list resources for any "old" mod.
-	view.mod.FixListResources()
+	view.fixListResources()
 }
 
+// requestSaveMod saves the mod after checking it against the target engine.
+// Should the mod not run correctly on the target, the user is asked to confirm.
 func (view *View) requestSaveMod(modPath string) {
-	view.mod.FixListResources()
-	err := saveModResourcesTo(view.mod, modPath)
+	profile, _ := target.ProfileByKey(view.mod.TargetProfile())
+	violations := target.Blocking(target.Check(profile, target.SubjectOf(view.mod, view.levels)))
+	if len(violations) > 0 {
+		view.modalStateMachine.SetState(&saveModViolationsState{
+			machine:    view.modalStateMachine,
+			view:       view,
+			modPath:    modPath,
+			profile:    profile,
+			violations: violations,
+		})
+		return
+	}
+	view.saveModTo(modPath)
+}
+
+// fixListResources pads the resource lists of the mod if the target engine requires this.
+func (view *View) fixListResources() {
+	profile, _ := target.ProfileByKey(view.mod.TargetProfile())
+	if profile.PaddedLists {
+		view.mod.FixListResources()
+	}
+}
+
+func (view *View) saveModTo(modPath string) {
+	view.fixListResources()
+	if fp := view.mod.World().Fingerprint(); (len(view.mod.Base()) == 0) && (len(fp) > 0) {
+		view.mod.SetBase(fp)
+	}
+	err := saveModResourcesTo(view.mod, modPath, view.sources)
 	if err != nil {
 		view.modalStateMachine.SetState(&saveModFailedState{
 			machine:   view.modalStateMachine,
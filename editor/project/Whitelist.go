@@ -1,6 +1,8 @@
 package project
 
 import (
+	"strings"
+
 	"github.com/inkyblackness/hacked/ss1/resource"
 	"github.com/inkyblackness/hacked/ss1/world/ids"
 )
@@ -27,3 +29,24 @@ var fileWhitelist = resource.FilenameList{
 	ids.Texture,
 	ids.VidMail,
 }
+
+// isWhitelisted returns true if the given file is one of the known resource files, either of the
+// built-in languages or of one of the given additional languages.
+func isWhitelisted(filename string, languages []resource.LanguageSpec) bool {
+	lowercase := strings.ToLower(filename)
+	for _, file := range fileWhitelist {
+		for lang := resource.LangDefault; lang < resource.BuiltinLanguageCount; lang++ {
+			if file.For(lang) == lowercase {
+				return true
+			}
+		}
+		if _, localized := file.(resource.I18nFile); localized {
+			for _, spec := range languages {
+				if spec.Filename(file.For(resource.LangDefault)) == lowercase {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package render
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ss1/content/audio"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+// AudioEditor provides a waveform with selection and controls for basic edit operations of a sound.
+// It keeps the selection and the operation parameters between frames.
+type AudioEditor struct {
+	from        int
+	to          int
+	sampleCount int
+
+	gainDecibel     float32
+	silenceDuration float32
+}
+
+// NewAudioEditor returns a new instance.
+func NewAudioEditor() AudioEditor {
+	return AudioEditor{
+		gainDecibel:     6.0,
+		silenceDuration: 0.5,
+	}
+}
+
+// Render renders the waveform of the sound and the edit controls with given width.
+// Any edit of the sound is reported via the callback, which is not called for read-only sounds.
+func (editor *AudioEditor) Render(label string, sound audio.L8, readOnly bool, width float32, guiScale float32,
+	changeCallback func(audio.L8)) {
+	sampleCount := len(sound.Samples)
+	if editor.sampleCount != sampleCount {
+		editor.sampleCount = sampleCount
+		editor.from = 0
+		editor.to = sampleCount
+	}
+	if sampleCount == 0 {
+		return
+	}
+	imgui.PushID(label)
+	Waveform("Waveform", sound, imgui.Vec2{X: width, Y: 80 * guiScale}, guiScale, editor.from, editor.to,
+		func(from, to int) {
+			editor.from = from
+			editor.to = to
+		})
+
+	gui.StepSliderIntV("Selection From", &editor.from, 0, sampleCount-1, "%d")
+	if editor.to <= editor.from {
+		editor.to = editor.from + 1
+	}
+	gui.StepSliderIntV("Selection To", &editor.to, 1, sampleCount, "%d")
+	if editor.from >= editor.to {
+		editor.from = editor.to - 1
+	}
+	imgui.LabelText("Selection", fmt.Sprintf("%.3f - %.3f sec",
+		editor.secondsOf(sound, editor.from), editor.secondsOf(sound, editor.to)))
+	if imgui.Button("Select All") {
+		editor.from = 0
+		editor.to = sampleCount
+	}
+
+	if !readOnly {
+		imgui.SameLine()
+		if imgui.Button("Crop") {
+			changeCallback(audio.Crop(sound, editor.from, editor.to))
+		}
+		imgui.SameLine()
+		if imgui.Button("Fade In") {
+			changeCallback(audio.FadeIn(sound, editor.from, editor.to))
+		}
+		imgui.SameLine()
+		if imgui.Button("Fade Out") {
+			changeCallback(audio.FadeOut(sound, editor.from, editor.to))
+		}
+
+		imgui.SliderFloatV("Gain", &editor.gainDecibel, -24.0, 24.0, "%.1f dB", 1.0)
+		if imgui.Button("Apply Gain") {
+			factor := float32(math.Pow(10, float64(editor.gainDecibel)/20))
+			changeCallback(audio.Gain(sound, editor.from, editor.to, factor))
+		}
+		imgui.SliderFloatV("Silence", &editor.silenceDuration, 0.05, 5.0, "%.2f sec", 1.0)
+		if imgui.Button("Insert Silence") {
+			count := int(editor.silenceDuration * sound.SampleRate)
+			changeCallback(audio.InsertSilence(sound, editor.from, count))
+		}
+	}
+	imgui.PopID()
+}
+
+func (editor AudioEditor) secondsOf(sound audio.L8, sample int) float32 {
+	if sound.SampleRate <= 0 {
+		return 0
+	}
+	return float32(sample) / sound.SampleRate
+}
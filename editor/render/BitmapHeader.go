@@ -0,0 +1,74 @@
+package render
+
+import (
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+// BitmapHeader renders the properties of a bitmap header.
+// Unless readOnly, changes to type, flags, factors, and the area are reported via the callback.
+// Size and stride are given by the pixel data and are always read-only.
+func BitmapHeader(header bitmap.Header, readOnly bool, changeCallback func(bitmap.Header)) {
+	imgui.LabelText("Size", fmt.Sprintf("%d x %d (stride %d)", header.Width, header.Height, header.Stride))
+	if header.PaletteOffset != 0 {
+		imgui.LabelText("Palette", "private")
+	}
+	if readOnly || !header.Type.Is8Bit() {
+		imgui.LabelText("Type", header.Type.String())
+	} else if imgui.BeginCombo("Type", header.Type.String()) {
+		for _, bitmapType := range bitmap.Types() {
+			if bitmapType.Is8Bit() && imgui.SelectableV(bitmapType.String(), bitmapType == header.Type, 0, imgui.Vec2{}) {
+				newHeader := header
+				newHeader.Type = bitmapType
+				changeCallback(newHeader)
+			}
+		}
+		imgui.EndCombo()
+	}
+
+	flag := func(label string, flag bitmap.Flag) {
+		set := (header.Flags & flag) != 0
+		if readOnly {
+			imgui.LabelText(label, fmt.Sprintf("%v", set))
+		} else if imgui.Checkbox(label, &set) {
+			newHeader := header
+			newHeader.Flags ^= flag
+			changeCallback(newHeader)
+		}
+	}
+	flag("Transparent", bitmap.FlagTransparent)
+	flag("Translucent", bitmap.FlagTranslucent)
+
+	factor := func(label string, value byte, modifier func(*bitmap.Header, byte)) {
+		intValue := int(value)
+		if readOnly {
+			imgui.LabelText(label, fmt.Sprintf("%d", intValue))
+		} else if gui.StepSliderInt(label, &intValue, 0, 15) {
+			newHeader := header
+			modifier(&newHeader, byte(intValue))
+			changeCallback(newHeader)
+		}
+	}
+	factor("Width Factor", header.WidthFactor, func(h *bitmap.Header, value byte) { h.WidthFactor = value })
+	factor("Height Factor", header.HeightFactor, func(h *bitmap.Header, value byte) { h.HeightFactor = value })
+
+	areaLabels := []string{"Anchor X / Left", "Anchor Y / Top", "Right", "Bottom"}
+	for index, label := range areaLabels {
+		limit := int(header.Width)
+		if (index % 2) == 1 {
+			limit = int(header.Height)
+		}
+		value := int(header.Area[index])
+		if readOnly {
+			imgui.LabelText(label, fmt.Sprintf("%d", value))
+		} else if gui.StepSliderInt(label, &value, 0, limit) {
+			newHeader := header
+			newHeader.Area[index] = int16(value)
+			changeCallback(newHeader)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package render
+
+import (
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+)
+
+// MapSettingsEditor provides controls for the settings that map images to the palette.
+// It keeps the chosen options between frames.
+type MapSettingsEditor struct {
+	dither     bitmap.DitherMethod
+	allowZero  bool
+	allowCycle bool
+}
+
+// Render renders the controls for the settings.
+func (editor *MapSettingsEditor) Render() {
+	if imgui.BeginCombo("Dithering", editor.dither.String()) {
+		for _, method := range bitmap.DitherMethods() {
+			if imgui.SelectableV(method.String(), method == editor.dither, 0, imgui.Vec2{}) {
+				editor.dither = method
+			}
+		}
+		imgui.EndCombo()
+	}
+	imgui.Checkbox("Use transparency index for opaque pixels", &editor.allowZero)
+	imgui.Checkbox("Use color cycling indices", &editor.allowCycle)
+	imgui.Text("Fully transparent pixels are always mapped to index 0.")
+}
+
+// Settings returns the settings according to the chosen options.
+func (editor MapSettingsEditor) Settings() bitmap.MapSettings {
+	reserved := bitmap.ReservedPaletteRanges()
+	mask := bitmap.DefaultIndexMask()
+	if editor.allowZero {
+		mask = mask.With(reserved.Purposed(bitmap.PaletteRangeTransparency))
+	}
+	if editor.allowCycle {
+		mask = mask.With(reserved.Purposed(bitmap.PaletteRangeColorCycling))
+	}
+	return bitmap.MapSettings{
+		Dither:         editor.dither,
+		AllowedIndices: mask,
+	}
+}
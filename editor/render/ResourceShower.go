@@ -0,0 +1,11 @@
+package render
+
+import "github.com/inkyblackness/hacked/ss1/resource"
+
+// ResourceShower is called to show the resource of given key in its typed editor.
+// It returns false if there is no editor for the resource.
+type ResourceShower func(key resource.Key) bool
+
+// BlockShower is called to show the raw data of the block of given key.
+// The key refers to the resource itself, with the index of the block.
+type BlockShower func(key resource.Key)
@@ -0,0 +1,217 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/editor/graphics"
+	"github.com/inkyblackness/hacked/ss1/content/font"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+	"github.com/inkyblackness/hacked/ui/gui"
+	"github.com/inkyblackness/hacked/ui/opengl"
+)
+
+// DefaultTextColor is the palette index used for texts that have no explicit color.
+const DefaultTextColor byte = 0x35
+
+// PreviewDisplays lists the displays a text can be previewed in.
+var PreviewDisplays = []font.Display{font.MessageDisplay, font.MFDDisplay, font.MessageLineDisplay}
+
+// FontSource provides the resources fonts are taken from.
+type FontSource interface {
+	ResourceIDsIn(file resource.Filename) []resource.ID
+	LocalizedResources(lang resource.Language) resource.Selector
+}
+
+type colorMask struct {
+	color   byte
+	texture *graphics.BitmapTexture
+}
+
+// TextPreview renders texts with the fonts of the game, in the size of a display of the game.
+// The preview shows page breaks and warns if the text does not fit.
+type TextPreview struct {
+	gl           opengl.OpenGL
+	source       FontSource
+	fontCache    *font.Cache
+	paletteCache *graphics.PaletteCache
+	guiScale     float32
+
+	fontID resource.ID
+	page   int
+
+	pixels []byte
+	width  int
+	masks  []colorMask
+}
+
+// NewTextPreview returns a new instance.
+func NewTextPreview(gl opengl.OpenGL, source FontSource, fontCache *font.Cache, paletteCache *graphics.PaletteCache,
+	guiScale float32) *TextPreview {
+	return &TextPreview{
+		gl:           gl,
+		source:       source,
+		fontCache:    fontCache,
+		paletteCache: paletteCache,
+		guiScale:     guiScale,
+	}
+}
+
+// Render draws the given paragraphs as they would be laid out in the display.
+func (preview *TextPreview) Render(label string, display font.Display, paragraphs ...font.Paragraph) {
+	fontIDs := preview.fontIDs()
+	if len(fontIDs) == 0 {
+		imgui.Text("(no fonts available for preview)")
+		return
+	}
+	if !preview.hasFont(fontIDs, preview.fontID) {
+		preview.fontID = fontIDs[0]
+	}
+	imgui.PushItemWidth(-150 * preview.guiScale)
+	if imgui.BeginCombo("Font###"+label+"Font", fmt.Sprintf("%04X", preview.fontID.Value())) {
+		for _, id := range fontIDs {
+			if imgui.SelectableV(fmt.Sprintf("%04X", id.Value()), id == preview.fontID, 0, imgui.Vec2{}) {
+				preview.fontID = id
+			}
+		}
+		imgui.EndCombo()
+	}
+	f, err := preview.fontCache.Font(resource.KeyOf(preview.fontID, resource.LangAny, 0))
+	if err != nil {
+		imgui.PopItemWidth()
+		imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+		imgui.Text(fmt.Sprintf("Font not usable: %v", err))
+		imgui.PopStyleColor()
+		return
+	}
+
+	layout := display.Arrange(f, paragraphs...)
+	pageCount := len(layout.Pages)
+	if preview.page >= pageCount {
+		preview.page = pageCount - 1
+	}
+	if preview.page < 0 {
+		preview.page = 0
+	}
+	if pageCount > 1 {
+		gui.StepSliderInt("Page###"+label+"Page", &preview.page, 0, pageCount-1)
+	} else {
+		imgui.LabelText("Pages###"+label+"Page", fmt.Sprintf("%d", pageCount))
+	}
+	imgui.PopItemWidth()
+	if layout.Overflows(display) {
+		imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+		if layout.BrokenWords {
+			imgui.Text("Overflow: words are too long for a line of the " + display.Title + " display.")
+		}
+		if (display.MaxPages > 0) && (pageCount > display.MaxPages) {
+			imgui.Text(fmt.Sprintf("Overflow: %d pages, the %s display shows %d.", pageCount, display.Title, display.MaxPages))
+		}
+		imgui.PopStyleColor()
+	}
+
+	var lines []font.Line
+	if pageCount > 0 {
+		lines = layout.Pages[preview.page]
+	}
+	preview.update(display.Width, display.Render(f, lines))
+	preview.renderImage(label, display)
+}
+
+func (preview *TextPreview) fontIDs() []resource.ID {
+	var result []resource.ID
+	selector := preview.source.LocalizedResources(resource.LangAny)
+	for _, id := range preview.source.ResourceIDsIn(ids.GameScr) {
+		view, err := selector.Select(id)
+		if (err == nil) && (view.ContentType() == resource.Font) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func (preview *TextPreview) hasFont(fontIDs []resource.ID, id resource.ID) bool {
+	for _, available := range fontIDs {
+		if available == id {
+			return true
+		}
+	}
+	return false
+}
+
+// update recreates the color masks if the pixel data changed.
+// Each mask covers the pixels of one palette index, to be tinted with the color of the palette.
+func (preview *TextPreview) update(width int, pixels []byte) {
+	if (preview.width == width) && bytes.Equal(preview.pixels, pixels) {
+		return
+	}
+	preview.dispose()
+	preview.width = width
+	preview.pixels = pixels
+	height := 0
+	if width > 0 {
+		height = len(pixels) / width
+	}
+	var used [256]bool
+	for _, value := range pixels {
+		used[value] = true
+	}
+	for color := 1; color < len(used); color++ {
+		if !used[color] {
+			continue
+		}
+		mask := make([]byte, len(pixels))
+		for index, value := range pixels {
+			if int(value) == color {
+				mask[index] = 0xFF
+			}
+		}
+		preview.masks = append(preview.masks, colorMask{
+			color:   byte(color),
+			texture: graphics.NewBitmapTexture(preview.gl, width, height, mask),
+		})
+	}
+}
+
+func (preview *TextPreview) dispose() {
+	for _, mask := range preview.masks {
+		mask.texture.Dispose()
+	}
+	preview.masks = nil
+}
+
+func (preview *TextPreview) renderImage(label string, display font.Display) {
+	scale := float32(int(2*preview.guiScale + 0.5))
+	if scale < 1 {
+		scale = 1
+	}
+	size := imgui.Vec2{X: float32(display.Width) * scale, Y: float32(display.Height) * scale}
+	var palette [256][3]float32
+	if paletteTexture, err := preview.paletteCache.Palette(0); err == nil {
+		pal := paletteTexture.Palette()
+		for index, entry := range pal {
+			palette[index] = [3]float32{float32(entry.Red) / 255, float32(entry.Green) / 255, float32(entry.Blue) / 255}
+		}
+	}
+
+	imgui.PushStyleColor(imgui.StyleColorChildBg, imgui.Vec4{X: 0, Y: 0, Z: 0, W: 1})
+	imgui.PushStyleVarVec2(imgui.StyleVarWindowPadding, imgui.Vec2{X: 0, Y: 0})
+	if imgui.BeginChildV(label, size, false,
+		imgui.WindowFlagsNoNav|imgui.WindowFlagsNoInputs|imgui.WindowFlagsNoScrollWithMouse|
+			imgui.WindowFlagsNoScrollbar) {
+		for _, mask := range preview.masks {
+			var uv imgui.Vec2
+			uv.X, uv.Y = mask.texture.UV()
+			color := palette[mask.color]
+			imgui.SetCursorPos(imgui.Vec2{})
+			imgui.ImageV(gui.TextureIDForSimpleTexture(mask.texture.Handle()), size, imgui.Vec2{}, uv,
+				imgui.Vec4{X: color[0], Y: color[1], Z: color[2], W: 1}, imgui.Vec4{})
+		}
+	}
+	imgui.EndChild()
+	imgui.PopStyleVar()
+	imgui.PopStyleColor()
+}
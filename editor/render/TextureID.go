@@ -15,3 +15,18 @@ func TextureIDForBitmapTexture(key resource.Key) imgui.TextureID {
 	id |= imgui.TextureID(key.Index & 0xFFFF)
 	return id
 }
+
+// PrivatePalette is the palette identifier for bitmaps that shall be rendered with their own palette.
+const PrivatePalette resource.ID = 0xFFFF
+
+// TextureIDForBitmapTextureWithPalette returns a texture ID that identifies a bitmap texture,
+// to be rendered with the palette of given resource. A palette ID of zero refers to the default game palette,
+// PrivatePalette to the palette stored in the bitmap.
+func TextureIDForBitmapTextureWithPalette(key resource.Key, paletteID resource.ID) imgui.TextureID {
+	return TextureIDForBitmapTexture(key) | imgui.TextureID(paletteID)<<40
+}
+
+// PaletteIDFromTextureID returns the palette identifier of a texture ID.
+func PaletteIDFromTextureID(id imgui.TextureID) resource.ID {
+	return resource.ID((id >> 40) & 0xFFFF)
+}
@@ -7,19 +7,35 @@ import (
 
 	"github.com/inkyblackness/hacked/editor/graphics"
 	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ui/gui"
 )
 
+type sizedTexture interface {
+	Size() (width, height float32)
+	UV() (u, v float32)
+}
+
 // TextureImage renders an image centered and fitted within the given size.
 func TextureImage(label string, cache *graphics.TextureCache, key resource.Key, size imgui.Vec2) {
-	textureID := TextureIDForBitmapTexture(key)
+	var texture sizedTexture
+	if bitmapTexture, err := cache.Texture(key); err == nil {
+		texture = bitmapTexture
+	}
+	fittedImage(label, TextureIDForBitmapTexture(key), texture, size)
+}
+
+// RGBATextureImage renders a full color image centered and fitted within the given size.
+func RGBATextureImage(label string, texture *graphics.RGBATexture, size imgui.Vec2) {
+	fittedImage(label, gui.TextureIDForRGBATexture(texture.Handle()), texture, size)
+}
 
+func fittedImage(label string, textureID imgui.TextureID, texture sizedTexture, size imgui.Vec2) {
 	imgui.PushStyleColor(imgui.StyleColorChildBg, imgui.Vec4{X: 0, Y: 0, Z: 0, W: 1})
 	imgui.PushStyleVarVec2(imgui.StyleVarWindowPadding, imgui.Vec2{X: 0, Y: 0})
 	if imgui.BeginChildV(label, size, false,
 		imgui.WindowFlagsNoNav|imgui.WindowFlagsNoInputs|imgui.WindowFlagsNoScrollWithMouse|
 			imgui.WindowFlagsNoScrollbar) {
-		texture, err := cache.Texture(key)
-		if err == nil {
+		if texture != nil {
 			var uv imgui.Vec2
 			uv.X, uv.Y = texture.UV()
 			width, height := texture.Size()
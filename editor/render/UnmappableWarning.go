@@ -0,0 +1,18 @@
+package render
+
+import (
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go"
+)
+
+// UnmappableWarning renders a warning about characters that could not be stored with the codepage of a text.
+// Nothing is rendered if the given slice is empty.
+func UnmappableWarning(runes []rune) {
+	if len(runes) == 0 {
+		return
+	}
+	imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1, Y: 1, Z: 0, W: 1})
+	imgui.Text(fmt.Sprintf("Not in codepage, stored as '?': %s", string(runes)))
+	imgui.PopStyleColor()
+}
@@ -0,0 +1,103 @@
+package render
+
+import (
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ss1/content/audio"
+)
+
+// waveformColumnWidth is the unscaled width of one column of the waveform.
+const waveformColumnWidth = 2
+
+// Waveform renders the envelope of a sound within the given size. Columns within the selected range are highlighted.
+// The selection is given with an inclusive start and an exclusive end.
+// Clicking on a column moves the nearer boundary of the selection to the column and reports the new range.
+func Waveform(label string, sound audio.L8, size imgui.Vec2, guiScale float32,
+	from, to int, selectionCallback func(from, to int)) {
+	sampleCount := len(sound.Samples)
+	columnCount := int(size.X / (waveformColumnWidth * guiScale))
+	if columnCount > sampleCount {
+		columnCount = sampleCount
+	}
+
+	imgui.PushStyleColor(imgui.StyleColorChildBg, imgui.Vec4{X: 0, Y: 0, Z: 0, W: 1})
+	imgui.PushStyleVarVec2(imgui.StyleVarWindowPadding, imgui.Vec2{X: 0, Y: 0})
+	imgui.PushStyleVarVec2(imgui.StyleVarFramePadding, imgui.Vec2{X: 0, Y: 0})
+	imgui.PushStyleVarFloat(imgui.StyleVarFrameRounding, 0)
+	if imgui.BeginChildV(label, size, false,
+		imgui.WindowFlagsNoNav|imgui.WindowFlagsNoScrollWithMouse|imgui.WindowFlagsNoScrollbar) && (columnCount > 0) {
+		columnWidth := size.X / float32(columnCount)
+		for column := 0; column < columnCount; column++ {
+			start := column * sampleCount / columnCount
+			end := (column + 1) * sampleCount / columnCount
+			minSample, maxSample := sampleRange(sound.Samples[start:end])
+			top := size.Y * float32(255-maxSample) / 256
+			height := size.Y * float32(int(maxSample)-int(minSample)+1) / 256
+			selected := (end > from) && (start < to)
+
+			clicked := waveformSection(fmt.Sprintf("##top%d", column), selected, false,
+				imgui.Vec2{X: float32(column) * columnWidth, Y: 0}, imgui.Vec2{X: columnWidth, Y: top})
+			clicked = waveformSection(fmt.Sprintf("##wave%d", column), selected, true,
+				imgui.Vec2{X: float32(column) * columnWidth, Y: top}, imgui.Vec2{X: columnWidth, Y: height}) || clicked
+			clicked = waveformSection(fmt.Sprintf("##bottom%d", column), selected, false,
+				imgui.Vec2{X: float32(column) * columnWidth, Y: top + height},
+				imgui.Vec2{X: columnWidth, Y: size.Y - top - height}) || clicked
+			if clicked {
+				if abs(start-from) <= abs(end-to) {
+					selectionCallback(start, to)
+				} else {
+					selectionCallback(from, end)
+				}
+			}
+		}
+	}
+	imgui.EndChild()
+	imgui.PopStyleVarV(3)
+	imgui.PopStyleColor()
+}
+
+func waveformSection(id string, selected bool, wave bool, pos imgui.Vec2, size imgui.Vec2) bool {
+	if size.Y <= 0 {
+		return false
+	}
+	var color imgui.Vec4
+	switch {
+	case wave && selected:
+		color = imgui.Vec4{X: 0.9, Y: 0.9, Z: 0.2, W: 1}
+	case wave:
+		color = imgui.Vec4{X: 0.2, Y: 0.8, Z: 0.2, W: 1}
+	case selected:
+		color = imgui.Vec4{X: 0.2, Y: 0.2, Z: 0.5, W: 1}
+	default:
+		color = imgui.Vec4{X: 0, Y: 0, Z: 0, W: 1}
+	}
+	imgui.PushStyleColor(imgui.StyleColorButton, color)
+	imgui.PushStyleColor(imgui.StyleColorButtonHovered, color)
+	imgui.PushStyleColor(imgui.StyleColorButtonActive, color)
+	imgui.SetCursorPos(pos)
+	clicked := imgui.ButtonV(id, size)
+	imgui.PopStyleColorV(3)
+	return clicked
+}
+
+func sampleRange(samples []byte) (minSample, maxSample byte) {
+	minSample = 0xFF
+	for _, sample := range samples {
+		if sample < minSample {
+			minSample = sample
+		}
+		if sample > maxSample {
+			maxSample = sample
+		}
+	}
+	return
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
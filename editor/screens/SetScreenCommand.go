@@ -0,0 +1,42 @@
+package screens
+
+import (
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+type setScreenCommand struct {
+	model *viewModel
+
+	fileIndex int
+	paletteID resource.ID
+
+	bitmapKey resource.Key
+	oldBitmap []byte
+	newBitmap []byte
+
+	paletteKey resource.Key
+	oldPalette []byte
+	newPalette []byte
+}
+
+func (cmd setScreenCommand) Do(modder world.Modder) error {
+	return cmd.perform(modder, cmd.newBitmap, cmd.newPalette)
+}
+
+func (cmd setScreenCommand) Undo(modder world.Modder) error {
+	return cmd.perform(modder, cmd.oldBitmap, cmd.oldPalette)
+}
+
+func (cmd setScreenCommand) perform(modder world.Modder, bitmapData []byte, paletteData []byte) error {
+	modder.SetResourceBlock(cmd.bitmapKey.Lang, cmd.bitmapKey.ID, cmd.bitmapKey.Index, bitmapData)
+	if cmd.paletteKey.ID != 0 {
+		modder.SetResourceBlock(cmd.paletteKey.Lang, cmd.paletteKey.ID, cmd.paletteKey.Index, paletteData)
+	}
+
+	cmd.model.restoreFocus = true
+	cmd.model.fileIndex = cmd.fileIndex
+	cmd.model.currentKey = cmd.bitmapKey
+	cmd.model.paletteID = cmd.paletteID
+	return nil
+}
@@ -0,0 +1,351 @@
+package screens
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/editor/external"
+	"github.com/inkyblackness/hacked/editor/graphics"
+	"github.com/inkyblackness/hacked/editor/render"
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+	"github.com/inkyblackness/hacked/ui/gui"
+	"github.com/inkyblackness/hacked/ui/opengl"
+)
+
+type screenFile struct {
+	title string
+	file  resource.AnyLanguage
+}
+
+var screenFiles = []screenFile{
+	{title: "Splash", file: ids.Splash},
+	{title: "Side Art", file: ids.SideArt},
+	{title: "Game Screens", file: ids.GameScr},
+}
+
+// View provides edit controls for the full-screen bitmaps and their palettes.
+type View struct {
+	mod          *world.Mod
+	gl           opengl.OpenGL
+	textureCache *graphics.TextureCache
+	paletteCache *graphics.PaletteCache
+
+	modalStateMachine gui.ModalStateMachine
+	guiScale          float32
+	commander         cmd.Commander
+
+	model viewModel
+}
+
+// NewScreensView returns a new instance.
+func NewScreensView(mod *world.Mod, gl opengl.OpenGL, textureCache *graphics.TextureCache, paletteCache *graphics.PaletteCache,
+	modalStateMachine gui.ModalStateMachine, guiScale float32, commander cmd.Commander) *View {
+	view := &View{
+		mod:          mod,
+		gl:           gl,
+		textureCache: textureCache,
+		paletteCache: paletteCache,
+
+		modalStateMachine: modalStateMachine,
+		guiScale:          guiScale,
+		commander:         commander,
+
+		model: freshViewModel(),
+	}
+	return view
+}
+
+// WindowOpen returns the flag address, to be used with the main menu.
+func (view *View) WindowOpen() *bool {
+	return &view.model.windowOpen
+}
+
+// Render renders the view.
+func (view *View) Render() {
+	if view.model.restoreFocus {
+		imgui.SetNextWindowFocus()
+		view.model.restoreFocus = false
+		view.model.windowOpen = true
+	}
+	if view.model.windowOpen {
+		imgui.SetNextWindowSizeV(imgui.Vec2{X: 1000 * view.guiScale, Y: 480 * view.guiScale}, imgui.ConditionOnce)
+		if imgui.BeginV("Screens", view.WindowOpen(), imgui.WindowFlagsNoCollapse) {
+			view.renderContent()
+		}
+		imgui.End()
+	}
+}
+
+func (view *View) renderContent() {
+	screens, palettes := view.resourcesOf(screenFiles[view.model.fileIndex].file)
+	hasScreen := false
+	for _, key := range screens {
+		hasScreen = hasScreen || (key == view.model.currentKey)
+	}
+	if !hasScreen && (len(screens) > 0) {
+		view.selectScreen(screens[0], palettes)
+	}
+
+	if imgui.BeginChildV("Properties", imgui.Vec2{X: 350 * view.guiScale, Y: 0}, false, 0) {
+		imgui.PushItemWidth(-150 * view.guiScale)
+		if imgui.BeginCombo("File", screenFiles[view.model.fileIndex].title) {
+			for index, file := range screenFiles {
+				if imgui.SelectableV(file.title, index == view.model.fileIndex, 0, imgui.Vec2{}) {
+					view.model.fileIndex = index
+				}
+			}
+			imgui.EndCombo()
+		}
+		if hasScreen {
+			view.renderScreenControls(screens, palettes)
+		} else {
+			imgui.Text("(no screens available)")
+		}
+		imgui.PopItemWidth()
+	}
+	imgui.EndChild()
+	imgui.SameLine()
+	if imgui.BeginChildV("Preview", imgui.Vec2{X: -1, Y: 0}, true, imgui.WindowFlagsHorizontalScrollbar) && hasScreen {
+		view.renderPreview()
+	}
+	imgui.EndChild()
+}
+
+func (view *View) renderScreenControls(screens []resource.Key, palettes []resource.ID) {
+	if imgui.BeginCombo("Screen", screenLabel(view.model.currentKey)) {
+		for _, key := range screens {
+			if imgui.SelectableV(screenLabel(key), key == view.model.currentKey, 0, imgui.Vec2{}) {
+				view.selectScreen(key, palettes)
+			}
+		}
+		imgui.EndCombo()
+	}
+	header, hasHeader := view.currentHeader()
+	hasPrivatePalette := hasHeader && (header.PaletteOffset != 0)
+	if imgui.BeginCombo("Palette", paletteLabel(view.model.paletteID)) {
+		var options []resource.ID
+		if hasPrivatePalette {
+			options = append(options, render.PrivatePalette)
+		}
+		options = append(options, palettes...)
+		options = append(options, 0)
+		for _, paletteID := range options {
+			if imgui.SelectableV(paletteLabel(paletteID), paletteID == view.model.paletteID, 0, imgui.Vec2{}) {
+				view.model.paletteID = paletteID
+			}
+		}
+		imgui.EndCombo()
+	}
+	if hasHeader {
+		imgui.LabelText("Size", fmt.Sprintf("%d x %d", header.Width, header.Height))
+		imgui.LabelText("Type", header.Type.String())
+	}
+	if imgui.Button("Export") {
+		view.requestExport()
+	}
+	imgui.SameLine()
+	if imgui.Button("Import") {
+		view.requestImport()
+	}
+	if view.hasModCurrentScreen() {
+		imgui.SameLine()
+		if imgui.Button("Remove") {
+			view.requestSetScreenData(nil, nil)
+		}
+	}
+	if view.model.paletteID != 0 {
+		imgui.Text("Importing generates a new palette\nfrom the image.")
+	}
+}
+
+func (view *View) renderPreview() {
+	texture, err := view.textureCache.Texture(view.model.currentKey)
+	if err != nil {
+		imgui.Text("(bitmap not available)")
+		return
+	}
+	var uv imgui.Vec2
+	uv.X, uv.Y = texture.UV()
+	width, height := texture.Size()
+	textureID := render.TextureIDForBitmapTextureWithPalette(view.model.currentKey, view.model.paletteID)
+	imgui.ImageV(textureID, imgui.Vec2{X: width, Y: height}, imgui.Vec2{}, uv,
+		imgui.Vec4{X: 1, Y: 1, Z: 1, W: 1}, imgui.Vec4{X: 0, Y: 0, Z: 0, W: 0})
+}
+
+func screenLabel(key resource.Key) string {
+	return fmt.Sprintf("%04X:%d", key.ID.Value(), key.Index)
+}
+
+func paletteLabel(paletteID resource.ID) string {
+	switch paletteID {
+	case 0:
+		return "Game Palette"
+	case render.PrivatePalette:
+		return "Private"
+	default:
+		return fmt.Sprintf("Palette %04X", paletteID.Value())
+	}
+}
+
+// resourcesOf returns the bitmaps and palettes of the given file, considering both world and mod.
+func (view *View) resourcesOf(file resource.Filename) (screens []resource.Key, palettes []resource.ID) {
+	idList := view.mod.ResourceIDsIn(file)
+	selector := view.mod.LocalizedResources(resource.LangAny)
+	for _, id := range idList {
+		resourceView, err := selector.Select(id)
+		if err != nil {
+			continue
+		}
+		switch resourceView.ContentType() {
+		case resource.Bitmap:
+			for index := 0; index < resourceView.BlockCount(); index++ {
+				screens = append(screens, resource.KeyOf(id, resource.LangAny, index))
+			}
+		case resource.Palette:
+			palettes = append(palettes, id)
+		}
+	}
+	return
+}
+
+func (view *View) selectScreen(key resource.Key, palettes []resource.ID) {
+	view.model.currentKey = key
+	view.model.paletteID = 0
+	if header, hasHeader := view.currentHeader(); hasHeader && (header.PaletteOffset != 0) {
+		view.model.paletteID = render.PrivatePalette
+	} else if len(palettes) > 0 {
+		view.model.paletteID = palettes[0]
+	}
+}
+
+func (view *View) currentHeader() (bitmap.Header, bool) {
+	var header bitmap.Header
+	key := view.model.currentKey
+	resourceView, err := view.mod.LocalizedResources(key.Lang).Select(key.ID)
+	if err != nil {
+		return header, false
+	}
+	reader, err := resourceView.Block(key.Index)
+	if err != nil {
+		return header, false
+	}
+	err = binary.Read(reader, binary.LittleEndian, &header)
+	return header, err == nil
+}
+
+func (view *View) currentPalette() (bitmap.Palette, error) {
+	var paletteTexture *graphics.PaletteTexture
+	var err error
+	switch view.model.paletteID {
+	case 0:
+		paletteTexture, err = view.paletteCache.Palette(0)
+	case render.PrivatePalette:
+		paletteTexture, err = view.paletteCache.BitmapPalette(view.model.currentKey)
+	default:
+		paletteTexture, err = view.paletteCache.PaletteOf(resource.KeyOf(view.model.paletteID, resource.LangAny, 0))
+	}
+	if err != nil {
+		return bitmap.Palette{}, err
+	}
+	return paletteTexture.Palette(), nil
+}
+
+func (view *View) hasModCurrentScreen() bool {
+	key := view.model.currentKey
+	return len(view.mod.ModifiedBlock(key.Lang, key.ID, key.Index)) > 0
+}
+
+func (view *View) requestExport() {
+	key := view.model.currentKey
+	texture, err := view.textureCache.Texture(key)
+	if err != nil {
+		return
+	}
+	palette, err := view.currentPalette()
+	if err != nil {
+		return
+	}
+	base := strings.TrimSuffix(string(screenFiles[view.model.fileIndex].file), ".res")
+	filename := fmt.Sprintf("%s_%04X_%d.png", base, key.ID.Value(), key.Index)
+	width, height := texture.Size()
+	bmp := bitmap.Bitmap{
+		Header: bitmap.Header{
+			Width:  int16(width),
+			Height: int16(height),
+		},
+		Pixels:  texture.PixelData(),
+		Palette: &palette,
+	}
+
+	external.ExportImage(view.modalStateMachine, filename, bmp)
+}
+
+func (view *View) requestImport() {
+	var paletteRetriever func() (bitmap.Palette, error)
+	if view.model.paletteID == 0 {
+		paletteRetriever = func() (bitmap.Palette, error) {
+			palette, err := view.paletteCache.Palette(0)
+			if err != nil {
+				return bitmap.Palette{}, err
+			}
+			return palette.Palette(), nil
+		}
+	}
+	external.ImportImage(view.modalStateMachine, view.gl, paletteRetriever, func(bmp bitmap.Bitmap) {
+		palette := bmp.Palette
+		bmp.Palette = nil
+		view.requestSetScreen(bmp, palette)
+	})
+}
+
+func (view *View) requestSetScreen(bmp bitmap.Bitmap, palette *bitmap.Palette) {
+	bmp.Header.Type = bitmap.TypeFlat8Bit
+	if oldHeader, hasOld := view.currentHeader(); hasOld {
+		if oldHeader.Type.Is8Bit() {
+			bmp.Header.Type = oldHeader.Type
+		}
+		bmp.Header.Flags = oldHeader.Flags
+		bmp.Header.Area = oldHeader.Area.Scaled(oldHeader.Width, oldHeader.Height, bmp.Header.Width, bmp.Header.Height)
+	}
+	bmp.Header.WidthFactor = bitmap.HighestBitShift(bmp.Header.Width)
+	bmp.Header.HeightFactor = bitmap.HighestBitShift(bmp.Header.Height)
+	bmp.Header.Stride = uint16(bmp.Header.Width)
+	var paletteData []byte
+	if palette != nil {
+		if view.model.paletteID == render.PrivatePalette {
+			bmp.Palette = palette
+		} else {
+			buf := bytes.NewBuffer(nil)
+			_ = binary.Write(buf, binary.LittleEndian, palette)
+			paletteData = buf.Bytes()
+		}
+	}
+	view.requestSetScreenData(bitmap.Encode(&bmp, 0), paletteData)
+}
+
+func (view *View) requestSetScreenData(bitmapData []byte, paletteData []byte) {
+	key := view.model.currentKey
+	command := setScreenCommand{
+		model:     &view.model,
+		fileIndex: view.model.fileIndex,
+		paletteID: view.model.paletteID,
+
+		bitmapKey: key,
+		oldBitmap: view.mod.ModifiedBlock(key.Lang, key.ID, key.Index),
+		newBitmap: bitmapData,
+	}
+	if paletteData != nil {
+		command.paletteKey = resource.KeyOf(view.model.paletteID, resource.LangAny, 0)
+		command.oldPalette = view.mod.ModifiedBlock(resource.LangAny, view.model.paletteID, 0)
+		command.newPalette = paletteData
+	}
+	view.commander.Queue(command)
+}
@@ -0,0 +1,18 @@
+package screens
+
+import "github.com/inkyblackness/hacked/ss1/resource"
+
+type viewModel struct {
+	windowOpen   bool
+	restoreFocus bool
+
+	fileIndex  int
+	currentKey resource.Key
+	paletteID  resource.ID
+}
+
+func freshViewModel() viewModel {
+	return viewModel{
+		currentKey: resource.KeyOf(0, resource.LangAny, 0),
+	}
+}
@@ -0,0 +1,52 @@
+package search
+
+import (
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+type textChange struct {
+	lang  resource.Language
+	id    resource.ID
+	index int
+
+	oldData [][]byte
+	newData [][]byte
+}
+
+type replaceTextsCommand struct {
+	model *viewModel
+
+	changes []textChange
+}
+
+func (cmd replaceTextsCommand) Do(modder world.Modder) error {
+	return cmd.perform(modder, func(change textChange) [][]byte { return change.newData })
+}
+
+func (cmd replaceTextsCommand) Undo(modder world.Modder) error {
+	return cmd.perform(modder, func(change textChange) [][]byte { return change.oldData })
+}
+
+func (cmd replaceTextsCommand) perform(modder world.Modder, dataResolver func(textChange) [][]byte) error {
+	for _, change := range cmd.changes {
+		data := dataResolver(change)
+		switch {
+		case change.index >= 0:
+			var block []byte
+			if len(data) > 0 {
+				block = data[0]
+			}
+			modder.SetResourceBlock(change.lang, change.id, change.index, block)
+		case len(data) > 0:
+			modder.SetResourceBlocks(change.lang, change.id, data)
+		default:
+			modder.DelResource(change.lang, change.id)
+		}
+	}
+	cmd.model.restoreFocus = true
+	cmd.model.results = nil
+	cmd.model.searched = false
+	cmd.model.selectedIndex = -1
+	return nil
+}
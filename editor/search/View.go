@@ -0,0 +1,220 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/editor/render"
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/edit/localization"
+	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+// snippetContext is the number of characters shown before and after the first occurrence in a result.
+const snippetContext = 30
+
+// View provides a search over all texts of the mod, with the option to replace all occurrences.
+type View struct {
+	mod          *world.Mod
+	codepages    text.Codepages
+	showResource render.ResourceShower
+
+	guiScale  float32
+	commander cmd.Commander
+
+	model viewModel
+}
+
+// NewTextSearchView returns a new instance.
+func NewTextSearchView(mod *world.Mod, codepages text.Codepages, showResource render.ResourceShower,
+	guiScale float32, commander cmd.Commander) *View {
+	view := &View{
+		mod:          mod,
+		codepages:    codepages,
+		showResource: showResource,
+
+		guiScale:  guiScale,
+		commander: commander,
+
+		model: freshViewModel(),
+	}
+	return view
+}
+
+// WindowOpen returns the flag address, to be used with the main menu.
+func (view *View) WindowOpen() *bool {
+	return &view.model.windowOpen
+}
+
+// Render renders the view.
+func (view *View) Render() {
+	if view.model.restoreFocus {
+		imgui.SetNextWindowFocus()
+		view.model.restoreFocus = false
+		view.model.windowOpen = true
+	}
+	if view.model.windowOpen {
+		imgui.SetNextWindowSizeV(imgui.Vec2{X: 640 * view.guiScale, Y: 480 * view.guiScale}, imgui.ConditionOnce)
+		if imgui.BeginV("Text Search", view.WindowOpen(), imgui.WindowFlagsNoCollapse) {
+			view.renderContent()
+		}
+		imgui.End()
+	}
+}
+
+func (view *View) renderContent() {
+	imgui.PushItemWidth(-150 * view.guiScale)
+	if imgui.InputTextV("Expression", &view.model.pattern, imgui.InputTextFlagsEnterReturnsTrue, nil) {
+		view.search()
+	}
+	imgui.InputText("Replacement", &view.model.replacement)
+	view.renderLanguageCombo()
+	imgui.PopItemWidth()
+	imgui.Checkbox("Ignore Case", &view.model.ignoreCase)
+
+	if imgui.Button("Search") {
+		view.search()
+	}
+	if view.model.searched && (len(view.model.results) > 0) {
+		imgui.SameLine()
+		if imgui.Button("Replace All") {
+			view.requestReplaceAll()
+		}
+	}
+	if view.model.patternErr != nil {
+		gui.TextColored(fmt.Sprintf("Invalid expression: %v", view.model.patternErr), imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1})
+	}
+	if len(view.model.resultInfo) > 0 {
+		imgui.Text(view.model.resultInfo)
+	}
+	if view.model.searched {
+		imgui.Text(fmt.Sprintf("%d texts found. Select one to show it in its editor.", len(view.model.results)))
+	}
+	imgui.Separator()
+	if imgui.BeginChildV("Results", imgui.Vec2{X: -1, Y: 0}, true, imgui.WindowFlagsHorizontalScrollbar) {
+		for index, res := range view.model.results {
+			label := fmt.Sprintf("%s - %s: %s##%d", view.mod.LanguageName(res.lang), res.match.Note, snippet(res.match), index)
+			if imgui.SelectableV(label, index == view.model.selectedIndex, 0, imgui.Vec2{}) {
+				view.model.selectedIndex = index
+				view.model.resultInfo = ""
+				if !view.showResource(res.match.Key) {
+					view.model.resultInfo = "There is no editor for this text."
+				}
+			}
+		}
+	}
+	imgui.EndChild()
+}
+
+func (view *View) renderLanguageCombo() {
+	selected := "All"
+	if !view.model.allLanguages {
+		selected = view.mod.LanguageName(view.model.lang)
+	}
+	if imgui.BeginCombo("Language", selected) {
+		if imgui.SelectableV("All", view.model.allLanguages, 0, imgui.Vec2{}) {
+			view.model.allLanguages = true
+		}
+		for _, lang := range view.mod.Languages() {
+			if imgui.SelectableV(view.mod.LanguageName(lang), !view.model.allLanguages && (lang == view.model.lang), 0, imgui.Vec2{}) {
+				view.model.allLanguages = false
+				view.model.lang = lang
+			}
+		}
+		imgui.EndCombo()
+	}
+}
+
+func (view *View) languages() []resource.Language {
+	if view.model.allLanguages {
+		return view.mod.Languages()
+	}
+	return []resource.Language{view.model.lang}
+}
+
+func (view *View) expression() (*regexp.Regexp, error) {
+	pattern := view.model.pattern
+	if view.model.ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	return regexp.Compile(pattern)
+}
+
+func (view *View) search() {
+	view.model.results = nil
+	view.model.searched = false
+	view.model.selectedIndex = -1
+	view.model.resultInfo = ""
+	expr, err := view.expression()
+	view.model.patternErr = err
+	if (err != nil) || (len(view.model.pattern) == 0) {
+		return
+	}
+	for _, lang := range view.languages() {
+		for _, match := range localization.Search(view.mod, view.codepages, lang, expr) {
+			view.model.results = append(view.model.results, result{lang: lang, match: match})
+		}
+	}
+	view.model.searched = true
+}
+
+func (view *View) requestReplaceAll() {
+	expr, err := view.expression()
+	view.model.patternErr = err
+	if (err != nil) || (len(view.model.pattern) == 0) {
+		return
+	}
+	command := replaceTextsCommand{model: &view.model}
+	replaced, overLength, unmappable := 0, 0, 0
+	for _, lang := range view.languages() {
+		result := localization.Replace(view.mod, view.codepages, lang, expr, view.model.replacement)
+		replaced += len(result.Replaced)
+		overLength += len(result.OverLength)
+		unmappable += len(result.Unmappable)
+		for _, change := range result.Changes {
+			entry := textChange{lang: lang, id: change.ID, index: change.Index, newData: change.Data}
+			if change.Index >= 0 {
+				if oldData := view.mod.ModifiedBlock(lang, change.ID, change.Index); oldData != nil {
+					entry.oldData = [][]byte{oldData}
+				}
+			} else {
+				entry.oldData = view.mod.ModifiedBlocks(lang, change.ID)
+			}
+			command.changes = append(command.changes, entry)
+		}
+	}
+	if len(command.changes) > 0 {
+		view.commander.Queue(command)
+	}
+	view.model.resultInfo = fmt.Sprintf("Replaced %d texts. Skipped %d too long and %d not in codepage.",
+		replaced, overLength, unmappable)
+}
+
+// snippet returns the part of the text around the first occurrence, on a single line.
+// The occurrence is enclosed in brackets.
+func snippet(match localization.Match) string {
+	if len(match.Ranges) == 0 {
+		return ""
+	}
+	start, end := match.Ranges[0][0], match.Ranges[0][1]
+	before := []rune(match.Value[:start])
+	prefix := ""
+	if len(before) > snippetContext {
+		before = before[len(before)-snippetContext:]
+		prefix = "..."
+	}
+	after := []rune(match.Value[end:])
+	suffix := ""
+	if len(after) > snippetContext {
+		after = after[:snippetContext]
+		suffix = "..."
+	}
+	result := prefix + string(before) + "[" + match.Value[start:end] + "]" + string(after) + suffix
+	return strings.NewReplacer("\n", " ", "\r", " ").Replace(result)
+}
@@ -0,0 +1,36 @@
+package search
+
+import (
+	"github.com/inkyblackness/hacked/ss1/edit/localization"
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+type viewModel struct {
+	windowOpen   bool
+	restoreFocus bool
+
+	pattern      string
+	replacement  string
+	ignoreCase   bool
+	allLanguages bool
+	lang         resource.Language
+
+	patternErr    error
+	searched      bool
+	results       []result
+	selectedIndex int
+	resultInfo    string
+}
+
+type result struct {
+	lang  resource.Language
+	match localization.Match
+}
+
+func freshViewModel() viewModel {
+	return viewModel{
+		allLanguages:  true,
+		lang:          resource.LangDefault,
+		selectedIndex: -1,
+	}
+}
@@ -0,0 +1,36 @@
+package sounds
+
+import (
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+type setSoundCommand struct {
+	model *viewModel
+
+	currentIndex int
+
+	id      resource.ID
+	oldData []byte
+	newData []byte
+}
+
+func (cmd setSoundCommand) Do(modder world.Modder) error {
+	return cmd.perform(modder, cmd.newData)
+}
+
+func (cmd setSoundCommand) Undo(modder world.Modder) error {
+	return cmd.perform(modder, cmd.oldData)
+}
+
+func (cmd setSoundCommand) perform(modder world.Modder, data []byte) error {
+	if len(data) > 0 {
+		modder.SetResourceBlock(resource.LangAny, cmd.id, 0, data)
+	} else {
+		modder.DelResource(resource.LangAny, cmd.id)
+	}
+
+	cmd.model.restoreFocus = true
+	cmd.model.currentIndex = cmd.currentIndex
+	return nil
+}
@@ -0,0 +1,180 @@
+package sounds
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/editor/external"
+	"github.com/inkyblackness/hacked/ss1/content/audio"
+	"github.com/inkyblackness/hacked/ss1/content/audio/voc"
+	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+	"github.com/inkyblackness/hacked/ui/gui"
+)
+
+// View provides edit controls for sound effects.
+type View struct {
+	mod        *world.Mod
+	soundCache *voc.Cache
+
+	modalStateMachine gui.ModalStateMachine
+	audioPlayer       *external.AudioPlayer
+	guiScale          float32
+	commander         cmd.Commander
+
+	model viewModel
+}
+
+// NewSoundEffectsView returns a new instance.
+func NewSoundEffectsView(mod *world.Mod, soundCache *voc.Cache,
+	modalStateMachine gui.ModalStateMachine, audioPlayer *external.AudioPlayer,
+	guiScale float32, commander cmd.Commander) *View {
+	view := &View{
+		mod:        mod,
+		soundCache: soundCache,
+
+		modalStateMachine: modalStateMachine,
+		audioPlayer:       audioPlayer,
+		guiScale:          guiScale,
+		commander:         commander,
+
+		model: freshViewModel(),
+	}
+	return view
+}
+
+// WindowOpen returns the flag address, to be used with the main menu.
+func (view *View) WindowOpen() *bool {
+	return &view.model.windowOpen
+}
+
+// Render renders the view.
+func (view *View) Render() {
+	if view.model.restoreFocus {
+		imgui.SetNextWindowFocus()
+		view.model.restoreFocus = false
+		view.model.windowOpen = true
+	}
+	if view.model.windowOpen {
+		imgui.SetNextWindowSizeV(imgui.Vec2{X: 600 * view.guiScale, Y: 300 * view.guiScale}, imgui.ConditionOnce)
+		if imgui.BeginV("Sound Effects", view.WindowOpen(), imgui.WindowFlagsNoCollapse) {
+			view.renderContent()
+		}
+		imgui.End()
+	}
+}
+
+func (view *View) renderContent() {
+	info, _ := ids.Info(ids.SoundEffectsAudioStart)
+
+	if imgui.BeginChildV("Properties", imgui.Vec2{X: 350 * view.guiScale, Y: 0}, false, 0) {
+		imgui.PushItemWidth(-150 * view.guiScale)
+		gui.StepSliderInt("Index", &view.model.currentIndex, 0, info.MaxCount-1)
+		imgui.LabelText("ID", fmt.Sprintf("%04X", view.currentID().Value()))
+
+		sound, err := view.soundCache.Sound(view.currentKey())
+		if err == nil {
+			imgui.LabelText("Sample Rate", fmt.Sprintf("%.0f Hz", sound.SampleRate))
+			imgui.LabelText("Samples", fmt.Sprintf("%d", len(sound.Samples)))
+			imgui.LabelText("Duration", fmt.Sprintf("%.2f sec", sound.Duration()))
+		} else {
+			imgui.LabelText("Audio", "(no sound)")
+		}
+
+		if imgui.Button("Import") {
+			view.requestImport()
+		}
+		if err == nil {
+			imgui.SameLine()
+			if imgui.Button("Play") {
+				view.requestPlay(sound)
+			}
+			imgui.SameLine()
+			if imgui.Button("Export WAV") {
+				view.requestExport(sound, "wav")
+			}
+			imgui.SameLine()
+			if imgui.Button("Export VOC") {
+				view.requestExport(sound, "voc")
+			}
+		}
+		if view.model.playbackFailed {
+			imgui.Text("Playback failed.")
+		}
+		if view.hasModCurrentSound() {
+			if imgui.Button("Remove") {
+				view.requestSetSoundData(nil)
+			}
+		} else {
+			imgui.Text("(read-only)")
+		}
+
+		imgui.PopItemWidth()
+	}
+	imgui.EndChild()
+	imgui.SameLine()
+	if imgui.BeginChildV("Sounds", imgui.Vec2{X: -1, Y: 0}, true, 0) {
+		for index := 0; index < info.MaxCount; index++ {
+			description := "(no sound)"
+			sound, err := view.soundCache.Sound(resource.KeyOf(ids.SoundEffectsAudioStart, resource.LangAny, index))
+			if err == nil {
+				description = fmt.Sprintf("%.2f sec, %.0f Hz", sound.Duration(), sound.SampleRate)
+			}
+			if imgui.SelectableV(fmt.Sprintf("%3d: %s", index, description), index == view.model.currentIndex, 0, imgui.Vec2{}) {
+				view.model.currentIndex = index
+				view.model.playbackFailed = false
+			}
+		}
+	}
+	imgui.EndChild()
+}
+
+func (view *View) currentID() resource.ID {
+	return ids.SoundEffectsAudioStart.Plus(view.model.currentIndex)
+}
+
+func (view *View) currentKey() resource.Key {
+	return resource.KeyOf(ids.SoundEffectsAudioStart, resource.LangAny, view.model.currentIndex)
+}
+
+func (view *View) hasModCurrentSound() bool {
+	return len(view.mod.ModifiedBlocks(resource.LangAny, view.currentID())) > 0
+}
+
+func (view *View) requestPlay(sound audio.L8) {
+	view.model.playbackFailed = view.audioPlayer.Play(sound) != nil
+}
+
+func (view *View) requestExport(sound audio.L8, extension string) {
+	filename := fmt.Sprintf("%05d.%s", view.currentID().Value(), extension)
+
+	external.ExportAudio(view.modalStateMachine, filename, sound)
+}
+
+func (view *View) requestImport() {
+	existing, _ := view.soundCache.Sound(view.currentKey())
+	external.ImportAudio(view.modalStateMachine, view.audioPlayer, view.guiScale, external.ImportSampleRateFor(existing), func(sound audio.L8) {
+		buf := bytes.NewBuffer(nil)
+		err := voc.Save(buf, sound.SampleRate, sound.Samples)
+		if err != nil {
+			return
+		}
+		view.requestSetSoundData(buf.Bytes())
+	})
+}
+
+func (view *View) requestSetSoundData(newData []byte) {
+	command := setSoundCommand{
+		model:        &view.model,
+		currentIndex: view.model.currentIndex,
+
+		id:      view.currentID(),
+		oldData: view.mod.ModifiedBlock(resource.LangAny, view.currentID(), 0),
+		newData: newData,
+	}
+	view.commander.Queue(command)
+}
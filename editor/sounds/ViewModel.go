@@ -0,0 +1,13 @@
+package sounds
+
+type viewModel struct {
+	windowOpen   bool
+	restoreFocus bool
+
+	currentIndex   int
+	playbackFailed bool
+}
+
+func freshViewModel() viewModel {
+	return viewModel{}
+}
@@ -0,0 +1,26 @@
+package targets
+
+import (
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+type targetProfileSetter interface {
+	SetTargetProfile(key string)
+}
+
+type setTargetProfileCommand struct {
+	setter targetProfileSetter
+
+	oldKey string
+	newKey string
+}
+
+func (cmd setTargetProfileCommand) Do(modder world.Modder) error {
+	cmd.setter.SetTargetProfile(cmd.newKey)
+	return nil
+}
+
+func (cmd setTargetProfileCommand) Undo(modder world.Modder) error {
+	cmd.setter.SetTargetProfile(cmd.oldKey)
+	return nil
+}
@@ -0,0 +1,129 @@
+package targets
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/inkyblackness/imgui-go"
+
+	"github.com/inkyblackness/hacked/ss1/content/archive/level"
+	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/target"
+)
+
+// checkInterval is the time after which the mod is checked again, even without a change of the mod itself.
+// This covers changes to the static world data.
+const checkInterval = 2 * time.Second
+
+// View shows the violations of the mod regarding the engine profile it is made for.
+type View struct {
+	mod    *world.Mod
+	levels []*level.Level
+
+	guiScale  float32
+	commander cmd.Commander
+
+	model viewModel
+}
+
+// NewTargetCheckView returns a new instance.
+func NewTargetCheckView(mod *world.Mod, levels []*level.Level, guiScale float32, commander cmd.Commander) *View {
+	view := &View{
+		mod:    mod,
+		levels: levels,
+
+		guiScale:  guiScale,
+		commander: commander,
+
+		model: freshViewModel(),
+	}
+	return view
+}
+
+// WindowOpen returns the flag address, to be used with the main menu.
+func (view *View) WindowOpen() *bool {
+	return &view.model.windowOpen
+}
+
+// Render renders the view.
+func (view *View) Render() {
+	if view.model.restoreFocus {
+		imgui.SetNextWindowFocus()
+		view.model.restoreFocus = false
+		view.model.windowOpen = true
+	}
+	if view.model.windowOpen {
+		title := "Target Check"
+		if count := len(view.currentViolations()); count > 0 {
+			title += fmt.Sprintf(" - %d issue(s)", count)
+		}
+		imgui.SetNextWindowSizeV(imgui.Vec2{X: 480 * view.guiScale, Y: 320 * view.guiScale}, imgui.ConditionOnce)
+		if imgui.BeginV(title+"###Target Check", view.WindowOpen(), imgui.WindowFlagsNoCollapse) {
+			view.renderContent()
+		}
+		imgui.End()
+	}
+}
+
+func (view *View) renderContent() {
+	profile, _ := target.ProfileByKey(view.mod.TargetProfile())
+	imgui.PushItemWidth(-150 * view.guiScale)
+	if imgui.BeginCombo("Target Engine", profile.Title) {
+		for _, candidate := range target.Profiles() {
+			if imgui.SelectableV(candidate.Title, candidate.Key == profile.Key, 0, imgui.Vec2{}) {
+				view.requestSetTargetProfile(candidate.Key)
+			}
+		}
+		imgui.EndCombo()
+	}
+	imgui.PopItemWidth()
+	if imgui.Button("Refresh") {
+		view.model.violations = nil
+		view.model.checkedTimestamp = time.Time{}
+	}
+
+	imgui.Separator()
+	violations := view.currentViolations()
+	if len(violations) == 0 {
+		imgui.Text("No issues found.")
+		return
+	}
+	if imgui.BeginChildV("Violations", imgui.Vec2{X: -1, Y: 0}, true, 0) {
+		for _, violation := range violations {
+			color := imgui.Vec4{X: 1, Y: 1, Z: 0, W: 1}
+			if violation.Severity == target.Error {
+				color = imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1}
+			}
+			imgui.PushStyleColor(imgui.StyleColorText, color)
+			imgui.Text(violation.Severity.String())
+			imgui.PopStyleColor()
+			imgui.SameLine()
+			imgui.Text(violation.Area + ": " + violation.Message)
+		}
+	}
+	imgui.EndChild()
+}
+
+func (view *View) currentViolations() []target.Violation {
+	key := view.mod.TargetProfile()
+	changeTime := view.mod.LastChangeTime()
+	if (view.model.checkedProfile != key) || !view.model.checkedChange.Equal(changeTime) ||
+		(time.Since(view.model.checkedTimestamp) > checkInterval) {
+		profile, _ := target.ProfileByKey(key)
+		view.model.violations = target.Check(profile, target.SubjectOf(view.mod, view.levels))
+		view.model.checkedProfile = key
+		view.model.checkedChange = changeTime
+		view.model.checkedTimestamp = time.Now()
+	}
+	return view.model.violations
+}
+
+func (view *View) requestSetTargetProfile(key string) {
+	command := setTargetProfileCommand{
+		setter: view.mod,
+		oldKey: view.mod.TargetProfile(),
+		newKey: key,
+	}
+	view.commander.Queue(command)
+}
@@ -0,0 +1,21 @@
+package targets
+
+import (
+	"time"
+
+	"github.com/inkyblackness/hacked/ss1/world/target"
+)
+
+type viewModel struct {
+	windowOpen   bool
+	restoreFocus bool
+
+	violations       []target.Violation
+	checkedProfile   string
+	checkedChange    time.Time
+	checkedTimestamp time.Time
+}
+
+func freshViewModel() viewModel {
+	return viewModel{}
+}
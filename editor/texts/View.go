@@ -6,34 +6,46 @@ import (
 	"github.com/inkyblackness/imgui-go"
 
 	"github.com/inkyblackness/hacked/editor/external"
+	"github.com/inkyblackness/hacked/editor/render"
 	"github.com/inkyblackness/hacked/ss1/content/audio"
+	"github.com/inkyblackness/hacked/ss1/content/font"
+	"github.com/inkyblackness/hacked/ss1/content/text"
 	"github.com/inkyblackness/hacked/ss1/edit"
 	"github.com/inkyblackness/hacked/ss1/edit/undoable"
 	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
 	"github.com/inkyblackness/hacked/ss1/world/ids"
 	"github.com/inkyblackness/hacked/ui/gui"
 )
 
 // View provides edit controls for texts.
 type View struct {
+	mod         *world.Mod
 	textService undoable.AugmentedTextService
+	codepages   text.Codepages
+	textPreview *render.TextPreview
 
 	modalStateMachine gui.ModalStateMachine
 	clipboard         external.Clipboard
+	audioPlayer       *external.AudioPlayer
 	guiScale          float32
 
 	model viewModel
 }
 
 // NewTextsView returns a new instance.
-func NewTextsView(textService undoable.AugmentedTextService,
-	modalStateMachine gui.ModalStateMachine, clipboard external.Clipboard,
+func NewTextsView(mod *world.Mod, textService undoable.AugmentedTextService, codepages text.Codepages, textPreview *render.TextPreview,
+	modalStateMachine gui.ModalStateMachine, clipboard external.Clipboard, audioPlayer *external.AudioPlayer,
 	guiScale float32) *View {
 	view := &View{
+		mod:         mod,
 		textService: textService,
+		codepages:   codepages,
+		textPreview: textPreview,
 
 		modalStateMachine: modalStateMachine,
 		clipboard:         clipboard,
+		audioPlayer:       audioPlayer,
 		guiScale:          guiScale,
 
 		model: freshViewModel(),
@@ -46,6 +58,20 @@ func (view *View) WindowOpen() *bool {
 	return &view.model.windowOpen
 }
 
+// ShowResource selects the text with given key and brings the window to front.
+// It returns false if the key does not refer to a known text type.
+func (view *View) ShowResource(key resource.Key) bool {
+	for _, info := range edit.KnownTexts() {
+		if info.ID == key.ID {
+			view.model.currentKey = key
+			view.model.previewDisplay = previewDisplayFor(key.ID)
+			view.model.restoreFocus = true
+			return true
+		}
+	}
+	return false
+}
+
 // Render renders the view.
 func (view *View) Render() {
 	if view.model.restoreFocus {
@@ -54,7 +80,7 @@ func (view *View) Render() {
 		view.model.windowOpen = true
 	}
 	if view.model.windowOpen {
-		imgui.SetNextWindowSizeV(imgui.Vec2{X: 400 * view.guiScale, Y: 300 * view.guiScale}, imgui.ConditionOnce)
+		imgui.SetNextWindowSizeV(imgui.Vec2{X: 640 * view.guiScale, Y: 560 * view.guiScale}, imgui.ConditionOnce)
 		if imgui.BeginV("Texts", view.WindowOpen(), imgui.WindowFlagsNoCollapse) {
 			view.renderContent()
 		}
@@ -70,6 +96,7 @@ func (view *View) renderContent() {
 			if imgui.SelectableV(info.Title, info.ID == view.model.currentKey.ID, 0, imgui.Vec2{}) {
 				view.model.currentKey.ID = info.ID
 				view.model.currentKey.Index = 0
+				view.model.previewDisplay = previewDisplayFor(info.ID)
 			}
 		}
 		imgui.EndCombo()
@@ -77,10 +104,10 @@ func (view *View) renderContent() {
 	info, _ := ids.Info(view.model.currentKey.ID)
 	gui.StepSliderInt("Index", &view.model.currentKey.Index, 0, info.MaxCount-1)
 
-	if imgui.BeginCombo("Language", view.model.currentKey.Lang.String()) {
-		languages := resource.Languages()
+	if imgui.BeginCombo("Language", view.mod.LanguageName(view.model.currentKey.Lang)) {
+		languages := view.mod.Languages()
 		for _, lang := range languages {
-			if imgui.SelectableV(lang.String(), lang == view.model.currentKey.Lang, 0, imgui.Vec2{}) {
+			if imgui.SelectableV(view.mod.LanguageName(lang), lang == view.model.currentKey.Lang, 0, imgui.Vec2{}) {
 				view.model.currentKey.Lang = lang
 			}
 		}
@@ -102,13 +129,17 @@ func (view *View) renderContent() {
 		if imgui.Button("Import") {
 			view.requestImportAudio()
 		}
+		view.model.audioEditor.Render("Audio", sound, false, 300*view.guiScale, view.guiScale, view.requestSoundEdit)
 	}
 	imgui.Separator()
 
 	imgui.PopItemWidth()
 
+	if view.model.unmappableKey == view.model.currentKey {
+		render.UnmappableWarning(view.model.unmappable)
+	}
 	currentText := view.currentText()
-	imgui.BeginChildV("Text", imgui.Vec2{X: -100 * view.guiScale, Y: 0}, true, 0)
+	imgui.BeginChildV("Text", imgui.Vec2{X: -100 * view.guiScale, Y: 120 * view.guiScale}, true, 0)
 	imgui.PushTextWrapPos()
 	if len(currentText) == 0 {
 		imgui.PushStyleColor(imgui.StyleColorText, imgui.Vec4{X: 1.0, Y: 1.0, Z: 1.0, W: 0.5})
@@ -136,6 +167,26 @@ func (view *View) renderContent() {
 		view.removeText()
 	}
 	imgui.EndGroup()
+
+	view.renderPreview(currentText)
+}
+
+func (view *View) renderPreview(currentText string) {
+	imgui.Separator()
+	imgui.PushItemWidth(-150 * view.guiScale)
+	display := render.PreviewDisplays[view.model.previewDisplay]
+	if imgui.BeginCombo("Preview Display", display.Title) {
+		for index, available := range render.PreviewDisplays {
+			if imgui.SelectableV(available.Title, index == view.model.previewDisplay, 0, imgui.Vec2{}) {
+				view.model.previewDisplay = index
+			}
+		}
+		imgui.EndCombo()
+	}
+	imgui.PopItemWidth()
+	cp := view.codepages.ForLanguage(view.model.currentKey.Lang)
+	view.textPreview.Render("TextPreview", render.PreviewDisplays[view.model.previewDisplay],
+		font.Paragraph{Text: cp.Encode(currentText), Color: render.DefaultTextColor})
 }
 
 func (view View) currentText() string {
@@ -154,6 +205,8 @@ func (view *View) setTextFromClipboard() {
 		return
 	}
 
+	view.model.unmappable = text.UnmappableRunes(view.codepages.ForLanguage(view.model.currentKey.Lang), value)
+	view.model.unmappableKey = view.model.currentKey
 	view.textService.RequestSetText(view.model.currentKey, value, view.restoreFunc())
 }
 
@@ -177,17 +230,21 @@ func (view *View) requestExportAudio(sound audio.L8) {
 	if !view.textIsWithAudio() {
 		return
 	}
-	filename := fmt.Sprintf("%05d_%03d_%s.wav", view.model.currentKey.ID, view.model.currentKey.Index, view.model.currentKey.Lang.String())
+	filename := fmt.Sprintf("%05d_%03d_%s.wav", view.model.currentKey.ID, view.model.currentKey.Index, view.mod.LanguageName(view.model.currentKey.Lang))
 
 	external.ExportAudio(view.modalStateMachine, filename, sound)
 }
 
 func (view *View) requestImportAudio() {
-	external.ImportAudio(view.modalStateMachine, func(sound audio.L8) {
+	external.ImportAudio(view.modalStateMachine, view.audioPlayer, view.guiScale, external.ImportSampleRateFor(view.currentSound()), func(sound audio.L8) {
 		view.textService.RequestSetSound(view.model.currentKey, sound, view.restoreFunc())
 	})
 }
 
+func (view *View) requestSoundEdit(sound audio.L8) {
+	view.textService.RequestSetSound(view.model.currentKey, sound, view.restoreFunc())
+}
+
 func (view *View) restoreFunc() func() {
 	oldKey := view.model.currentKey
 
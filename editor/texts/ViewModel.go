@@ -1,18 +1,37 @@
 package texts
 
 import (
+	"github.com/inkyblackness/hacked/editor/render"
 	"github.com/inkyblackness/hacked/ss1/edit"
 	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
 )
 
 type viewModel struct {
 	windowOpen   bool
 	restoreFocus bool
 	currentKey   resource.Key
+
+	unmappable    []rune
+	unmappableKey resource.Key
+
+	previewDisplay int
+
+	audioEditor render.AudioEditor
+}
+
+// previewDisplayFor returns the index of the display in which texts of given resource are shown by the game.
+func previewDisplayFor(id resource.ID) int {
+	if id == ids.PaperTextsStart {
+		return 1
+	}
+	return 2
 }
 
 func freshViewModel() viewModel {
 	return viewModel{
-		currentKey: resource.KeyOf(edit.KnownTexts()[0].ID, resource.LangDefault, 0),
+		currentKey:     resource.KeyOf(edit.KnownTexts()[0].ID, resource.LangDefault, 0),
+		previewDisplay: previewDisplayFor(edit.KnownTexts()[0].ID),
+		audioEditor:    render.NewAudioEditor(),
 	}
 }
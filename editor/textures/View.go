@@ -18,13 +18,15 @@ import (
 	"github.com/inkyblackness/hacked/ss1/world"
 	"github.com/inkyblackness/hacked/ss1/world/ids"
 	"github.com/inkyblackness/hacked/ui/gui"
+	"github.com/inkyblackness/hacked/ui/opengl"
 )
 
 // View provides edit controls for textures.
 type View struct {
 	mod          *world.Mod
+	gl           opengl.OpenGL
 	textCache    *text.Cache
-	cp           text.Codepage
+	codepages    text.Codepages
 	imageCache   *graphics.TextureCache
 	paletteCache *graphics.PaletteCache
 
@@ -37,14 +39,15 @@ type View struct {
 }
 
 // NewTexturesView returns a new instance.
-func NewTexturesView(mod *world.Mod, textCache *text.Cache, cp text.Codepage,
+func NewTexturesView(mod *world.Mod, gl opengl.OpenGL, textCache *text.Cache, codepages text.Codepages,
 	imageCache *graphics.TextureCache, paletteCache *graphics.PaletteCache,
 	modalStateMachine gui.ModalStateMachine,
 	clipboard external.Clipboard, guiScale float32, commander cmd.Commander) *View {
 	view := &View{
 		mod:          mod,
+		gl:           gl,
 		textCache:    textCache,
-		cp:           cp,
+		codepages:    codepages,
 		imageCache:   imageCache,
 		paletteCache: paletteCache,
 
@@ -63,6 +66,19 @@ func (view *View) WindowOpen() *bool {
 	return &view.model.windowOpen
 }
 
+// ShowResource selects the texture of the given name or usage text and brings the window to front.
+// It returns false if the key does not refer to a texture text.
+func (view *View) ShowResource(key resource.Key) bool {
+	if ((key.ID != ids.TextureNames) && (key.ID != ids.TextureUsages)) ||
+		(key.Index < 0) || (key.Index >= world.MaxWorldTextures) {
+		return false
+	}
+	view.model.currentIndex = key.Index
+	view.model.currentLang = key.Lang
+	view.model.restoreFocus = true
+	return true
+}
+
 // Render renders the view.
 func (view *View) Render() {
 	if view.model.restoreFocus {
@@ -98,10 +114,10 @@ func (view *View) renderContent() {
 		readOnly := !view.mod.HasModifyableTextureProperties()
 
 		imgui.Separator()
-		if imgui.BeginCombo("Language", view.model.currentLang.String()) {
-			languages := resource.Languages()
+		if imgui.BeginCombo("Language", view.mod.LanguageName(view.model.currentLang)) {
+			languages := view.mod.Languages()
 			for _, lang := range languages {
-				if imgui.SelectableV(lang.String(), lang == view.model.currentLang, 0, imgui.Vec2{}) {
+				if imgui.SelectableV(view.mod.LanguageName(lang), lang == view.model.currentLang, 0, imgui.Vec2{}) {
 					view.model.currentLang = lang
 				}
 			}
@@ -115,6 +131,9 @@ func (view *View) renderContent() {
 		useKey := resource.KeyOf(ids.TextureUsages, view.model.currentLang, view.model.currentIndex)
 		use, _ := view.textCache.Text(useKey)
 		view.renderText(readOnly, "Use", use, view.requestSetTextureUsage)
+		if (view.model.unmappableLang == view.model.currentLang) && (view.model.unmappableIndex == view.model.currentIndex) {
+			render.UnmappableWarning(view.model.unmappable)
+		}
 
 		imgui.Separator()
 		view.renderTextureProperties(readOnly)
@@ -310,11 +329,15 @@ func (view *View) requestSetTextureText(id resource.ID, newValue string) {
 	oldValue, _ := view.textCache.Text(key)
 
 	if oldValue != newValue {
+		cp := view.codepages.ForLanguage(key.Lang)
+		view.model.unmappable = text.UnmappableRunes(cp, newValue)
+		view.model.unmappableLang = key.Lang
+		view.model.unmappableIndex = key.Index
 		command := setTextureTextCommand{
 			model:   &view.model,
 			key:     key,
-			oldData: view.cp.Encode(oldValue),
-			newData: view.cp.Encode(text.Blocked(newValue)[0]),
+			oldData: cp.Encode(oldValue),
+			newData: cp.Encode(text.Blocked(newValue)[0]),
 		}
 		view.commander.Queue(command)
 	}
@@ -354,7 +377,7 @@ func (view *View) requestImport(id resource.ID, index int) {
 		return palette.Palette(), nil
 	}
 
-	external.ImportImage(view.modalStateMachine, paletteRetriever, func(bmp bitmap.Bitmap) {
+	external.ImportImage(view.modalStateMachine, view.gl, paletteRetriever, func(bmp bitmap.Bitmap) {
 		view.requestSetBitmap(id, index, bmp)
 	})
 }
@@ -371,19 +394,10 @@ func (view *View) requestClear(id resource.ID, index int, sideLength int) {
 }
 
 func (view *View) requestSetBitmap(id resource.ID, index int, bmp bitmap.Bitmap) {
-	highestBitShift := func(value int16) (result byte) {
-		if value != 0 {
-			for (value >> result) != 1 {
-				result++
-			}
-		}
-		return
-	}
-
 	bmp.Header.Flags = 0
 	bmp.Header.Type = bitmap.TypeFlat8Bit
-	bmp.Header.WidthFactor = highestBitShift(bmp.Header.Width)
-	bmp.Header.HeightFactor = highestBitShift(bmp.Header.Height)
+	bmp.Header.WidthFactor = bitmap.HighestBitShift(bmp.Header.Width)
+	bmp.Header.HeightFactor = bitmap.HighestBitShift(bmp.Header.Height)
 	bmp.Header.Stride = uint16(bmp.Header.Width)
 	data := bitmap.Encode(&bmp, 0)
 	view.requestSetBitmapData(id, index, data)
@@ -10,6 +10,10 @@ type viewModel struct {
 
 	currentLang  resource.Language
 	currentIndex int
+
+	unmappable      []rune
+	unmappableLang  resource.Language
+	unmappableIndex int
 }
 
 func freshViewModel() viewModel {
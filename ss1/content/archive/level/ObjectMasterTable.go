@@ -6,7 +6,9 @@ const (
 	// ObjectMasterEntrySize describes the size, in bytes, of a ObjectMasterEntry.
 	ObjectMasterEntrySize = 27
 
-	defaultObjectMasterEntryCount = 872
+	// DefaultObjectMasterEntryCount is the amount of entries the object master table has in a level,
+	// including the list head at index 0.
+	DefaultObjectMasterEntryCount = 872
 )
 
 // ObjectMasterEntry describes an object in the level.
@@ -55,7 +57,7 @@ type ObjectMasterTable []ObjectMasterEntry
 
 // DefaultObjectMasterTable returns an initialized table with a default size.
 func DefaultObjectMasterTable() ObjectMasterTable {
-	table := make(ObjectMasterTable, defaultObjectMasterEntryCount)
+	table := make(ObjectMasterTable, DefaultObjectMasterEntryCount)
 	table.Reset()
 	return table
 }
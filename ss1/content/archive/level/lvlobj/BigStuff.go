@@ -56,10 +56,7 @@ var solidBridge = baseBigStuff.
 var forceBridge = baseBigStuff.
 	With("Size", 2, 1).As(interpreters.Bitfield(map[uint32]string{0x0F: "X", 0xF0: "Y"})).
 	With("Height", 3, 1).As(interpreters.SpecialValue("ObjectHeight")).
-	With("Color", 6, 1).As(interpreters.FormattedRangedValue(0, 255,
-	func(value int) (result string) {
-		return forceColors[value]
-	}))
+	With("Color", 6, 1).As(interpreters.RangedValueWithFormat(0, 255, forceColorFormat))
 
 func initBigStuff() interpreterRetriever {
 	displays := newInterpreterLeaf(displayScenery)
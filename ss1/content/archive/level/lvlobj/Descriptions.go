@@ -0,0 +1,218 @@
+package lvlobj
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/inkyblackness/hacked/ss1/content/interpreters"
+	"github.com/inkyblackness/hacked/ss1/content/object"
+)
+
+// Kind identifies one set of level object descriptions.
+type Kind string
+
+// Known kinds of level object descriptions.
+const (
+	KindRealWorld       Kind = "realWorld"
+	KindCyberspace      Kind = "cyberspace"
+	KindRealWorldExtra  Kind = "realWorldExtra"
+	KindCyberspaceExtra Kind = "cyberspaceExtra"
+)
+
+// Kinds returns all known kinds.
+func Kinds() []Kind {
+	return []Kind{KindRealWorld, KindCyberspace, KindRealWorldExtra, KindCyberspaceExtra}
+}
+
+// AnyKey is used in selectors for levels of the object triple that are not specified.
+const AnyKey = -1
+
+// Selector specifies the objects a description applies to.
+// Unspecified levels are set to AnyKey. Once a level is unspecified, the following must be as well.
+// A selector with AnyKey for a level applies to all objects that have no more specific description.
+type Selector struct {
+	Class    int
+	Subclass int
+	Type     int
+}
+
+// Validate returns an error if the selector is not properly specified.
+func (sel Selector) Validate() error {
+	_, err := sel.keys()
+	return err
+}
+
+func (sel Selector) keys() ([]int, error) {
+	all := []int{sel.Class, sel.Subclass, sel.Type}
+	var keys []int
+	for index, key := range all {
+		if key == AnyKey {
+			for _, remaining := range all[index:] {
+				if remaining != AnyKey {
+					return nil, fmt.Errorf("selector %v has specified key after unspecified one", sel)
+				}
+			}
+			break
+		}
+		if (key < 0) || (key > 0xFF) {
+			return nil, fmt.Errorf("selector %v has invalid key %v", sel, key)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func selectorFrom(keys []int) Selector {
+	all := []int{AnyKey, AnyKey, AnyKey}
+	copy(all, keys)
+	return Selector{Class: all[0], Subclass: all[1], Type: all[2]}
+}
+
+// Described is a description with the selector it applies to.
+type Described struct {
+	Selector
+	Description *interpreters.Description
+}
+
+var builtinEntries = make(map[Kind]*interpreterEntry)
+
+// BuiltinDescriptions returns the list of built-in descriptions of given kind.
+// The list is ordered from generic to specific.
+func BuiltinDescriptions(kind Kind) []Described {
+	var result []Described
+	root := builtinEntries[kind]
+	if root != nil {
+		root.collect(nil, &result)
+	}
+	return result
+}
+
+// Interpreters provides the interpreters for the data of level objects, for all kinds.
+type Interpreters struct {
+	entries map[Kind]*interpreterEntry
+}
+
+// BuiltinInterpreters returns the interpreters based on the built-in descriptions.
+func BuiltinInterpreters() *Interpreters {
+	return &Interpreters{entries: builtinEntries}
+}
+
+// NewInterpreters returns interpreters based on the given descriptions per kind.
+// The given descriptions are applied on top of the built-in ones, with the more generic applied first.
+// Kinds without descriptions use the built-in ones.
+func NewInterpreters(descriptions map[Kind][]Described) (*Interpreters, error) {
+	entries := make(map[Kind]*interpreterEntry)
+	for kind, root := range builtinEntries {
+		entries[kind] = root
+	}
+	for kind, list := range descriptions {
+		root, err := overriddenEntries(kind, list)
+		if err != nil {
+			return nil, err
+		}
+		entries[kind] = root
+	}
+	return &Interpreters{entries: entries}, nil
+}
+
+// ForRealWorld returns an interpreter instance that handles the level class
+// data of the specified object - in real world.
+func (set *Interpreters) ForRealWorld(triple object.Triple, data []byte) *interpreters.Instance {
+	return set.instance(KindRealWorld, triple, data)
+}
+
+// RealWorldExtra returns an interpreter instance that handles the level object extra
+// data of the specified object - in real world.
+func (set *Interpreters) RealWorldExtra(triple object.Triple, data []byte) *interpreters.Instance {
+	return set.instance(KindRealWorldExtra, triple, data)
+}
+
+// ForCyberspace returns an interpreter instance that handles the level class
+// data of the specified object - in cyberspace.
+func (set *Interpreters) ForCyberspace(triple object.Triple, data []byte) *interpreters.Instance {
+	return set.instance(KindCyberspace, triple, data)
+}
+
+// CyberspaceExtra returns an interpreter instance that handles the level object extra
+// data of the specified object - in cyberspace.
+func (set *Interpreters) CyberspaceExtra(triple object.Triple, data []byte) *interpreters.Instance {
+	return set.instance(KindCyberspaceExtra, triple, data)
+}
+
+func (set *Interpreters) instance(kind Kind, triple object.Triple, data []byte) *interpreters.Instance {
+	return set.entries[kind].specialize(int(triple.Class)).specialize(int(triple.Subclass)).specialize(int(triple.Type)).instance(data)
+}
+
+func overriddenEntries(kind Kind, descriptions []Described) (*interpreterEntry, error) {
+	builtin, known := builtinEntries[kind]
+	if !known {
+		return nil, fmt.Errorf("unknown kind %q", kind)
+	}
+	type pendingOverride struct {
+		keys []int
+		desc *interpreters.Description
+	}
+	overrides := make([]pendingOverride, 0, len(descriptions))
+	for _, described := range descriptions {
+		keys, keysErr := described.keys()
+		if keysErr != nil {
+			return nil, keysErr
+		}
+		if described.Description == nil {
+			return nil, fmt.Errorf("selector %v has no description", described.Selector)
+		}
+		overrides = append(overrides, pendingOverride{keys: keys, desc: described.Description})
+	}
+	sort.SliceStable(overrides, func(a, b int) bool { return len(overrides[a].keys) < len(overrides[b].keys) })
+
+	root := builtin.clone()
+	for _, override := range overrides {
+		node := root
+		for _, key := range override.keys {
+			node = node.editable(key)
+		}
+		node.defaultLeaf = newInterpreterLeaf(override.desc)
+	}
+	return root, nil
+}
+
+func (node *interpreterEntry) clone() *interpreterEntry {
+	cloned := newInterpreterEntry(node.defaultLeaf.desc)
+	for key, sub := range node.subEntries {
+		cloned.subEntries[key] = sub
+	}
+	return cloned
+}
+
+// editable returns a copy of the specialized entry, which is set as the new sub entry.
+func (node *interpreterEntry) editable(key int) *interpreterEntry {
+	var sub *interpreterEntry
+	switch existing := node.subEntries[key].(type) {
+	case *interpreterEntry:
+		sub = existing.clone()
+	case *interpreterLeaf:
+		sub = newInterpreterEntry(existing.desc)
+	default:
+		sub = newInterpreterEntry(node.defaultLeaf.desc)
+	}
+	node.set(key, sub)
+	return sub
+}
+
+func (node *interpreterEntry) collect(keys []int, result *[]Described) {
+	*result = append(*result, Described{Selector: selectorFrom(keys), Description: node.defaultLeaf.desc})
+	subKeys := make([]int, 0, len(node.subEntries))
+	for key := range node.subEntries {
+		subKeys = append(subKeys, key)
+	}
+	sort.Ints(subKeys)
+	for _, key := range subKeys {
+		subPath := append(append([]int{}, keys...), key)
+		switch sub := node.subEntries[key].(type) {
+		case *interpreterEntry:
+			sub.collect(subPath, result)
+		case *interpreterLeaf:
+			*result = append(*result, Described{Selector: selectorFrom(subPath), Description: sub.desc})
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package lvlobj_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/content/archive/level/lvlobj"
+	"github.com/inkyblackness/hacked/ss1/content/interpreters"
+	"github.com/inkyblackness/hacked/ss1/content/object"
+)
+
+func TestBuiltinDescriptionsAreOrderedFromGenericToSpecific(t *testing.T) {
+	for _, kind := range lvlobj.Kinds() {
+		list := lvlobj.BuiltinDescriptions(kind)
+		require.True(t, len(list) > 0, "no descriptions for %v", kind)
+		assert.Equal(t, lvlobj.Selector{Class: lvlobj.AnyKey, Subclass: lvlobj.AnyKey, Type: lvlobj.AnyKey}, list[0].Selector)
+	}
+}
+
+func TestNewInterpretersOverrideSpecificObjects(t *testing.T) {
+	triple := object.TripleFrom(int(object.ClassDoor), 0, 1)
+	otherTriple := object.TripleFrom(int(object.ClassDoor), 0, 2)
+	builtinKeys := lvlobj.ForRealWorld(triple, make([]byte, 10)).Keys()
+
+	set, err := lvlobj.NewInterpreters(map[lvlobj.Kind][]lvlobj.Described{
+		lvlobj.KindRealWorld: {
+			{Selector: lvlobj.Selector{Class: int(object.ClassDoor), Subclass: 0, Type: 1},
+				Description: interpreters.New().With("Test", 0, 1)},
+		},
+	})
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"Test"}, set.ForRealWorld(triple, make([]byte, 10)).Keys())
+	assert.Equal(t, builtinKeys, set.ForRealWorld(otherTriple, make([]byte, 10)).Keys())
+	assert.Equal(t, builtinKeys, lvlobj.ForRealWorld(triple, make([]byte, 10)).Keys(), "built-in should be unaffected")
+	assert.Equal(t, builtinKeys, lvlobj.BuiltinInterpreters().ForRealWorld(triple, make([]byte, 10)).Keys())
+}
+
+func TestNewInterpretersApplyGenericBeforeSpecific(t *testing.T) {
+	set, err := lvlobj.NewInterpreters(map[lvlobj.Kind][]lvlobj.Described{
+		lvlobj.KindCyberspaceExtra: {
+			{Selector: lvlobj.Selector{Class: 1, Subclass: 2, Type: lvlobj.AnyKey},
+				Description: interpreters.New().With("Specific", 0, 1)},
+			{Selector: lvlobj.Selector{Class: 1, Subclass: lvlobj.AnyKey, Type: lvlobj.AnyKey},
+				Description: interpreters.New().With("Generic", 0, 1)},
+		},
+	})
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"Specific"}, set.CyberspaceExtra(object.TripleFrom(1, 2, 3), make([]byte, 4)).Keys())
+	assert.Equal(t, []string{"Generic"}, set.CyberspaceExtra(object.TripleFrom(1, 3, 3), make([]byte, 4)).Keys())
+}
+
+func TestNewInterpretersDoNotAffectOtherKinds(t *testing.T) {
+	triple := object.TripleFrom(int(object.ClassSoftware), 0, 0)
+	cyberspaceKeys := lvlobj.ForCyberspace(triple, make([]byte, 10)).Keys()
+
+	set, err := lvlobj.NewInterpreters(map[lvlobj.Kind][]lvlobj.Described{
+		lvlobj.KindRealWorld: {
+			{Selector: lvlobj.Selector{Class: int(object.ClassSoftware), Subclass: 0, Type: 0},
+				Description: interpreters.New().With("Test", 0, 1)},
+		},
+	})
+	require.Nil(t, err)
+
+	assert.Equal(t, cyberspaceKeys, set.ForCyberspace(triple, make([]byte, 10)).Keys())
+}
+
+func TestNewInterpretersFailForInvalidDescriptions(t *testing.T) {
+	_, err := lvlobj.NewInterpreters(map[lvlobj.Kind][]lvlobj.Described{
+		lvlobj.KindRealWorld: {
+			{Selector: lvlobj.Selector{Class: 1, Subclass: lvlobj.AnyKey, Type: 2}, Description: interpreters.New()},
+		},
+	})
+	assert.NotNil(t, err, "invalid selector")
+	_, err = lvlobj.NewInterpreters(map[lvlobj.Kind][]lvlobj.Described{"unknown": nil})
+	assert.NotNil(t, err, "unknown kind")
+}
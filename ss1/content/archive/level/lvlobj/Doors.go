@@ -1,38 +1,29 @@
 package lvlobj
 
 import (
-	"fmt"
-
 	"github.com/inkyblackness/hacked/ss1/content/interpreters"
 )
 
 var baseDoor = interpreters.New().
 	With("LockVariableIndex", 0, 2).As(interpreters.RangedValue(0, 0x1FF)).
-	With("LockMessageIndex", 2, 1).As(interpreters.FormattedRangedValue(0, 255,
-	func(value int) (result string) {
-		return fmt.Sprintf("%d", value+7)
-	})).
-	With("ForceDoorColor", 3, 1).As(interpreters.FormattedRangedValue(0, 255,
-	func(value int) (result string) {
-		return forceColors[value]
-	})).
-	With("RequiredAccessLevel", 4, 1).As(interpreters.FormattedRangedValue(0, 255,
-	func(value int) (result string) {
-		if value == 255 {
-			result = "SHODAN"
-		} else if accessLevel, known := accessLevelMasks[1<<uint32(value)]; known {
-			result = accessLevel
-		} else {
-			result = "Unknown"
-		}
-		return
-	})).
-	With("AutoCloseTime", 5, 1).As(interpreters.FormattedRangedValue(0, 255,
-	func(value int) string {
-		return fmt.Sprintf("%.2f sec", float64(value)*0.5)
-	})).
+	With("LockMessageIndex", 2, 1).As(interpreters.RangedValueWithFormat(0, 255,
+	interpreters.ValueFormat{Offset: 7})).
+	With("ForceDoorColor", 3, 1).As(interpreters.RangedValueWithFormat(0, 255, forceColorFormat)).
+	With("RequiredAccessLevel", 4, 1).As(interpreters.RangedValueWithFormat(0, 255, requiredAccessLevelFormat())).
+	With("AutoCloseTime", 5, 1).As(interpreters.RangedValueWithFormat(0, 255,
+	interpreters.ValueFormat{Scale: 0.5, Precision: 2, Unit: " sec"})).
 	With("OtherObjectID", 6, 2).As(interpreters.ObjectID())
 
+func requiredAccessLevelFormat() interpreters.ValueFormat {
+	names := map[uint32]string{255: "SHODAN"}
+	for bit := uint32(0); bit < 32; bit++ {
+		if accessLevel, known := accessLevelMasks[1<<bit]; known {
+			names[bit] = accessLevel
+		}
+	}
+	return interpreters.ValueFormat{Names: names, Unknown: "Unknown"}
+}
+
 func initDoors() interpreterRetriever {
 	return newInterpreterLeaf(baseDoor)
 }
@@ -1,8 +1,6 @@
 package lvlobj
 
 import (
-	"fmt"
-
 	"github.com/inkyblackness/hacked/ss1/content/archive/level/lvlobj/actions"
 	"github.com/inkyblackness/hacked/ss1/content/archive/level/lvlobj/conditions"
 	"github.com/inkyblackness/hacked/ss1/content/interpreters"
@@ -40,10 +38,8 @@ var cyberspaceTerminal = gameVariablePanel.
 
 var energyChargeStation = gameVariablePanel.
 	With("EnergyDelta", 6, 4).As(interpreters.RangedValue(0, 255)).
-	With("RechargeTime", 10, 4).As(interpreters.FormattedRangedValue(0, 3600,
-	func(value int) string {
-		return fmt.Sprintf("%d sec", value)
-	})).
+	With("RechargeTime", 10, 4).As(interpreters.RangedValueWithFormat(0, 3600,
+	interpreters.ValueFormat{Unit: " sec"})).
 	With("TriggerObjectID", 14, 4).As(interpreters.ObjectID()).
 	With("RechargedTimestamp", 18, 4)
 
@@ -86,12 +82,8 @@ var blockPuzzleData = interpreters.New().
 
 var puzzleSpecificData = interpreters.New().
 	With("Type", 7, 1).As(interpreters.EnumValue(map[uint32]string{0: "WirePuzzle", 0x10: "BlockPuzzle"})).
-	Refining("Wire", 0, 18, wirePuzzleData, func(inst *interpreters.Instance) bool {
-		return inst.Get("Type") == 0
-	}).
-	Refining("Block", 0, 18, blockPuzzleData, func(inst *interpreters.Instance) bool {
-		return inst.Get("Type") == 0x10
-	})
+	RefiningWhen("Wire", 0, 18, wirePuzzleData, interpreters.FieldIs("Type", 0)).
+	RefiningWhen("Block", 0, 18, blockPuzzleData, interpreters.FieldIs("Type", 0x10))
 
 var puzzlePanel = inputPanel.
 	Refining("Puzzle", 6, 18, puzzleSpecificData, interpreters.Always)
@@ -7,12 +7,14 @@ import (
 
 // ForCyberspace returns an interpreter instance that handles the level class
 // data of the specified object - in cyberspace.
+// The interpreter is based on the built-in descriptions.
 func ForCyberspace(triple object.Triple, data []byte) *interpreters.Instance {
-	return cyberspaceEntries.specialize(int(triple.Class)).specialize(int(triple.Subclass)).specialize(int(triple.Type)).instance(data)
+	return BuiltinInterpreters().ForCyberspace(triple, data)
 }
 
 // CyberspaceExtra returns an interpreter instance that handles the level object extra
 // data of the specified object - in cybperspace.
+// The interpreter is based on the built-in descriptions.
 func CyberspaceExtra(triple object.Triple, data []byte) *interpreters.Instance {
-	return cyberspaceExtras.specialize(int(triple.Class)).specialize(int(triple.Subclass)).specialize(int(triple.Type)).instance(data)
+	return BuiltinInterpreters().CyberspaceExtra(triple, data)
 }
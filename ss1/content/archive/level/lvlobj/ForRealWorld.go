@@ -7,12 +7,14 @@ import (
 
 // ForRealWorld returns an interpreter instance that handles the level class
 // data of the specified object - in real world.
+// The interpreter is based on the built-in descriptions.
 func ForRealWorld(triple object.Triple, data []byte) *interpreters.Instance {
-	return realWorldEntries.specialize(int(triple.Class)).specialize(int(triple.Subclass)).specialize(int(triple.Type)).instance(data)
+	return BuiltinInterpreters().ForRealWorld(triple, data)
 }
 
 // RealWorldExtra returns an interpreter instance that handles the level object extra
 // data of the specified object - in real world.
+// The interpreter is based on the built-in descriptions.
 func RealWorldExtra(triple object.Triple, data []byte) *interpreters.Instance {
-	return realWorldExtras.specialize(int(triple.Class)).specialize(int(triple.Subclass)).specialize(int(triple.Type)).instance(data)
+	return BuiltinInterpreters().RealWorldExtra(triple, data)
 }
@@ -1,6 +1,8 @@
 package lvlobj
 
-var forceColors = map[int]string{
+import "github.com/inkyblackness/hacked/ss1/content/interpreters"
+
+var forceColors = map[uint32]string{
 	0: "Default (red)",
 
 	247: "Gray, medium",
@@ -15,3 +17,5 @@ var forceColors = map[int]string{
 	253: "Blue, medium",
 	254: "Green, medium",
 	255: "Red, medium"}
+
+var forceColorFormat = interpreters.ValueFormat{Names: forceColors}
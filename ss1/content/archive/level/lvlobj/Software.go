@@ -26,14 +26,10 @@ var funPack = baseSoftware.
 var baseCyberspaceScenery = interpreters.New()
 
 var scenerySoftware = baseCyberspaceScenery.
-	Refining("FunPack", 0, 2, funPack,
-		func(inst *interpreters.Instance) bool {
-			return (inst.Get("Subclass") == 3) && (inst.Get("Type") == 0)
-		}).
-	Refining("Program", 0, 2, cyberspaceProgram,
-		func(inst *interpreters.Instance) bool {
-			return (inst.Get("Subclass") == 0) || (inst.Get("Subclass") == 1)
-		}).
+	RefiningWhen("FunPack", 0, 2, funPack,
+		interpreters.AllOf(interpreters.FieldIs("Subclass", 3), interpreters.FieldIs("Type", 0))).
+	RefiningWhen("Program", 0, 2, cyberspaceProgram,
+		interpreters.FieldIs("Subclass", 0, 1)).
 	With("Subclass", 2, 4).As(interpreters.RangedValue(0, 7)).
 	With("Type", 6, 4).As(interpreters.RangedValue(0, 16))
 
@@ -32,19 +32,14 @@ var puzzleData = interpreters.New()
 
 var nullTrigger = baseTraps.
 	Refining("Action", 0, 22, actions.Unconditional().
-		Refining("PuzzleData", 6, 16, puzzleData, func(inst *interpreters.Instance) bool { return inst.Get("Type") == 0 }),
+		RefiningWhen("PuzzleData", 6, 16, puzzleData, interpreters.FieldIs("Type", 0)),
 		interpreters.Always).
-	Refining("Condition", 2, 4, conditions.GameVariable(),
-		func(inst *interpreters.Instance) bool { return inst.Refined("Action").Get("Type") != 0 })
+	RefiningWhen("Condition", 2, 4, conditions.GameVariable(), interpreters.FieldIsNot("Action.Type", 0))
 
 var deathWatchTrigger = baseTrigger.
 	With("ConditionType", 5, 1).As(interpreters.EnumValue(map[uint32]string{0: "Object Type", 1: "Object ID"})).
-	Refining("TypeCondition", 2, 4, conditions.ObjectType(), func(inst *interpreters.Instance) bool {
-		return inst.Get("ConditionType") == 0
-	}).
-	Refining("IndexCondition", 2, 4, conditions.ObjectID(), func(inst *interpreters.Instance) bool {
-		return inst.Get("ConditionType") == 1
-	})
+	RefiningWhen("TypeCondition", 2, 4, conditions.ObjectType(), interpreters.FieldIs("ConditionType", 0)).
+	RefiningWhen("IndexCondition", 2, 4, conditions.ObjectID(), interpreters.FieldIs("ConditionType", 1))
 
 var ecologyTrigger = baseTrigger.
 	Refining("TypeCondition", 2, 4, conditions.ObjectType(), interpreters.Always).
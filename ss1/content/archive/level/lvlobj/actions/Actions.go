@@ -1,15 +1,11 @@
 package actions
 
 import (
-	"fmt"
-
 	"github.com/inkyblackness/hacked/ss1/content/interpreters"
 )
 
-func forType(typeID int) func(*interpreters.Instance) bool {
-	return func(inst *interpreters.Instance) bool {
-		return inst.Get("Type") == uint32(typeID)
-	}
+func forType(typeID uint32) interpreters.Condition {
+	return interpreters.FieldIs("Type", typeID)
 }
 
 var transportHackerDetails = interpreters.New().
@@ -54,48 +50,38 @@ var showCutsceneDetails = interpreters.New().
 	With("CutsceneIndex", 0, 4).As(interpreters.EnumValue(map[uint32]string{0: "Death", 1: "Intro", 2: "Ending"})).
 	With("EndGameFlag", 4, 4).As(interpreters.EnumValue(map[uint32]string{0: "No (not working)", 1: "Yes"}))
 
-func pointOneSecond(value int) string {
-	return fmt.Sprintf("%.1f sec", float64(value)*0.1)
-}
+var pointOneSecond = interpreters.ValueFormat{Scale: 0.1, Precision: 1, Unit: " sec"}
 
 var triggerOtherObjectsDetails = interpreters.New().
 	With("Object1ID", 0, 2).As(interpreters.ObjectID()).
-	With("Object1Delay", 2, 2).As(interpreters.FormattedRangedValue(0, 6000, pointOneSecond)).
+	With("Object1Delay", 2, 2).As(interpreters.RangedValueWithFormat(0, 6000, pointOneSecond)).
 	With("Object2ID", 4, 2).As(interpreters.ObjectID()).
-	With("Object2Delay", 6, 2).As(interpreters.FormattedRangedValue(0, 6000, pointOneSecond)).
+	With("Object2Delay", 6, 2).As(interpreters.RangedValueWithFormat(0, 6000, pointOneSecond)).
 	With("Object3ID", 8, 2).As(interpreters.ObjectID()).
-	With("Object3Delay", 10, 2).As(interpreters.FormattedRangedValue(0, 6000, pointOneSecond)).
+	With("Object3Delay", 10, 2).As(interpreters.RangedValueWithFormat(0, 6000, pointOneSecond)).
 	With("Object4ID", 12, 2).As(interpreters.ObjectID()).
-	With("Object4Delay", 14, 2).As(interpreters.FormattedRangedValue(0, 6000, pointOneSecond))
+	With("Object4Delay", 14, 2).As(interpreters.RangedValueWithFormat(0, 6000, pointOneSecond))
 
 var changeLightingDetails = interpreters.New().
-	Refining("ObjectExtent", 0, 2, interpreters.New().With("Index", 0, 2).As(interpreters.ObjectID()), func(inst *interpreters.Instance) bool {
-		var lightType = inst.Get("LightType")
-
-		return (lightType == 0x00) || (lightType == 0x01)
-	}).
-	Refining("RadiusExtent", 0, 2, interpreters.New().With("Tiles", 0, 2).As(interpreters.RangedValue(0, 31)), func(inst *interpreters.Instance) bool {
-		return inst.Get("LightType") == 0x03
-	}).
+	RefiningWhen("ObjectExtent", 0, 2, interpreters.New().With("Index", 0, 2).As(interpreters.ObjectID()),
+		interpreters.FieldIs("LightType", 0x00, 0x01)).
+	RefiningWhen("RadiusExtent", 0, 2, interpreters.New().With("Tiles", 0, 2).As(interpreters.RangedValue(0, 31)),
+		interpreters.FieldIs("LightType", 0x03)).
 	With("ReferenceObjectID", 2, 2).As(interpreters.ObjectID()).
 	With("TransitionType", 4, 2).As(interpreters.EnumValue(map[uint32]string{0x0000: "immediate", 0x0001: "fade", 0x0100: "flicker"})).
 	With("LightModification", 7, 1).As(interpreters.EnumValue(map[uint32]string{0x00: "light on", 0x10: "light off"})).
 	With("LightType", 8, 1).As(interpreters.EnumValue(map[uint32]string{0x00: "rectangular", 0x03: "circular gradient"})).
 	With("LightSurface", 10, 2).As(interpreters.EnumValue(map[uint32]string{0: "floor", 1: "ceiling", 2: "floor and ceiling"})).
-	Refining("Rectangular", 12, 2, interpreters.New().
+	RefiningWhen("Rectangular", 12, 2, interpreters.New().
 		With("Off light value", 0, 1).As(interpreters.RangedValue(0, 15)).
-		With("On light value", 1, 1).As(interpreters.RangedValue(0, 15)), func(inst *interpreters.Instance) bool {
-		return inst.Get("LightType") == 0x00
-	}).
-	Refining("Gradient", 12, 4, interpreters.New().
+		With("On light value", 1, 1).As(interpreters.RangedValue(0, 15)),
+		interpreters.FieldIs("LightType", 0x00)).
+	RefiningWhen("Gradient", 12, 4, interpreters.New().
 		With("Off light begin intensity", 0, 1).As(interpreters.RangedValue(0, 127)).
 		With("Off light end intensity", 1, 1).As(interpreters.RangedValue(0, 127)).
 		With("On light begin intensity", 2, 1).As(interpreters.RangedValue(0, 127)).
-		With("On light end intensity", 3, 1).As(interpreters.RangedValue(0, 127)), func(inst *interpreters.Instance) bool {
-		var lightType = inst.Get("LightType")
-
-		return (lightType == 0x01) || (lightType == 0x03)
-	})
+		With("On light end intensity", 3, 1).As(interpreters.RangedValue(0, 127)),
+		interpreters.FieldIs("LightType", 0x01, 0x03))
 
 var effectDetails = interpreters.New().
 	With("SoundIndex", 0, 2).As(interpreters.RangedValue(0, 512)).
@@ -132,7 +118,7 @@ var changeTerrainDetails = interpreters.New().
 
 var scheduledTrapDetails = interpreters.New().
 	With("ObjectID", 0, 4).As(interpreters.ObjectID()).
-	With("TimeInterval", 4, 4).As(interpreters.FormattedRangedValue(0, 6000, pointOneSecond)).
+	With("TimeInterval", 4, 4).As(interpreters.RangedValueWithFormat(0, 6000, pointOneSecond)).
 	With("ActivationValue", 8, 4).As(interpreters.EnumValue(map[uint32]string{0: "Off",
 	0xFFFF: "On (0xFFFF)", 0x10000: "On (0x10000)", 0x11111: "On (0x11111)"})).
 	With("Variance", 12, 2).As(interpreters.RangedValue(0, 512))
@@ -281,22 +267,22 @@ var hackDetails = interpreters.New().
 	14: "Close Data MFD",
 	15: "Earth Destruction by Laser",
 	16: "Change Objects Type (Level)"})).
-	Refining("ToggleRepulsor", 4, 12, toggleRepulsorChange, forType(1)).
-	Refining("ShowGameCodeDigit", 4, 12, showGameCodeDigitChange, forType(2)).
-	Refining("SetParameterFromVariable", 4, 12, setParameterFromVariableChange, forType(3)).
-	Refining("SetFrameState", 4, 12, setFrameStateChange, forType(4)).
-	Refining("DoorControl", 4, 12, doorControlChange, forType(5)).
-	Refining("ReturnToMenu", 4, 12, interpreters.New(), forType(6)).
-	Refining("RotateObject", 4, 12, rotateObjectChange, forType(7)).
-	Refining("RemoveObjects", 4, 12, removeObjectsChange, forType(8)).
-	Refining("ShodanPixelation", 4, 12, interpreters.New(), forType(9)).
-	Refining("SetCondition", 4, 12, setConditionChange, forType(10)).
-	Refining("ShowSystemAnalyzer", 4, 12, interpreters.New(), forType(11)).
-	Refining("MakeItemRadioactive", 4, 12, makeItemRadioactiveChange, forType(12)).
-	Refining("OrientedTriggerObject", 4, 12, orientedTriggerObjectChange, forType(13)).
-	Refining("CloseDataMfd", 4, 12, closeDataMfdChange, forType(14)).
-	Refining("EarthDestructionByLaser", 4, 12, interpreters.New(), forType(15)).
-	Refining("ChangeObjectsType", 4, 12, changeObjectTypeGlobalChange, forType(16))
+	RefiningWhen("ToggleRepulsor", 4, 12, toggleRepulsorChange, forType(1)).
+	RefiningWhen("ShowGameCodeDigit", 4, 12, showGameCodeDigitChange, forType(2)).
+	RefiningWhen("SetParameterFromVariable", 4, 12, setParameterFromVariableChange, forType(3)).
+	RefiningWhen("SetFrameState", 4, 12, setFrameStateChange, forType(4)).
+	RefiningWhen("DoorControl", 4, 12, doorControlChange, forType(5)).
+	RefiningWhen("ReturnToMenu", 4, 12, interpreters.New(), forType(6)).
+	RefiningWhen("RotateObject", 4, 12, rotateObjectChange, forType(7)).
+	RefiningWhen("RemoveObjects", 4, 12, removeObjectsChange, forType(8)).
+	RefiningWhen("ShodanPixelation", 4, 12, interpreters.New(), forType(9)).
+	RefiningWhen("SetCondition", 4, 12, setConditionChange, forType(10)).
+	RefiningWhen("ShowSystemAnalyzer", 4, 12, interpreters.New(), forType(11)).
+	RefiningWhen("MakeItemRadioactive", 4, 12, makeItemRadioactiveChange, forType(12)).
+	RefiningWhen("OrientedTriggerObject", 4, 12, orientedTriggerObjectChange, forType(13)).
+	RefiningWhen("CloseDataMfd", 4, 12, closeDataMfdChange, forType(14)).
+	RefiningWhen("EarthDestructionByLaser", 4, 12, interpreters.New(), forType(15)).
+	RefiningWhen("ChangeObjectsType", 4, 12, changeObjectTypeGlobalChange, forType(16))
 
 var unconditionalAction = interpreters.New().
 	With("Type", 0, 1).As(interpreters.EnumValue(map[uint32]string{
@@ -326,30 +312,30 @@ var unconditionalAction = interpreters.New().
 	23: "Spawn Objects",
 	24: "Change Object Type"})).
 	With("UsageQuota", 1, 1).
-	Refining("TransportHacker", 6, 16, transportHackerDetails, forType(1)).
-	Refining("ChangeHealth", 6, 16, changeHealthDetails, forType(2)).
-	Refining("CloneMoveObject", 6, 16, cloneMoveObjectDetails, forType(3)).
-	Refining("SetGameVariable", 6, 16, setGameVariableDetails, forType(4)).
-	Refining("ShowCutscene", 6, 16, showCutsceneDetails, forType(5)).
-	Refining("TriggerOtherObjects", 6, 16, triggerOtherObjectsDetails, forType(6)).
-	Refining("ChangeLighting", 6, 16, changeLightingDetails, forType(7)).
-	Refining("Effect", 6, 16, effectDetails, forType(8)).
-	Refining("ChangeTileHeights", 6, 16, changeTileHeightsDetails, forType(9)).
-	Refining("ChangeTerrain", 6, 16, changeTerrainDetails, forType(10)).
-	Refining("ScheduledTrap", 6, 16, scheduledTrapDetails, forType(11)).
-	Refining("CycleObjects", 6, 16, cycleObjectsDetails, forType(12)).
-	Refining("DeleteObjects", 6, 16, deleteObjectsDetails, forType(13)).
+	RefiningWhen("TransportHacker", 6, 16, transportHackerDetails, forType(1)).
+	RefiningWhen("ChangeHealth", 6, 16, changeHealthDetails, forType(2)).
+	RefiningWhen("CloneMoveObject", 6, 16, cloneMoveObjectDetails, forType(3)).
+	RefiningWhen("SetGameVariable", 6, 16, setGameVariableDetails, forType(4)).
+	RefiningWhen("ShowCutscene", 6, 16, showCutsceneDetails, forType(5)).
+	RefiningWhen("TriggerOtherObjects", 6, 16, triggerOtherObjectsDetails, forType(6)).
+	RefiningWhen("ChangeLighting", 6, 16, changeLightingDetails, forType(7)).
+	RefiningWhen("Effect", 6, 16, effectDetails, forType(8)).
+	RefiningWhen("ChangeTileHeights", 6, 16, changeTileHeightsDetails, forType(9)).
+	RefiningWhen("ChangeTerrain", 6, 16, changeTerrainDetails, forType(10)).
+	RefiningWhen("ScheduledTrap", 6, 16, scheduledTrapDetails, forType(11)).
+	RefiningWhen("CycleObjects", 6, 16, cycleObjectsDetails, forType(12)).
+	RefiningWhen("DeleteObjects", 6, 16, deleteObjectsDetails, forType(13)).
 	// 14 unused
-	Refining("ReceiveEmail", 6, 16, receiveEmailDetails, forType(15)).
-	Refining("Expose", 6, 16, exposeDetails, forType(16)).
-	Refining("SetObjectParameter", 6, 16, setObjectParameterDetails, forType(17)).
-	Refining("SetScreenPicture", 6, 16, setScreenPictureDetails, forType(18)).
-	Refining("Hack", 6, 16, hackDetails, forType(19)).
+	RefiningWhen("ReceiveEmail", 6, 16, receiveEmailDetails, forType(15)).
+	RefiningWhen("Expose", 6, 16, exposeDetails, forType(16)).
+	RefiningWhen("SetObjectParameter", 6, 16, setObjectParameterDetails, forType(17)).
+	RefiningWhen("SetScreenPicture", 6, 16, setScreenPictureDetails, forType(18)).
+	RefiningWhen("Hack", 6, 16, hackDetails, forType(19)).
 	// 20 unknown
-	Refining("SetCritterState", 6, 16, setCritterStateDetails, forType(21)).
-	Refining("TrapMessage", 6, 16, trapMessageDetails, forType(22)).
-	Refining("SpawnObjects", 6, 16, spawnObjectsDetails, forType(23)).
-	Refining("ChangeObjectType", 6, 16, changeObjectTypeDetails, forType(24))
+	RefiningWhen("SetCritterState", 6, 16, setCritterStateDetails, forType(21)).
+	RefiningWhen("TrapMessage", 6, 16, trapMessageDetails, forType(22)).
+	RefiningWhen("SpawnObjects", 6, 16, spawnObjectsDetails, forType(23)).
+	RefiningWhen("ChangeObjectType", 6, 16, changeObjectTypeDetails, forType(24))
 
 // Unconditional returns the description of actions without a condition.
 func Unconditional() *interpreters.Description {
@@ -75,4 +75,9 @@ func init() {
 	cyberspaceExtras.set(int(object.ClassBigStuff), newInterpreterLeaf(extraIced))
 	cyberspaceExtras.set(int(object.ClassSmallStuff), newInterpreterLeaf(extraIced))
 	cyberspaceExtras.set(int(object.ClassFixture), newInterpreterLeaf(extraIcedFixtures))
+
+	builtinEntries[KindRealWorld] = realWorldEntries
+	builtinEntries[KindCyberspace] = cyberspaceEntries
+	builtinEntries[KindRealWorldExtra] = realWorldExtras
+	builtinEntries[KindCyberspaceExtra] = cyberspaceExtras
 }
@@ -0,0 +1,96 @@
+package audio
+
+import "math"
+
+// l8Silence is the sample value of an 8-bit sound that represents silence.
+const l8Silence = 0x80
+
+// clampedRange limits the given sample range to the samples of the sound.
+func clampedRange(sound L8, from, to int) (int, int) {
+	count := len(sound.Samples)
+	if from < 0 {
+		from = 0
+	}
+	if from > count {
+		from = count
+	}
+	if to > count {
+		to = count
+	}
+	if to < from {
+		to = from
+	}
+	return from, to
+}
+
+func copyOf(sound L8) L8 {
+	return L8{SampleRate: sound.SampleRate, Samples: append([]byte{}, sound.Samples...)}
+}
+
+// Crop returns a sound that only contains the samples within the given range.
+// The range is given with an inclusive start and an exclusive end.
+func Crop(sound L8, from, to int) L8 {
+	from, to = clampedRange(sound, from, to)
+	return L8{SampleRate: sound.SampleRate, Samples: append([]byte{}, sound.Samples[from:to]...)}
+}
+
+// FadeIn returns a sound with a linear fade from silence to full level over the given range.
+// Samples before the range are silenced.
+func FadeIn(sound L8, from, to int) L8 {
+	from, to = clampedRange(sound, from, to)
+	result := copyOf(sound)
+	for index := 0; index < from; index++ {
+		result.Samples[index] = l8Silence
+	}
+	length := to - from
+	for index := from; index < to; index++ {
+		result.Samples[index] = scaledSample(sound.Samples[index], float64(index-from)/float64(length))
+	}
+	return result
+}
+
+// FadeOut returns a sound with a linear fade from full level to silence over the given range.
+// Samples after the range are silenced.
+func FadeOut(sound L8, from, to int) L8 {
+	from, to = clampedRange(sound, from, to)
+	result := copyOf(sound)
+	length := to - from
+	for index := from; index < to; index++ {
+		result.Samples[index] = scaledSample(sound.Samples[index], float64(to-index-1)/float64(length))
+	}
+	for index := to; index < len(result.Samples); index++ {
+		result.Samples[index] = l8Silence
+	}
+	return result
+}
+
+// Gain returns a sound with the samples of the given range amplified by given factor.
+// Values exceeding the range of 8-bit samples are clipped.
+func Gain(sound L8, from, to int, factor float32) L8 {
+	from, to = clampedRange(sound, from, to)
+	result := copyOf(sound)
+	for index := from; index < to; index++ {
+		result.Samples[index] = scaledSample(sound.Samples[index], float64(factor))
+	}
+	return result
+}
+
+// InsertSilence returns a sound with given amount of silent samples inserted at the given position.
+func InsertSilence(sound L8, at int, count int) L8 {
+	at, _ = clampedRange(sound, at, len(sound.Samples))
+	if count < 0 {
+		count = 0
+	}
+	samples := make([]byte, 0, len(sound.Samples)+count)
+	samples = append(samples, sound.Samples[:at]...)
+	for index := 0; index < count; index++ {
+		samples = append(samples, l8Silence)
+	}
+	samples = append(samples, sound.Samples[at:]...)
+	return L8{SampleRate: sound.SampleRate, Samples: samples}
+}
+
+func scaledSample(sample byte, factor float64) byte {
+	value := math.Round((float64(sample)-l8Center)*factor + l8Center)
+	return byte(math.Max(0, math.Min(255, value)))
+}
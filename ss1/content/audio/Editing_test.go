@@ -0,0 +1,55 @@
+package audio_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inkyblackness/hacked/ss1/content/audio"
+)
+
+func TestCropKeepsRange(t *testing.T) {
+	result := audio.Crop(audio.L8{SampleRate: 22050, Samples: []byte{1, 2, 3, 4, 5}}, 1, 4)
+
+	assert.Equal(t, float32(22050), result.SampleRate)
+	assert.Equal(t, []byte{2, 3, 4}, result.Samples)
+}
+
+func TestCropClampsRange(t *testing.T) {
+	result := audio.Crop(audio.L8{Samples: []byte{1, 2, 3}}, -10, 10)
+
+	assert.Equal(t, []byte{1, 2, 3}, result.Samples)
+}
+
+func TestFadeInRampsUpAndSilencesBefore(t *testing.T) {
+	source := audio.L8{Samples: []byte{0xFF, 0xC0, 0xC0, 0xC0, 0xC0, 0xC0}}
+	result := audio.FadeIn(source, 1, 5)
+
+	assert.Equal(t, []byte{0x80, 0x80, 0x90, 0xA0, 0xB0, 0xC0}, result.Samples)
+	assert.Equal(t, byte(0xFF), source.Samples[0], "source must not be modified")
+}
+
+func TestFadeOutRampsDownAndSilencesAfter(t *testing.T) {
+	result := audio.FadeOut(audio.L8{Samples: []byte{0xC0, 0xC0, 0xC0, 0xC0, 0xC0, 0x00}}, 1, 5)
+
+	assert.Equal(t, []byte{0xC0, 0xB0, 0xA0, 0x90, 0x80, 0x80}, result.Samples)
+}
+
+func TestGainAmplifiesRangeWithClipping(t *testing.T) {
+	result := audio.Gain(audio.L8{Samples: []byte{0xA0, 0xA0, 0x00, 0xF0}}, 1, 4, 2.0)
+
+	assert.Equal(t, []byte{0xA0, 0xC0, 0x00, 0xFF}, result.Samples)
+}
+
+func TestInsertSilence(t *testing.T) {
+	result := audio.InsertSilence(audio.L8{SampleRate: 11025, Samples: []byte{1, 2, 3}}, 1, 2)
+
+	assert.Equal(t, float32(11025), result.SampleRate)
+	assert.Equal(t, []byte{1, 0x80, 0x80, 2, 3}, result.Samples)
+}
+
+func TestInsertSilenceAtEnd(t *testing.T) {
+	result := audio.InsertSilence(audio.L8{Samples: []byte{1}}, 5, 1)
+
+	assert.Equal(t, []byte{1, 0x80}, result.Samples)
+}
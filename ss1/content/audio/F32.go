@@ -0,0 +1,41 @@
+package audio
+
+import "math"
+
+// F32 is a linear, mono sound snippet with floating point samples.
+// Samples are nominally in the range of -1.0 to 1.0.
+type F32 struct {
+	SampleRate float32
+	Samples    []float32
+}
+
+// Empty returns true if there are no samples to play.
+func (sound F32) Empty() bool {
+	return len(sound.Samples) == 0
+}
+
+// Duration returns the length of the sound in seconds.
+func (sound F32) Duration() float32 {
+	if sound.SampleRate <= 0 {
+		return 0.0
+	}
+	return float32(len(sound.Samples)) / sound.SampleRate
+}
+
+// Peak returns the highest absolute sample value.
+func (sound F32) Peak() float32 {
+	peak := float32(0.0)
+	for _, sample := range sound.Samples {
+		peak = float32(math.Max(float64(peak), math.Abs(float64(sample))))
+	}
+	return peak
+}
+
+// F32FromL8 returns the floating point representation of given sound.
+func F32FromL8(sound L8) F32 {
+	samples := make([]float32, len(sound.Samples))
+	for index, sample := range sound.Samples {
+		samples[index] = (float32(sample) - l8Center) / l8Center
+	}
+	return F32{SampleRate: sound.SampleRate, Samples: samples}
+}
@@ -0,0 +1,119 @@
+package audio
+
+import (
+	"math"
+	"math/rand"
+)
+
+const l8Center = 128.0
+
+// resampleLobes is the number of zero crossings on each side of the windowed sinc filter.
+const resampleLobes = 8
+
+// Resample returns a sound with given sample rate, converted with a windowed sinc filter.
+// When reducing the sample rate, the filter also removes the frequencies the target rate can not represent.
+func Resample(sound F32, rate float32) F32 {
+	if (rate <= 0) || (sound.SampleRate <= 0) || (rate == sound.SampleRate) || sound.Empty() {
+		return F32{SampleRate: sound.SampleRate, Samples: append([]float32{}, sound.Samples...)}
+	}
+	ratio := float64(sound.SampleRate) / float64(rate)
+	cutoff := math.Min(1.0, 1.0/ratio)
+	halfWidth := resampleLobes / cutoff
+	sourceCount := len(sound.Samples)
+	result := make([]float32, int(math.Round(float64(sourceCount)/ratio)))
+
+	for index := range result {
+		center := float64(index) * ratio
+		first := int(math.Max(0, math.Ceil(center-halfWidth)))
+		last := int(math.Min(float64(sourceCount-1), math.Floor(center+halfWidth)))
+		sum := 0.0
+		weightSum := 0.0
+		for source := first; source <= last; source++ {
+			x := (float64(source) - center) * cutoff
+			weight := sinc(x) * sinc(x/resampleLobes)
+			sum += float64(sound.Samples[source]) * weight
+			weightSum += weight
+		}
+		if weightSum != 0 {
+			result[index] = float32(sum / weightSum)
+		}
+	}
+	return F32{SampleRate: rate, Samples: result}
+}
+
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1.0
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
+
+// Normalize returns a sound that is amplified (or attenuated) so that its peak matches the given level.
+// Silent sounds are returned unchanged.
+func Normalize(sound F32, level float32) F32 {
+	peak := sound.Peak()
+	result := F32{SampleRate: sound.SampleRate, Samples: make([]float32, len(sound.Samples))}
+	factor := float32(1.0)
+	if peak > 0 {
+		factor = level / peak
+	}
+	for index, sample := range sound.Samples {
+		result.Samples[index] = sample * factor
+	}
+	return result
+}
+
+// TrimSilence returns a sound without the leading and trailing samples that are below the given threshold.
+func TrimSilence(sound F32, threshold float32) F32 {
+	isSilent := func(sample float32) bool { return math.Abs(float64(sample)) < float64(threshold) }
+	start := 0
+	end := len(sound.Samples)
+	for (start < end) && isSilent(sound.Samples[start]) {
+		start++
+	}
+	for (end > start) && isSilent(sound.Samples[end-1]) {
+		end--
+	}
+	return F32{SampleRate: sound.SampleRate, Samples: append([]float32{}, sound.Samples[start:end]...)}
+}
+
+// Quantize converts the given sound to L8, clipping any samples beyond the nominal range.
+// If dither is requested, triangular noise of one quantization step is added before truncation.
+// The noise is deterministic for equal input.
+func Quantize(sound F32, dither bool) L8 {
+	noise := rand.New(rand.NewSource(int64(len(sound.Samples)))) // nolint: gosec
+	result := L8{SampleRate: sound.SampleRate, Samples: make([]byte, len(sound.Samples))}
+	for index, sample := range sound.Samples {
+		value := float64(sample) * l8Center
+		if dither {
+			value += noise.Float64() - noise.Float64()
+		}
+		result.Samples[index] = byte(math.Max(0, math.Min(255, math.Floor(value)+l8Center)))
+	}
+	return result
+}
+
+// ConversionSettings describe how a high-resolution sound is brought into the format of the game.
+type ConversionSettings struct {
+	// SampleRate is the target rate. Zero keeps the rate of the source.
+	SampleRate float32
+	// Dither adds noise during quantization to avoid distortion of quiet parts.
+	Dither bool
+	// Normalize amplifies the sound so that its peak reaches the highest value without clipping.
+	Normalize bool
+	// TrimSilence removes leading and trailing samples below SilenceThreshold.
+	TrimSilence      bool
+	SilenceThreshold float32
+}
+
+// Convert processes the given sound according to the settings and returns the result.
+func (settings ConversionSettings) Convert(sound F32) L8 {
+	if settings.TrimSilence {
+		sound = TrimSilence(sound, settings.SilenceThreshold)
+	}
+	sound = Resample(sound, settings.SampleRate)
+	if settings.Normalize {
+		sound = Normalize(sound, (l8Center-1)/l8Center)
+	}
+	return Quantize(sound, settings.Dither)
+}
@@ -0,0 +1,138 @@
+package audio_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/content/audio"
+)
+
+func TestF32FromL8(t *testing.T) {
+	result := audio.F32FromL8(audio.L8{SampleRate: 11025, Samples: []byte{0x00, 0x80, 0xC0}})
+
+	assert.Equal(t, float32(11025), result.SampleRate)
+	assert.Equal(t, []float32{-1.0, 0.0, 0.5}, result.Samples)
+}
+
+func TestQuantizeIsInverseOfF32FromL8(t *testing.T) {
+	samples := make([]byte, 256)
+	for index := range samples {
+		samples[index] = byte(index)
+	}
+	result := audio.Quantize(audio.F32FromL8(audio.L8{SampleRate: 22050, Samples: samples}), false)
+
+	assert.Equal(t, samples, result.Samples)
+}
+
+func TestQuantizeClipsOutOfRangeValues(t *testing.T) {
+	result := audio.Quantize(audio.F32{Samples: []float32{-2.0, 2.0}}, false)
+
+	assert.Equal(t, []byte{0x00, 0xFF}, result.Samples)
+}
+
+func TestQuantizeWithDitherStaysCloseToSource(t *testing.T) {
+	source := audio.F32{Samples: make([]float32, 1000)}
+	for index := range source.Samples {
+		source.Samples[index] = 0.25
+	}
+	result := audio.Quantize(source, true)
+
+	sum := 0
+	for _, sample := range result.Samples {
+		assert.InDelta(t, 0xA0, int(sample), 2)
+		sum += int(sample)
+	}
+	assert.InDelta(t, 0xA0, float64(sum)/float64(len(result.Samples)), 0.6)
+}
+
+func TestResampleKeepsDuration(t *testing.T) {
+	source := audio.F32{SampleRate: 44100, Samples: make([]float32, 44100)}
+
+	result := audio.Resample(source, 22050)
+
+	assert.Equal(t, float32(22050), result.SampleRate)
+	assert.Equal(t, 22050, len(result.Samples))
+}
+
+func TestResampleKeepsLowFrequencies(t *testing.T) {
+	source := audio.F32{SampleRate: 44100, Samples: make([]float32, 4410)}
+	for index := range source.Samples {
+		source.Samples[index] = float32(0.5 * math.Sin(2*math.Pi*440*float64(index)/44100))
+	}
+
+	result := audio.Resample(source, 11025)
+
+	require.Equal(t, 1103, len(result.Samples))
+	for index := 100; index < 1000; index++ {
+		expected := 0.5 * math.Sin(2*math.Pi*440*float64(index)/11025)
+		assert.InDelta(t, expected, result.Samples[index], 0.01, "index %d", index)
+	}
+}
+
+func TestResampleRemovesFrequenciesAboveTargetNyquist(t *testing.T) {
+	source := audio.F32{SampleRate: 44100, Samples: make([]float32, 4410)}
+	for index := range source.Samples {
+		source.Samples[index] = float32(0.5 * math.Sin(2*math.Pi*15000*float64(index)/44100))
+	}
+
+	result := audio.Resample(source, 22050)
+
+	for index := 100; index < len(result.Samples)-100; index++ {
+		assert.InDelta(t, 0.0, result.Samples[index], 0.05, "index %d", index)
+	}
+}
+
+func TestResampleReturnsCopyForSameRate(t *testing.T) {
+	source := audio.F32{SampleRate: 22050, Samples: []float32{0.1, 0.2}}
+
+	result := audio.Resample(source, 22050)
+	result.Samples[0] = 1.0
+
+	assert.Equal(t, float32(0.1), source.Samples[0])
+}
+
+func TestNormalizeScalesToLevel(t *testing.T) {
+	result := audio.Normalize(audio.F32{Samples: []float32{0.25, -0.5}}, 1.0)
+
+	assert.Equal(t, []float32{0.5, -1.0}, result.Samples)
+}
+
+func TestNormalizeKeepsSilence(t *testing.T) {
+	result := audio.Normalize(audio.F32{Samples: []float32{0.0, 0.0}}, 1.0)
+
+	assert.Equal(t, []float32{0.0, 0.0}, result.Samples)
+}
+
+func TestTrimSilenceRemovesLeadingAndTrailingSamples(t *testing.T) {
+	result := audio.TrimSilence(audio.F32{Samples: []float32{0.0, 0.01, 0.5, 0.0, -0.5, 0.01}}, 0.1)
+
+	assert.Equal(t, []float32{0.5, 0.0, -0.5}, result.Samples)
+}
+
+func TestTrimSilenceOfSilentSoundIsEmpty(t *testing.T) {
+	result := audio.TrimSilence(audio.F32{Samples: []float32{0.0, 0.01}}, 0.1)
+
+	assert.True(t, result.Empty())
+}
+
+func TestConvertAppliesAllSteps(t *testing.T) {
+	settings := audio.ConversionSettings{
+		SampleRate:       11025,
+		Normalize:        true,
+		TrimSilence:      true,
+		SilenceThreshold: 0.01,
+	}
+	source := audio.F32{SampleRate: 22050, Samples: make([]float32, 2205)}
+	for index := 105; index < 2105; index++ {
+		source.Samples[index] = 0.25
+	}
+
+	result := settings.Convert(source)
+
+	assert.Equal(t, float32(11025), result.SampleRate)
+	assert.Equal(t, 1000, len(result.Samples))
+	assert.Equal(t, byte(0xFF), result.Samples[500])
+}
@@ -0,0 +1,79 @@
+package voc
+
+import (
+	"errors"
+
+	"github.com/inkyblackness/hacked/ss1/content/audio"
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+// Cache retrieves sounds from a localizer and keeps them decoded until they are invalidated.
+// Failed retrievals are remembered as well, so that missing sounds are not looked up repeatedly.
+type Cache struct {
+	localizer resource.Localizer
+
+	sounds   map[resource.Key]audio.L8
+	failures map[resource.Key]error
+}
+
+// NewCache returns a new instance.
+func NewCache(localizer resource.Localizer) *Cache {
+	cache := &Cache{
+		localizer: localizer,
+
+		sounds:   make(map[resource.Key]audio.L8),
+		failures: make(map[resource.Key]error),
+	}
+	return cache
+}
+
+// InvalidateResources lets the cache remove any sounds from resources that are specified in the given slice.
+func (cache *Cache) InvalidateResources(ids []resource.ID) {
+	for _, id := range ids {
+		for key := range cache.sounds {
+			if key.ID == id {
+				delete(cache.sounds, key)
+			}
+		}
+		for key := range cache.failures {
+			if key.ID == id {
+				delete(cache.failures, key)
+			}
+		}
+	}
+}
+
+// Sound retrieves and caches the sound of given key.
+func (cache *Cache) Sound(key resource.Key) (audio.L8, error) {
+	cacheKey := resource.KeyOf(key.ID.Plus(key.Index), key.Lang, 0)
+	value, existing := cache.sounds[cacheKey]
+	if existing {
+		return value, nil
+	}
+	if err, failed := cache.failures[cacheKey]; failed {
+		return audio.L8{}, err
+	}
+	value, err := cache.load(cacheKey)
+	if err != nil {
+		cache.failures[cacheKey] = err
+		return audio.L8{}, err
+	}
+	cache.sounds[cacheKey] = value
+	return value, nil
+}
+
+func (cache *Cache) load(key resource.Key) (audio.L8, error) {
+	selector := cache.localizer.LocalizedResources(key.Lang)
+	view, err := selector.Select(key.ID)
+	if err != nil {
+		return audio.L8{}, errors.New("no sound found")
+	}
+	if (view.ContentType() != resource.Sound) || view.Compound() || (view.BlockCount() != 1) {
+		return audio.L8{}, errors.New("invalid resource type")
+	}
+	reader, err := view.Block(0)
+	if err != nil {
+		return audio.L8{}, err
+	}
+	return Load(reader)
+}
@@ -0,0 +1,196 @@
+package voc_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/inkyblackness/hacked/ss1/content/audio"
+	"github.com/inkyblackness/hacked/ss1/content/audio/voc"
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+type CacheSuite struct {
+	suite.Suite
+
+	localizedResources resource.LocalizedResourcesList
+
+	instance *voc.Cache
+}
+
+func TestCacheSuite(t *testing.T) {
+	suite.Run(t, new(CacheSuite))
+}
+
+func (suite *CacheSuite) SetupTest() {
+	suite.instance = nil
+}
+
+func (suite *CacheSuite) TestSoundReturnsValueIfOK() {
+	suite.givenACache()
+	suite.whenResourcesAre(
+		suite.someLocalizedResources(resource.LangAny,
+			suite.storing(0x00C9, []byte{0x80, 0x90})))
+	suite.thenSoundShouldReturn(resource.KeyOf(0x00C9, resource.LangAny, 0), func(sound audio.L8) {
+		assert.Equal(suite.T(), []byte{0x80, 0x90}, sound.Samples)
+	})
+}
+
+func (suite *CacheSuite) TestSoundConsidersIndexAsOffset() {
+	suite.givenACache()
+	suite.whenResourcesAre(
+		suite.someLocalizedResources(resource.LangAny,
+			suite.storing(0x00CA, []byte{0x10})))
+	suite.thenSoundShouldReturn(resource.KeyOf(0x00C9, resource.LangAny, 1), func(sound audio.L8) {
+		assert.Equal(suite.T(), []byte{0x10}, sound.Samples)
+	})
+}
+
+func (suite *CacheSuite) TestSoundReturnsErrorIfResourceNotExisting() {
+	suite.givenACache()
+	suite.whenResourcesAre()
+	suite.thenSoundShouldReturnError(resource.KeyOf(0x00C9, resource.LangAny, 0))
+}
+
+func (suite *CacheSuite) TestSoundReturnsErrorIfResourceIsNotASound() {
+	suite.givenACache()
+	suite.whenResourcesAre(suite.someLocalizedResources(resource.LangAny,
+		suite.storingNonSound(0x00C9)))
+	suite.thenSoundShouldReturnError(resource.KeyOf(0x00C9, resource.LangAny, 0))
+}
+
+func (suite *CacheSuite) TestSoundReturnsCachedValueIfPreviouslyRetrieved() {
+	key := resource.KeyOf(0x00C9, resource.LangAny, 0)
+	suite.givenACache()
+	suite.givenResourcesAre(
+		suite.someLocalizedResources(resource.LangAny,
+			suite.storing(0x00C9, []byte{0x01})))
+	suite.givenSoundWasRetrieved(key)
+	suite.whenResourcesAre()
+	suite.thenSoundShouldReturn(key, func(sound audio.L8) {
+		assert.Equal(suite.T(), []byte{0x01}, sound.Samples)
+	})
+}
+
+func (suite *CacheSuite) TestSoundTriesToReloadWhenCacheInvalidated() {
+	key := resource.KeyOf(0x00C9, resource.LangAny, 0)
+	suite.givenACache()
+	suite.givenResourcesAre(
+		suite.someLocalizedResources(resource.LangAny,
+			suite.storing(0x00C9, []byte{0x01})))
+	suite.givenSoundWasRetrieved(key)
+	suite.givenResourcesAre()
+	suite.whenCacheResourcesAreInvalidated(0x00C9)
+	suite.thenSoundShouldReturnError(key)
+}
+
+func (suite *CacheSuite) TestSoundReturnsCachedErrorIfPreviouslyFailed() {
+	key := resource.KeyOf(0x00C9, resource.LangAny, 0)
+	suite.givenACache()
+	suite.givenResourcesAre()
+	suite.givenSoundRetrievalFailed(key)
+	suite.whenResourcesAre(
+		suite.someLocalizedResources(resource.LangAny,
+			suite.storing(0x00C9, []byte{0x01})))
+	suite.thenSoundShouldReturnError(key)
+}
+
+func (suite *CacheSuite) TestSoundRetriesFailedLookupWhenCacheInvalidated() {
+	key := resource.KeyOf(0x00C9, resource.LangAny, 0)
+	suite.givenACache()
+	suite.givenResourcesAre()
+	suite.givenSoundRetrievalFailed(key)
+	suite.givenResourcesAre(
+		suite.someLocalizedResources(resource.LangAny,
+			suite.storing(0x00C9, []byte{0x01})))
+	suite.whenCacheResourcesAreInvalidated(0x00C9)
+	suite.thenSoundShouldReturn(key, func(sound audio.L8) {
+		assert.Equal(suite.T(), []byte{0x01}, sound.Samples)
+	})
+}
+
+func (suite *CacheSuite) givenACache() {
+	suite.instance = voc.NewCache(suite)
+}
+
+func (suite *CacheSuite) givenResourcesAre(resources ...resource.LocalizedResources) {
+	suite.localizedResources = resources
+}
+
+func (suite *CacheSuite) givenSoundWasRetrieved(key resource.Key) {
+	_, err := suite.instance.Sound(key)
+	assert.Nil(suite.T(), err, "no error expected")
+}
+
+func (suite *CacheSuite) givenSoundRetrievalFailed(key resource.Key) {
+	_, err := suite.instance.Sound(key)
+	assert.NotNil(suite.T(), err, "error expected")
+}
+
+func (suite *CacheSuite) whenResourcesAre(resources ...resource.LocalizedResources) {
+	suite.localizedResources = resources
+}
+
+func (suite *CacheSuite) whenCacheResourcesAreInvalidated(ids ...resource.ID) {
+	suite.instance.InvalidateResources(ids)
+}
+
+func (suite *CacheSuite) thenSoundShouldReturn(key resource.Key, validator func(sound audio.L8)) {
+	result, err := suite.instance.Sound(key)
+	require.Nil(suite.T(), err, "No error expected")
+	validator(result)
+}
+
+func (suite *CacheSuite) thenSoundShouldReturnError(key resource.Key) {
+	_, err := suite.instance.Sound(key)
+	require.NotNil(suite.T(), err, "Error expected")
+}
+
+func (suite *CacheSuite) someLocalizedResources(lang resource.Language, modifiers ...func(*resource.Store)) resource.LocalizedResources {
+	var store resource.Store
+	for _, modifier := range modifiers {
+		modifier(&store)
+	}
+	return resource.LocalizedResources{
+		ID:       "unnamed",
+		Language: lang,
+		Viewer:   store,
+	}
+}
+
+func (suite *CacheSuite) storing(id int, samples []byte) func(*resource.Store) {
+	buf := bytes.NewBuffer(nil)
+	err := voc.Save(buf, 22050, samples)
+	require.Nil(suite.T(), err)
+	return func(store *resource.Store) {
+		_ = store.Put(resource.ID(id), resource.Resource{
+			Properties: resource.Properties{
+				ContentType: resource.Sound,
+				Compound:    false,
+			},
+			Blocks: resource.BlocksFrom([][]byte{buf.Bytes()}),
+		})
+	}
+}
+
+func (suite *CacheSuite) storingNonSound(id int) func(*resource.Store) {
+	return func(store *resource.Store) {
+		_ = store.Put(resource.ID(id), resource.Resource{
+			Properties: resource.Properties{
+				ContentType: resource.Text,
+				Compound:    true,
+			},
+			Blocks: resource.BlocksFrom([][]byte{{}}),
+		})
+	}
+}
+
+func (suite *CacheSuite) LocalizedResources(lang resource.Language) resource.Selector {
+	return resource.Selector{
+		From: suite.localizedResources,
+		Lang: lang,
+	}
+}
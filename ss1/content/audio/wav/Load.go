@@ -10,7 +10,19 @@ import (
 var errNotASupportedWave = fmt.Errorf("not a supported WAV")
 
 // Load reads from the provided source and returns the data.
+// Sounds with several channels are mixed down, higher bit depths are truncated to 8 bits.
 func Load(source io.Reader) (data audio.L8, err error) {
+	sound, err := LoadF32(source)
+	if err != nil {
+		return data, err
+	}
+	return audio.Quantize(sound, false), nil
+}
+
+// LoadF32 reads from the provided source and returns the data in floating point format.
+// Supported are uncompressed PCM data with 8, 16, 24 or 32 bits, as well as 32 or 64 bit float data,
+// with any number of channels. Sounds with several channels are mixed down to mono.
+func LoadF32(source io.Reader) (data audio.F32, err error) {
 	if source == nil {
 		return data, fmt.Errorf("source is nil")
 	}
@@ -5,38 +5,67 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"math"
 )
 
-func l8FromL8(input []byte) []byte {
-	return input
+type sampleDecoder func(data []byte) float32
+
+func decodeUnsigned8(data []byte) float32 {
+	return (float32(data[0]) - 128.0) / 128.0
 }
 
-func l8FromL16(input []byte) []byte {
-	samples := len(input) / 2
-	output := make([]byte, samples)
+func decodeSigned16(data []byte) float32 {
+	return float32(int16(binary.LittleEndian.Uint16(data))) / float32(1<<15)
+}
 
-	for i := 0; i < samples; i++ {
-		output[i] = byte(int(input[i*2+1]) + 0x80)
-	}
+func decodeSigned24(data []byte) float32 {
+	value := int32(uint32(data[0])<<8|uint32(data[1])<<16|uint32(data[2])<<24) >> 8
+	return float32(value) / float32(1<<23)
+}
+
+func decodeSigned32(data []byte) float32 {
+	return float32(float64(int32(binary.LittleEndian.Uint32(data))) / float64(1<<31))
+}
+
+func decodeFloat32(data []byte) float32 {
+	return math.Float32frombits(binary.LittleEndian.Uint32(data))
+}
+
+func decodeFloat64(data []byte) float32 {
+	return float32(math.Float64frombits(binary.LittleEndian.Uint64(data)))
+}
 
-	return output
+var sampleDecoders = map[waveFormatType]map[uint16]sampleDecoder{
+	waveFormatTypePcm: {
+		8:  decodeUnsigned8,
+		16: decodeSigned16,
+		24: decodeSigned24,
+		32: decodeSigned32,
+	},
+	waveFormatTypeIEEEFloat: {
+		32: decodeFloat32,
+		64: decodeFloat64,
+	},
 }
 
 type waveLoader struct {
 	dataRead   bool
 	formatRead bool
-	samples    []byte
+	rawData    []byte
+	samples    []float32
 	sampleRate float32
 
+	channels      int
+	bytesPerFrame int
+	decoder       sampleDecoder
+
 	reader io.Reader
 	err    error
-
-	dataConverter func([]byte) []byte
 }
 
 func (loader *waveLoader) load(reader io.Reader) {
 	loader.reader = reader
-	loader.dataConverter = l8FromL8
 	loader.loadRiff()
 }
 
@@ -49,10 +78,14 @@ func (loader *waveLoader) read(data interface{}) bool {
 
 func (loader *waveLoader) readBytes(size uint32) (data []byte) {
 	data = make([]byte, int(size))
-	_, loader.err = loader.reader.Read(data)
+	_, loader.err = io.ReadFull(loader.reader, data)
 	return
 }
 
+func (loader *waveLoader) skipBytes(size uint32) {
+	_, loader.err = io.CopyN(ioutil.Discard, loader.reader, int64(size))
+}
+
 func (loader *waveLoader) loadChunk(handler func(riffChunkType, uint32)) {
 	var tag riffChunkTag
 
@@ -82,7 +115,9 @@ func (loader *waveLoader) loadWave() {
 	for !loader.isDone() {
 		loader.loadFormatOrData()
 	}
-	loader.samples = loader.dataConverter(loader.samples)
+	if loader.err == nil {
+		loader.samples = loader.mixDown(loader.rawData)
+	}
 }
 
 func (loader *waveLoader) loadFormatOrData() {
@@ -90,37 +125,79 @@ func (loader *waveLoader) loadFormatOrData() {
 }
 
 func (loader *waveLoader) handleFormatOrData(chunkType riffChunkType, size uint32) {
-	if chunkType == riffChunkTypeFmt {
+	switch chunkType {
+	case riffChunkTypeFmt:
 		loader.loadFormat(size)
-	} else if chunkType == riffChunkTypeData {
+	case riffChunkTypeData:
 		loader.loadData(size)
+	default:
+		loader.skipBytes(size)
+	}
+	if !loader.isDone() && ((size % 2) != 0) {
+		loader.skipBytes(1)
 	}
 }
 
 func (loader *waveLoader) loadFormat(size uint32) {
 	headerData := loader.readBytes(size)
+	if loader.err != nil {
+		return
+	}
 	headerReader := bytes.NewReader(headerData)
 	var header formatHeader
 
 	loader.formatRead = true
 	loader.err = binary.Read(headerReader, binary.LittleEndian, &header.base)
+	if loader.err != nil {
+		return
+	}
 	loader.err = binary.Read(headerReader, binary.LittleEndian, &header.extension.BitsPerSample)
+	if loader.err != nil {
+		return
+	}
 	loader.sampleRate = float32(header.base.SamplesPerSec)
-
-	if header.extension.BitsPerSample == 16 {
-		loader.dataConverter = l8FromL16
+	formatType := header.base.FormatType
+	if formatType == waveFormatTypeExtensible {
+		var extensible waveFormatExtensible
+		_ = binary.Read(headerReader, binary.LittleEndian, &header.extension.ExtensionSize)
+		loader.err = binary.Read(headerReader, binary.LittleEndian, &extensible)
+		if loader.err != nil {
+			return
+		}
+		formatType = extensible.SubFormat
 	}
 
-	if (header.base.FormatType != waveFormatTypePcm) ||
-		(header.base.Channels != 1) ||
-		((header.extension.BitsPerSample != 8) && (header.extension.BitsPerSample != 16)) {
+	bitsPerSample := header.extension.BitsPerSample
+	loader.decoder = sampleDecoders[formatType][bitsPerSample]
+	loader.channels = int(header.base.Channels)
+	loader.bytesPerFrame = int(bitsPerSample/8) * loader.channels
+	if (loader.decoder == nil) || (loader.channels < 1) || ((bitsPerSample % 8) != 0) {
 		loader.err = fmt.Errorf("unsupported WAVE format")
 	}
 }
 
 func (loader *waveLoader) loadData(size uint32) {
 	loader.dataRead = true
-	loader.samples = loader.readBytes(size)
+	loader.rawData = loader.readBytes(size)
+}
+
+func (loader *waveLoader) mixDown(data []byte) []float32 {
+	if !loader.formatRead {
+		loader.err = fmt.Errorf("missing WAVE format")
+		return nil
+	}
+	frameCount := len(data) / loader.bytesPerFrame
+	bytesPerSample := loader.bytesPerFrame / loader.channels
+	samples := make([]float32, frameCount)
+	for frame := 0; frame < frameCount; frame++ {
+		frameData := data[frame*loader.bytesPerFrame:]
+		sum := float32(0.0)
+		for channel := 0; channel < loader.channels; channel++ {
+			sum += loader.decoder(frameData[channel*bytesPerSample:])
+		}
+		samples[frame] = sum / float32(loader.channels)
+	}
+	return samples
 }
 
 func (loader *waveLoader) isDone() bool {
@@ -7,7 +7,9 @@ import (
 type waveFormatType uint16
 
 const (
-	waveFormatTypePcm = 1
+	waveFormatTypePcm        = 0x0001
+	waveFormatTypeIEEEFloat  = 0x0003
+	waveFormatTypeExtensible = 0xFFFE
 )
 
 type waveFormat struct {
@@ -23,6 +25,15 @@ type waveFormatExtension struct {
 	ExtensionSize uint16
 }
 
+// waveFormatExtensible follows waveFormatExtension if the format type is waveFormatTypeExtensible.
+// The first two bytes of the sub-format GUID contain the actual format type.
+type waveFormatExtensible struct {
+	ValidBitsPerSample uint16
+	ChannelMask        uint32
+	SubFormat          waveFormatType
+	_                  [14]byte
+}
+
 type formatHeader struct {
 	base      waveFormat
 	extension waveFormatExtension
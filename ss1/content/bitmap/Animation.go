@@ -25,6 +25,10 @@ type Animation struct {
 	Entries    []AnimationEntry
 }
 
+// MaxAnimationFrames is the highest number of frames an animation can refer to.
+// The entries store the frame indices as bytes.
+const MaxAnimationFrames = 256
+
 // AnimationEntry describes one part of an animation with common frame time.
 type AnimationEntry struct {
 	FirstFrame byte
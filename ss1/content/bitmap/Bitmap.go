@@ -27,6 +27,8 @@ func Decode(reader io.Reader) (*Bitmap, error) {
 // DecodeReferenced tries to read a bitmap from given reader.
 // If the serialized bitmap describes a compressed bitmap, then the pixels from the reference are used as a basis for the result.
 // The returned byte array from the provider will be used as pixel buffer for the new bitmap.
+//
+// Monochrome bitmaps are expanded to one byte per pixel, with the values 0x00 and 0x01, and a stride equal to the width.
 func DecodeReferenced(reader io.Reader, provider func(width, height int16) ([]byte, error)) (*Bitmap, error) {
 	if reader == nil {
 		return nil, errors.New("reader is nil")
@@ -47,6 +49,9 @@ func DecodeReferenced(reader io.Reader, provider func(width, height int16) ([]by
 			return nil, err
 		}
 		err = rle.Decompress(reader, bmp.Pixels)
+	} else if bmp.Header.Type == TypeMonochrome {
+		bmp.Pixels, err = readMonochrome(reader, bmp.Header)
+		bmp.Header.Stride = uint16(bmp.Header.Width)
 	} else {
 		bmp.Pixels = make([]byte, int(bmp.Header.Height)*int(bmp.Header.Stride))
 		_, err = reader.Read(bmp.Pixels)
@@ -70,14 +75,17 @@ func DecodeReferenced(reader io.Reader, provider func(width, height int16) ([]by
 
 // Encode writes the bitmap to a byte array and returns it.
 // Compressed bitmaps will be compressed with a reference image with pixel data all 0x00.
+// Monochrome bitmaps set a pixel for every non-zero value, and are packed with the minimal stride for their width.
 func Encode(bmp *Bitmap, offsetBase int) []byte {
+	header := bmp.Header
 	rawData := bmp.Pixels
-	if bmp.Header.Type == TypeCompressed8Bit {
+	if header.Type == TypeCompressed8Bit {
 		buf := bytes.NewBuffer(nil)
 		_ = rle.Compress(buf, rawData, nil)
 		rawData = buf.Bytes()
+	} else if header.Type == TypeMonochrome {
+		rawData, header.Stride = packMonochrome(bmp.Pixels, header)
 	}
-	header := bmp.Header
 	if bmp.Palette != nil {
 		header.PaletteOffset = int32(offsetBase + HeaderSize + len(rawData))
 	}
@@ -92,3 +100,75 @@ func Encode(bmp *Bitmap, offsetBase int) []byte {
 
 	return buf.Bytes()
 }
+
+// ModifyHeader returns the serialized bitmap with its header changed by the modifier.
+// Size, stride, and palette offset are kept as they are defined by the stored data.
+// Changing the type re-encodes the pixel data, which is only possible between 8-bit types.
+func ModifyHeader(data []byte, modifier func(*Header)) ([]byte, error) {
+	var header Header
+	err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &header)
+	if err != nil {
+		return nil, err
+	}
+	newHeader := header
+	modifier(&newHeader)
+	newHeader.Width = header.Width
+	newHeader.Height = header.Height
+	newHeader.Stride = header.Stride
+	newHeader.PaletteOffset = header.PaletteOffset
+	if newHeader.Type == header.Type {
+		buf := bytes.NewBuffer(nil)
+		_ = binary.Write(buf, binary.LittleEndian, &newHeader)
+		_, _ = buf.Write(data[HeaderSize:])
+		return buf.Bytes(), nil
+	}
+	if !header.Type.Is8Bit() || !newHeader.Type.Is8Bit() {
+		return nil, errors.New("type can not be converted")
+	}
+	bmp, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	width := int(header.Width)
+	if int(header.Stride) != width {
+		pixels := make([]byte, width*int(header.Height))
+		for row := 0; row < int(header.Height); row++ {
+			copy(pixels[row*width:(row+1)*width], bmp.Pixels[row*int(header.Stride):])
+		}
+		bmp.Pixels = pixels
+		newHeader.Stride = uint16(width)
+	}
+	bmp.Header = newHeader
+	return Encode(bmp, 0), nil
+}
+
+func readMonochrome(reader io.Reader, header Header) ([]byte, error) {
+	stride := int(header.Stride)
+	width := int(header.Width)
+	raw := make([]byte, int(header.Height)*stride)
+	_, err := io.ReadFull(reader, raw)
+	if err != nil {
+		return nil, err
+	}
+	pixels := make([]byte, width*int(header.Height))
+	for row := 0; row < int(header.Height); row++ {
+		for column := 0; column < width; column++ {
+			pixels[row*width+column] = (raw[row*stride+column/8] >> (7 - uint(column%8))) & 0x01
+		}
+	}
+	return pixels, nil
+}
+
+func packMonochrome(pixels []byte, header Header) ([]byte, uint16) {
+	width := int(header.Width)
+	stride := (width + 7) / 8
+	raw := make([]byte, int(header.Height)*stride)
+	for row := 0; row < int(header.Height); row++ {
+		for column := 0; column < width; column++ {
+			if pixels[row*width+column] != 0x00 {
+				raw[row*stride+column/8] |= 0x80 >> uint(column%8)
+			}
+		}
+	}
+	return raw, uint16(stride)
+}
@@ -89,6 +89,92 @@ func TestWriteWithForceTransparency(t *testing.T) {
 	assert.Equal(t, sourceData, result)
 }
 
+func TestDecodeOfMonochromeDataExpandsPixels(t *testing.T) {
+	var header bitmap.Header
+	header.Type = bitmap.TypeMonochrome
+	header.Width = 10
+	header.Height = 2
+	header.Stride = 2
+	buf := bytes.NewBuffer(nil)
+	_ = binary.Write(buf, binary.LittleEndian, &header)
+	buf.Write([]byte{0xA0, 0x40, 0x01, 0x80})
+	bmp, err := bitmap.Decode(bytes.NewReader(buf.Bytes()))
+
+	require.Nil(t, err, "no error expected")
+	assert.Equal(t, []byte{1, 0, 1, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 1, 1, 0}, bmp.Pixels)
+}
+
+func TestDecodeOfMonochromeDataSetsStrideOfExpandedPixels(t *testing.T) {
+	var header bitmap.Header
+	header.Type = bitmap.TypeMonochrome
+	header.Width = 10
+	header.Height = 1
+	header.Stride = 2
+	buf := bytes.NewBuffer(nil)
+	_ = binary.Write(buf, binary.LittleEndian, &header)
+	buf.Write([]byte{0xA0, 0x40})
+	bmp, err := bitmap.Decode(bytes.NewReader(buf.Bytes()))
+
+	require.Nil(t, err, "no error expected")
+	assert.Equal(t, uint16(10), bmp.Header.Stride)
+}
+
+func TestEncodeOfMonochromePacksPixels(t *testing.T) {
+	var header bitmap.Header
+	header.Type = bitmap.TypeMonochrome
+	header.Width = 10
+	header.Height = 1
+	header.Stride = 2
+	buf := bytes.NewBuffer(nil)
+	_ = binary.Write(buf, binary.LittleEndian, &header)
+	buf.Write([]byte{0xA0, 0x40})
+	bmp, _ := bitmap.Decode(bytes.NewReader(buf.Bytes()))
+
+	result := bitmap.Encode(bmp, 0)
+	assert.Equal(t, buf.Bytes(), result)
+}
+
+func TestWriteMonochrome(t *testing.T) {
+	sourceData := getTestData(bitmap.TypeMonochrome, []byte{0x80}, false)
+	bmp, _ := bitmap.Decode(bytes.NewReader(sourceData))
+
+	result := bitmap.Encode(bmp, 0)
+	assert.Equal(t, sourceData, result)
+}
+
+func TestModifyHeaderKeepsPixelData(t *testing.T) {
+	sourceData := getTestData(bitmap.TypeCompressed8Bit, []byte{0x01, 0xBB, 0x80, 0x00, 0x00}, false)
+	result, err := bitmap.ModifyHeader(sourceData, func(header *bitmap.Header) {
+		header.Flags = bitmap.FlagTransparent
+		header.Area = bitmap.Area{1, 2, 3, 4}
+		header.Width = 20
+	})
+	require.Nil(t, err, "no error expected")
+	bmp, err := bitmap.Decode(bytes.NewReader(result))
+	require.Nil(t, err, "no error expected")
+	assert.Equal(t, bitmap.FlagTransparent, bmp.Header.Flags)
+	assert.Equal(t, bitmap.Area{1, 2, 3, 4}, bmp.Header.Area)
+	assert.Equal(t, int16(1), bmp.Header.Width)
+	assert.Equal(t, []byte{0xBB}, bmp.Pixels)
+}
+
+func TestModifyHeaderConvertsBetween8BitTypes(t *testing.T) {
+	sourceData := getTestData(bitmap.TypeCompressed8Bit, []byte{0x01, 0xBB, 0x80, 0x00, 0x00}, false)
+	result, err := bitmap.ModifyHeader(sourceData, func(header *bitmap.Header) {
+		header.Type = bitmap.TypeFlat8Bit
+	})
+	require.Nil(t, err, "no error expected")
+	assert.Equal(t, getTestData(bitmap.TypeFlat8Bit, []byte{0xBB}, false), result)
+}
+
+func TestModifyHeaderReturnsErrorConvertingMonochrome(t *testing.T) {
+	sourceData := getTestData(bitmap.TypeMonochrome, []byte{0x80}, false)
+	_, err := bitmap.ModifyHeader(sourceData, func(header *bitmap.Header) {
+		header.Type = bitmap.TypeFlat8Bit
+	})
+	assert.Error(t, err, "error expected")
+}
+
 func getTestData(bmpType bitmap.Type, data []byte, withPalette bool) []byte {
 	var header bitmap.Header
 	buf := bytes.NewBuffer(nil)
@@ -46,9 +46,68 @@ func (entry labEntry) distanceTo(other labEntry) float64 {
 	return math.Sqrt(square(entry.l-other.l) + square(entry.a-other.a) + square(entry.b-other.b))
 }
 
+// DitherMethod describes how colors that are not within the palette are approximated.
+type DitherMethod int
+
+// DitherMethod constants
+const (
+	// DitherNone maps each pixel to the nearest color.
+	DitherNone DitherMethod = iota
+	// DitherOrdered applies a Bayer threshold matrix before mapping each pixel.
+	DitherOrdered
+	// DitherErrorDiffusion distributes the error of each mapped pixel to its neighbours (Floyd-Steinberg).
+	DitherErrorDiffusion
+)
+
+// String returns a textual representation.
+func (method DitherMethod) String() string {
+	switch method {
+	case DitherNone:
+		return "None"
+	case DitherOrdered:
+		return "Ordered"
+	case DitherErrorDiffusion:
+		return "Error Diffusion"
+	default:
+		return "Unknown"
+	}
+}
+
+// DitherMethods returns all known dither methods.
+func DitherMethods() []DitherMethod {
+	return []DitherMethod{DitherNone, DitherOrdered, DitherErrorDiffusion}
+}
+
+// orderedSpread is the range of the color offset, in 8-bit steps, that the ordered dithering applies.
+const orderedSpread = 32.0
+
+var bayer4x4 = [4][4]float64{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+// MapSettings describe how an image is mapped to a bitmap.
+type MapSettings struct {
+	// Dither specifies the method to approximate colors.
+	Dither DitherMethod
+	// AllowedIndices specifies which palette entries may be used for opaque pixels.
+	AllowedIndices IndexMask
+}
+
+// DefaultMapSettings returns the settings that map without dithering, avoiding the reserved palette ranges.
+func DefaultMapSettings() MapSettings {
+	return MapSettings{
+		Dither:         DitherNone,
+		AllowedIndices: DefaultIndexMask(),
+	}
+}
+
 // Bitmapper creates bitmap images from generic images.
 type Bitmapper struct {
 	pal []labEntry
+	rgb []RGB
 }
 
 // NewBitmapper returns a new bitmapper instance based on the given palette.
@@ -57,49 +116,103 @@ func NewBitmapper(palette *Palette) *Bitmapper {
 
 	for _, clr := range palette {
 		bitmapper.pal = append(bitmapper.pal, labEntryFromColor(clr.Color(0xFF)))
+		bitmapper.rgb = append(bitmapper.rgb, clr)
 	}
 
 	return bitmapper
 }
 
-// Map maps the provided image to a bitmap based on the internal palette.
+// Map maps the provided image to a bitmap based on the internal palette, using the default settings.
 func (bitmapper *Bitmapper) Map(img image.Image) Bitmap {
+	return bitmapper.MapWith(img, DefaultMapSettings())
+}
+
+// MapWith maps the provided image to a bitmap based on the internal palette, using the given settings.
+// Fully transparent pixels are mapped to index 0.
+func (bitmapper *Bitmapper) MapWith(img image.Image, settings MapSettings) Bitmap {
 	var bmp Bitmap
 	bounds := img.Bounds()
 
 	bmp.Header.Width = int16(math.Max(0, math.Min(float64(bounds.Dx()), math.MaxInt16)))
 	bmp.Header.Height = int16(math.Max(0, math.Min(float64(bounds.Dy()), math.MaxInt16)))
-	bmp.Pixels = make([]byte, int(bmp.Header.Width)*int(bmp.Header.Height))
+	width := int(bmp.Header.Width)
+	bmp.Pixels = make([]byte, width*int(bmp.Header.Height))
+	cache := make(map[RGB]byte)
+	currentErrors := make([][3]float64, width+2)
+	nextErrors := make([][3]float64, width+2)
+
 	for row := 0; row < int(bmp.Header.Height); row++ {
-		for column := 0; column < int(bmp.Header.Width); column++ {
-			bmp.Pixels[row*int(bmp.Header.Width)+column] = bitmapper.MapColor(img.At(column, row))
+		for column := 0; column < width; column++ {
+			r, g, b, a := img.At(bounds.Min.X+column, bounds.Min.Y+row).RGBA()
+			if a == 0 {
+				continue
+			}
+			value := [3]float64{float64(r) / 257.0, float64(g) / 257.0, float64(b) / 257.0}
+			switch settings.Dither {
+			case DitherOrdered:
+				offset := ((bayer4x4[row%4][column%4]+0.5)/16.0 - 0.5) * orderedSpread
+				for i := range value {
+					value[i] += offset
+				}
+			case DitherErrorDiffusion:
+				for i := range value {
+					value[i] += currentErrors[column+1][i]
+				}
+			}
+			clr := RGB{Red: clampedByte(value[0]), Green: clampedByte(value[1]), Blue: clampedByte(value[2])}
+			palIndex, cached := cache[clr]
+			if !cached {
+				palIndex = bitmapper.nearestIndex(labEntryFromColor(clr.Color(0xFF)), &settings.AllowedIndices)
+				cache[clr] = palIndex
+			}
+			bmp.Pixels[row*width+column] = palIndex
+
+			if settings.Dither == DitherErrorDiffusion {
+				mapped := bitmapper.rgb[palIndex]
+				mappedValue := [3]float64{float64(mapped.Red), float64(mapped.Green), float64(mapped.Blue)}
+				for i := range value {
+					diff := value[i] - mappedValue[i]
+					currentErrors[column+2][i] += diff * 7.0 / 16.0
+					nextErrors[column][i] += diff * 3.0 / 16.0
+					nextErrors[column+1][i] += diff * 5.0 / 16.0
+					nextErrors[column+2][i] += diff * 1.0 / 16.0
+				}
+			}
+		}
+		currentErrors, nextErrors = nextErrors, currentErrors
+		for i := range nextErrors {
+			nextErrors[i] = [3]float64{}
 		}
 	}
 
 	return bmp
 }
 
-// MapColor maps the provided color to the nearest index in the palette.
+// MapColor maps the provided color to the nearest index in the palette, avoiding the reserved palette ranges.
 func (bitmapper *Bitmapper) MapColor(clr color.Color) (palIndex byte) {
 	_, _, _, a := clr.RGBA()
-	indexWithin := func(index, from, to int) bool {
-		return (index >= from) && (index <= to)
+	if a > 0 {
+		mask := DefaultIndexMask()
+		palIndex = bitmapper.nearestIndex(labEntryFromColor(clr), &mask)
 	}
+	return
+}
 
-	if a > 0 {
-		clrEntry := labEntryFromColor(clr)
-		palDistance := 1000.0
-
-		for colorIndex, palEntry := range bitmapper.pal {
-			isRegularColor := indexWithin(colorIndex, 0x01, 0x02) || indexWithin(colorIndex, 0x08, 0x0A) || indexWithin(colorIndex, 0x20, 0xFF)
-			if isRegularColor {
-				distance := palEntry.distanceTo(clrEntry)
-				if distance < palDistance {
-					palDistance = distance
-					palIndex = byte(colorIndex)
-				}
+func (bitmapper *Bitmapper) nearestIndex(clrEntry labEntry, mask *IndexMask) (palIndex byte) {
+	palDistance := 1000.0
+
+	for colorIndex, palEntry := range bitmapper.pal {
+		if mask[colorIndex] {
+			distance := palEntry.distanceTo(clrEntry)
+			if distance < palDistance {
+				palDistance = distance
+				palIndex = byte(colorIndex)
 			}
 		}
 	}
 	return
 }
+
+func clampedByte(value float64) byte {
+	return byte(math.Max(0, math.Min(255, math.Round(value))))
+}
@@ -0,0 +1,104 @@
+package bitmap_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+)
+
+func blackAndWhitePalette() *bitmap.Palette {
+	var pal bitmap.Palette
+	pal[1] = bitmap.RGB{Red: 0x00, Green: 0x00, Blue: 0x00}
+	pal[2] = bitmap.RGB{Red: 0xFF, Green: 0xFF, Blue: 0xFF}
+	for index := 3; index < len(pal); index++ {
+		pal[index] = bitmap.RGB{Red: 0xFF, Green: 0x00, Blue: 0x00}
+	}
+	return &pal
+}
+
+func blackAndWhiteOnly() bitmap.IndexMask {
+	var mask bitmap.IndexMask
+	mask[1] = true
+	mask[2] = true
+	return mask
+}
+
+func uniformImage(clr color.Color, width, height int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, clr)
+		}
+	}
+	return img
+}
+
+func countOf(pixels []byte, value byte) (count int) {
+	for _, pixel := range pixels {
+		if pixel == value {
+			count++
+		}
+	}
+	return
+}
+
+func TestDefaultIndexMaskExcludesReservedRanges(t *testing.T) {
+	mask := bitmap.DefaultIndexMask()
+
+	for _, index := range []int{0x00, 0x03, 0x07, 0x0B, 0x1F} {
+		assert.False(t, mask[index], "index %d should not be allowed", index)
+	}
+	for _, index := range []int{0x01, 0x02, 0x08, 0x0A, 0x20, 0xFF} {
+		assert.True(t, mask[index], "index %d should be allowed", index)
+	}
+}
+
+func TestMapWithoutDitherUsesNearestColor(t *testing.T) {
+	bitmapper := bitmap.NewBitmapper(blackAndWhitePalette())
+	bmp := bitmapper.MapWith(uniformImage(color.NRGBA{R: 0x10, G: 0x10, B: 0x10, A: 0xFF}, 4, 4),
+		bitmap.MapSettings{Dither: bitmap.DitherNone, AllowedIndices: blackAndWhiteOnly()})
+
+	assert.Equal(t, 16, countOf(bmp.Pixels, 1))
+}
+
+func TestMapKeepsTransparentPixelsAtZero(t *testing.T) {
+	bitmapper := bitmap.NewBitmapper(blackAndWhitePalette())
+	bmp := bitmapper.MapWith(uniformImage(color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0x00}, 2, 2),
+		bitmap.MapSettings{Dither: bitmap.DitherErrorDiffusion, AllowedIndices: blackAndWhiteOnly()})
+
+	assert.Equal(t, []byte{0, 0, 0, 0}, bmp.Pixels)
+}
+
+func TestMapRespectsAllowedIndices(t *testing.T) {
+	bitmapper := bitmap.NewBitmapper(blackAndWhitePalette())
+	bmp := bitmapper.MapWith(uniformImage(color.NRGBA{R: 0xFF, G: 0x00, B: 0x00, A: 0xFF}, 2, 2),
+		bitmap.MapSettings{Dither: bitmap.DitherNone, AllowedIndices: blackAndWhiteOnly()})
+
+	assert.Equal(t, 0, countOf(bmp.Pixels, 0)+countOf(bmp.Pixels, 3))
+}
+
+func TestMapWithOrderedDitheringMixesColorsNearBoundary(t *testing.T) {
+	bitmapper := bitmap.NewBitmapper(blackAndWhitePalette())
+	img := uniformImage(color.NRGBA{R: 0x30, G: 0x30, B: 0x30, A: 0xFF}, 8, 8)
+	bmp := bitmapper.MapWith(img, bitmap.MapSettings{Dither: bitmap.DitherOrdered, AllowedIndices: blackAndWhiteOnly()})
+	blackCount := countOf(bmp.Pixels, 1)
+	whiteCount := countOf(bmp.Pixels, 2)
+
+	assert.Equal(t, 64, blackCount+whiteCount)
+	assert.True(t, (blackCount > 0) && (whiteCount > 0), "black and white should be mixed")
+}
+
+func TestMapWithErrorDiffusionKeepsAverageIntensity(t *testing.T) {
+	bitmapper := bitmap.NewBitmapper(blackAndWhitePalette())
+	img := uniformImage(color.NRGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xFF}, 8, 8)
+	bmp := bitmapper.MapWith(img, bitmap.MapSettings{Dither: bitmap.DitherErrorDiffusion, AllowedIndices: blackAndWhiteOnly()})
+	blackCount := countOf(bmp.Pixels, 1)
+	whiteCount := countOf(bmp.Pixels, 2)
+
+	assert.Equal(t, 64, blackCount+whiteCount)
+	assert.InDelta(t, 32, whiteCount, 4)
+}
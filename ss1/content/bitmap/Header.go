@@ -1,17 +1,54 @@
 package bitmap
 
+import "fmt"
+
 // Type describes the data layout of a bitmap.
 type Type byte
 
 // Type constants
+//
+// The engine knows further types (24-bit, span and generic bitmaps) that are only used at runtime
+// and never stored in resources. None of its types describes a 4-bit layout.
 const (
+	// TypeMonochrome bitmaps have one bit per pixel, with the highest bit of each byte being the left-most pixel.
+	// Set bits are drawn in a color specified at runtime.
+	TypeMonochrome Type = 1
 	// TypeFlat8Bit bitmaps are 8-bit paletted bitmaps that have their pixel stored in a flat layout.
 	TypeFlat8Bit Type = 2
 	// TypeCompressed8Bit bitmaps are 8-bit paletted bitmaps that have their pixel compressed in storage.
 	// Compression is using run-length-encoding (RLE); See package rle.
 	TypeCompressed8Bit Type = 4
+	// TypeTranslucent8Bit bitmaps are 8-bit paletted bitmaps like TypeFlat8Bit, with their pixel
+	// being drawn through the translucency tables.
+	TypeTranslucent8Bit Type = 5
 )
 
+// Types returns all types that are supported for serialization.
+func Types() []Type {
+	return []Type{TypeMonochrome, TypeFlat8Bit, TypeCompressed8Bit, TypeTranslucent8Bit}
+}
+
+// Is8Bit returns true for the types that store one palette index per pixel.
+func (t Type) Is8Bit() bool {
+	return (t == TypeFlat8Bit) || (t == TypeCompressed8Bit) || (t == TypeTranslucent8Bit)
+}
+
+// String returns the textual representation of the type.
+func (t Type) String() string {
+	switch t {
+	case TypeMonochrome:
+		return "Monochrome"
+	case TypeFlat8Bit:
+		return "Flat 8-bit"
+	case TypeCompressed8Bit:
+		return "Compressed 8-bit"
+	case TypeTranslucent8Bit:
+		return "Translucent 8-bit"
+	default:
+		return fmt.Sprintf("Unknown%02X", int(t))
+	}
+}
+
 // Flag adds further properties
 type Flag uint16
 
@@ -19,6 +56,8 @@ type Flag uint16
 const (
 	// FlagTransparent is set for bitmaps that shall treat palette index 0x00 as fully transparent.
 	FlagTransparent Flag = 0x0001
+	// FlagTranslucent is set for bitmaps that have translucent pixel.
+	FlagTranslucent Flag = 0x0002
 )
 
 // HeaderSize is the size of the Header structure, in bytes.
@@ -27,6 +66,34 @@ const HeaderSize = 28
 // Area is a placeholder for either a rectangle, or an anchoring point (first two entries).
 type Area [4]int16
 
+// Scaled returns the area with its coordinates scaled from one bitmap size to another.
+// This keeps anchors, such as the hotspots of sprites, at their relative position.
+func (area Area) Scaled(fromWidth, fromHeight, toWidth, toHeight int16) Area {
+	scale := func(value, from, to int16) int16 {
+		if from == 0 {
+			return value
+		}
+		return int16(int(value) * int(to) / int(from))
+	}
+	return Area{
+		scale(area[0], fromWidth, toWidth),
+		scale(area[1], fromHeight, toHeight),
+		scale(area[2], fromWidth, toWidth),
+		scale(area[3], fromHeight, toHeight),
+	}
+}
+
+// HighestBitShift returns the position of the highest set bit of given size value.
+// This is the value of the WidthFactor and HeightFactor fields for a respective width and height.
+func HighestBitShift(value int16) (result byte) {
+	if value != 0 {
+		for (value >> result) != 1 {
+			result++
+		}
+	}
+	return
+}
+
 // Header contains the meta information for a bitmap.
 type Header struct {
 	_             [4]byte
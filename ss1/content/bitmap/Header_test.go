@@ -0,0 +1,26 @@
+package bitmap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+)
+
+func TestHighestBitShift(t *testing.T) {
+	tt := []struct {
+		value    int16
+		expected byte
+	}{
+		{value: 0, expected: 0},
+		{value: 1, expected: 0},
+		{value: 2, expected: 1},
+		{value: 64, expected: 6},
+		{value: 100, expected: 6},
+		{value: 320, expected: 8},
+	}
+	for _, tc := range tt {
+		assert.Equal(t, tc.expected, bitmap.HighestBitShift(tc.value), "Wrong result for %d", tc.value)
+	}
+}
@@ -0,0 +1,41 @@
+package bitmap
+
+// IndexMask specifies which palette indices may be used when mapping colors.
+type IndexMask [256]bool
+
+// FullIndexMask returns a mask that allows all indices.
+func FullIndexMask() IndexMask {
+	var mask IndexMask
+	for index := range mask {
+		mask[index] = true
+	}
+	return mask
+}
+
+// DefaultIndexMask returns a mask that allows all indices outside of the reserved palette ranges.
+func DefaultIndexMask() IndexMask {
+	return FullIndexMask().Without(ReservedPaletteRanges())
+}
+
+// Without returns a mask that has all indices of given ranges disallowed.
+func (mask IndexMask) Without(ranges PaletteRanges) IndexMask {
+	for _, r := range ranges {
+		mask = mask.withRange(r, false)
+	}
+	return mask
+}
+
+// With returns a mask that has all indices of given ranges allowed.
+func (mask IndexMask) With(ranges PaletteRanges) IndexMask {
+	for _, r := range ranges {
+		mask = mask.withRange(r, true)
+	}
+	return mask
+}
+
+func (mask IndexMask) withRange(r PaletteRange, allowed bool) IndexMask {
+	for index := r.Start; (index < (r.Start + r.Count)) && (index < len(mask)); index++ {
+		mask[index] = allowed
+	}
+	return mask
+}
@@ -0,0 +1,142 @@
+package bitmap
+
+import (
+	"image"
+	"image/color"
+	"sort"
+)
+
+type colorCount struct {
+	rgb   RGB
+	count int
+}
+
+type colorBox []colorCount
+
+// GeneratePalette creates a palette with up to the given number of colors that best represents the given image.
+// Images with fewer distinct colors have them taken over exactly, others are reduced with the median cut algorithm.
+// Fully transparent pixel are ignored. Remaining entries of the palette are black.
+func GeneratePalette(img image.Image, colorLimit int) Palette {
+	var pal Palette
+	if colorLimit > len(pal) {
+		colorLimit = len(pal)
+	}
+	if colorLimit <= 0 {
+		return pal
+	}
+	boxes := []colorBox{distinctColors(img)}
+	if len(boxes[0]) == 0 {
+		return pal
+	}
+	for len(boxes) < colorLimit {
+		splitIndex := -1
+		splitRange := 0
+		for index, box := range boxes {
+			if _, boxRange := box.widestChannel(); (len(box) > 1) && (boxRange > splitRange) {
+				splitIndex = index
+				splitRange = boxRange
+			}
+		}
+		if splitIndex < 0 {
+			break
+		}
+		first, second := boxes[splitIndex].split()
+		boxes[splitIndex] = first
+		boxes = append(boxes, second)
+	}
+	for index, box := range boxes {
+		pal[index] = box.average()
+	}
+	return pal
+}
+
+func distinctColors(img image.Image) colorBox {
+	counts := make(map[RGB]int)
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			clr := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if clr.A == 0 {
+				continue
+			}
+			counts[RGB{Red: clr.R, Green: clr.G, Blue: clr.B}]++
+		}
+	}
+	box := make(colorBox, 0, len(counts))
+	for rgb, count := range counts {
+		box = append(box, colorCount{rgb: rgb, count: count})
+	}
+	sort.Slice(box, func(a, b int) bool { return box[a].rgb.key() < box[b].rgb.key() })
+	return box
+}
+
+func (rgb RGB) key() int {
+	return int(rgb.Red)<<16 | int(rgb.Green)<<8 | int(rgb.Blue)
+}
+
+func channelOf(rgb RGB, channel int) int {
+	switch channel {
+	case 0:
+		return int(rgb.Red)
+	case 1:
+		return int(rgb.Green)
+	default:
+		return int(rgb.Blue)
+	}
+}
+
+func (box colorBox) widestChannel() (channel int, width int) {
+	for candidate := 0; candidate < 3; candidate++ {
+		low, high := 255, 0
+		for _, entry := range box {
+			value := channelOf(entry.rgb, candidate)
+			if value < low {
+				low = value
+			}
+			if value > high {
+				high = value
+			}
+		}
+		if high-low > width {
+			channel = candidate
+			width = high - low
+		}
+	}
+	return
+}
+
+// split divides the box at the median of its widest channel, weighted by the pixel count.
+func (box colorBox) split() (colorBox, colorBox) {
+	channel, _ := box.widestChannel()
+	sort.SliceStable(box, func(a, b int) bool { return channelOf(box[a].rgb, channel) < channelOf(box[b].rgb, channel) })
+	total := 0
+	for _, entry := range box {
+		total += entry.count
+	}
+	splitAt := 1
+	sum := box[0].count
+	for (splitAt < len(box)-1) && (sum < total/2) {
+		sum += box[splitAt].count
+		splitAt++
+	}
+	first := make(colorBox, splitAt)
+	copy(first, box[:splitAt])
+	second := make(colorBox, len(box)-splitAt)
+	copy(second, box[splitAt:])
+	return first, second
+}
+
+func (box colorBox) average() RGB {
+	var red, green, blue, total int
+	for _, entry := range box {
+		red += int(entry.rgb.Red) * entry.count
+		green += int(entry.rgb.Green) * entry.count
+		blue += int(entry.rgb.Blue) * entry.count
+		total += entry.count
+	}
+	return RGB{
+		Red:   uint8((red + total/2) / total),
+		Green: uint8((green + total/2) / total),
+		Blue:  uint8((blue + total/2) / total),
+	}
+}
@@ -0,0 +1,53 @@
+package bitmap_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+)
+
+func TestGeneratePaletteTakesFewColorsExactly(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.NRGBA{R: 0x10, G: 0x20, B: 0x30, A: 0xFF})
+	img.Set(1, 0, color.NRGBA{R: 0xF0, G: 0x00, B: 0x00, A: 0xFF})
+	img.Set(0, 1, color.NRGBA{R: 0xF0, G: 0x00, B: 0x00, A: 0xFF})
+	img.Set(1, 1, color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0x00})
+
+	pal := bitmap.GeneratePalette(img, 256)
+
+	assert.Contains(t, pal[:2], bitmap.RGB{Red: 0x10, Green: 0x20, Blue: 0x30})
+	assert.Contains(t, pal[:2], bitmap.RGB{Red: 0xF0, Green: 0x00, Blue: 0x00})
+	assert.Equal(t, bitmap.RGB{}, pal[2], "remaining entries should be black")
+}
+
+func TestGeneratePaletteReducesToLimit(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 256, 4))
+	for x := 0; x < 256; x++ {
+		for y := 0; y < 4; y++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y * 60), B: 0x80, A: 0xFF})
+		}
+	}
+
+	pal := bitmap.GeneratePalette(img, 16)
+
+	for index := 16; index < len(pal); index++ {
+		assert.Equal(t, bitmap.RGB{}, pal[index], "entries beyond limit should be black")
+	}
+	mapped := bitmap.NewBitmapper(&pal).MapWith(img, bitmap.MapSettings{AllowedIndices: bitmap.FullIndexMask()})
+	maxDistance := 0
+	for x := 0; x < 256; x++ {
+		entry := pal[mapped.Pixels[x]]
+		distance := int(entry.Red) - x
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance > maxDistance {
+			maxDistance = distance
+		}
+	}
+	assert.True(t, maxDistance < 64, "colors should be approximated")
+}
@@ -0,0 +1,80 @@
+package bitmap
+
+// PaletteRangePurpose describes why a range of palette entries is reserved.
+type PaletteRangePurpose int
+
+// PaletteRangePurpose constants
+const (
+	// PaletteRangeTransparency marks the entry that is drawn as fully transparent.
+	PaletteRangeTransparency PaletteRangePurpose = iota
+	// PaletteRangeColorCycling marks entries that are rotated by the engine to animate colors.
+	PaletteRangeColorCycling
+	// PaletteRangeShading marks entries that are reserved for lighting and shading effects.
+	PaletteRangeShading
+)
+
+// String returns a textual representation.
+func (purpose PaletteRangePurpose) String() string {
+	switch purpose {
+	case PaletteRangeTransparency:
+		return "Transparency"
+	case PaletteRangeColorCycling:
+		return "Color Cycling"
+	case PaletteRangeShading:
+		return "Shading"
+	default:
+		return "Unknown"
+	}
+}
+
+// PaletteRange describes a consecutive set of palette entries.
+type PaletteRange struct {
+	Start   int
+	Count   int
+	Purpose PaletteRangePurpose
+}
+
+// Contains returns true if the given index is within the range.
+func (r PaletteRange) Contains(index int) bool {
+	return (index >= r.Start) && (index < (r.Start + r.Count))
+}
+
+// PaletteRanges is a list of palette ranges.
+type PaletteRanges []PaletteRange
+
+// ReservedPaletteRanges returns the ranges of the game palette that are treated specially by the engine.
+// The color cycling ranges are rotated at runtime. Shading is applied via tables that are calculated from
+// the whole palette, so there are no entries exclusively reserved for it in the game palette.
+func ReservedPaletteRanges() PaletteRanges {
+	return PaletteRanges{
+		{Start: 0x00, Count: 1, Purpose: PaletteRangeTransparency},
+		{Start: 0x03, Count: 5, Purpose: PaletteRangeColorCycling},
+		{Start: 0x0B, Count: 5, Purpose: PaletteRangeColorCycling},
+		{Start: 0x10, Count: 5, Purpose: PaletteRangeColorCycling},
+		{Start: 0x15, Count: 3, Purpose: PaletteRangeColorCycling},
+		{Start: 0x18, Count: 3, Purpose: PaletteRangeColorCycling},
+		{Start: 0x1B, Count: 5, Purpose: PaletteRangeColorCycling},
+	}
+}
+
+// RangeOf returns the range that contains the given index.
+// The returned boolean is false if the index is not within a range of the list.
+func (ranges PaletteRanges) RangeOf(index int) (PaletteRange, bool) {
+	for _, r := range ranges {
+		if r.Contains(index) {
+			return r, true
+		}
+	}
+	return PaletteRange{}, false
+}
+
+// Purposed returns the ranges of the list that have given purpose.
+func (ranges PaletteRanges) Purposed(purpose PaletteRangePurpose) PaletteRanges {
+	var result PaletteRanges
+	for _, r := range ranges {
+		if r.Purpose == purpose {
+			result = append(result, r)
+		}
+	}
+	return result
+}
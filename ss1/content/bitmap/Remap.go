@@ -0,0 +1,68 @@
+package bitmap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/inkyblackness/hacked/ss1/serial/rle"
+)
+
+// IndexMapping describes for each palette index the index it shall become.
+type IndexMapping [256]byte
+
+// IdentityMapping returns a mapping that keeps all indices.
+func IdentityMapping() IndexMapping {
+	var mapping IndexMapping
+	for index := range mapping {
+		mapping[index] = byte(index)
+	}
+	return mapping
+}
+
+// IsIdentity returns true if the mapping keeps all indices.
+func (mapping IndexMapping) IsIdentity() bool {
+	return mapping == IdentityMapping()
+}
+
+// Remap returns the serialized bitmap with all its pixels mapped to new palette indices.
+// Bitmaps with a private palette are not based on a game palette and are returned unchanged,
+// as are monochrome bitmaps, which have no palette indices.
+// Compressed bitmaps keep their skipped areas as they are, which is relevant for frames of animations.
+// The returned boolean is true if the data was changed.
+func Remap(data []byte, mapping IndexMapping) ([]byte, bool, error) {
+	var header Header
+	err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &header)
+	if err != nil {
+		return nil, false, err
+	}
+	if (header.PaletteOffset != 0) || !header.Type.Is8Bit() {
+		return data, false, nil
+	}
+	pixelData := data[HeaderSize:]
+	var mapped []byte
+	pixelDataLength := 0
+	if header.Type == TypeCompressed8Bit {
+		mapped, pixelDataLength, err = rle.MapValues(pixelData, func(value byte) byte { return mapping[value] })
+		if err != nil {
+			return nil, false, err
+		}
+	} else {
+		pixelDataLength = int(header.Height) * int(header.Stride)
+		if pixelDataLength > len(pixelData) {
+			return nil, false, errors.New("data could not be read")
+		}
+		mapped = make([]byte, pixelDataLength)
+		for index, value := range pixelData[:pixelDataLength] {
+			mapped[index] = mapping[value]
+		}
+	}
+	if bytes.Equal(mapped, pixelData[:pixelDataLength]) {
+		return data, false, nil
+	}
+	result := make([]byte, 0, len(data))
+	result = append(result, data[:HeaderSize]...)
+	result = append(result, mapped...)
+	result = append(result, pixelData[pixelDataLength:]...)
+	return result, true, nil
+}
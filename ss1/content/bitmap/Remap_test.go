@@ -0,0 +1,67 @@
+package bitmap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+)
+
+func swappingMapping(a, b byte) bitmap.IndexMapping {
+	mapping := bitmap.IdentityMapping()
+	mapping[a] = b
+	mapping[b] = a
+	return mapping
+}
+
+func TestIdentityMappingIsIdentity(t *testing.T) {
+	assert.True(t, bitmap.IdentityMapping().IsIdentity())
+	assert.False(t, swappingMapping(1, 2).IsIdentity())
+}
+
+func TestRemapOfUncompressedData(t *testing.T) {
+	data := getTestData(bitmap.TypeFlat8Bit, []byte{0xAA}, false)
+	result, changed, err := bitmap.Remap(data, swappingMapping(0xAA, 0x10))
+
+	require.Nil(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, getTestData(bitmap.TypeFlat8Bit, []byte{0x10}, false), result)
+}
+
+func TestRemapOfCompressedData(t *testing.T) {
+	data := getTestData(bitmap.TypeCompressed8Bit, []byte{0x00, 0x01, 0xBB, 0x80, 0x00, 0x00}, false)
+	result, changed, err := bitmap.Remap(data, swappingMapping(0xBB, 0x20))
+
+	require.Nil(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, getTestData(bitmap.TypeCompressed8Bit, []byte{0x00, 0x01, 0x20, 0x80, 0x00, 0x00}, false), result)
+}
+
+func TestRemapReportsUnchangedData(t *testing.T) {
+	data := getTestData(bitmap.TypeFlat8Bit, []byte{0xAA}, false)
+	result, changed, err := bitmap.Remap(data, swappingMapping(0x01, 0x02))
+
+	require.Nil(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, data, result)
+}
+
+func TestRemapIgnoresBitmapsWithPrivatePalette(t *testing.T) {
+	data := getTestData(bitmap.TypeFlat8Bit, []byte{0xAA}, true)
+	result, changed, err := bitmap.Remap(data, swappingMapping(0xAA, 0x10))
+
+	require.Nil(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, data, result)
+}
+
+func TestRemapIgnoresMonochromeBitmaps(t *testing.T) {
+	data := getTestData(bitmap.TypeMonochrome, []byte{0x80}, false)
+	result, changed, err := bitmap.Remap(data, swappingMapping(0x80, 0x10))
+
+	require.Nil(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, data, result)
+}
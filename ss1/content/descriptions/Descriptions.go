@@ -0,0 +1,171 @@
+package descriptions
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/inkyblackness/hacked/ss1/content/archive/level/lvlobj"
+	"github.com/inkyblackness/hacked/ss1/content/interpreters"
+	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/content/object/objprop"
+)
+
+// Defaults returns a file with all the built-in descriptions.
+func Defaults() (File, error) {
+	var file File
+	for _, kind := range lvlobj.Kinds() {
+		for _, described := range lvlobj.BuiltinDescriptions(kind) {
+			def, err := described.Description.Definition()
+			if err != nil {
+				return File{}, fmt.Errorf("%v %v: %v", kind, described.Selector, err)
+			}
+			file.Entries = append(file.Entries, Entry{
+				Set:         string(kind),
+				Class:       keyOrAny(described.Class),
+				Subclass:    keyOrAny(described.Subclass),
+				Type:        keyOrAny(described.Type),
+				Description: def,
+			})
+		}
+	}
+	generic := objprop.BuiltinGenericDescriptions()
+	for _, class := range object.Classes() {
+		desc, existing := generic[class]
+		if !existing {
+			continue
+		}
+		def, err := desc.Definition()
+		if err != nil {
+			return File{}, fmt.Errorf("%v %v: %v", SetGenericProperties, class, err)
+		}
+		file.Entries = append(file.Entries, Entry{
+			Set:         SetGenericProperties,
+			Class:       keyOrAny(int(class)),
+			Description: def,
+		})
+	}
+	specific := objprop.BuiltinSpecificDescriptions()
+	for _, triple := range sortedTriples(specific) {
+		def, err := specific[triple].Definition()
+		if err != nil {
+			return File{}, fmt.Errorf("%v %v: %v", SetSpecificProperties, triple, err)
+		}
+		entry := Entry{
+			Set:         SetSpecificProperties,
+			Class:       keyOrAny(int(triple.Class)),
+			Subclass:    keyOrAny(int(triple.Subclass)),
+			Description: def,
+		}
+		if triple.Type != objprop.AnyType {
+			entry.Type = keyOrAny(int(triple.Type))
+		}
+		file.Entries = append(file.Entries, entry)
+	}
+	return file, nil
+}
+
+// Interpreters provides the interpreters of level objects and object properties, based on one set of descriptions.
+type Interpreters struct {
+	// Level provides the interpreters of the class and extra data of level objects.
+	Level *lvlobj.Interpreters
+	// Properties provides the interpreters of object properties.
+	Properties *objprop.Interpreters
+}
+
+// Builtin returns the interpreters based on the built-in descriptions.
+func Builtin() Interpreters {
+	return Interpreters{Level: lvlobj.BuiltinInterpreters(), Properties: objprop.BuiltinInterpreters()}
+}
+
+// Build returns the interpreters based on the descriptions of the given file.
+// The descriptions of the file take precedence over the built-in ones. An empty file results in the built-in ones.
+// The file is validated completely. In case of an error, no interpreters are returned.
+func Build(file File) (Interpreters, error) {
+	levelObjects := make(map[lvlobj.Kind][]lvlobj.Described)
+	generic := make(map[object.Class]*interpreters.Description)
+	specific := make(map[object.Triple]*interpreters.Description)
+	for index, entry := range file.Entries {
+		desc, err := interpreters.Build(entry.Description)
+		if err != nil {
+			return Interpreters{}, fmt.Errorf("entry %d: %v", index, err)
+		}
+		switch entry.Set {
+		case SetGenericProperties:
+			if (entry.Class == nil) || (entry.Subclass != nil) || (entry.Type != nil) {
+				return Interpreters{}, fmt.Errorf("entry %d: generic properties require only a class", index)
+			}
+			if !isValidKey(*entry.Class) {
+				return Interpreters{}, fmt.Errorf("entry %d: invalid class", index)
+			}
+			generic[object.Class(*entry.Class)] = desc
+		case SetSpecificProperties:
+			if (entry.Class == nil) || (entry.Subclass == nil) {
+				return Interpreters{}, fmt.Errorf("entry %d: specific properties require a class and subclass", index)
+			}
+			objType := objprop.AnyType
+			if entry.Type != nil {
+				objType = *entry.Type
+			}
+			if !isValidKey(*entry.Class) || !isValidKey(*entry.Subclass) || !isValidKey(objType) {
+				return Interpreters{}, fmt.Errorf("entry %d: invalid selector", index)
+			}
+			specific[object.TripleFrom(*entry.Class, *entry.Subclass, objType)] = desc
+		default:
+			kind, err := kindOf(entry.Set)
+			if err != nil {
+				return Interpreters{}, fmt.Errorf("entry %d: %v", index, err)
+			}
+			sel := lvlobj.Selector{
+				Class:    anyOrKey(entry.Class),
+				Subclass: anyOrKey(entry.Subclass),
+				Type:     anyOrKey(entry.Type),
+			}
+			err = sel.Validate()
+			if err != nil {
+				return Interpreters{}, fmt.Errorf("entry %d: %v", index, err)
+			}
+			levelObjects[kind] = append(levelObjects[kind], lvlobj.Described{Selector: sel, Description: desc})
+		}
+	}
+	level, err := lvlobj.NewInterpreters(levelObjects)
+	if err != nil {
+		return Interpreters{}, err
+	}
+	return Interpreters{Level: level, Properties: objprop.NewInterpreters(generic, specific)}, nil
+}
+
+func kindOf(set string) (lvlobj.Kind, error) {
+	for _, kind := range lvlobj.Kinds() {
+		if string(kind) == set {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown set %q", set)
+}
+
+func isValidKey(key int) bool {
+	return (key >= 0) && (key <= 0xFF)
+}
+
+func keyOrAny(key int) *int {
+	if key == lvlobj.AnyKey {
+		return nil
+	}
+	return &key
+}
+
+func anyOrKey(key *int) int {
+	if key == nil {
+		return lvlobj.AnyKey
+	}
+	return *key
+}
+
+func sortedTriples(descriptions map[object.Triple]*interpreters.Description) []object.Triple {
+	triples := make([]object.Triple, 0, len(descriptions))
+	for triple := range descriptions {
+		triples = append(triples, triple)
+	}
+	sort.Slice(triples, func(a, b int) bool { return triples[a].Int() < triples[b].Int() })
+	return triples
+}
@@ -0,0 +1,129 @@
+package descriptions_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/content/archive/level/lvlobj"
+	"github.com/inkyblackness/hacked/ss1/content/descriptions"
+	"github.com/inkyblackness/hacked/ss1/content/interpreters"
+	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/content/object/objprop"
+)
+
+func TestDefaultsCanBeSavedAndLoaded(t *testing.T) {
+	defaults, err := descriptions.Defaults()
+	require.Nil(t, err, "built-in descriptions must be storable")
+	require.True(t, len(defaults.Entries) > 0)
+
+	buf := bytes.NewBuffer(nil)
+	err = descriptions.Save(buf, defaults)
+	require.Nil(t, err)
+	loaded, err := descriptions.Load(buf)
+	require.Nil(t, err)
+
+	assert.Equal(t, defaults, loaded)
+}
+
+func TestBuildOfDefaultsKeepsInterpretation(t *testing.T) {
+	triples := []object.Triple{
+		object.TripleFrom(int(object.ClassGun), 0, 0),
+		object.TripleFrom(int(object.ClassSoftware), 3, 0),
+		object.TripleFrom(int(object.ClassBigStuff), 2, 1),
+		object.TripleFrom(int(object.ClassFixture), 0, 1),
+		object.TripleFrom(int(object.ClassDoor), 1, 2),
+		object.TripleFrom(int(object.ClassTrap), 0, 0),
+		object.TripleFrom(int(object.ClassTrap), 1, 0),
+		object.TripleFrom(int(object.ClassCritter), 0, 0),
+	}
+	data := []byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x00, 0x08, 0x09, 0x0A, 0x0B,
+		0x0C, 0x0D, 0x0E, 0x0F, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19}
+	type snapshot struct {
+		keys    []string
+		refined []string
+		values  []uint32
+	}
+	take := func(inst *interpreters.Instance) snapshot {
+		var snap snapshot
+		snap.keys = inst.Keys()
+		snap.refined = inst.ActiveRefinements()
+		for _, key := range snap.keys {
+			snap.values = append(snap.values, inst.Get(key))
+		}
+		return snap
+	}
+	takeAll := func(set descriptions.Interpreters) []snapshot {
+		var all []snapshot
+		for _, triple := range triples {
+			all = append(all, take(set.Level.ForRealWorld(triple, data)))
+			all = append(all, take(set.Level.ForCyberspace(triple, data)))
+			all = append(all, take(set.Level.RealWorldExtra(triple, data)))
+			all = append(all, take(set.Level.CyberspaceExtra(triple, data)))
+			all = append(all, take(set.Properties.GenericProperties(triple.Class, data)))
+			all = append(all, take(set.Properties.SpecificProperties(triple, data)))
+		}
+		return all
+	}
+	builtin := takeAll(descriptions.Builtin())
+
+	defaults, err := descriptions.Defaults()
+	require.Nil(t, err)
+	set, err := descriptions.Build(defaults)
+	require.Nil(t, err)
+
+	assert.Equal(t, builtin, takeAll(set))
+}
+
+func TestBuildOverridesDescriptions(t *testing.T) {
+	class := int(object.ClassGun)
+	subclass := 1
+	def := interpreters.Definition{Fields: []interpreters.FieldDefinition{{Key: "Test", Start: 0, Count: 1}}}
+	file := descriptions.File{Entries: []descriptions.Entry{
+		{Set: string(lvlobj.KindRealWorld), Class: &class, Subclass: &subclass, Description: def},
+		{Set: descriptions.SetGenericProperties, Class: &class, Description: def},
+		{Set: descriptions.SetSpecificProperties, Class: &class, Subclass: &subclass, Description: def},
+	}}
+
+	set, err := descriptions.Build(file)
+	require.Nil(t, err)
+
+	triple := object.TripleFrom(class, subclass, 2)
+	assert.Equal(t, []string{"Test"}, set.Level.ForRealWorld(triple, make([]byte, 10)).Keys())
+	assert.Equal(t, []string{"Test"}, set.Properties.GenericProperties(triple.Class, make([]byte, 10)).Keys())
+	assert.Equal(t, []string{"Test"}, set.Properties.SpecificProperties(triple, make([]byte, 10)).Keys())
+
+	assert.NotEqual(t, []string{"Test"}, lvlobj.ForRealWorld(triple, make([]byte, 10)).Keys(), "built-in should be unaffected")
+	assert.NotEqual(t, []string{"Test"}, objprop.GenericProperties(triple.Class, make([]byte, 10)).Keys())
+}
+
+func TestBuildFailsForInvalidEntries(t *testing.T) {
+	class := int(object.ClassGun)
+	invalidType := 2
+	def := interpreters.Definition{Fields: []interpreters.FieldDefinition{{Key: "Test", Start: 0, Count: 1}}}
+
+	tt := []struct {
+		name  string
+		entry descriptions.Entry
+	}{
+		{name: "unknown set", entry: descriptions.Entry{Set: "something", Description: def}},
+		{name: "generic without class", entry: descriptions.Entry{Set: descriptions.SetGenericProperties, Description: def}},
+		{name: "specific without subclass", entry: descriptions.Entry{Set: descriptions.SetSpecificProperties, Class: &class, Description: def}},
+		{name: "type without subclass", entry: descriptions.Entry{Set: string(lvlobj.KindRealWorld), Class: &class, Type: &invalidType, Description: def}},
+		{name: "invalid description", entry: descriptions.Entry{Set: string(lvlobj.KindRealWorld), Class: &class,
+			Description: interpreters.Definition{Fields: []interpreters.FieldDefinition{{Key: "Test", Start: 0, Count: 0}}}}},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			file := descriptions.File{Entries: []descriptions.Entry{
+				{Set: string(lvlobj.KindRealWorld), Class: &class, Description: def},
+				td.entry,
+			}}
+			_, err := descriptions.Build(file)
+			assert.NotNil(t, err)
+		})
+	}
+}
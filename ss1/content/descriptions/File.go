@@ -0,0 +1,53 @@
+// Package descriptions handles files that contain the descriptions of object data.
+// Such files allow to share knowledge about object fields without the need to rebuild the editor.
+package descriptions
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/inkyblackness/hacked/ss1/content/interpreters"
+)
+
+// Known sets of descriptions.
+const (
+	// SetGenericProperties describes the generic properties of an object class.
+	SetGenericProperties = "genericProperties"
+	// SetSpecificProperties describes the specific properties of an object subclass or type.
+	SetSpecificProperties = "specificProperties"
+)
+
+// Entry is one description in a file, selected for a set of objects.
+// Selectors that are not specified (nil) apply to all objects of that level.
+type Entry struct {
+	Set         string                  `json:"set"`
+	Class       *int                    `json:"class,omitempty"`
+	Subclass    *int                    `json:"subclass,omitempty"`
+	Type        *int                    `json:"type,omitempty"`
+	Description interpreters.Definition `json:"description"`
+}
+
+// File is the collection of description entries.
+type File struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Load reads a file from given reader.
+// The file is expected to be in JSON format.
+func Load(reader io.Reader) (File, error) {
+	var file File
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&file)
+	if err != nil {
+		return File{}, err
+	}
+	return file, nil
+}
+
+// Save writes the given file to the writer, in JSON format.
+func Save(writer io.Writer, file File) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(file)
+}
@@ -0,0 +1,60 @@
+package font
+
+import (
+	"errors"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+// Cache retrieves fonts from a localizer and keeps them decoded until they are invalidated.
+type Cache struct {
+	localizer resource.Localizer
+
+	fonts map[resource.Key]*Font
+}
+
+// NewCache returns a new instance.
+func NewCache(localizer resource.Localizer) *Cache {
+	cache := &Cache{
+		localizer: localizer,
+		fonts:     make(map[resource.Key]*Font),
+	}
+	return cache
+}
+
+// InvalidateResources lets the cache remove any fonts from resources that are specified in the given slice.
+func (cache *Cache) InvalidateResources(ids []resource.ID) {
+	for _, id := range ids {
+		for key := range cache.fonts {
+			if key.ID == id {
+				delete(cache.fonts, key)
+			}
+		}
+	}
+}
+
+// Font tries to look up given font.
+func (cache *Cache) Font(key resource.Key) (*Font, error) {
+	f, existing := cache.fonts[key]
+	if existing {
+		return f, nil
+	}
+	selector := cache.localizer.LocalizedResources(key.Lang)
+	view, err := selector.Select(key.ID)
+	if err != nil {
+		return nil, err
+	}
+	if (view.ContentType() != resource.Font) || (view.BlockCount() != 1) {
+		return nil, errors.New("resource is not a font")
+	}
+	reader, err := view.Block(0)
+	if err != nil {
+		return nil, err
+	}
+	f, err = Decode(reader)
+	if err != nil {
+		return nil, err
+	}
+	cache.fonts[key] = f
+	return f, nil
+}
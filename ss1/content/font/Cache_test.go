@@ -0,0 +1,83 @@
+package font_test
+
+import (
+	"testing"
+
+	"github.com/inkyblackness/hacked/ss1/content/font"
+	"github.com/inkyblackness/hacked/ss1/resource"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const fontResourceID = 0x025E
+
+type CacheSuite struct {
+	suite.Suite
+
+	localizedResources resource.LocalizedResourcesList
+
+	instance *font.Cache
+}
+
+func TestCacheSuite(t *testing.T) {
+	suite.Run(t, new(CacheSuite))
+}
+
+func (suite *CacheSuite) SetupTest() {
+	suite.instance = font.NewCache(suite)
+}
+
+func (suite *CacheSuite) TestFontReturnsDecodedFont() {
+	suite.givenResourcesAre(suite.storing(resource.Font, someFontData(2, 3)))
+	f, err := suite.instance.Font(suite.key())
+	require.Nil(suite.T(), err, "No error expected")
+	assert.Equal(suite.T(), 3, f.Height)
+}
+
+func (suite *CacheSuite) TestFontReturnsErrorIfResourceIsNotAFont() {
+	suite.givenResourcesAre(suite.storing(resource.Sound, someFontData(2, 3)))
+	_, err := suite.instance.Font(suite.key())
+	assert.NotNil(suite.T(), err, "Error expected")
+}
+
+func (suite *CacheSuite) TestFontTriesToReloadWhenCacheInvalidated() {
+	suite.givenResourcesAre(suite.storing(resource.Font, someFontData(2, 3)))
+	_, err := suite.instance.Font(suite.key())
+	require.Nil(suite.T(), err, "No error expected setting up")
+	suite.givenResourcesAre()
+	suite.instance.InvalidateResources([]resource.ID{fontResourceID})
+	_, err = suite.instance.Font(suite.key())
+	assert.NotNil(suite.T(), err, "Error expected")
+}
+
+func (suite *CacheSuite) givenResourcesAre(modifiers ...func(*resource.Store)) {
+	var store resource.Store
+	for _, modifier := range modifiers {
+		modifier(&store)
+	}
+	suite.localizedResources = resource.LocalizedResourcesList{
+		{ID: "unnamed", Language: resource.LangAny, Viewer: store},
+	}
+}
+
+func (suite *CacheSuite) storing(contentType resource.ContentType, data []byte) func(*resource.Store) {
+	return func(store *resource.Store) {
+		_ = store.Put(fontResourceID, resource.Resource{
+			Properties: resource.Properties{ContentType: contentType},
+			Blocks:     resource.BlocksFrom([][]byte{data}),
+		})
+	}
+}
+
+func (suite *CacheSuite) key() resource.Key {
+	return resource.KeyOf(fontResourceID, resource.LangAny, 0)
+}
+
+func (suite *CacheSuite) LocalizedResources(lang resource.Language) resource.Selector {
+	return resource.Selector{
+		From: suite.localizedResources,
+		Lang: lang,
+	}
+}
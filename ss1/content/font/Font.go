@@ -0,0 +1,179 @@
+package font
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+)
+
+// Type describes how the glyphs of a font are stored.
+type Type uint16
+
+// Type constants
+const (
+	// TypeMonochrome fonts have one bit per pixel, with the highest bit of each byte being the left-most pixel.
+	// Set bits are drawn in a color specified at runtime.
+	TypeMonochrome Type = 0x0000
+	// TypeColor fonts have one palette index per pixel. Pixel with index 0x00 are transparent.
+	TypeColor Type = 0xCCCC
+)
+
+type header struct {
+	Type           Type
+	_              [34]byte
+	FirstCharacter int16
+	LastCharacter  int16
+	_              [32]byte
+	OffsetTable    int32
+	BitmapOffset   int32
+	Stride         int16
+	Height         int16
+}
+
+// Font describes the glyphs of all characters of a font.
+// The glyphs are stored side by side in one bitmap, with an offset table describing the horizontal start of each.
+type Font struct {
+	// Type specifies the layout of the bitmap.
+	Type Type
+	// FirstCharacter is the code of the first character provided by the font.
+	FirstCharacter int
+	// LastCharacter is the code of the last character provided by the font.
+	LastCharacter int
+	// Offsets contains the horizontal start of each glyph in the bitmap, plus one entry for the end of the last.
+	Offsets []int
+	// Stride is the amount of bytes per row of the bitmap.
+	Stride int
+	// Height of the bitmap, and the glyphs, in pixel.
+	Height int
+	// Bitmap contains the pixel data of all glyphs.
+	Bitmap []byte
+}
+
+// Decode reads a font from given reader.
+func Decode(reader io.Reader) (*Font, error) {
+	if reader == nil {
+		return nil, errors.New("reader is nil")
+	}
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	var head header
+	err = binary.Read(bytes.NewReader(data), binary.LittleEndian, &head)
+	if err != nil {
+		return nil, err
+	}
+	if (head.Type != TypeMonochrome) && (head.Type != TypeColor) {
+		return nil, errors.New("unknown font type")
+	}
+	if (head.FirstCharacter < 0) || (head.LastCharacter < head.FirstCharacter) || (head.LastCharacter > 0xFF) {
+		return nil, errors.New("invalid character range")
+	}
+	if (head.Stride < 0) || (head.Height < 0) {
+		return nil, errors.New("invalid bitmap size")
+	}
+	f := &Font{
+		Type:           head.Type,
+		FirstCharacter: int(head.FirstCharacter),
+		LastCharacter:  int(head.LastCharacter),
+		Stride:         int(head.Stride),
+		Height:         int(head.Height),
+	}
+	offsetCount := f.LastCharacter - f.FirstCharacter + 2
+	offsetEnd := int(head.OffsetTable) + offsetCount*2
+	if (head.OffsetTable < 0) || (offsetEnd > len(data)) {
+		return nil, errors.New("offset table out of range")
+	}
+	f.Offsets = make([]int, offsetCount)
+	for index := range f.Offsets {
+		start := int(head.OffsetTable) + index*2
+		f.Offsets[index] = int(binary.LittleEndian.Uint16(data[start : start+2]))
+	}
+	bitmapEnd := int(head.BitmapOffset) + f.Stride*f.Height
+	if (head.BitmapOffset < 0) || (bitmapEnd > len(data)) {
+		return nil, errors.New("bitmap out of range")
+	}
+	f.Bitmap = data[head.BitmapOffset:bitmapEnd]
+	for index := 1; index < len(f.Offsets); index++ {
+		if (f.Offsets[index] < f.Offsets[index-1]) || (f.Offsets[index] > f.bitmapWidth()) {
+			return nil, errors.New("invalid glyph offsets")
+		}
+	}
+	return f, nil
+}
+
+func (f *Font) bitmapWidth() int {
+	if f.Type == TypeMonochrome {
+		return f.Stride * 8
+	}
+	return f.Stride
+}
+
+// GlyphWidth returns the width of the glyph of given character. Characters not provided by the font have no width.
+func (f *Font) GlyphWidth(ch byte) int {
+	index := int(ch) - f.FirstCharacter
+	if (index < 0) || (int(ch) > f.LastCharacter) {
+		return 0
+	}
+	return f.Offsets[index+1] - f.Offsets[index]
+}
+
+// Width returns the width of the given encoded text. The text ends at the first 0x00 byte.
+func (f *Font) Width(encoded []byte) int {
+	width := 0
+	for _, ch := range encoded {
+		if ch == 0x00 {
+			break
+		}
+		width += f.GlyphWidth(ch)
+	}
+	return width
+}
+
+// Draw renders the encoded text into the given pixel buffer, starting at the provided top-left position.
+// Pixel outside the buffer are clipped. Monochrome fonts use the given color, color fonts their own pixel values.
+// Returns the horizontal position following the text.
+func (f *Font) Draw(pixels []byte, stride int, x, y int, encoded []byte, color byte) int {
+	height := 0
+	if stride > 0 {
+		height = len(pixels) / stride
+	}
+	for _, ch := range encoded {
+		if ch == 0x00 {
+			break
+		}
+		glyphWidth := f.GlyphWidth(ch)
+		if glyphWidth > 0 {
+			glyphStart := f.Offsets[int(ch)-f.FirstCharacter]
+			for glyphY := 0; glyphY < f.Height; glyphY++ {
+				targetY := y + glyphY
+				if (targetY < 0) || (targetY >= height) {
+					continue
+				}
+				for glyphX := 0; glyphX < glyphWidth; glyphX++ {
+					targetX := x + glyphX
+					if (targetX < 0) || (targetX >= stride) {
+						continue
+					}
+					if value := f.pixel(glyphStart+glyphX, glyphY, color); value != 0x00 {
+						pixels[targetY*stride+targetX] = value
+					}
+				}
+			}
+		}
+		x += glyphWidth
+	}
+	return x
+}
+
+func (f *Font) pixel(x, y int, color byte) byte {
+	if f.Type == TypeMonochrome {
+		if (f.Bitmap[y*f.Stride+x/8] & (0x80 >> uint(x%8))) != 0 {
+			return color
+		}
+		return 0x00
+	}
+	return f.Bitmap[y*f.Stride+x]
+}
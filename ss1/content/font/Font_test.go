@@ -0,0 +1,100 @@
+package font_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/inkyblackness/hacked/ss1/content/font"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// someFontData creates a color font for the printable ASCII range, with each glyph being
+// glyphWidth pixel wide and filled with its character code.
+func someFontData(glyphWidth, height int) []byte {
+	first, last := 0x20, 0x7E
+	count := last - first + 1
+	stride := count * glyphWidth
+	offsetTable := 84
+	bitmapOffset := offsetTable + (count+1)*2
+
+	data := make([]byte, bitmapOffset+stride*height)
+	binary.LittleEndian.PutUint16(data[0x00:], uint16(font.TypeColor))
+	binary.LittleEndian.PutUint16(data[0x24:], uint16(first))
+	binary.LittleEndian.PutUint16(data[0x26:], uint16(last))
+	binary.LittleEndian.PutUint32(data[0x48:], uint32(offsetTable))
+	binary.LittleEndian.PutUint32(data[0x4C:], uint32(bitmapOffset))
+	binary.LittleEndian.PutUint16(data[0x50:], uint16(stride))
+	binary.LittleEndian.PutUint16(data[0x52:], uint16(height))
+	for index := 0; index <= count; index++ {
+		binary.LittleEndian.PutUint16(data[offsetTable+index*2:], uint16(index*glyphWidth))
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < stride; x++ {
+			data[bitmapOffset+y*stride+x] = byte(first + x/glyphWidth)
+		}
+	}
+	return data
+}
+
+func someFont(t *testing.T, glyphWidth, height int) *font.Font {
+	f, err := font.Decode(bytes.NewReader(someFontData(glyphWidth, height)))
+	require.Nil(t, err, "no error expected decoding font")
+	return f
+}
+
+func TestDecodeReadsHeaderAndBitmap(t *testing.T) {
+	f := someFont(t, 2, 3)
+	assert.Equal(t, font.TypeColor, f.Type)
+	assert.Equal(t, 0x20, f.FirstCharacter)
+	assert.Equal(t, 0x7E, f.LastCharacter)
+	assert.Equal(t, 3, f.Height)
+	assert.Equal(t, 95*2, f.Stride)
+	assert.Equal(t, 96, len(f.Offsets))
+}
+
+func TestDecodeReturnsErrorForInvalidData(t *testing.T) {
+	tt := []struct {
+		name   string
+		modify func(data []byte) []byte
+	}{
+		{"truncated header", func(data []byte) []byte { return data[:20] }},
+		{"unknown type", func(data []byte) []byte {
+			binary.LittleEndian.PutUint16(data[0x00:], 0x1234)
+			return data
+		}},
+		{"inverted range", func(data []byte) []byte {
+			binary.LittleEndian.PutUint16(data[0x26:], 0x10)
+			return data
+		}},
+		{"truncated bitmap", func(data []byte) []byte { return data[:len(data)-1] }},
+		{"glyph out of bitmap", func(data []byte) []byte {
+			binary.LittleEndian.PutUint16(data[84+95*2:], 0x7FFF)
+			return data
+		}},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			_, err := font.Decode(bytes.NewReader(td.modify(someFontData(2, 3))))
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestWidthSumsGlyphsUntilTerminator(t *testing.T) {
+	f := someFont(t, 3, 2)
+	assert.Equal(t, 9, f.Width([]byte("abc")))
+	assert.Equal(t, 6, f.Width([]byte{'a', 'b', 0x00, 'c'}))
+	assert.Equal(t, 0, f.GlyphWidth(0x10), "characters outside range should have no width")
+}
+
+func TestDrawUsesGlyphPixelsAndClips(t *testing.T) {
+	f := someFont(t, 1, 2)
+	pixels := make([]byte, 3*2)
+	next := f.Draw(pixels, 3, 1, 1, []byte("ab"), 0xFF)
+	assert.Equal(t, 3, next)
+	assert.Equal(t, []byte{0, 0, 0, 0, 'a', 'b'}, pixels)
+}
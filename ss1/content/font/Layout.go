@@ -0,0 +1,155 @@
+package font
+
+// Display describes an area of the screen in which the game shows texts.
+type Display struct {
+	// Title is the name of the display.
+	Title string
+	// Width of the display in pixel.
+	Width int
+	// Height of the display in pixel.
+	Height int
+	// LineSpacing is the amount of pixel between two lines.
+	LineSpacing int
+	// MaxPages limits how many pages the game shows. Zero for no limit.
+	MaxPages int
+}
+
+// Displays of the game, with their dimensions in the low resolution mode.
+var (
+	// MessageDisplay is the main view area, which shows the texts of messages.
+	MessageDisplay = Display{Title: "Message", Width: 268, Height: 108, LineSpacing: 1}
+	// MFDDisplay is the area of one multi-function display, which shows short texts and papers.
+	MFDDisplay = Display{Title: "MFD", Width: 73, Height: 61, LineSpacing: 1}
+	// MessageLineDisplay is the line at the top of the main view area, which shows short notifications.
+	MessageLineDisplay = Display{Title: "Message Line", Width: 268, Height: 8, LineSpacing: 0, MaxPages: 1}
+)
+
+// Line is one line of encoded text, to be drawn in a color.
+type Line struct {
+	Text  []byte
+	Color byte
+}
+
+// Layout is the result of distributing text onto the pages of a display.
+type Layout struct {
+	// Pages contains the lines of each page.
+	Pages [][]Line
+	// BrokenWords is set if at least one word was too long for a line and had to be broken.
+	BrokenWords bool
+}
+
+// Overflows returns true if the layout does not fit into the display, or words had to be broken.
+func (layout Layout) Overflows(display Display) bool {
+	return layout.BrokenWords || ((display.MaxPages > 0) && (len(layout.Pages) > display.MaxPages))
+}
+
+// LinesPerPage returns how many lines of the given font fit onto one page of the display. At least one.
+func (display Display) LinesPerPage(f *Font) int {
+	lineHeight := f.Height + display.LineSpacing
+	lines := 1
+	if lineHeight > 0 {
+		lines = (display.Height + display.LineSpacing) / lineHeight
+	}
+	if lines < 1 {
+		lines = 1
+	}
+	return lines
+}
+
+// Paragraph is a text that starts on a new line, to be drawn in one color.
+type Paragraph struct {
+	Text  []byte
+	Color byte
+}
+
+// Arrange wraps the given paragraphs to the width of the display and distributes the lines onto pages.
+func (display Display) Arrange(f *Font, paragraphs ...Paragraph) Layout {
+	var layout Layout
+	var lines []Line
+	for _, paragraph := range paragraphs {
+		wrapped, broken := Wrap(f, paragraph.Text, display.Width)
+		layout.BrokenWords = layout.BrokenWords || broken
+		for _, text := range wrapped {
+			lines = append(lines, Line{Text: text, Color: paragraph.Color})
+		}
+	}
+	linesPerPage := display.LinesPerPage(f)
+	for len(lines) > 0 {
+		count := linesPerPage
+		if count > len(lines) {
+			count = len(lines)
+		}
+		layout.Pages = append(layout.Pages, lines[:count])
+		lines = lines[count:]
+	}
+	return layout
+}
+
+// Render draws the given lines into a new pixel buffer of the size of the display.
+// Pixel not covered by text have the value 0x00.
+func (display Display) Render(f *Font, lines []Line) []byte {
+	pixels := make([]byte, display.Width*display.Height)
+	y := 0
+	for _, line := range lines {
+		f.Draw(pixels, display.Width, 0, y, line.Text, line.Color)
+		y += f.Height + display.LineSpacing
+	}
+	return pixels
+}
+
+// Wrap splits the given encoded text into lines that fit into the given width.
+// Lines are broken at spaces and newline characters. Words that are wider than a line are broken
+// at the last fitting character, which is reported by the second return value.
+func Wrap(f *Font, encoded []byte, width int) (lines [][]byte, brokenWords bool) {
+	text := encoded
+	for index, ch := range text {
+		if ch == 0x00 {
+			text = text[:index]
+			break
+		}
+	}
+	start := 0
+	for start <= len(text) {
+		end := start
+		for (end < len(text)) && (text[end] != '\n') {
+			end++
+		}
+		paragraphLines, broken := wrapParagraph(f, text[start:end], width)
+		lines = append(lines, paragraphLines...)
+		brokenWords = brokenWords || broken
+		start = end + 1
+	}
+	return
+}
+
+func wrapParagraph(f *Font, text []byte, width int) (lines [][]byte, brokenWords bool) {
+	for {
+		if f.Width(text) <= width {
+			return append(lines, text), brokenWords
+		}
+		lastSpace := -1
+		fitting := 0
+		lineWidth := 0
+		for index, ch := range text {
+			if ch == ' ' {
+				lastSpace = index
+			}
+			lineWidth += f.GlyphWidth(ch)
+			if lineWidth > width {
+				break
+			}
+			fitting = index + 1
+		}
+		if lastSpace >= 0 {
+			lines = append(lines, text[:lastSpace])
+			text = text[lastSpace+1:]
+		} else {
+			if fitting == 0 {
+				fitting = 1
+			}
+			brokenWords = true
+			lines = append(lines, text[:fitting])
+			text = text[fitting:]
+		}
+	}
+}
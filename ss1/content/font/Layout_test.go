@@ -0,0 +1,60 @@
+package font_test
+
+import (
+	"testing"
+
+	"github.com/inkyblackness/hacked/ss1/content/font"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func linesAsStrings(lines [][]byte) []string {
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, string(line))
+	}
+	return result
+}
+
+func TestWrapBreaksAtSpacesAndNewlines(t *testing.T) {
+	f := someFont(t, 1, 1)
+	lines, broken := font.Wrap(f, []byte("aaa bbb ccc\ndd"), 7)
+	assert.Equal(t, []string{"aaa bbb", "ccc", "dd"}, linesAsStrings(lines))
+	assert.False(t, broken)
+}
+
+func TestWrapBreaksTooLongWords(t *testing.T) {
+	f := someFont(t, 1, 1)
+	lines, broken := font.Wrap(f, []byte("abcdefgh"), 3)
+	assert.Equal(t, []string{"abc", "def", "gh"}, linesAsStrings(lines))
+	assert.True(t, broken)
+}
+
+func TestArrangeDistributesLinesOntoPages(t *testing.T) {
+	f := someFont(t, 1, 2)
+	display := font.Display{Width: 3, Height: 5, LineSpacing: 1}
+	layout := display.Arrange(f,
+		font.Paragraph{Text: []byte("ab"), Color: 1},
+		font.Paragraph{Text: []byte("cd ef"), Color: 2})
+	assert.Equal(t, 2, len(layout.Pages))
+	assert.Equal(t, []font.Line{{Text: []byte("ab"), Color: 1}, {Text: []byte("cd"), Color: 2}}, layout.Pages[0])
+	assert.Equal(t, []font.Line{{Text: []byte("ef"), Color: 2}}, layout.Pages[1])
+}
+
+func TestLayoutOverflowsWhenExceedingMaxPages(t *testing.T) {
+	f := someFont(t, 1, 8)
+	display := font.MessageLineDisplay
+	assert.False(t, display.Arrange(f, font.Paragraph{Text: []byte("short")}).Overflows(display))
+	long := make([]byte, 0, 600)
+	for len(long) < 590 {
+		long = append(long, []byte("words ")...)
+	}
+	assert.True(t, display.Arrange(f, font.Paragraph{Text: long}).Overflows(display))
+}
+
+func TestRenderDrawsLinesBelowEachOther(t *testing.T) {
+	f := someFont(t, 1, 1)
+	display := font.Display{Width: 2, Height: 3, LineSpacing: 1}
+	pixels := display.Render(f, []font.Line{{Text: []byte("ab")}, {Text: []byte("c")}})
+	assert.Equal(t, []byte{'a', 'b', 0, 0, 'c', 0}, pixels)
+}
@@ -0,0 +1,5 @@
+// Package font provides the fonts of the game and the layout of texts with them.
+//
+// Fonts work on encoded texts: Each byte of a text selects the glyph of the same character code.
+// A font therefore has to match the codepage the texts are encoded in.
+package font
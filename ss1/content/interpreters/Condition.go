@@ -0,0 +1,53 @@
+package interpreters
+
+import "strings"
+
+// Condition is the storable form of a predicate. An empty condition is always true.
+type Condition struct {
+	// Field is the key of the field to check. Fields of refinements are addressed with a dot, as in "Action.Type".
+	Field string `json:"field,omitempty"`
+	// Values lists the values the field is compared with. The condition is true if the field has any of them.
+	Values []uint32 `json:"values,omitempty"`
+	// Not inverts the comparison of the field.
+	Not bool `json:"not,omitempty"`
+	// All lists further conditions that must be true as well.
+	All []Condition `json:"all,omitempty"`
+}
+
+// FieldIs returns a condition that is true if the given field has any of the given values.
+func FieldIs(field string, values ...uint32) Condition {
+	return Condition{Field: field, Values: values}
+}
+
+// FieldIsNot returns a condition that is true if the given field has none of the given values.
+func FieldIsNot(field string, values ...uint32) Condition {
+	return Condition{Field: field, Values: values, Not: true}
+}
+
+// AllOf returns a condition that is true if all of the given conditions are true.
+func AllOf(conditions ...Condition) Condition {
+	return Condition{All: conditions}
+}
+
+// Matches returns true if the condition is fulfilled by the given instance.
+func (cond Condition) Matches(inst *Instance) bool {
+	for _, sub := range cond.All {
+		if !sub.Matches(inst) {
+			return false
+		}
+	}
+	if len(cond.Field) == 0 {
+		return true
+	}
+	path := strings.Split(cond.Field, ".")
+	target := inst
+	for _, key := range path[:len(path)-1] {
+		target = target.Refined(key)
+	}
+	value := target.Get(path[len(path)-1])
+	found := false
+	for _, candidate := range cond.Values {
+		found = found || (candidate == value)
+	}
+	return found != cond.Not
+}
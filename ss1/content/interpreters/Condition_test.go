@@ -0,0 +1,40 @@
+package interpreters_test
+
+import (
+	"testing"
+
+	"github.com/inkyblackness/hacked/ss1/content/interpreters"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditionMatches(t *testing.T) {
+	sub := interpreters.New().With("Type", 0, 1)
+	desc := interpreters.New().With("Kind", 0, 1).Refining("Action", 1, 1, sub, interpreters.Always)
+	inst := desc.For([]byte{0x02, 0x05})
+
+	tt := []struct {
+		name      string
+		condition interpreters.Condition
+		expected  bool
+	}{
+		{name: "empty", condition: interpreters.Condition{}, expected: true},
+		{name: "single value", condition: interpreters.FieldIs("Kind", 2), expected: true},
+		{name: "other value", condition: interpreters.FieldIs("Kind", 1), expected: false},
+		{name: "any value", condition: interpreters.FieldIs("Kind", 1, 2, 3), expected: true},
+		{name: "not", condition: interpreters.FieldIsNot("Kind", 2), expected: false},
+		{name: "not other", condition: interpreters.FieldIsNot("Kind", 0), expected: true},
+		{name: "refined", condition: interpreters.FieldIs("Action.Type", 5), expected: true},
+		{name: "unknown field", condition: interpreters.FieldIs("Unknown", 0), expected: true},
+		{name: "all", condition: interpreters.AllOf(interpreters.FieldIs("Kind", 2), interpreters.FieldIs("Action.Type", 5)),
+			expected: true},
+		{name: "all failing", condition: interpreters.AllOf(interpreters.FieldIs("Kind", 2), interpreters.FieldIs("Action.Type", 4)),
+			expected: false},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			assert.Equal(t, td.expected, td.condition.Matches(inst))
+		})
+	}
+}
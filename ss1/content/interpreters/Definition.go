@@ -0,0 +1,205 @@
+package interpreters
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// RangeKind identifies the type of a field range in a definition.
+type RangeKind string
+
+// Known range kinds.
+const (
+	RangeKindRanged   RangeKind = "ranged"
+	RangeKindEnum     RangeKind = "enum"
+	RangeKindBitfield RangeKind = "bitfield"
+	RangeKindObjectID RangeKind = "objectID"
+	RangeKindSpecial  RangeKind = "special"
+)
+
+// Definition is the storable form of a Description.
+type Definition struct {
+	Fields      []FieldDefinition      `json:"fields,omitempty"`
+	Refinements []RefinementDefinition `json:"refinements,omitempty"`
+}
+
+// FieldDefinition is the storable form of one field.
+type FieldDefinition struct {
+	Key   string           `json:"key"`
+	Start int              `json:"start"`
+	Count int              `json:"count"`
+	Range *RangeDefinition `json:"range,omitempty"`
+}
+
+// RangeDefinition is the storable form of a FieldRange.
+// Which of the properties are used depends on the kind.
+type RangeDefinition struct {
+	Kind    RangeKind         `json:"kind"`
+	Min     int64             `json:"min,omitempty"`
+	Max     int64             `json:"max,omitempty"`
+	Format  *ValueFormat      `json:"format,omitempty"`
+	Values  map[uint32]string `json:"values,omitempty"`
+	Special string            `json:"special,omitempty"`
+}
+
+// RefinementDefinition is the storable form of a refinement.
+// Without a condition, the refinement is always active.
+type RefinementDefinition struct {
+	Key         string     `json:"key"`
+	Start       int        `json:"start"`
+	Count       int        `json:"count"`
+	Description Definition `json:"description"`
+	When        *Condition `json:"when,omitempty"`
+}
+
+// Build creates a description from given definition.
+func Build(def Definition) (*Description, error) {
+	desc := New()
+	known := make(map[string]bool)
+	for _, field := range def.Fields {
+		if known[field.Key] {
+			return nil, fmt.Errorf("duplicate field %q", field.Key)
+		}
+		known[field.Key] = true
+		if (field.Start < 0) || (field.Count < 1) {
+			return nil, fmt.Errorf("field %q has invalid position", field.Key)
+		}
+		desc = desc.With(field.Key, field.Start, field.Count)
+		if field.Range != nil {
+			fieldRange, err := buildRange(*field.Range)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %v", field.Key, err)
+			}
+			desc = desc.As(fieldRange)
+		}
+	}
+	for _, ref := range def.Refinements {
+		if _, existing := desc.refinements[ref.Key]; existing {
+			return nil, fmt.Errorf("duplicate refinement %q", ref.Key)
+		}
+		if (ref.Start < 0) || (ref.Count < 1) {
+			return nil, fmt.Errorf("refinement %q has invalid position", ref.Key)
+		}
+		refined, err := Build(ref.Description)
+		if err != nil {
+			return nil, fmt.Errorf("refinement %q: %v", ref.Key, err)
+		}
+		if ref.When != nil {
+			desc = desc.RefiningWhen(ref.Key, ref.Start, ref.Count, refined, *ref.When)
+		} else {
+			desc = desc.Refining(ref.Key, ref.Start, ref.Count, refined, Always)
+		}
+	}
+	return desc, nil
+}
+
+func buildRange(def RangeDefinition) (FieldRange, error) {
+	switch def.Kind {
+	case RangeKindRanged:
+		if def.Format != nil {
+			return RangedValueWithFormat(def.Min, def.Max, *def.Format), nil
+		}
+		return RangedValue(def.Min, def.Max), nil
+	case RangeKindEnum:
+		return EnumValue(def.Values), nil
+	case RangeKindBitfield:
+		return Bitfield(def.Values), nil
+	case RangeKindObjectID:
+		return ObjectID(), nil
+	case RangeKindSpecial:
+		return SpecialValue(def.Special), nil
+	default:
+		return nil, fmt.Errorf("unknown range kind %q", def.Kind)
+	}
+}
+
+// Definition returns the storable form of the description.
+// Descriptions that use arbitrary predicates or formatting functions can not be stored
+// and result in an error.
+func (desc *Description) Definition() (Definition, error) {
+	var def Definition
+	for _, key := range sortedKeys(desc.fields) {
+		e := desc.fields[key]
+		field := FieldDefinition{Key: key, Start: e.start, Count: e.count}
+		if e.via != nil {
+			fieldRange, err := rangeDefinitionOf(e.via)
+			if err != nil {
+				return Definition{}, fmt.Errorf("field %q: %v", key, err)
+			}
+			field.Range = &fieldRange
+		}
+		def.Fields = append(def.Fields, field)
+	}
+	refinementEntries := make(map[string]*entry)
+	for key, r := range desc.refinements {
+		refinementEntries[key] = &r.entry
+	}
+	for _, key := range sortedKeys(refinementEntries) {
+		r := desc.refinements[key]
+		refined, err := r.desc.Definition()
+		if err != nil {
+			return Definition{}, fmt.Errorf("refinement %q: %v", key, err)
+		}
+		ref := RefinementDefinition{Key: key, Start: r.start, Count: r.count, Description: refined}
+		if r.condition != nil {
+			condition := *r.condition
+			ref.When = &condition
+		} else if !isSameFunc(r.predicate, Always) {
+			return Definition{}, fmt.Errorf("refinement %q: predicate can not be stored", key)
+		}
+		def.Refinements = append(def.Refinements, ref)
+	}
+	return def, nil
+}
+
+func rangeDefinitionOf(fieldRange FieldRange) (RangeDefinition, error) {
+	var def RangeDefinition
+	var format *ValueFormat
+	var err error
+	simpl := NewSimplifier(func(minValue, maxValue int64, formatter RawValueFormatter) {
+		def = RangeDefinition{Kind: RangeKindRanged, Min: minValue, Max: maxValue, Format: format}
+		if (format == nil) && !isSameFunc(formatter, basicToString) {
+			err = fmt.Errorf("value formatter can not be stored")
+		}
+	})
+	simpl.formatHandler = func(valueFormat ValueFormat) {
+		format = &valueFormat
+	}
+	simpl.SetEnumValueHandler(func(values map[uint32]string) {
+		def = RangeDefinition{Kind: RangeKindEnum, Values: values}
+	})
+	simpl.SetBitfieldHandler(func(values map[uint32]string) {
+		def = RangeDefinition{Kind: RangeKindBitfield, Values: values}
+	})
+	simpl.SetObjectIDHandler(func() {
+		def = RangeDefinition{Kind: RangeKindObjectID}
+	})
+	simpl.anySpecialHandler = func(specialType string) {
+		def = RangeDefinition{Kind: RangeKindSpecial, Special: specialType}
+	}
+	if !fieldRange(simpl) || (len(def.Kind) == 0) {
+		return RangeDefinition{}, fmt.Errorf("range can not be stored")
+	}
+	return def, err
+}
+
+func isSameFunc(a, b interface{}) bool {
+	return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+}
+
+func sortedKeys(entries map[string]*entry) []string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		entryA := entries[keys[a]]
+		entryB := entries[keys[b]]
+		if entryA.start != entryB.start {
+			return entryA.start < entryB.start
+		}
+		return keys[a] < keys[b]
+	})
+	return keys
+}
@@ -0,0 +1,127 @@
+package interpreters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/inkyblackness/hacked/ss1/content/interpreters"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefinitionOfDescriptionContainsFieldsSortedByStart(t *testing.T) {
+	desc := interpreters.New().
+		With("second", 2, 2).As(interpreters.RangedValue(1, 100)).
+		With("first", 0, 1).As(interpreters.EnumValue(map[uint32]string{0: "zero"})).
+		With("third", 4, 1)
+
+	def, err := desc.Definition()
+	require.Nil(t, err)
+	assert.Equal(t, []interpreters.FieldDefinition{
+		{Key: "first", Start: 0, Count: 1,
+			Range: &interpreters.RangeDefinition{Kind: interpreters.RangeKindEnum, Values: map[uint32]string{0: "zero"}}},
+		{Key: "second", Start: 2, Count: 2,
+			Range: &interpreters.RangeDefinition{Kind: interpreters.RangeKindRanged, Min: 1, Max: 100}},
+		{Key: "third", Start: 4, Count: 1},
+	}, def.Fields)
+}
+
+func TestDefinitionOfDescriptionContainsValueFormats(t *testing.T) {
+	format := interpreters.ValueFormat{Scale: 0.5, Precision: 2, Unit: " sec"}
+	desc := interpreters.New().With("time", 0, 1).As(interpreters.RangedValueWithFormat(0, 255, format))
+
+	def, err := desc.Definition()
+	require.Nil(t, err)
+	require.Equal(t, 1, len(def.Fields))
+	require.NotNil(t, def.Fields[0].Range)
+	assert.Equal(t, &format, def.Fields[0].Range.Format)
+}
+
+func TestDefinitionOfDescriptionFailsForFormatterFunctions(t *testing.T) {
+	desc := interpreters.New().With("time", 0, 1).As(interpreters.FormattedRangedValue(0, 255,
+		func(value int) string { return "custom" }))
+
+	_, err := desc.Definition()
+	assert.NotNil(t, err)
+}
+
+func TestDefinitionOfDescriptionContainsRefinementConditions(t *testing.T) {
+	sub := interpreters.New().With("inner", 0, 1).As(interpreters.SpecialValue("Unknown"))
+	desc := interpreters.New().With("selector", 0, 1).
+		Refining("always", 1, 2, sub, interpreters.Always).
+		RefiningWhen("conditional", 1, 2, sub, interpreters.FieldIs("selector", 1, 2))
+
+	def, err := desc.Definition()
+	require.Nil(t, err)
+	require.Equal(t, 2, len(def.Refinements))
+	assert.Equal(t, "always", def.Refinements[0].Key)
+	assert.Nil(t, def.Refinements[0].When)
+	assert.Equal(t, "conditional", def.Refinements[1].Key)
+	assert.Equal(t, &interpreters.Condition{Field: "selector", Values: []uint32{1, 2}}, def.Refinements[1].When)
+	assert.Equal(t, interpreters.RangeKindSpecial, def.Refinements[1].Description.Fields[0].Range.Kind)
+}
+
+func TestDefinitionOfDescriptionFailsForPredicateFunctions(t *testing.T) {
+	desc := interpreters.New().Refining("sub", 0, 1, interpreters.New(),
+		func(inst *interpreters.Instance) bool { return true })
+
+	_, err := desc.Definition()
+	assert.NotNil(t, err)
+}
+
+func TestBuildCreatesEquivalentDescription(t *testing.T) {
+	sub := interpreters.New().With("inner", 0, 1).As(interpreters.Bitfield(map[uint32]string{0x01: "flag"}))
+	original := interpreters.New().
+		With("selector", 0, 1).As(interpreters.ObjectID()).
+		With("time", 1, 1).As(interpreters.RangedValueWithFormat(0, 10, interpreters.ValueFormat{Unit: " sec"})).
+		RefiningWhen("sub", 2, 1, sub, interpreters.FieldIsNot("selector", 0))
+
+	def, err := original.Definition()
+	require.Nil(t, err)
+	encoded, err := json.Marshal(def)
+	require.Nil(t, err)
+	var decoded interpreters.Definition
+	err = json.Unmarshal(encoded, &decoded)
+	require.Nil(t, err)
+	built, err := interpreters.Build(decoded)
+	require.Nil(t, err)
+
+	rebuiltDef, err := built.Definition()
+	require.Nil(t, err)
+	assert.Equal(t, def, rebuiltDef)
+
+	inst := built.For([]byte{0x00, 0x02, 0x01})
+	assert.Equal(t, []string{"selector", "time"}, inst.Keys())
+	assert.Equal(t, uint32(2), inst.Get("time"))
+	assert.Equal(t, 0, len(inst.ActiveRefinements()))
+	inst.Set("selector", 5)
+	assert.Equal(t, []string{"sub"}, inst.ActiveRefinements())
+	assert.Equal(t, uint32(1), inst.Refined("sub").Get("inner"))
+}
+
+func TestBuildFailsForInvalidDefinitions(t *testing.T) {
+	tt := []struct {
+		name string
+		def  interpreters.Definition
+	}{
+		{name: "duplicate field", def: interpreters.Definition{Fields: []interpreters.FieldDefinition{
+			{Key: "a", Start: 0, Count: 1}, {Key: "a", Start: 1, Count: 1}}}},
+		{name: "empty field", def: interpreters.Definition{Fields: []interpreters.FieldDefinition{
+			{Key: "a", Start: 0, Count: 0}}}},
+		{name: "unknown kind", def: interpreters.Definition{Fields: []interpreters.FieldDefinition{
+			{Key: "a", Start: 0, Count: 1, Range: &interpreters.RangeDefinition{Kind: "something"}}}}},
+		{name: "duplicate refinement", def: interpreters.Definition{Refinements: []interpreters.RefinementDefinition{
+			{Key: "a", Start: 0, Count: 1}, {Key: "a", Start: 1, Count: 1}}}},
+		{name: "invalid nested", def: interpreters.Definition{Refinements: []interpreters.RefinementDefinition{
+			{Key: "a", Start: 0, Count: 1, Description: interpreters.Definition{Fields: []interpreters.FieldDefinition{
+				{Key: "b", Start: -1, Count: 1}}}}}}},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			_, err := interpreters.Build(td.def)
+			assert.NotNil(t, err)
+		})
+	}
+}
@@ -84,3 +84,11 @@ func (desc *Description) Refining(key string, byteStart int, byteCount int, refi
 
 	return cloned
 }
+
+// RefiningWhen is similar to Refining, with the predicate given as a condition.
+// In contrast to an arbitrary predicate, a condition can be stored as part of a Definition.
+func (desc *Description) RefiningWhen(key string, byteStart int, byteCount int, refined *Description, condition Condition) *Description {
+	cloned := desc.Refining(key, byteStart, byteCount, refined, condition.Matches)
+	cloned.refinements[key].condition = &condition
+	return cloned
+}
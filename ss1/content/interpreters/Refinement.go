@@ -5,4 +5,5 @@ type refinement struct {
 
 	desc      *Description
 	predicate Predicate
+	condition *Condition
 }
@@ -40,6 +40,13 @@ func FormattedRangedValue(minValue, maxValue int64, formatter RawValueFormatter)
 	}
 }
 
+// RangedValueWithFormat is similar to FormattedRangedValue, with the format given in a storable form.
+func RangedValueWithFormat(minValue, maxValue int64, format ValueFormat) FieldRange {
+	return func(simpl *Simplifier) bool {
+		return simpl.rangedValueWithFormat(minValue, maxValue, format)
+	}
+}
+
 // EnumValue creates a field range describing enumerated values.
 func EnumValue(values map[uint32]string) FieldRange {
 	return func(simpl *Simplifier) bool {
@@ -89,6 +96,9 @@ type Simplifier struct {
 	bitfieldHandler  BitfieldHandler
 	objectIDHandler  ObjectIDHandler
 	specialHandler   map[string]SpecialHandler
+
+	formatHandler     func(format ValueFormat)
+	anySpecialHandler func(specialType string)
 }
 
 // NewSimplifier returns a new instance of a simplifier, with the minimal
@@ -114,6 +124,14 @@ func (simpl *Simplifier) rangedValue(minValue, maxValue int64, formatter RawValu
 	return true
 }
 
+func (simpl *Simplifier) rangedValueWithFormat(minValue, maxValue int64, format ValueFormat) bool {
+	if simpl.formatHandler != nil {
+		simpl.formatHandler(format)
+	}
+	simpl.rawValueHandler(minValue, maxValue, format.Format)
+	return true
+}
+
 // SetEnumValueHandler registers the handler for enumerations.
 func (simpl *Simplifier) SetEnumValueHandler(handler EnumValueHandler) {
 	simpl.enumValueHandler = handler
@@ -159,6 +177,10 @@ func (simpl *Simplifier) SetSpecialHandler(specialType string, handler SpecialHa
 }
 
 func (simpl *Simplifier) specialValue(specialType string) (result bool) {
+	if simpl.anySpecialHandler != nil {
+		simpl.anySpecialHandler(specialType)
+		return true
+	}
 	handler, existing := simpl.specialHandler[specialType]
 	if existing && (handler != nil) {
 		handler()
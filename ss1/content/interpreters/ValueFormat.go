@@ -0,0 +1,45 @@
+package interpreters
+
+import (
+	"strconv"
+)
+
+// ValueFormat describes how a raw value is displayed, in a form that can be stored.
+// Names take precedence; Values without a name are shown as a number should any numeric property be set.
+type ValueFormat struct {
+	// Names maps raw values to their textual representation.
+	Names map[uint32]string `json:"names,omitempty"`
+	// Unknown is shown for values that are not listed in Names.
+	Unknown string `json:"unknown,omitempty"`
+
+	// Scale is multiplied with the raw value. Zero is treated as one.
+	Scale float64 `json:"scale,omitempty"`
+	// Offset is added to the scaled value.
+	Offset float64 `json:"offset,omitempty"`
+	// Precision is the number of decimal places shown.
+	Precision int `json:"precision,omitempty"`
+	// Unit is appended to the number.
+	Unit string `json:"unit,omitempty"`
+}
+
+func (format ValueFormat) isNumeric() bool {
+	return (format.Scale != 0) || (format.Offset != 0) || (format.Precision != 0) || (len(format.Unit) > 0)
+}
+
+// Format returns the textual representation of the given value.
+func (format ValueFormat) Format(value int) string {
+	if name, known := format.Names[uint32(value)]; known {
+		return name
+	}
+	if len(format.Unknown) > 0 {
+		return format.Unknown
+	}
+	if !format.isNumeric() {
+		return ""
+	}
+	scale := format.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	return strconv.FormatFloat(float64(value)*scale+format.Offset, 'f', format.Precision, 64) + format.Unit
+}
@@ -0,0 +1,32 @@
+package interpreters_test
+
+import (
+	"testing"
+
+	"github.com/inkyblackness/hacked/ss1/content/interpreters"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueFormatFormat(t *testing.T) {
+	tt := []struct {
+		name     string
+		format   interpreters.ValueFormat
+		value    int
+		expected string
+	}{
+		{name: "empty", format: interpreters.ValueFormat{}, value: 10, expected: ""},
+		{name: "unit", format: interpreters.ValueFormat{Unit: " sec"}, value: 10, expected: "10 sec"},
+		{name: "scaled", format: interpreters.ValueFormat{Scale: 0.5, Precision: 2, Unit: " sec"}, value: 3, expected: "1.50 sec"},
+		{name: "offset", format: interpreters.ValueFormat{Offset: 7}, value: 3, expected: "10"},
+		{name: "named", format: interpreters.ValueFormat{Names: map[uint32]string{1: "one"}}, value: 1, expected: "one"},
+		{name: "unnamed", format: interpreters.ValueFormat{Names: map[uint32]string{1: "one"}}, value: 2, expected: ""},
+		{name: "unknown", format: interpreters.ValueFormat{Names: map[uint32]string{1: "one"}, Unknown: "?"}, value: 2, expected: "?"},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			assert.Equal(t, td.expected, td.format.Format(td.value))
+		})
+	}
+}
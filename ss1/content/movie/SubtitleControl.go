@@ -1,5 +1,9 @@
 package movie
 
+import (
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
 // SubtitleControl specifies how to interpret a subtitle entry.
 type SubtitleControl uint32
 
@@ -15,3 +19,24 @@ const (
 func (ctrl SubtitleControl) String() string {
 	return string([]rune{rune((ctrl >> 0) & 0xFF), rune((ctrl >> 8) & 0xFF), rune((ctrl >> 16) & 0xFF), rune((ctrl >> 24) & 0xFF)})
 }
+
+// SubtitleControlForLanguage returns the text control for given language.
+// The returned boolean is false if the language has no subtitle track, which is the case for
+// all languages beyond the built-in ones.
+func SubtitleControlForLanguage(lang resource.Language) (SubtitleControl, bool) {
+	switch lang {
+	case resource.LangDefault:
+		return SubtitleTextStd, true
+	case resource.LangFrench:
+		return SubtitleTextFrn, true
+	case resource.LangGerman:
+		return SubtitleTextGer, true
+	default:
+		return SubtitleTextStd, false
+	}
+}
+
+// SubtitleLanguages returns the languages that have a subtitle track.
+func SubtitleLanguages() []resource.Language {
+	return []resource.Language{resource.LangDefault, resource.LangFrench, resource.LangGerman}
+}
@@ -0,0 +1,30 @@
+package movie_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inkyblackness/hacked/ss1/content/movie"
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+func TestSubtitleControlForLanguageReturnsTrackOfBuiltinLanguages(t *testing.T) {
+	expected := map[resource.Language]movie.SubtitleControl{
+		resource.LangDefault: movie.SubtitleTextStd,
+		resource.LangFrench:  movie.SubtitleTextFrn,
+		resource.LangGerman:  movie.SubtitleTextGer,
+	}
+	for _, lang := range movie.SubtitleLanguages() {
+		control, hasTrack := movie.SubtitleControlForLanguage(lang)
+		assert.True(t, hasTrack, "track expected for %v", lang)
+		assert.Equal(t, expected[lang], control, "wrong control for %v", lang)
+	}
+}
+
+func TestSubtitleControlForLanguageRefusesAdditionalLanguages(t *testing.T) {
+	_, hasTrack := movie.SubtitleControlForLanguage(resource.Language(resource.BuiltinLanguageCount))
+	assert.False(t, hasTrack)
+	_, hasTrack = movie.SubtitleControlForLanguage(resource.LangAny)
+	assert.False(t, hasTrack)
+}
@@ -0,0 +1,105 @@
+package movie
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/inkyblackness/hacked/ss1/content/text"
+)
+
+// SubtitleCue is a text that is shown from its timestamp on, until the next cue of the same control.
+// An empty text clears the subtitle.
+type SubtitleCue struct {
+	Timestamp float32
+	Text      string
+}
+
+// SubtitlesOf returns the cues of given control, in the order as they are stored in the container.
+func SubtitlesOf(container Container, control SubtitleControl, cp text.Codepage) []SubtitleCue {
+	var cues []SubtitleCue
+	for index := 0; index < container.EntryCount(); index++ {
+		entry := container.Entry(index)
+		if isSubtitleOf(entry, control) {
+			cues = append(cues, SubtitleCue{
+				Timestamp: entry.Timestamp(),
+				Text:      cp.Decode(entry.Data()[SubtitleHeaderSize:]),
+			})
+		}
+	}
+	return cues
+}
+
+// WithSubtitles returns a new container that has the same properties and entries as the given one,
+// yet with all the subtitles of given control replaced by the provided cues.
+// The media duration is extended if a cue starts after the end of the original media.
+//
+// The unknown header bytes of the original subtitles are kept: each cue takes them from the original subtitle
+// at the same position, additional cues from the last original one.
+func WithSubtitles(container Container, control SubtitleControl, cues []SubtitleCue, cp text.Codepage) Container {
+	originalHeaders := subtitleHeadersOf(container, control)
+	newEntries := make([]Entry, 0, len(cues))
+	duration := container.MediaDuration()
+	for index, cue := range cues {
+		header := SubtitleHeader{Control: control}
+		if len(originalHeaders) > 0 {
+			header = originalHeaders[len(originalHeaders)-1]
+			if index < len(originalHeaders) {
+				header = originalHeaders[index]
+			}
+		}
+		buf := bytes.NewBuffer(nil)
+		_ = binary.Write(buf, binary.LittleEndian, &header)
+		buf.Write(cp.Encode(cue.Text))
+		timestamp := timeFromRaw(timeToRaw(cue.Timestamp))
+		newEntries = append(newEntries, NewMemoryEntry(timestamp, Subtitle, buf.Bytes()))
+		if timestamp > duration {
+			duration = timestamp
+		}
+	}
+	sort.SliceStable(newEntries, func(a, b int) bool { return newEntries[a].Timestamp() < newEntries[b].Timestamp() })
+
+	palette := container.StartPalette()
+	builder := NewContainerBuilder().
+		MediaDuration(duration).
+		VideoWidth(container.VideoWidth()).
+		VideoHeight(container.VideoHeight()).
+		StartPalette(&palette).
+		AudioSampleRate(container.AudioSampleRate())
+	for index := 0; index < container.EntryCount(); index++ {
+		entry := container.Entry(index)
+		for (len(newEntries) > 0) && (newEntries[0].Timestamp() < entry.Timestamp()) {
+			builder.AddEntry(newEntries[0])
+			newEntries = newEntries[1:]
+		}
+		if !isSubtitleOf(entry, control) {
+			builder.AddEntry(entry)
+		}
+	}
+	for _, entry := range newEntries {
+		builder.AddEntry(entry)
+	}
+	return builder.Build()
+}
+
+func subtitleHeadersOf(container Container, control SubtitleControl) []SubtitleHeader {
+	var headers []SubtitleHeader
+	for index := 0; index < container.EntryCount(); index++ {
+		entry := container.Entry(index)
+		if isSubtitleOf(entry, control) {
+			var header SubtitleHeader
+			_ = binary.Read(bytes.NewReader(entry.Data()), binary.LittleEndian, &header)
+			headers = append(headers, header)
+		}
+	}
+	return headers
+}
+
+func isSubtitleOf(entry Entry, control SubtitleControl) bool {
+	if entry.Type() != Subtitle {
+		return false
+	}
+	var header SubtitleHeader
+	err := binary.Read(bytes.NewReader(entry.Data()), binary.LittleEndian, &header)
+	return (err == nil) && (header.Control == control)
+}
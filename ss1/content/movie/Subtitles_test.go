@@ -0,0 +1,109 @@
+package movie_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/content/movie"
+	"github.com/inkyblackness/hacked/ss1/content/text"
+)
+
+func TestSubtitlesOfReturnsCuesOfControl(t *testing.T) {
+	cp := text.DefaultCodepage()
+	container := movie.NewContainerBuilder().
+		AddEntry(movie.NewMemoryEntry(0.5, movie.Subtitle, subtitleData(movie.SubtitleArea, "0 0 320 200", cp))).
+		AddEntry(movie.NewMemoryEntry(1.0, movie.Subtitle, subtitleData(movie.SubtitleTextStd, "first", cp))).
+		AddEntry(movie.NewMemoryEntry(1.0, movie.Subtitle, subtitleData(movie.SubtitleTextGer, "erste", cp))).
+		AddEntry(movie.NewMemoryEntry(2.0, movie.Audio, []byte{0x80})).
+		AddEntry(movie.NewMemoryEntry(3.0, movie.Subtitle, subtitleData(movie.SubtitleTextStd, "", cp))).
+		Build()
+
+	cues := movie.SubtitlesOf(container, movie.SubtitleTextStd, cp)
+
+	assert.Equal(t, []movie.SubtitleCue{{Timestamp: 1.0, Text: "first"}, {Timestamp: 3.0, Text: ""}}, cues)
+}
+
+func TestWithSubtitlesReplacesCuesOfControlOnly(t *testing.T) {
+	cp := text.DefaultCodepage()
+	original := movie.NewContainerBuilder().
+		MediaDuration(4.0).
+		AudioSampleRate(22050).
+		AddEntry(movie.NewMemoryEntry(0.0, movie.Audio, []byte{0x80})).
+		AddEntry(movie.NewMemoryEntry(1.0, movie.Subtitle, subtitleData(movie.SubtitleTextStd, "old", cp))).
+		AddEntry(movie.NewMemoryEntry(1.0, movie.Subtitle, subtitleData(movie.SubtitleTextFrn, "vieux", cp))).
+		AddEntry(movie.NewMemoryEntry(2.0, movie.Audio, []byte{0x81})).
+		Build()
+
+	result := movie.WithSubtitles(original, movie.SubtitleTextStd,
+		[]movie.SubtitleCue{{Timestamp: 3.0, Text: "new"}, {Timestamp: 1.5, Text: "newer"}}, cp)
+
+	require.Equal(t, 5, result.EntryCount())
+	assert.Equal(t, float32(4.0), result.MediaDuration())
+	assert.Equal(t, uint16(22050), result.AudioSampleRate())
+	assert.Equal(t, []movie.SubtitleCue{{Timestamp: 1.5, Text: "newer"}, {Timestamp: 3.0, Text: "new"}},
+		movie.SubtitlesOf(result, movie.SubtitleTextStd, cp))
+	assert.Equal(t, []movie.SubtitleCue{{Timestamp: 1.0, Text: "vieux"}},
+		movie.SubtitlesOf(result, movie.SubtitleTextFrn, cp))
+	var timestamps []float32
+	for index := 0; index < result.EntryCount(); index++ {
+		timestamps = append(timestamps, result.Entry(index).Timestamp())
+	}
+	assert.Equal(t, []float32{0.0, 1.0, 1.5, 2.0, 3.0}, timestamps)
+}
+
+func TestWithSubtitlesExtendsDuration(t *testing.T) {
+	cp := text.DefaultCodepage()
+	original := movie.NewContainerBuilder().MediaDuration(1.0).Build()
+
+	result := movie.WithSubtitles(original, movie.SubtitleTextStd, []movie.SubtitleCue{{Timestamp: 2.5, Text: "late"}}, cp)
+
+	assert.Equal(t, float32(2.5), result.MediaDuration())
+}
+
+func TestWithSubtitlesCanBeWrittenAndRead(t *testing.T) {
+	cp := text.DefaultCodepage()
+	original := movie.NewContainerBuilder().MediaDuration(2.0).Build()
+	cues := []movie.SubtitleCue{{Timestamp: 0.25, Text: "line one\nline two"}, {Timestamp: 1.75, Text: ""}}
+	buffer := bytes.NewBuffer(nil)
+
+	err := movie.Write(buffer, movie.WithSubtitles(original, movie.SubtitleTextStd, cues, cp))
+	require.Nil(t, err)
+	result, err := movie.Read(bytes.NewReader(buffer.Bytes()))
+	require.Nil(t, err)
+
+	assert.Equal(t, cues, movie.SubtitlesOf(result, movie.SubtitleTextStd, cp))
+}
+
+func TestWithSubtitlesKeepsUnknownHeaderBytes(t *testing.T) {
+	cp := text.DefaultCodepage()
+	first := subtitleData(movie.SubtitleTextStd, "one", cp)
+	first[4] = 0x11
+	first[15] = 0x22
+	second := subtitleData(movie.SubtitleTextStd, "two", cp)
+	second[4] = 0x33
+	original := movie.NewContainerBuilder().
+		MediaDuration(4.0).
+		AddEntry(movie.NewMemoryEntry(1.0, movie.Subtitle, first)).
+		AddEntry(movie.NewMemoryEntry(2.0, movie.Subtitle, second)).
+		Build()
+
+	result := movie.WithSubtitles(original, movie.SubtitleTextStd,
+		[]movie.SubtitleCue{{Timestamp: 1.0, Text: "eins"}, {Timestamp: 2.0, Text: "zwei"}, {Timestamp: 3.0, Text: "drei"}}, cp)
+
+	require.Equal(t, 3, result.EntryCount())
+	assert.Equal(t, []byte{0x11, 0x22}, []byte{result.Entry(0).Data()[4], result.Entry(0).Data()[15]})
+	assert.Equal(t, byte(0x33), result.Entry(1).Data()[4])
+	assert.Equal(t, byte(0x33), result.Entry(2).Data()[4], "additional cues take the last header")
+}
+
+func subtitleData(control movie.SubtitleControl, value string, cp text.Codepage) []byte {
+	data := make([]byte, movie.SubtitleHeaderSize)
+	data[0] = byte(control >> 0)
+	data[1] = byte(control >> 8)
+	data[2] = byte(control >> 16)
+	data[3] = byte(control >> 24)
+	return append(data, cp.Encode(value)...)
+}
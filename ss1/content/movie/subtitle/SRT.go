@@ -0,0 +1,53 @@
+package subtitle
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/inkyblackness/hacked/ss1/content/movie"
+)
+
+// LoadSRT reads SubRip formatted subtitles from given reader and returns them as movie cues.
+func LoadSRT(reader io.Reader) ([]movie.SubtitleCue, error) {
+	if reader == nil {
+		return nil, errors.New("reader is nil")
+	}
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	var spans []span
+	for _, block := range blocksOf(string(data)) {
+		timingLine := 0
+		if !strings.Contains(block[0], "-->") {
+			timingLine = 1
+		}
+		if timingLine >= len(block) {
+			return nil, fmt.Errorf("missing timing in entry '%v'", block[0])
+		}
+		start, end, err := parseTiming(block[timingLine])
+		if err != nil {
+			return nil, err
+		}
+		spans = append(spans, span{start: start, end: end, text: strings.Join(block[timingLine+1:], "\n")})
+	}
+	return cuesFromSpans(spans), nil
+}
+
+// SaveSRT writes the given cues in SubRip format. The last cue is shown until the given duration.
+func SaveSRT(writer io.Writer, cues []movie.SubtitleCue, duration float32) error {
+	buffered := bufio.NewWriter(writer)
+	for index, entry := range spansFromCues(cues, duration) {
+		if index > 0 {
+			_, _ = buffered.WriteString("\r\n")
+		}
+		_, _ = fmt.Fprintf(buffered, "%d\r\n%s --> %s\r\n%s\r\n", index+1,
+			formatTimecode(entry.start, ","), formatTimecode(entry.end, ","),
+			strings.Replace(entry.text, "\n", "\r\n", -1))
+	}
+	return buffered.Flush()
+}
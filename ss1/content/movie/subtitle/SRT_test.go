@@ -0,0 +1,72 @@
+package subtitle_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/content/movie"
+	"github.com/inkyblackness/hacked/ss1/content/movie/subtitle"
+)
+
+func TestLoadSRTReturnsErrorOnNil(t *testing.T) {
+	_, err := subtitle.LoadSRT(nil)
+
+	assert.NotNil(t, err)
+}
+
+func TestLoadSRTReturnsCuesWithClearingGaps(t *testing.T) {
+	input := "1\r\n00:00:01,000 --> 00:00:02,500\r\nHello\r\nWorld\r\n\r\n" +
+		"2\r\n00:00:02,500 --> 00:00:04,000\r\nSecond\r\n\r\n" +
+		"3\r\n00:01:00,250 --> 00:01:01,000\r\nThird\r\n"
+
+	cues, err := subtitle.LoadSRT(bytes.NewBufferString(input))
+
+	require.Nil(t, err)
+	assert.Equal(t, []movie.SubtitleCue{
+		{Timestamp: 1.0, Text: "Hello\nWorld"},
+		{Timestamp: 2.5, Text: "Second"},
+		{Timestamp: 4.0, Text: ""},
+		{Timestamp: 60.25, Text: "Third"},
+		{Timestamp: 61.0, Text: ""},
+	}, cues)
+}
+
+func TestLoadSRTReturnsErrorOnInvalidTiming(t *testing.T) {
+	_, err := subtitle.LoadSRT(bytes.NewBufferString("1\n00:00:01,000 -> 00:00:02,000\nText\n"))
+
+	assert.NotNil(t, err)
+}
+
+func TestSaveSRTWritesSpans(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	cues := []movie.SubtitleCue{
+		{Timestamp: 1.0, Text: "Hello\nWorld"},
+		{Timestamp: 2.5, Text: ""},
+		{Timestamp: 3.0, Text: "Last"},
+	}
+
+	err := subtitle.SaveSRT(buf, cues, 3725.5)
+
+	require.Nil(t, err)
+	assert.Equal(t, "1\r\n00:00:01,000 --> 00:00:02,500\r\nHello\r\nWorld\r\n\r\n"+
+		"2\r\n00:00:03,000 --> 01:02:05,500\r\nLast\r\n", buf.String())
+}
+
+func TestSRTRoundTrip(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	cues := []movie.SubtitleCue{
+		{Timestamp: 0.5, Text: "One"},
+		{Timestamp: 1.5, Text: "Two"},
+		{Timestamp: 2.0, Text: ""},
+	}
+
+	err := subtitle.SaveSRT(buf, cues, 10.0)
+	require.Nil(t, err)
+	result, err := subtitle.LoadSRT(buf)
+	require.Nil(t, err)
+
+	assert.Equal(t, cues, result)
+}
@@ -0,0 +1,48 @@
+package subtitle
+
+import (
+	"sort"
+
+	"github.com/inkyblackness/hacked/ss1/content/movie"
+)
+
+// span is a text that is shown for a limited time, as used by the exchange formats.
+type span struct {
+	start float32
+	end   float32
+	text  string
+}
+
+// cuesFromSpans converts the given spans to movie cues.
+// Gaps between spans are filled with clearing cues.
+func cuesFromSpans(spans []span) []movie.SubtitleCue {
+	sort.SliceStable(spans, func(a, b int) bool { return spans[a].start < spans[b].start })
+	var cues []movie.SubtitleCue
+	for index, current := range spans {
+		cues = append(cues, movie.SubtitleCue{Timestamp: current.start, Text: current.text})
+		if (index+1 >= len(spans)) || (spans[index+1].start > current.end) {
+			cues = append(cues, movie.SubtitleCue{Timestamp: current.end, Text: ""})
+		}
+	}
+	return cues
+}
+
+// spansFromCues converts the given movie cues to spans. Clearing cues are dropped.
+// The last cue is shown until the given duration.
+func spansFromCues(cues []movie.SubtitleCue, duration float32) []span {
+	var spans []span
+	for index, cue := range cues {
+		if len(cue.Text) == 0 {
+			continue
+		}
+		end := duration
+		if index+1 < len(cues) {
+			end = cues[index+1].Timestamp
+		}
+		if end < cue.Timestamp {
+			end = cue.Timestamp
+		}
+		spans = append(spans, span{start: cue.Timestamp, end: end, text: cue.Text})
+	}
+	return spans
+}
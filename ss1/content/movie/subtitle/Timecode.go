@@ -0,0 +1,80 @@
+package subtitle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseTimecode parses a time value in the form "[hh:]mm:ss.ttt", with either '.' or ',' as fraction separator.
+func parseTimecode(value string) (float32, error) {
+	parts := strings.Split(strings.Replace(strings.TrimSpace(value), ",", ".", 1), ":")
+	if (len(parts) < 2) || (len(parts) > 3) {
+		return 0, fmt.Errorf("invalid timecode '%v'", value)
+	}
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timecode '%v'", value)
+	}
+	multiplier := 60.0
+	for index := len(parts) - 2; index >= 0; index-- {
+		number, err := strconv.ParseUint(parts[index], 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timecode '%v'", value)
+		}
+		seconds += float64(number) * multiplier
+		multiplier *= 60
+	}
+	return float32(seconds), nil
+}
+
+// formatTimecode returns the given time in the form "hh:mm:ss" + separator + "ttt".
+func formatTimecode(value float32, separator string) string {
+	milliseconds := int64(float64(value)*1000.0 + 0.5)
+	if milliseconds < 0 {
+		milliseconds = 0
+	}
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d",
+		milliseconds/3600000, (milliseconds/60000)%60, (milliseconds/1000)%60, separator, milliseconds%1000)
+}
+
+// parseTiming parses a line in the form "start --> end [settings]".
+func parseTiming(line string) (start, end float32, err error) {
+	parts := strings.SplitN(line, "-->", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid timing '%v'", line)
+	}
+	endFields := strings.Fields(parts[1])
+	if len(endFields) == 0 {
+		return 0, 0, fmt.Errorf("invalid timing '%v'", line)
+	}
+	start, err = parseTimecode(parts[0])
+	if err != nil {
+		return
+	}
+	end, err = parseTimecode(endFields[0])
+	return
+}
+
+// blocksOf splits the given text into blocks of lines that are separated by empty lines.
+func blocksOf(data string) [][]string {
+	data = strings.TrimPrefix(data, "\uFEFF")
+	data = strings.Replace(data, "\r\n", "\n", -1)
+	data = strings.Replace(data, "\r", "\n", -1)
+	var blocks [][]string
+	var current []string
+	for _, line := range strings.Split(data, "\n") {
+		if len(strings.TrimSpace(line)) == 0 {
+			if len(current) > 0 {
+				blocks = append(blocks, current)
+			}
+			current = nil
+		} else {
+			current = append(current, line)
+		}
+	}
+	if len(current) > 0 {
+		blocks = append(blocks, current)
+	}
+	return blocks
+}
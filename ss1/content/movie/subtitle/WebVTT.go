@@ -0,0 +1,70 @@
+package subtitle
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/inkyblackness/hacked/ss1/content/movie"
+)
+
+const webVTTTag = "WEBVTT"
+
+var webVTTMarkup = regexp.MustCompile(`<[^>]*>`)
+
+var webVTTUnescaper = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&nbsp;", " ", "&lrm;", "", "&rlm;", "", "&amp;", "&")
+
+var webVTTEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// LoadWebVTT reads WebVTT formatted subtitles from given reader and returns them as movie cues.
+// Any markup within the cue text is removed.
+func LoadWebVTT(reader io.Reader) ([]movie.SubtitleCue, error) {
+	if reader == nil {
+		return nil, errors.New("reader is nil")
+	}
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	blocks := blocksOf(string(data))
+	if (len(blocks) == 0) || !strings.HasPrefix(blocks[0][0], webVTTTag) {
+		return nil, errors.New("not a WebVTT file")
+	}
+	var spans []span
+	for _, block := range blocks[1:] {
+		timingLine := -1
+		for index, line := range block {
+			if strings.Contains(line, "-->") {
+				timingLine = index
+				break
+			}
+		}
+		if (timingLine < 0) || (timingLine > 1) {
+			continue // NOTE, STYLE, REGION or otherwise unsupported block
+		}
+		start, end, err := parseTiming(block[timingLine])
+		if err != nil {
+			return nil, err
+		}
+		text := strings.Join(block[timingLine+1:], "\n")
+		text = webVTTUnescaper.Replace(webVTTMarkup.ReplaceAllString(text, ""))
+		spans = append(spans, span{start: start, end: end, text: text})
+	}
+	return cuesFromSpans(spans), nil
+}
+
+// SaveWebVTT writes the given cues in WebVTT format. The last cue is shown until the given duration.
+func SaveWebVTT(writer io.Writer, cues []movie.SubtitleCue, duration float32) error {
+	buffered := bufio.NewWriter(writer)
+	_, _ = buffered.WriteString(webVTTTag + "\n")
+	for _, entry := range spansFromCues(cues, duration) {
+		_, _ = fmt.Fprintf(buffered, "\n%s --> %s\n%s\n",
+			formatTimecode(entry.start, "."), formatTimecode(entry.end, "."),
+			webVTTEscaper.Replace(entry.text))
+	}
+	return buffered.Flush()
+}
@@ -0,0 +1,69 @@
+package subtitle_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/content/movie"
+	"github.com/inkyblackness/hacked/ss1/content/movie/subtitle"
+)
+
+func TestLoadWebVTTReturnsErrorOnNil(t *testing.T) {
+	_, err := subtitle.LoadWebVTT(nil)
+
+	assert.NotNil(t, err)
+}
+
+func TestLoadWebVTTReturnsErrorOnMissingTag(t *testing.T) {
+	_, err := subtitle.LoadWebVTT(bytes.NewBufferString("00:01.000 --> 00:02.000\nText\n"))
+
+	assert.NotNil(t, err)
+}
+
+func TestLoadWebVTTReturnsCues(t *testing.T) {
+	input := "WEBVTT - some title\n\n" +
+		"NOTE this is a comment\n\n" +
+		"intro\n00:01.000 --> 00:02.000 align:start\n<v Rebecca>Hello &amp; <b>welcome</b>\n\n" +
+		"00:00:03.000 --> 00:00:04.000\nBye\n"
+
+	cues, err := subtitle.LoadWebVTT(bytes.NewBufferString(input))
+
+	require.Nil(t, err)
+	assert.Equal(t, []movie.SubtitleCue{
+		{Timestamp: 1.0, Text: "Hello & welcome"},
+		{Timestamp: 2.0, Text: ""},
+		{Timestamp: 3.0, Text: "Bye"},
+		{Timestamp: 4.0, Text: ""},
+	}, cues)
+}
+
+func TestSaveWebVTTWritesSpans(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	cues := []movie.SubtitleCue{
+		{Timestamp: 1.0, Text: "A < B"},
+		{Timestamp: 2.0, Text: ""},
+	}
+
+	err := subtitle.SaveWebVTT(buf, cues, 5.0)
+
+	require.Nil(t, err)
+	assert.Equal(t, "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nA &lt; B\n", buf.String())
+}
+
+func TestWebVTTRoundTrip(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	cues := []movie.SubtitleCue{
+		{Timestamp: 0.25, Text: "One & two"},
+		{Timestamp: 1.5, Text: ""},
+	}
+
+	err := subtitle.SaveWebVTT(buf, cues, 10.0)
+	require.Nil(t, err)
+	result, err := subtitle.LoadWebVTT(buf)
+	require.Nil(t, err)
+
+	assert.Equal(t, cues, result)
+}
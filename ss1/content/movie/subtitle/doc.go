@@ -0,0 +1,3 @@
+// Package subtitle provides the exchange of movie subtitles with common text formats.
+// The supported formats are SubRip (SRT) and WebVTT.
+package subtitle
@@ -3,6 +3,9 @@ package object
 const (
 	// ClassCount describes how many object classes there are.
 	ClassCount = 15
+	// MaxTypesPerSubclass describes how many types a subclass can have at most.
+	// Types are identified by a single byte.
+	MaxTypesPerSubclass = 256
 
 	propertiesFileVersion uint32 = 0x0000002D
 )
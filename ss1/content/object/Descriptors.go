@@ -42,3 +42,24 @@ type SubclassDescriptor struct {
 func (desc SubclassDescriptor) TotalDataSize() int {
 	return (desc.SpecificDataSize + CommonPropertiesSize) * desc.TypeCount
 }
+
+// Clone returns a deep copy of the descriptors.
+func (desc Descriptors) Clone() Descriptors {
+	result := make(Descriptors, len(desc))
+	for index, classDesc := range desc {
+		result[index] = ClassDescriptor{
+			GenericDataSize: classDesc.GenericDataSize,
+			Subclasses:      append([]SubclassDescriptor{}, classDesc.Subclasses...),
+		}
+	}
+	return result
+}
+
+// PropertiesFileSize returns the length, in bytes, of a properties file described by the descriptors.
+func (desc Descriptors) PropertiesFileSize() int {
+	total := 4 // version prefix
+	for _, classDesc := range desc {
+		total += classDesc.TotalDataSize()
+	}
+	return total
+}
@@ -27,3 +27,17 @@ func TestClassDescriptorTotalTypeCountReturnsTotalAmount(t *testing.T) {
 
 	assert.Equal(t, 3, mainDesc.TotalTypeCount())
 }
+
+func TestDescriptorsCloneReturnsIndependentCopy(t *testing.T) {
+	desc := StandardDescriptors()
+	clone := desc.Clone()
+
+	clone[0].Subclasses[0].TypeCount++
+
+	assert.Equal(t, 5, desc[0].Subclasses[0].TypeCount)
+	assert.Equal(t, 6, clone[0].Subclasses[0].TypeCount)
+}
+
+func TestDescriptorsPropertiesFileSizeIncludesVersion(t *testing.T) {
+	assert.Equal(t, 17951, StandardDescriptors().PropertiesFileSize())
+}
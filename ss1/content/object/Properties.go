@@ -153,3 +153,64 @@ func (table PropertiesTable) Iterate(consumer func(Triple, *Properties) bool) {
 		}
 	}
 }
+
+// Clone returns a deep copy of the table.
+func (table PropertiesTable) Clone() PropertiesTable {
+	result := make(PropertiesTable, len(table))
+	for class, subclasses := range table {
+		result[class] = make(ClassProperties, len(subclasses))
+		for subclass, types := range subclasses {
+			clonedTypes := make(SubclassProperties, len(types))
+			for objType, prop := range types {
+				clonedTypes[objType] = prop.Clone()
+			}
+			result[class][subclass] = clonedTypes
+		}
+	}
+	return result
+}
+
+// Descriptors returns the descriptors that match the layout of the table.
+// The data sizes are taken from the first type of each class and subclass.
+// Subclasses without types report a data size of zero.
+func (table PropertiesTable) Descriptors() Descriptors {
+	desc := make(Descriptors, len(table))
+	for class, subclasses := range table {
+		classDesc := &desc[class]
+		classDesc.Subclasses = make([]SubclassDescriptor, len(subclasses))
+		for subclass, types := range subclasses {
+			subclassDesc := &classDesc.Subclasses[subclass]
+			subclassDesc.TypeCount = len(types)
+			if len(types) > 0 {
+				subclassDesc.SpecificDataSize = len(types[0].Specific)
+				classDesc.GenericDataSize = len(types[0].Generic)
+			}
+		}
+	}
+	return desc
+}
+
+// WithTypeAdded returns a copy of the table that has one more type in the given subclass.
+// The new type is appended to the existing ones and has all its properties set to zero.
+// The returned triple identifies the new type.
+func (table PropertiesTable) WithTypeAdded(class Class, subclass Subclass) (PropertiesTable, Triple, error) {
+	if int(class) >= len(table) {
+		return nil, Triple{}, errors.New("invalid class")
+	}
+	if int(subclass) >= len(table[class]) {
+		return nil, Triple{}, errors.New("invalid subclass")
+	}
+	types := table[class][subclass]
+	if len(types) == 0 {
+		return nil, Triple{}, errors.New("subclass has no template for data sizes")
+	}
+	if len(types) >= MaxTypesPerSubclass {
+		return nil, Triple{}, errors.New("subclass has maximum number of types")
+	}
+	result := table.Clone()
+	result[class][subclass] = append(result[class][subclass], Properties{
+		Generic:  make([]byte, len(types[0].Generic)),
+		Specific: make([]byte, len(types[0].Specific)),
+	})
+	return result, TripleFrom(int(class), int(subclass), len(types)), nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/inkyblackness/hacked/ss1/serial"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPropertiesForObjectReturnErrorForWrongIDs(t *testing.T) {
@@ -56,3 +57,34 @@ func TestPropertiesEncoding(t *testing.T) {
 	result := buf.Bytes()
 	assert.Equal(t, 17951, len(result)) // as taken from original CD
 }
+
+func TestPropertiesDescriptorsMatchStandard(t *testing.T) {
+	table := object.StandardPropertiesTable()
+
+	assert.Equal(t, object.StandardDescriptors(), table.Descriptors())
+}
+
+func TestPropertiesWithTypeAddedExtendsCopyOfSubclass(t *testing.T) {
+	table := object.StandardPropertiesTable()
+
+	extended, triple, err := table.WithTypeAdded(object.Class(1), object.Subclass(2))
+	require.Nil(t, err)
+	assert.Equal(t, object.TripleFrom(1, 2, 3), triple)
+	prop, err := extended.ForObject(triple)
+	require.Nil(t, err)
+	assert.Equal(t, 14, len(prop.Generic))
+	assert.Equal(t, 1, len(prop.Specific))
+	assert.Equal(t, len(table.TriplesInClass(object.Class(1)))+1, len(extended.TriplesInClass(object.Class(1))))
+	_, err = table.ForObject(triple)
+	assert.Error(t, err, "original table must not be modified")
+	assert.Equal(t, 17951+14+1+object.CommonPropertiesSize, extended.Descriptors().PropertiesFileSize())
+}
+
+func TestPropertiesWithTypeAddedReturnsErrorForWrongIDs(t *testing.T) {
+	table := object.StandardPropertiesTable()
+
+	_, _, err := table.WithTypeAdded(object.Class(20), object.Subclass(0))
+	assert.Error(t, err, "error expected for wrong class")
+	_, _, err = table.WithTypeAdded(object.Class(1), object.Subclass(40))
+	assert.Error(t, err, "error expected for wrong subclass")
+}
@@ -1,17 +1,13 @@
 package objprop
 
 import (
-	"fmt"
-
 	"github.com/inkyblackness/hacked/ss1/content/interpreters"
 	"github.com/inkyblackness/hacked/ss1/content/object"
 )
 
 var animationGenerics = interpreters.New().
-	With("FrameTime", 0, 1).As(interpreters.FormattedRangedValue(0, 255,
-	func(value int) string {
-		return fmt.Sprintf("%3.0f millisec", (float64(value)*900)/255.0)
-	})).
+	With("FrameTime", 0, 1).As(interpreters.RangedValueWithFormat(0, 255,
+	interpreters.ValueFormat{Scale: 900.0 / 255.0, Unit: " millisec"})).
 	With("Flags", 1, 1).As(interpreters.Bitfield(map[uint32]string{0x01: "Emit Light"}))
 
 var explosionAnimation = interpreters.New().
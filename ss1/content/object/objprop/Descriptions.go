@@ -0,0 +1,71 @@
+package objprop
+
+import (
+	"github.com/inkyblackness/hacked/ss1/content/interpreters"
+	"github.com/inkyblackness/hacked/ss1/content/object"
+)
+
+// BuiltinGenericDescriptions returns a copy of the built-in descriptions of generic properties.
+func BuiltinGenericDescriptions() map[object.Class]*interpreters.Description {
+	result := make(map[object.Class]*interpreters.Description)
+	for key, desc := range genericDescriptions {
+		result[key] = desc
+	}
+	return result
+}
+
+// BuiltinSpecificDescriptions returns a copy of the built-in descriptions of specific properties.
+// Descriptions with type AnyType apply to all types of their subclass that have no own description.
+func BuiltinSpecificDescriptions() map[object.Triple]*interpreters.Description {
+	result := make(map[object.Triple]*interpreters.Description)
+	for key, desc := range specificDescriptions {
+		result[key] = desc
+	}
+	return result
+}
+
+// Interpreters provides the interpreters for the properties of objects.
+type Interpreters struct {
+	generic  map[object.Class]*interpreters.Description
+	specific map[object.Triple]*interpreters.Description
+}
+
+// BuiltinInterpreters returns the interpreters based on the built-in descriptions.
+func BuiltinInterpreters() *Interpreters {
+	return &Interpreters{generic: genericDescriptions, specific: specificDescriptions}
+}
+
+// NewInterpreters returns interpreters based on the given descriptions.
+// The given descriptions take precedence over the built-in ones.
+func NewInterpreters(generic map[object.Class]*interpreters.Description,
+	specific map[object.Triple]*interpreters.Description) *Interpreters {
+	set := &Interpreters{generic: BuiltinGenericDescriptions(), specific: BuiltinSpecificDescriptions()}
+	for key, desc := range generic {
+		set.generic[key] = desc
+	}
+	for key, desc := range specific {
+		set.specific[key] = desc
+	}
+	return set
+}
+
+// GenericProperties returns an interpreter specific for the given object class.
+func (set *Interpreters) GenericProperties(objClass object.Class, data []byte) *interpreters.Instance {
+	desc := set.generic[objClass]
+	if desc == nil {
+		desc = interpreters.New()
+	}
+	return desc.For(data)
+}
+
+// SpecificProperties returns an interpreter specific for the given object class and subclass.
+func (set *Interpreters) SpecificProperties(triple object.Triple, data []byte) *interpreters.Instance {
+	desc := set.specific[triple]
+	if desc == nil {
+		desc = set.specific[object.TripleFrom(int(triple.Class), int(triple.Subclass), AnyType)]
+	}
+	if desc == nil {
+		desc = interpreters.New()
+	}
+	return desc.For(data)
+}
@@ -6,10 +6,7 @@ import (
 )
 
 // GenericProperties returns an interpreter specific for the given object class.
+// The interpreter is based on the built-in descriptions.
 func GenericProperties(objClass object.Class, data []byte) *interpreters.Instance {
-	desc := genericDescriptions[objClass]
-	if desc == nil {
-		desc = interpreters.New()
-	}
-	return desc.For(data)
+	return BuiltinInterpreters().GenericProperties(objClass, data)
 }
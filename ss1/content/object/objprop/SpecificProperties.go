@@ -6,13 +6,7 @@ import (
 )
 
 // SpecificProperties returns an interpreter specific for the given object class and subclass.
+// The interpreter is based on the built-in descriptions.
 func SpecificProperties(triple object.Triple, data []byte) *interpreters.Instance {
-	desc := specificDescriptions[triple]
-	if desc == nil {
-		desc = specificDescriptions[object.TripleFrom(int(triple.Class), int(triple.Subclass), anyObjectType)]
-	}
-	if desc == nil {
-		desc = interpreters.New()
-	}
-	return desc.For(data)
+	return BuiltinInterpreters().SpecificProperties(triple, data)
 }
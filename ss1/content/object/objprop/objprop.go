@@ -8,7 +8,8 @@ import (
 var genericDescriptions map[object.Class]*interpreters.Description
 var specificDescriptions map[object.Triple]*interpreters.Description
 
-const anyObjectType = 0xFF
+// AnyType is the object type of specific descriptions that apply to all types of a subclass.
+const AnyType = 0xFF
 
 var damageType = interpreters.Bitfield(map[uint32]string{
 	0x01: "Explosion",
@@ -40,7 +41,7 @@ func init() {
 }
 
 func setSpecific(objClass object.Class, objSubclass int, desc *interpreters.Description) {
-	specificDescriptions[object.TripleFrom(int(objClass), objSubclass, anyObjectType)] = desc
+	specificDescriptions[object.TripleFrom(int(objClass), objSubclass, AnyType)] = desc
 }
 
 func setSpecificByType(objClass object.Class, objSubclass int, objType int, desc *interpreters.Description) {
@@ -0,0 +1,66 @@
+package objsheet
+
+import (
+	"github.com/inkyblackness/hacked/ss1/content/object"
+)
+
+type commonField struct {
+	name     string
+	maxValue int64
+	minValue int64
+	get      func(*object.CommonProperties) int64
+	set      func(*object.CommonProperties, int64)
+}
+
+var commonFields = []commonField{
+	{name: "Mass", minValue: -0x80000000, maxValue: 0x7FFFFFFF,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.Mass) },
+		set: func(prop *object.CommonProperties, value int64) { prop.Mass = int32(value) }},
+	{name: "Hitpoints", minValue: -0x8000, maxValue: 0x7FFF,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.Hitpoints) },
+		set: func(prop *object.CommonProperties, value int64) { prop.Hitpoints = int16(value) }},
+	{name: "Armor", maxValue: 0xFF,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.Armor) },
+		set: func(prop *object.CommonProperties, value int64) { prop.Armor = byte(value) }},
+	{name: "RenderType", maxValue: 0xFF,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.RenderType) },
+		set: func(prop *object.CommonProperties, value int64) { prop.RenderType = object.RenderType(value) }},
+	{name: "PhysicsModel", maxValue: 0xFF,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.PhysicsModel) },
+		set: func(prop *object.CommonProperties, value int64) { prop.PhysicsModel = object.PhysicsModel(value) }},
+	{name: "Hardness", maxValue: object.HardnessLimit,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.Hardness) },
+		set: func(prop *object.CommonProperties, value int64) { prop.Hardness = byte(value) }},
+	{name: "PhysicsXR", maxValue: object.PhysicsXRLimit,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.PhysicsXR) },
+		set: func(prop *object.CommonProperties, value int64) { prop.PhysicsXR = byte(value) }},
+	{name: "PhysicsZ", maxValue: 0xFF,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.PhysicsZ) },
+		set: func(prop *object.CommonProperties, value int64) { prop.PhysicsZ = byte(value) }},
+	{name: "Vulnerabilities", maxValue: 0xFF,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.Vulnerabilities) },
+		set: func(prop *object.CommonProperties, value int64) { prop.Vulnerabilities = object.DamageTypeMask(value) }},
+	{name: "SpecialVulnerabilities", maxValue: 0xFF,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.SpecialVulnerabilities) },
+		set: func(prop *object.CommonProperties, value int64) {
+			prop.SpecialVulnerabilities = object.SpecialDamageType(value)
+		}},
+	{name: "Defense", maxValue: 0xFF,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.Defense) },
+		set: func(prop *object.CommonProperties, value int64) { prop.Defense = byte(value) }},
+	{name: "Toughness", maxValue: 0xFF,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.Toughness) },
+		set: func(prop *object.CommonProperties, value int64) { prop.Toughness = byte(value) }},
+	{name: "Flags", maxValue: 0xFFFF,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.Flags) },
+		set: func(prop *object.CommonProperties, value int64) { prop.Flags = object.CommonFlagField(value) }},
+	{name: "MfdOrMeshID", maxValue: 0xFFFF,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.MfdOrMeshID) },
+		set: func(prop *object.CommonProperties, value int64) { prop.MfdOrMeshID = uint16(value) }},
+	{name: "Bitmap3D", maxValue: 0xFFFF,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.Bitmap3D) },
+		set: func(prop *object.CommonProperties, value int64) { prop.Bitmap3D = object.Bitmap3D(value) }},
+	{name: "DestroyEffect", maxValue: 0xFF,
+		get: func(prop *object.CommonProperties) int64 { return int64(prop.DestroyEffect) },
+		set: func(prop *object.CommonProperties, value int64) { prop.DestroyEffect = object.DestroyEffect(value) }},
+}
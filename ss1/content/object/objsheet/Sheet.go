@@ -0,0 +1,182 @@
+// Package objsheet exchanges object properties with spreadsheet applications.
+// Properties are stored as CSV tables, one per object class, with a column per interpreted value.
+package objsheet
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/inkyblackness/hacked/ss1/content/interpreters"
+	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/content/object/objprop"
+)
+
+// Identifying columns of each row.
+const (
+	ColumnClass    = "Class"
+	ColumnSubclass = "Subclass"
+	ColumnType     = "Type"
+	ColumnName     = "Name"
+)
+
+const (
+	commonPrefix   = "Common."
+	genericPrefix  = "Generic."
+	specificPrefix = "Specific."
+)
+
+// Sheet is a table of the properties of the objects of one class.
+type Sheet struct {
+	Class   object.Class
+	Columns []string
+	Rows    [][]string
+}
+
+// Export creates one sheet per class of the given table. The properties are interpreted with the given interpreters.
+// The name function is used to provide a readable name per object. It is only informative and ignored during import.
+func Export(table object.PropertiesTable, properties *objprop.Interpreters, name func(object.Triple) string) []Sheet {
+	var sheets []Sheet
+	for _, class := range object.Classes() {
+		triples := table.TriplesInClass(class)
+		if len(triples) == 0 {
+			continue
+		}
+		sheet := Sheet{Class: class, Columns: []string{ColumnClass, ColumnSubclass, ColumnType, ColumnName}}
+		for _, field := range commonFields {
+			sheet.Columns = append(sheet.Columns, commonPrefix+field.name)
+		}
+		var interpretedColumns []string
+		knownColumns := make(map[string]bool)
+		var allCells []map[string]string
+		for _, triple := range triples {
+			prop, _ := table.ForObject(triple)
+			cells := cellValues(properties, triple, prop)
+			for _, column := range interpretedKeys(properties, triple, prop) {
+				if !knownColumns[column] {
+					knownColumns[column] = true
+					interpretedColumns = append(interpretedColumns, column)
+				}
+			}
+			cells[ColumnName] = name(triple)
+			allCells = append(allCells, cells)
+		}
+		sheet.Columns = append(sheet.Columns, interpretedColumns...)
+		for _, cells := range allCells {
+			row := make([]string, len(sheet.Columns))
+			for index, column := range sheet.Columns {
+				row[index] = cells[column]
+			}
+			sheet.Rows = append(sheet.Rows, row)
+		}
+		sheets = append(sheets, sheet)
+	}
+	return sheets
+}
+
+// Write stores the sheet as CSV, with the column names as the first record.
+func Write(writer io.Writer, sheet Sheet) error {
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.Write(sheet.Columns)
+	if err != nil {
+		return err
+	}
+	err = csvWriter.WriteAll(sheet.Rows)
+	if err != nil {
+		return err
+	}
+	return csvWriter.Error()
+}
+
+// Read loads a sheet from CSV. The first record must contain the column names.
+func Read(reader io.Reader) (Sheet, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return Sheet{}, err
+	}
+	if len(records) == 0 {
+		return Sheet{}, errors.New("no columns")
+	}
+	sheet := Sheet{Columns: records[0], Rows: records[1:]}
+	for index, column := range sheet.Columns {
+		sheet.Columns[index] = strings.TrimSpace(column)
+	}
+	for _, required := range []string{ColumnClass, ColumnSubclass, ColumnType} {
+		if sheet.columnIndex(required) < 0 {
+			return Sheet{}, fmt.Errorf("missing column %q", required)
+		}
+	}
+	if len(sheet.Rows) > 0 {
+		value, err := parseValue(sheet.Rows[0][sheet.columnIndex(ColumnClass)])
+		if err != nil {
+			return Sheet{}, fmt.Errorf("row 1: %v", err)
+		}
+		sheet.Class = object.Class(value)
+	}
+	return sheet, nil
+}
+
+func (sheet Sheet) columnIndex(name string) int {
+	for index, column := range sheet.Columns {
+		if column == name {
+			return index
+		}
+	}
+	return -1
+}
+
+func cellValues(properties *objprop.Interpreters, triple object.Triple, prop *object.Properties) map[string]string {
+	cells := map[string]string{
+		ColumnClass:    strconv.Itoa(int(triple.Class)),
+		ColumnSubclass: strconv.Itoa(int(triple.Subclass)),
+		ColumnType:     strconv.Itoa(int(triple.Type)),
+	}
+	for _, field := range commonFields {
+		cells[commonPrefix+field.name] = strconv.FormatInt(field.get(&prop.Common), 10)
+	}
+	walkInterpreter(genericPrefix, properties.GenericProperties(triple.Class, prop.Generic), func(column string, value uint32) {
+		cells[column] = strconv.FormatUint(uint64(value), 10)
+	})
+	walkInterpreter(specificPrefix, properties.SpecificProperties(triple, prop.Specific), func(column string, value uint32) {
+		cells[column] = strconv.FormatUint(uint64(value), 10)
+	})
+	return cells
+}
+
+func interpretedKeys(properties *objprop.Interpreters, triple object.Triple, prop *object.Properties) []string {
+	var keys []string
+	collector := func(column string, value uint32) {
+		keys = append(keys, column)
+	}
+	walkInterpreter(genericPrefix, properties.GenericProperties(triple.Class, prop.Generic), collector)
+	walkInterpreter(specificPrefix, properties.SpecificProperties(triple, prop.Specific), collector)
+	return keys
+}
+
+func walkInterpreter(path string, inst *interpreters.Instance, consumer func(string, uint32)) {
+	for _, key := range inst.Keys() {
+		consumer(path+key, inst.Get(key))
+	}
+	for _, key := range inst.ActiveRefinements() {
+		walkInterpreter(path+key+".", inst.Refined(key), consumer)
+	}
+}
+
+func parseValue(cell string) (int64, error) {
+	return strconv.ParseInt(strings.TrimSpace(cell), 0, 64)
+}
+
+func sortedByDepth(columns []string) []string {
+	sorted := make([]string, len(columns))
+	copy(sorted, columns)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return strings.Count(sorted[a], ".") < strings.Count(sorted[b], ".")
+	})
+	return sorted
+}
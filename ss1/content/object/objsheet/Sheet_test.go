@@ -0,0 +1,58 @@
+package objsheet_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/content/object/objprop"
+	"github.com/inkyblackness/hacked/ss1/content/object/objsheet"
+)
+
+func noName(object.Triple) string {
+	return "name"
+}
+
+func TestExportCreatesOneSheetPerClass(t *testing.T) {
+	table := object.StandardPropertiesTable()
+
+	sheets := objsheet.Export(table, objprop.BuiltinInterpreters(), noName)
+
+	require.Equal(t, len(object.Classes()), len(sheets))
+	for index, sheet := range sheets {
+		assert.Equal(t, object.Class(index), sheet.Class)
+		assert.Equal(t, len(table.TriplesInClass(sheet.Class)), len(sheet.Rows))
+		for _, row := range sheet.Rows {
+			assert.Equal(t, len(sheet.Columns), len(row))
+		}
+	}
+}
+
+func TestExportContainsInterpretedColumns(t *testing.T) {
+	sheets := objsheet.Export(object.StandardPropertiesTable(), objprop.BuiltinInterpreters(), noName)
+	guns := sheets[object.ClassGun]
+
+	assert.Contains(t, guns.Columns, "Common.Mass")
+	assert.Contains(t, guns.Columns, "Generic.FireRate")
+	assert.Contains(t, guns.Columns, "Specific.BasicWeapon.Damage")
+}
+
+func TestWriteAndReadKeepSheet(t *testing.T) {
+	sheets := objsheet.Export(object.StandardPropertiesTable(), objprop.BuiltinInterpreters(), noName)
+	buf := bytes.NewBuffer(nil)
+
+	err := objsheet.Write(buf, sheets[object.ClassGrenade])
+	require.Nil(t, err)
+	sheet, err := objsheet.Read(buf)
+	require.Nil(t, err)
+
+	assert.Equal(t, sheets[object.ClassGrenade], sheet)
+}
+
+func TestReadFailsWithoutIdentifyingColumns(t *testing.T) {
+	_, err := objsheet.Read(bytes.NewBufferString("Class,Subclass,Common.Mass\n0,0,10\n"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,242 @@
+package objsheet
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/inkyblackness/hacked/ss1/content/interpreters"
+	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/content/object/objprop"
+)
+
+// Difference describes the change of one value of an object.
+type Difference struct {
+	Column   string
+	OldValue string
+	NewValue string
+}
+
+// Update describes the changed properties of one object.
+type Update struct {
+	Triple        object.Triple
+	OldProperties object.Properties
+	NewProperties object.Properties
+	Differences   []Difference
+}
+
+// Compare determines the updates the given sheet would cause for the table.
+// The properties are interpreted with the given interpreters.
+// Empty cells keep their current value. The table is not modified.
+// Objects without any change are not reported.
+func Compare(table object.PropertiesTable, properties *objprop.Interpreters, sheet Sheet) ([]Update, error) {
+	comparison := NewComparison(table, properties)
+	err := comparison.Add(sheet)
+	if err != nil {
+		return nil, err
+	}
+	return comparison.Updates(), nil
+}
+
+// Comparison determines the updates of several sheets, as Compare() does for a single one.
+// An object may only be listed once among all the sheets.
+type Comparison struct {
+	table      object.PropertiesTable
+	properties *objprop.Interpreters
+	listed     map[object.Triple]bool
+	updates    []Update
+}
+
+// NewComparison returns a comparison against the given table, interpreting the properties with the given interpreters.
+func NewComparison(table object.PropertiesTable, properties *objprop.Interpreters) *Comparison {
+	return &Comparison{
+		table:      table,
+		properties: properties,
+		listed:     make(map[object.Triple]bool),
+	}
+}
+
+// Add compares the given sheet. Should the sheet be invalid, or list an object that a previously added sheet
+// listed as well, an error is returned and none of the updates of the sheet are kept.
+func (comparison *Comparison) Add(sheet Sheet) error {
+	for _, column := range sheet.Columns {
+		if !isKnownColumn(column) {
+			return fmt.Errorf("unknown column %q", column)
+		}
+	}
+	var updates []Update
+	handled := make(map[object.Triple]bool)
+	for rowIndex, row := range sheet.Rows {
+		update, err := compareRow(comparison.table, comparison.properties, sheet.Columns, row)
+		if err != nil {
+			return fmt.Errorf("row %d: %v", rowIndex+1, err)
+		}
+		if handled[update.Triple] || comparison.listed[update.Triple] {
+			return fmt.Errorf("row %d: object %v listed more than once", rowIndex+1, update.Triple)
+		}
+		handled[update.Triple] = true
+		if len(update.Differences) > 0 {
+			updates = append(updates, update)
+		}
+	}
+	for triple := range handled {
+		comparison.listed[triple] = true
+	}
+	comparison.updates = append(comparison.updates, updates...)
+	return nil
+}
+
+// Updates returns the updates of all sheets added so far.
+func (comparison *Comparison) Updates() []Update {
+	return comparison.updates
+}
+
+func isKnownColumn(column string) bool {
+	switch column {
+	case ColumnClass, ColumnSubclass, ColumnType, ColumnName:
+		return true
+	}
+	for _, field := range commonFields {
+		if column == commonPrefix+field.name {
+			return true
+		}
+	}
+	return strings.HasPrefix(column, genericPrefix) || strings.HasPrefix(column, specificPrefix)
+}
+
+func compareRow(table object.PropertiesTable, properties *objprop.Interpreters,
+	columns []string, row []string) (Update, error) {
+	cells := make(map[string]string)
+	for index, column := range columns {
+		if (index < len(row)) && (len(strings.TrimSpace(row[index])) > 0) {
+			cells[column] = row[index]
+		}
+	}
+	var key [3]int64
+	for index, column := range []string{ColumnClass, ColumnSubclass, ColumnType} {
+		value, err := parseValue(cells[column])
+		if err != nil || (value < 0) || (value > 0xFF) {
+			return Update{}, fmt.Errorf("invalid %v", column)
+		}
+		key[index] = value
+	}
+	triple := object.TripleFrom(int(key[0]), int(key[1]), int(key[2]))
+	prop, err := table.ForObject(triple)
+	if err != nil {
+		return Update{}, fmt.Errorf("object %v: %v", triple, err)
+	}
+	update := Update{Triple: triple, OldProperties: prop.Clone(), NewProperties: prop.Clone()}
+
+	for _, field := range commonFields {
+		cell, set := cells[commonPrefix+field.name]
+		if !set {
+			continue
+		}
+		value, err := parseValue(cell)
+		if err != nil || (value < field.minValue) || (value > field.maxValue) {
+			return Update{}, fmt.Errorf("invalid value for %v: %q", field.name, cell)
+		}
+		field.set(&update.NewProperties.Common, value)
+	}
+	for _, column := range sortedByDepth(columns) {
+		cell, set := cells[column]
+		if !set {
+			continue
+		}
+		var inst *interpreters.Instance
+		var key string
+		switch {
+		case strings.HasPrefix(column, genericPrefix):
+			inst = properties.GenericProperties(triple.Class, update.NewProperties.Generic)
+			key = column[len(genericPrefix):]
+		case strings.HasPrefix(column, specificPrefix):
+			inst = properties.SpecificProperties(triple, update.NewProperties.Specific)
+			key = column[len(specificPrefix):]
+		default:
+			continue
+		}
+		err := setInterpreted(inst, key, cell)
+		if err != nil {
+			return Update{}, fmt.Errorf("%v: %v", column, err)
+		}
+	}
+
+	update.Differences = differences(columns,
+		cellValues(properties, triple, &update.OldProperties), cellValues(properties, triple, &update.NewProperties))
+	if (update.OldProperties.Common == update.NewProperties.Common) &&
+		bytes.Equal(update.OldProperties.Generic, update.NewProperties.Generic) &&
+		bytes.Equal(update.OldProperties.Specific, update.NewProperties.Specific) {
+		update.Differences = nil
+	}
+	return update, nil
+}
+
+func setInterpreted(inst *interpreters.Instance, path string, cell string) error {
+	keys := strings.Split(path, ".")
+	for _, refinement := range keys[:len(keys)-1] {
+		if !contains(inst.ActiveRefinements(), refinement) {
+			return fmt.Errorf("not applicable")
+		}
+		inst = inst.Refined(refinement)
+	}
+	key := keys[len(keys)-1]
+	if !contains(inst.Keys(), key) {
+		return fmt.Errorf("not applicable")
+	}
+	value, err := parseValue(cell)
+	if err != nil || (value < 0) || (value > 0xFFFFFFFF) {
+		return fmt.Errorf("invalid value %q", cell)
+	}
+	inst.Set(key, uint32(value))
+	if inst.Get(key) != uint32(value) {
+		return fmt.Errorf("value %q out of range", cell)
+	}
+	return nil
+}
+
+func differences(columns []string, oldCells, newCells map[string]string) []Difference {
+	var result []Difference
+	listed := make(map[string]bool)
+	add := func(column string) {
+		if listed[column] {
+			return
+		}
+		listed[column] = true
+		oldValue, newValue := oldCells[column], newCells[column]
+		if oldValue != newValue {
+			result = append(result, Difference{Column: column, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	for _, column := range columns {
+		add(column)
+	}
+	for _, column := range sortedKeys(oldCells, newCells) {
+		add(column)
+	}
+	return result
+}
+
+func sortedKeys(maps ...map[string]string) []string {
+	known := make(map[string]bool)
+	var keys []string
+	for _, m := range maps {
+		for key := range m {
+			if !known[key] {
+				known[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(list []string, value string) bool {
+	for _, entry := range list {
+		if entry == value {
+			return true
+		}
+	}
+	return false
+}
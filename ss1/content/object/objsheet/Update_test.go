@@ -0,0 +1,113 @@
+package objsheet_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/content/object/objprop"
+	"github.com/inkyblackness/hacked/ss1/content/object/objsheet"
+)
+
+func TestCompareOfUnchangedSheetHasNoUpdates(t *testing.T) {
+	table := object.StandardPropertiesTable()
+	for _, sheet := range objsheet.Export(table, objprop.BuiltinInterpreters(), noName) {
+		updates, err := objsheet.Compare(table, objprop.BuiltinInterpreters(), sheet)
+		require.Nil(t, err, "class %v", sheet.Class)
+		assert.Equal(t, 0, len(updates), "class %v", sheet.Class)
+	}
+}
+
+func TestCompareReportsChangedValues(t *testing.T) {
+	table := object.StandardPropertiesTable()
+	sheet := objsheet.Sheet{
+		Columns: []string{"Class", "Subclass", "Type", "Name", "Common.Mass", "Generic.FireRate", "Specific.BasicWeapon.Damage"},
+		Rows: [][]string{
+			{"0", "2", "1", "ignored", "1234", "", "0x20"},
+			{"0", "0", "0", "", "", "", ""},
+		},
+	}
+
+	updates, err := objsheet.Compare(table, objprop.BuiltinInterpreters(), sheet)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(updates))
+	update := updates[0]
+	assert.Equal(t, object.TripleFrom(0, 2, 1), update.Triple)
+	assert.Equal(t, []objsheet.Difference{
+		{Column: "Common.Mass", OldValue: "0", NewValue: "1234"},
+		{Column: "Specific.BasicWeapon.Damage", OldValue: "0", NewValue: "32"},
+	}, update.Differences)
+	assert.Equal(t, int32(1234), update.NewProperties.Common.Mass)
+	assert.Equal(t, []byte{0x20, 0x00}, update.NewProperties.Specific[0:2])
+
+	original, _ := table.ForObject(object.TripleFrom(0, 2, 1))
+	assert.Equal(t, int32(0), original.Common.Mass, "table must not be modified")
+}
+
+func TestCompareFailsForInvalidContent(t *testing.T) {
+	table := object.StandardPropertiesTable()
+	tt := []struct {
+		name    string
+		columns []string
+		row     []string
+	}{
+		{name: "unknown column", columns: []string{"Class", "Subclass", "Type", "Something"}, row: []string{"0", "0", "0", "1"}},
+		{name: "unknown object", columns: []string{"Class", "Subclass", "Type"}, row: []string{"0", "0", "100"}},
+		{name: "invalid number", columns: []string{"Class", "Subclass", "Type", "Common.Mass"}, row: []string{"0", "0", "0", "abc"}},
+		{name: "common out of range", columns: []string{"Class", "Subclass", "Type", "Common.Armor"}, row: []string{"0", "0", "0", "256"}},
+		{name: "interpreted out of range", columns: []string{"Class", "Subclass", "Type", "Generic.FireRate"}, row: []string{"0", "0", "0", "256"}},
+		{name: "not applicable", columns: []string{"Class", "Subclass", "Type", "Generic.Unknown"}, row: []string{"0", "0", "0", "1"}},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			_, err := objsheet.Compare(table, objprop.BuiltinInterpreters(), objsheet.Sheet{Columns: td.columns, Rows: [][]string{td.row}})
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestComparisonFailsForObjectsListedInSeveralSheets(t *testing.T) {
+	table := object.StandardPropertiesTable()
+	first := objsheet.Sheet{
+		Columns: []string{"Class", "Subclass", "Type"},
+		Rows:    [][]string{{"0", "0", "0"}},
+	}
+	second := objsheet.Sheet{
+		Columns: []string{"Class", "Subclass", "Type", "Common.Mass"},
+		Rows:    [][]string{{"0", "0", "1"}, {"0", "0", "0"}},
+	}
+	comparison := objsheet.NewComparison(table, objprop.BuiltinInterpreters())
+	require.Nil(t, comparison.Add(first))
+	err := comparison.Add(second)
+	assert.NotNil(t, err)
+}
+
+func TestComparisonCollectsUpdatesOfAllSheets(t *testing.T) {
+	table := object.StandardPropertiesTable()
+	comparison := objsheet.NewComparison(table, objprop.BuiltinInterpreters())
+	require.Nil(t, comparison.Add(objsheet.Sheet{
+		Columns: []string{"Class", "Subclass", "Type", "Common.Mass"},
+		Rows:    [][]string{{"0", "0", "0", "1234"}},
+	}))
+	require.Nil(t, comparison.Add(objsheet.Sheet{
+		Columns: []string{"Class", "Subclass", "Type", "Common.Mass"},
+		Rows:    [][]string{{"0", "0", "1", "1234"}},
+	}))
+	updates := comparison.Updates()
+	require.Equal(t, 2, len(updates))
+	assert.Equal(t, object.TripleFrom(0, 0, 0), updates[0].Triple)
+	assert.Equal(t, object.TripleFrom(0, 0, 1), updates[1].Triple)
+}
+
+func TestCompareFailsForDuplicateObjects(t *testing.T) {
+	table := object.StandardPropertiesTable()
+	sheet := objsheet.Sheet{
+		Columns: []string{"Class", "Subclass", "Type"},
+		Rows:    [][]string{{"0", "0", "0"}, {"0", "0", "0"}},
+	}
+	_, err := objsheet.Compare(table, objprop.BuiltinInterpreters(), sheet)
+	assert.NotNil(t, err)
+}
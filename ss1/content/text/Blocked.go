@@ -3,9 +3,9 @@ package text
 import "strings"
 
 const (
-	// textLineLimit is the lowest number found by a cursory search.
-	// It appears that various texts have different limits.
-	textLineLimit = 79
+	// LineLimit is the maximum length of one block of text, in bytes.
+	// It is the lowest number found by a cursory search. It appears that various texts have different limits.
+	LineLimit = 79
 )
 
 // Blocked splits the given input string into a series of lines, usable for blocked serialization.
@@ -28,7 +28,7 @@ func Blocked(input string) []string {
 			if wordIndex > 0 {
 				resultLine += " "
 			}
-			if (len(resultLine) + len(word)) > textLineLimit {
+			if (len(resultLine) + len(word)) > LineLimit {
 				addBlock()
 			}
 			resultLine += word
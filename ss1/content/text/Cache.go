@@ -12,7 +12,7 @@ type textReader func(resource.Selector, resource.Key, Codepage) (string, error)
 
 // Cache retrieves texts from a localizer and keeps them decoded until they are invalidated.
 type Cache struct {
-	cp        Codepage
+	codepages Codepages
 	localizer resource.Localizer
 	reader    textReader
 
@@ -20,9 +20,9 @@ type Cache struct {
 	texts       map[resource.Key]string
 }
 
-func newCache(cp Codepage, localizer resource.Localizer, keyResolver keyResolver, reader textReader) *Cache {
+func newCache(codepages Codepages, localizer resource.Localizer, keyResolver keyResolver, reader textReader) *Cache {
 	cache := &Cache{
-		cp:        cp,
+		codepages: codepages,
 		localizer: localizer,
 		reader:    reader,
 
@@ -33,13 +33,13 @@ func newCache(cp Codepage, localizer resource.Localizer, keyResolver keyResolver
 }
 
 // NewLineCache returns a cache for single-block texts.
-func NewLineCache(cp Codepage, localizer resource.Localizer) *Cache {
-	return newCache(cp, localizer, func(key resource.Key) resource.Key { return key }, readLine)
+func NewLineCache(codepages Codepages, localizer resource.Localizer) *Cache {
+	return newCache(codepages, localizer, func(key resource.Key) resource.Key { return key }, readLine)
 }
 
 // NewPageCache returns a cache for resource-based texts.
-func NewPageCache(cp Codepage, localizer resource.Localizer) *Cache {
-	return newCache(cp, localizer, func(key resource.Key) resource.Key {
+func NewPageCache(codepages Codepages, localizer resource.Localizer) *Cache {
+	return newCache(codepages, localizer, func(key resource.Key) resource.Key {
 		return resource.KeyOf(key.ID.Plus(key.Index), key.Lang, 0)
 	}, readPage)
 }
@@ -106,7 +106,7 @@ func (cache *Cache) Text(key resource.Key) (string, error) {
 		return value, nil
 	}
 	selector := cache.localizer.LocalizedResources(key.Lang)
-	value, err := cache.reader(selector, key, cache.cp)
+	value, err := cache.reader(selector, key, cache.codepages.ForLanguage(key.Lang))
 	if err != nil {
 		return "", err
 	}
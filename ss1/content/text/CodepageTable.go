@@ -0,0 +1,51 @@
+package text
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LoadCodepage reads a custom codepage from a mapping table.
+// The table uses the format of the mapping files of the Unicode Consortium: Each line maps one byte value
+// to one Unicode code point, both in hexadecimal notation, such as "0x80 0x0106". Anything after a '#' is a comment.
+// Byte values that are not mentioned in the table keep their mapping of CP437.
+func LoadCodepage(reader io.Reader) (Codepage, error) {
+	if reader == nil {
+		return nil, errors.New("reader is nil")
+	}
+	table := cp437ToRune
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if commentStart := strings.Index(line, "#"); commentStart >= 0 {
+			line = line[:commentStart]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected byte value and code point", lineNumber)
+		}
+		value, err := strconv.ParseUint(fields[0], 0, 8)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid byte value: %v", lineNumber, err)
+		}
+		codePoint, err := strconv.ParseUint(fields[1], 0, 32)
+		if (err != nil) || (codePoint > 0xFFFF) {
+			return nil, fmt.Errorf("line %d: invalid code point %v", lineNumber, fields[1])
+		}
+		if value == 0x00 {
+			return nil, fmt.Errorf("line %d: byte value 0x00 is reserved as terminator", lineNumber)
+		}
+		table[value] = rune(codePoint)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return newTabledCodepage(table), nil
+}
@@ -0,0 +1,42 @@
+package text_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/inkyblackness/hacked/ss1/content/text"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCodepageMapsGivenValues(t *testing.T) {
+	cp, err := text.LoadCodepage(strings.NewReader("# custom table\n0x80\t0x0106 # C with acute\n\n0x81 0x0107\n"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte{0x80, 0x81, 0x00}, cp.Encode("Ćć"))
+	assert.Equal(t, "Ćć", cp.Decode([]byte{0x80, 0x81}))
+}
+
+func TestLoadCodepageKeepsUnmentionedValuesOfDefault(t *testing.T) {
+	cp, err := text.LoadCodepage(strings.NewReader("0x80 0x0106\n"))
+	require.Nil(t, err)
+	assert.Equal(t, "Aü", cp.Decode([]byte{0x41, 0x81}))
+}
+
+func TestLoadCodepageReturnsErrorForInvalidLines(t *testing.T) {
+	invalid := []string{
+		"0x80\n",
+		"0x100 0x0041\n",
+		"0x80 abc\n",
+		"0x00 0x0041\n",
+	}
+	for _, table := range invalid {
+		_, err := text.LoadCodepage(strings.NewReader(table))
+		assert.NotNil(t, err, "error expected for <"+table+">")
+	}
+}
+
+func TestLoadCodepageReturnsErrorForNilReader(t *testing.T) {
+	_, err := text.LoadCodepage(nil)
+	assert.NotNil(t, err)
+}
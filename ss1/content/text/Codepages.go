@@ -0,0 +1,47 @@
+package text
+
+import "github.com/inkyblackness/hacked/ss1/resource"
+
+// Codepages provides the codepage to use for the texts of a language.
+type Codepages interface {
+	// ForLanguage returns the codepage for given language.
+	ForLanguage(lang resource.Language) Codepage
+}
+
+// LanguageCodepages is a set of codepages, with one codepage per language.
+// Languages without an explicit codepage use the default codepage.
+type LanguageCodepages struct {
+	defaultCodepage Codepage
+	byLanguage      map[resource.Language]Codepage
+}
+
+// NewLanguageCodepages returns a new instance with given default codepage.
+func NewLanguageCodepages(defaultCodepage Codepage) *LanguageCodepages {
+	return &LanguageCodepages{
+		defaultCodepage: defaultCodepage,
+		byLanguage:      make(map[resource.Language]Codepage),
+	}
+}
+
+// ForLanguage returns the codepage for given language.
+func (codepages *LanguageCodepages) ForLanguage(lang resource.Language) Codepage {
+	cp, set := codepages.byLanguage[lang]
+	if !set {
+		return codepages.defaultCodepage
+	}
+	return cp
+}
+
+// Set registers the codepage to use for given language. A nil codepage resets the language to the default.
+func (codepages *LanguageCodepages) Set(lang resource.Language, cp Codepage) {
+	if cp == nil {
+		delete(codepages.byLanguage, lang)
+	} else {
+		codepages.byLanguage[lang] = cp
+	}
+}
+
+// Clear resets all languages to the default codepage.
+func (codepages *LanguageCodepages) Clear() {
+	codepages.byLanguage = make(map[resource.Language]Codepage)
+}
@@ -0,0 +1,39 @@
+package text_test
+
+import (
+	"testing"
+
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/resource"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguageCodepagesReturnsDefaultForUnsetLanguage(t *testing.T) {
+	defaultCodepage := text.DefaultCodepage()
+	codepages := text.NewLanguageCodepages(defaultCodepage)
+
+	assert.Equal(t, defaultCodepage, codepages.ForLanguage(resource.LangGerman))
+}
+
+func TestLanguageCodepagesReturnsSetCodepage(t *testing.T) {
+	codepages := text.NewLanguageCodepages(text.DefaultCodepage())
+	cp866, _ := text.CodepageByName(text.CP866)
+	codepages.Set(resource.LangFrench, cp866)
+
+	assert.Equal(t, cp866, codepages.ForLanguage(resource.LangFrench))
+	assert.NotEqual(t, cp866, codepages.ForLanguage(resource.LangDefault))
+}
+
+func TestLanguageCodepagesCanBeReset(t *testing.T) {
+	defaultCodepage := text.DefaultCodepage()
+	codepages := text.NewLanguageCodepages(defaultCodepage)
+	cp866, _ := text.CodepageByName(text.CP866)
+	codepages.Set(resource.LangFrench, cp866)
+	codepages.Set(resource.LangGerman, cp866)
+
+	codepages.Set(resource.LangFrench, nil)
+	assert.Equal(t, defaultCodepage, codepages.ForLanguage(resource.LangFrench))
+	codepages.Clear()
+	assert.Equal(t, defaultCodepage, codepages.ForLanguage(resource.LangGerman))
+}
@@ -22,13 +22,5 @@ var cp437ToRune = [256]rune{
 // DefaultCodepage returns a Codepage instance that represents the one used for the resources.
 // It is based on the Code Page 437 ( https://en.wikipedia.org/wiki/Code_page_437 ).
 func DefaultCodepage() Codepage {
-	cp437ToByte := make(map[rune]byte)
-
-	for i, r := range &cp437ToRune {
-		cp437ToByte[r] = byte(i)
-	}
-
-	cp := &tabledCodepage{tableToRune: cp437ToRune[:], tableToByte: cp437ToByte}
-
-	return cp
+	return newTabledCodepage(cp437ToRune)
 }
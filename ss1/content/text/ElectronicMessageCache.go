@@ -8,16 +8,16 @@ import (
 
 // ElectronicMessageCache retrieves messages from a localizer and keeps them decoded until they are invalidated.
 type ElectronicMessageCache struct {
-	cp        Codepage
+	codepages Codepages
 	localizer resource.Localizer
 
 	messages map[resource.Key]ElectronicMessage
 }
 
 // NewElectronicMessageCache returns a new instance.
-func NewElectronicMessageCache(cp Codepage, localizer resource.Localizer) *ElectronicMessageCache {
+func NewElectronicMessageCache(codepages Codepages, localizer resource.Localizer) *ElectronicMessageCache {
 	cache := &ElectronicMessageCache{
-		cp:        cp,
+		codepages: codepages,
 		localizer: localizer,
 
 		messages: make(map[resource.Key]ElectronicMessage),
@@ -51,7 +51,7 @@ func (cache *ElectronicMessageCache) Message(key resource.Key) (ElectronicMessag
 	if (view.ContentType() != resource.Text) || !view.Compound() {
 		return EmptyElectronicMessage(), errors.New("invalid resource type")
 	}
-	value, err = DecodeElectronicMessage(cache.cp, view)
+	value, err = DecodeElectronicMessage(cache.codepages.ForLanguage(key.Lang), view)
 	if err != nil {
 		return EmptyElectronicMessage(), err
 	}
@@ -113,7 +113,7 @@ func (suite *ElectronicMessageCacheSuite) TestTextReturnsErrorIfResourceIsNotATe
 }
 
 func (suite *ElectronicMessageCacheSuite) givenACache() {
-	suite.instance = text.NewElectronicMessageCache(suite.cp, suite)
+	suite.instance = text.NewElectronicMessageCache(text.NewLanguageCodepages(suite.cp), suite)
 }
 
 func (suite *ElectronicMessageCacheSuite) givenResourcesAre(resources ...resource.LocalizedResources) {
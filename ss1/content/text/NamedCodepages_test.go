@@ -0,0 +1,49 @@
+package text_test
+
+import (
+	"testing"
+
+	"github.com/inkyblackness/hacked/ss1/content/text"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodepageByNameProvidesAllNamedCodepages(t *testing.T) {
+	for _, name := range text.CodepageNames() {
+		cp, known := text.CodepageByName(name)
+		require.True(t, known, "codepage "+name+" should be known")
+		assert.Equal(t, "Text 123", cp.Decode(cp.Encode("Text 123")), "lower half should match for "+name)
+	}
+}
+
+func TestCodepageByNameIsCaseInsensitive(t *testing.T) {
+	_, known := text.CodepageByName("cp852")
+	assert.True(t, known)
+}
+
+func TestCodepageByNameReturnsFalseForUnknownName(t *testing.T) {
+	_, known := text.CodepageByName("CP1252")
+	assert.False(t, known)
+}
+
+func TestCodepageByNameMapsCharactersOfCodepage(t *testing.T) {
+	tt := []struct {
+		name    string
+		value   string
+		encoded []byte
+	}{
+		{name: text.CP437, value: "ä", encoded: []byte{0x84, 0x00}},
+		{name: text.CP850, value: "ø", encoded: []byte{0x9B, 0x00}},
+		{name: text.CP852, value: "ł", encoded: []byte{0x88, 0x00}},
+		{name: text.CP866, value: "Ж", encoded: []byte{0x86, 0x00}},
+	}
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.name, func(t *testing.T) {
+			cp, _ := text.CodepageByName(td.name)
+			assert.Equal(t, td.encoded, cp.Encode(td.value))
+			assert.Equal(t, td.value, cp.Decode(td.encoded))
+		})
+	}
+}
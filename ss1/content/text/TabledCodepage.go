@@ -5,6 +5,14 @@ type tabledCodepage struct {
 	tableToByte map[rune]byte
 }
 
+func newTabledCodepage(table [256]rune) *tabledCodepage {
+	tableToByte := make(map[rune]byte)
+	for i, r := range &table {
+		tableToByte[r] = byte(i)
+	}
+	return &tabledCodepage{tableToRune: table[:], tableToByte: tableToByte}
+}
+
 func (cp *tabledCodepage) Encode(value string) []byte {
 	result := make([]byte, 0, len(value)+1)
 
@@ -0,0 +1,28 @@
+package text
+
+// UnmappableRunes returns the characters of given value that can not be represented by the codepage.
+// Each character is reported once, in the order of their first occurrence.
+func UnmappableRunes(cp Codepage, value string) []rune {
+	var result []rune
+	checked := make(map[rune]bool)
+	for _, r := range value {
+		if checked[r] {
+			continue
+		}
+		checked[r] = true
+		single := string(r)
+		if cp.Decode(cp.Encode(single)) != single {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// CodepageRunes returns all characters the codepage can represent, excluding the terminator.
+func CodepageRunes(cp Codepage) []rune {
+	var result []rune
+	for value := 1; value < 256; value++ {
+		result = append(result, []rune(cp.Decode([]byte{byte(value)}))...)
+	}
+	return result
+}
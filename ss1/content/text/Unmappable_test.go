@@ -0,0 +1,29 @@
+package text_test
+
+import (
+	"testing"
+
+	"github.com/inkyblackness/hacked/ss1/content/text"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmappableRunesReturnsNilForMappableText(t *testing.T) {
+	result := text.UnmappableRunes(text.DefaultCodepage(), "Größe?\nJa.")
+
+	assert.Nil(t, result)
+}
+
+func TestUnmappableRunesReturnsEachUnmappableCharacterOnce(t *testing.T) {
+	result := text.UnmappableRunes(text.DefaultCodepage(), "„Łódź” Łódź")
+
+	assert.Equal(t, []rune{'„', 'Ł', 'ź', '”'}, result)
+}
+
+func TestCodepageRunesReturnsAllCharactersExceptTerminator(t *testing.T) {
+	result := text.CodepageRunes(text.DefaultCodepage())
+
+	assert.Equal(t, 255, len(result))
+	assert.Equal(t, rune(0x01), result[0])
+	assert.Equal(t, rune(0xA0), result[254])
+}
@@ -0,0 +1,50 @@
+package artwork
+
+import (
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+)
+
+// BitmapCategory describes a list resource of bitmaps that share their encoding.
+type BitmapCategory struct {
+	// ID identifies the list resource.
+	ID resource.ID
+	// Title is the human readable name of the category.
+	Title string
+	// Name is the name of the category within paths.
+	Name string
+	// LanguageSpecific is set if the bitmaps exist per language.
+	LanguageSpecific bool
+	// Type is the bitmap type to use for new bitmaps.
+	Type bitmap.Type
+	// Flags are the bitmap flags to use for new bitmaps.
+	Flags bitmap.Flag
+}
+
+// BitmapCategories returns the list resources of bitmaps, in order of presentation.
+func BitmapCategories() []BitmapCategory {
+	return []BitmapCategory{
+		{ID: ids.MfdDataBitmaps, Title: "MFD Data Images", Name: "mfd-data", LanguageSpecific: true,
+			Type: bitmap.TypeCompressed8Bit, Flags: bitmap.FlagTransparent},
+		{ID: ids.ObjectMaterialBitmaps, Title: "Object Materials", Name: "object-materials",
+			Type: bitmap.TypeFlat8Bit},
+		{ID: ids.ObjectTextureBitmaps, Title: "Object Textures", Name: "object-textures",
+			Type: bitmap.TypeFlat8Bit},
+		{ID: ids.IconBitmaps, Title: "Wall Icons", Name: "wall-icons",
+			Type: bitmap.TypeCompressed8Bit, Flags: bitmap.FlagTransparent},
+		{ID: ids.GraffitiBitmaps, Title: "Graffiti", Name: "graffiti",
+			Type: bitmap.TypeCompressed8Bit, Flags: bitmap.FlagTransparent},
+	}
+}
+
+// BitmapCategoryOf returns the category of the given resource.
+// The returned boolean is false if the resource is not a category.
+func BitmapCategoryOf(id resource.ID) (BitmapCategory, bool) {
+	for _, category := range BitmapCategories() {
+		if category.ID == id {
+			return category, true
+		}
+	}
+	return BitmapCategory{}, false
+}
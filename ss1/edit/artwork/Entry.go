@@ -0,0 +1,159 @@
+package artwork
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/edit/objtypes"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+)
+
+// Entry describes one graphic that can be exchanged as an image file.
+type Entry struct {
+	// Path is the slash separated, relative path of the image file.
+	Path string
+	// Key identifies the resource block of a bitmap. For animations, it identifies the animation resource.
+	Key resource.Key
+	// Animation is set for entries that are exchanged as animated GIF files.
+	Animation bool
+	// FramesID is the resource that holds the frames of an animation.
+	FramesID resource.ID
+
+	// Type is the bitmap type to use when encoding imported images.
+	Type bitmap.Type
+	// Flags are the bitmap flags to use when encoding imported images.
+	Flags bitmap.Flag
+}
+
+// Source provides the resources and properties the entries are based on.
+type Source interface {
+	resource.Localizer
+	// ObjectProperties returns the table of object types.
+	ObjectProperties() object.PropertiesTable
+	// ResourceIDsIn returns the identifiers of all resources in the given file.
+	ResourceIDsIn(file resource.Filename) []resource.ID
+	// AdditionalLanguages returns the languages beyond the built-in ones.
+	AdditionalLanguages() resource.LanguageSpecs
+}
+
+var textureSizes = []struct {
+	name string
+	id   resource.ID
+}{
+	{name: "large", id: ids.LargeTextures},
+	{name: "medium", id: ids.MediumTextures},
+	{name: "small", id: ids.SmallTextures},
+	{name: "icon", id: ids.IconTextures},
+}
+
+// Entries returns the list of all exchangeable graphics of the source.
+// The object bitmaps are named after the object types of the source. The MFD art covers the bitmaps that
+// currently exist in the MFD art files, apart from the ones of the bitmap categories.
+func Entries(source Source) []Entry {
+	var entries []Entry
+	languages := source.AdditionalLanguages()
+	for _, category := range BitmapCategories() {
+		info, _ := ids.Info(category.ID)
+		for _, lang := range languagesFor(languages, category.LanguageSpecific) {
+			for index := 0; index < info.MaxCount; index++ {
+				entries = append(entries, Entry{
+					Path: categoryPath("bitmaps", category.Name, languages, lang, category.LanguageSpecific,
+						fmt.Sprintf("%03d.png", index)),
+					Key:   blockKey(category.ID, lang, index),
+					Type:  category.Type,
+					Flags: category.Flags,
+				})
+			}
+		}
+	}
+	entries = append(entries, mfdArtEntries(source)...)
+	for _, size := range textureSizes {
+		for index := 0; index < world.MaxWorldTextures; index++ {
+			entries = append(entries, Entry{
+				Path: fmt.Sprintf("textures/%s/%03d.png", size.name, index),
+				Key:  blockKey(size.id, resource.LangAny, index),
+				Type: bitmap.TypeFlat8Bit,
+			})
+		}
+	}
+	properties := source.ObjectProperties()
+	baseOffsets := objtypes.BitmapOffsets(properties)
+	properties.Iterate(func(triple object.Triple, prop *object.Properties) bool {
+		for offset := 0; offset < objtypes.BitmapCount(prop); offset++ {
+			entries = append(entries, Entry{
+				Path: fmt.Sprintf("objects/%02d_%d_%02d-%02d.png", triple.Class, triple.Subclass, triple.Type, offset),
+				Key:  resource.KeyOf(ids.ObjectBitmaps, resource.LangAny, baseOffsets[triple]+offset),
+				Type: bitmap.TypeFlat8Bit, Flags: bitmap.FlagTransparent,
+			})
+		}
+		return true
+	})
+	animInfo, _ := ids.Info(ids.VideoMailAnimationsStart)
+	for index := 0; index < animInfo.MaxCount; index++ {
+		entries = append(entries, Entry{
+			Path:      fmt.Sprintf("animations/video-mail/%02d.gif", index),
+			Key:       resource.KeyOf(ids.VideoMailAnimationsStart.Plus(index), resource.LangAny, 0),
+			Animation: true,
+			FramesID:  ids.VideoMailBitmapsStart.Plus(index),
+			Type:      bitmap.TypeCompressed8Bit,
+		})
+	}
+	return entries
+}
+
+// mfdArtEntries returns the entries of all compound bitmap resources of the MFD art files.
+func mfdArtEntries(source Source) []Entry {
+	var entries []Entry
+	languages := source.AdditionalLanguages()
+	for _, id := range source.ResourceIDsIn(ids.MfdArt) {
+		if _, isCategory := BitmapCategoryOf(id); isCategory {
+			continue
+		}
+		for _, lang := range languages.Languages() {
+			view, err := source.LocalizedResources(lang).Select(id)
+			if (err != nil) || !view.Compound() || (view.ContentType() != resource.Bitmap) {
+				continue
+			}
+			for index := 0; index < view.BlockCount(); index++ {
+				entries = append(entries, Entry{
+					Path: categoryPath("bitmaps", "mfd-art", languages, lang, true,
+						fmt.Sprintf("%04X-%03d.png", id.Value(), index)),
+					Key:   resource.KeyOf(id, lang, index),
+					Type:  bitmap.TypeCompressed8Bit,
+					Flags: bitmap.FlagTransparent,
+				})
+			}
+		}
+	}
+	return entries
+}
+
+func languagesFor(languages resource.LanguageSpecs, languageSpecific bool) []resource.Language {
+	if languageSpecific {
+		return languages.Languages()
+	}
+	return []resource.Language{resource.LangAny}
+}
+
+func categoryPath(base string, name string, languages resource.LanguageSpecs, lang resource.Language,
+	languageSpecific bool, filename string) string {
+	parts := []string{base, name}
+	if languageSpecific {
+		parts = append(parts, strings.ToLower(languages.Name(lang)))
+	}
+	return strings.Join(append(parts, filename), "/")
+}
+
+// blockKey returns the key of the block that holds the bitmap with given index.
+// List resources store their bitmaps as blocks, other resources have one resource per bitmap.
+func blockKey(id resource.ID, lang resource.Language, index int) resource.Key {
+	info, _ := ids.Info(id)
+	if info.List {
+		return resource.KeyOf(id, lang, index)
+	}
+	return resource.KeyOf(id.Plus(index), lang, 0)
+}
@@ -0,0 +1,330 @@
+package artwork_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/edit/artwork"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+)
+
+type ExchangeSuite struct {
+	suite.Suite
+
+	dir                string
+	palette            bitmap.Palette
+	mapSettings        bitmap.MapSettings
+	localizedResources resource.LocalizedResourcesList
+
+	exportResult artwork.ExportResult
+	importResult artwork.ImportResult
+}
+
+func TestExchangeSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeSuite))
+}
+
+func (suite *ExchangeSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "artwork")
+	require.Nil(suite.T(), err)
+	suite.dir = dir
+	for index := range suite.palette {
+		suite.palette[index] = bitmap.RGB{Red: byte(index), Green: byte(255 - index), Blue: 0x40}
+	}
+	suite.mapSettings = bitmap.DefaultMapSettings()
+	suite.localizedResources = nil
+	suite.exportResult = artwork.ExportResult{}
+	suite.importResult = artwork.ImportResult{}
+}
+
+func (suite *ExchangeSuite) TearDownTest() {
+	_ = os.RemoveAll(suite.dir)
+}
+
+func (suite *ExchangeSuite) TestEntriesHavePredictableUniquePaths() {
+	entries := artwork.Entries(suite)
+	paths := make(map[string]bool)
+	for _, entry := range entries {
+		assert.False(suite.T(), paths[entry.Path], "duplicate path "+entry.Path)
+		paths[entry.Path] = true
+	}
+	assert.True(suite.T(), paths["textures/large/000.png"])
+	assert.True(suite.T(), paths["textures/icon/292.png"])
+	assert.True(suite.T(), paths["bitmaps/graffiti/063.png"])
+	assert.True(suite.T(), paths["bitmaps/mfd-data/german/255.png"])
+	assert.True(suite.T(), paths["animations/video-mail/11.gif"])
+}
+
+func (suite *ExchangeSuite) TestEntriesCoverExistingMfdArtBesideCategories() {
+	suite.givenLocalizedResources(resource.LangGerman,
+		suite.storingBitmap(resource.ID(0x0AC0), 2, 1, []byte{0x01, 0x02}),
+		suite.storingBitmap(ids.MfdDataBitmaps, 2, 1, []byte{0x01, 0x02}))
+	var paths []string
+	for _, entry := range artwork.Entries(suite) {
+		if strings.HasPrefix(entry.Path, "bitmaps/mfd-art/") {
+			paths = append(paths, entry.Path)
+			assert.Equal(suite.T(), resource.KeyOf(resource.ID(0x0AC0), resource.LangGerman, 0), entry.Key)
+		}
+	}
+	assert.Equal(suite.T(), []string{"bitmaps/mfd-art/german/0AC0-000.png"}, paths)
+}
+
+func (suite *ExchangeSuite) TestExportWritesExistingBitmapsOnly() {
+	suite.givenResources(suite.storingBitmap(ids.GraffitiBitmaps, 2, 1, []byte{0x01, 0x02}))
+	suite.whenExporting(suite.graffitiEntries())
+	assert.Equal(suite.T(), 1, suite.exportResult.Written)
+	assert.Empty(suite.T(), suite.exportResult.Failed)
+	suite.thenFileShouldExist("bitmaps/graffiti/000.png")
+}
+
+func (suite *ExchangeSuite) TestImportOfUnmodifiedExportReportsNoChanges() {
+	suite.givenResources(suite.storingBitmap(ids.GraffitiBitmaps, 2, 2, []byte{0x01, 0x02, 0x03, 0x04}))
+	suite.givenExported(suite.graffitiEntries())
+	suite.whenImporting(suite.graffitiEntries())
+	assert.Empty(suite.T(), suite.importResult.Bitmaps)
+	assert.Equal(suite.T(), 1, suite.importResult.Unchanged)
+}
+
+func (suite *ExchangeSuite) TestImportReturnsChangedBitmapKeepingAnchor() {
+	suite.givenResources(suite.storingBitmap(ids.GraffitiBitmaps, 2, 1, []byte{0x01, 0x02}))
+	suite.givenExported(suite.graffitiEntries())
+	suite.givenFile("bitmaps/graffiti/000.png", []byte{0x05, 0x06}, 2, 1)
+	suite.whenImporting(suite.graffitiEntries())
+	require.Len(suite.T(), suite.importResult.Bitmaps, 1)
+	change := suite.importResult.Bitmaps[0]
+	assert.Equal(suite.T(), resource.KeyOf(ids.GraffitiBitmaps, resource.LangAny, 0), change.Entry.Key)
+	bmp, err := bitmap.Decode(bytes.NewReader(change.Data))
+	require.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []byte{0x05, 0x06}, bmp.Pixels)
+	assert.Equal(suite.T(), bitmap.Area{1, 2, 0, 0}, bmp.Header.Area)
+	assert.Equal(suite.T(), bitmap.TypeCompressed8Bit, bmp.Header.Type)
+}
+
+func (suite *ExchangeSuite) TestImportAddsNewBitmapsWithTemplateType() {
+	suite.givenResources()
+	suite.givenFile("bitmaps/graffiti/003.png", []byte{0x07, 0x08, 0x09, 0x0A}, 2, 2)
+	suite.whenImporting(suite.graffitiEntries())
+	require.Len(suite.T(), suite.importResult.Bitmaps, 1)
+	bmp, err := bitmap.Decode(bytes.NewReader(suite.importResult.Bitmaps[0].Data))
+	require.Nil(suite.T(), err)
+	assert.Equal(suite.T(), bitmap.FlagTransparent, bmp.Header.Flags)
+	assert.Equal(suite.T(), byte(1), bmp.Header.WidthFactor)
+	assert.Equal(suite.T(), 3, suite.importResult.Bitmaps[0].Entry.Key.Index)
+}
+
+func (suite *ExchangeSuite) TestImportReportsUnreadableFiles() {
+	suite.givenResources()
+	suite.givenRawFile("bitmaps/graffiti/001.png", []byte{0x00, 0x01})
+	suite.whenImporting(suite.graffitiEntries())
+	assert.Equal(suite.T(), []string{"bitmaps/graffiti/001.png"}, suite.importResult.Failed)
+}
+
+func (suite *ExchangeSuite) TestImportMapsImagesWithGivenSettings() {
+	cyclingColor := color.NRGBA{R: 0x0C, G: 0xF3, B: 0x40, A: 0xFF}
+	suite.givenResources()
+	suite.givenTrueColorFile("bitmaps/graffiti/000.png", cyclingColor)
+
+	suite.whenImporting(suite.graffitiEntries())
+	assert.NotEqual(suite.T(), byte(0x0C), suite.importedPixel(0), "default settings must avoid color cycling")
+
+	suite.mapSettings.AllowedIndices = bitmap.DefaultIndexMask().
+		With(bitmap.ReservedPaletteRanges().Purposed(bitmap.PaletteRangeColorCycling))
+	suite.whenImporting(suite.graffitiEntries())
+	assert.Equal(suite.T(), byte(0x0C), suite.importedPixel(0), "allowed index should be used")
+}
+
+func (suite *ExchangeSuite) TestAnimationsRoundTrip() {
+	entries := suite.animationEntries()
+	suite.givenResources(suite.storingAnimation(entries[0], [][]byte{{0x01, 0x02}, {0x01, 0x03}}))
+	suite.givenExported(entries)
+	suite.whenImporting(entries)
+	assert.Empty(suite.T(), suite.importResult.Animations)
+	assert.Equal(suite.T(), 1, suite.importResult.Unchanged)
+}
+
+func (suite *ExchangeSuite) TestImportRefusesAnimationsWithTooManyFrames() {
+	entries := suite.animationEntries()
+	suite.givenResources()
+	palette := suite.palette.ColorPalette(false)
+	data := gif.GIF{Config: image.Config{Width: 1, Height: 1, ColorModel: palette}}
+	for index := 0; index <= bitmap.MaxAnimationFrames; index++ {
+		data.Image = append(data.Image, image.NewPaletted(image.Rect(0, 0, 1, 1), palette))
+		data.Delay = append(data.Delay, 10)
+	}
+	buf := bytes.NewBuffer(nil)
+	require.Nil(suite.T(), gif.EncodeAll(buf, &data))
+	suite.givenRawFile(entries[0].Path, buf.Bytes())
+	suite.whenImporting(entries)
+	assert.Empty(suite.T(), suite.importResult.Animations)
+	assert.Equal(suite.T(), []string{entries[0].Path}, suite.importResult.Failed)
+}
+
+func (suite *ExchangeSuite) givenResources(modifiers ...func(*resource.Store)) {
+	suite.localizedResources = nil
+	suite.givenLocalizedResources(resource.LangAny, modifiers...)
+}
+
+func (suite *ExchangeSuite) givenLocalizedResources(lang resource.Language, modifiers ...func(*resource.Store)) {
+	var store resource.Store
+	for _, modifier := range modifiers {
+		modifier(&store)
+	}
+	suite.localizedResources = append(suite.localizedResources,
+		resource.LocalizedResources{ID: lang.String(), Language: lang, Viewer: store})
+}
+
+func (suite *ExchangeSuite) givenExported(entries []artwork.Entry) {
+	suite.whenExporting(entries)
+	require.Empty(suite.T(), suite.exportResult.Failed)
+}
+
+func (suite *ExchangeSuite) givenFile(path string, pixels []byte, width, height int) {
+	img := image.NewPaletted(image.Rect(0, 0, width, height), suite.palette.ColorPalette(false))
+	copy(img.Pix, pixels)
+	buf := bytes.NewBuffer(nil)
+	err := png.Encode(buf, img)
+	require.Nil(suite.T(), err)
+	suite.givenRawFile(path, buf.Bytes())
+}
+
+func (suite *ExchangeSuite) givenTrueColorFile(path string, clr color.Color) {
+	img := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, clr)
+	buf := bytes.NewBuffer(nil)
+	err := png.Encode(buf, img)
+	require.Nil(suite.T(), err)
+	suite.givenRawFile(path, buf.Bytes())
+}
+
+func (suite *ExchangeSuite) givenRawFile(path string, data []byte) {
+	filename := filepath.Join(suite.dir, filepath.FromSlash(path))
+	require.Nil(suite.T(), os.MkdirAll(filepath.Dir(filename), 0755))
+	require.Nil(suite.T(), ioutil.WriteFile(filename, data, 0644))
+}
+
+func (suite *ExchangeSuite) whenExporting(entries []artwork.Entry) {
+	suite.exportResult = artwork.Export(suite, suite.palette, entries, suite.dir)
+}
+
+func (suite *ExchangeSuite) whenImporting(entries []artwork.Entry) {
+	suite.importResult = artwork.Import(suite, suite.palette, suite.mapSettings, entries, suite.dir)
+}
+
+func (suite *ExchangeSuite) importedPixel(index int) byte {
+	require.Len(suite.T(), suite.importResult.Bitmaps, 1)
+	bmp, err := bitmap.Decode(bytes.NewReader(suite.importResult.Bitmaps[0].Data))
+	require.Nil(suite.T(), err)
+	return bmp.Pixels[index]
+}
+
+func (suite *ExchangeSuite) thenFileShouldExist(path string) {
+	_, err := os.Stat(filepath.Join(suite.dir, filepath.FromSlash(path)))
+	assert.Nil(suite.T(), err, "file should exist: "+path)
+}
+
+func (suite *ExchangeSuite) graffitiEntries() []artwork.Entry {
+	return suite.entriesWithID(ids.GraffitiBitmaps)
+}
+
+func (suite *ExchangeSuite) animationEntries() []artwork.Entry {
+	var entries []artwork.Entry
+	for _, entry := range artwork.Entries(suite) {
+		if entry.Animation {
+			entries = append(entries, entry)
+		}
+	}
+	return entries[:1]
+}
+
+func (suite *ExchangeSuite) entriesWithID(id resource.ID) []artwork.Entry {
+	var entries []artwork.Entry
+	for _, entry := range artwork.Entries(suite) {
+		if entry.Key.ID == id {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func (suite *ExchangeSuite) storingBitmap(id resource.ID, width, height int16, pixels []byte) func(*resource.Store) {
+	bmp := bitmap.Bitmap{
+		Header: bitmap.Header{
+			Type:   bitmap.TypeCompressed8Bit,
+			Flags:  bitmap.FlagTransparent,
+			Width:  width,
+			Height: height,
+			Stride: uint16(width),
+			Area:   bitmap.Area{1, 2, 0, 0},
+		},
+		Pixels: pixels,
+	}
+	return func(store *resource.Store) {
+		_ = store.Put(id, resource.Resource{
+			Properties: resource.Properties{ContentType: resource.Bitmap, Compound: true},
+			Blocks:     resource.BlocksFrom([][]byte{bitmap.Encode(&bmp, 0)}),
+		})
+	}
+}
+
+func (suite *ExchangeSuite) storingAnimation(entry artwork.Entry, frames [][]byte) func(*resource.Store) {
+	anim := bitmap.Animation{Width: 2, Height: 1, ResourceID: entry.FramesID}
+	var frameData [][]byte
+	for index, pixels := range frames {
+		anim.Entries = append(anim.Entries, bitmap.AnimationEntry{FirstFrame: byte(index), LastFrame: byte(index), FrameTime: 100})
+		bmp := bitmap.Bitmap{
+			Header: bitmap.Header{Type: bitmap.TypeFlat8Bit, Width: 2, Height: 1, Stride: 2},
+			Pixels: pixels,
+		}
+		frameData = append(frameData, bitmap.Encode(&bmp, 0))
+	}
+	animData := bytes.NewBuffer(nil)
+	require.Nil(suite.T(), bitmap.WriteAnimation(animData, anim))
+	return func(store *resource.Store) {
+		_ = store.Put(entry.Key.ID, resource.Resource{
+			Properties: resource.Properties{ContentType: resource.Animation},
+			Blocks:     resource.BlocksFrom([][]byte{animData.Bytes()}),
+		})
+		_ = store.Put(entry.FramesID, resource.Resource{
+			Properties: resource.Properties{ContentType: resource.Bitmap, Compound: true},
+			Blocks:     resource.BlocksFrom(frameData),
+		})
+	}
+}
+
+func (suite *ExchangeSuite) AdditionalLanguages() resource.LanguageSpecs {
+	return nil
+}
+
+func (suite *ExchangeSuite) ObjectProperties() object.PropertiesTable {
+	return nil
+}
+
+func (suite *ExchangeSuite) ResourceIDsIn(file resource.Filename) []resource.ID {
+	var result []resource.ID
+	for _, entry := range suite.localizedResources {
+		result = append(result, entry.Viewer.IDs()...)
+	}
+	return result
+}
+
+func (suite *ExchangeSuite) LocalizedResources(lang resource.Language) resource.Selector {
+	return resource.Selector{
+		From: suite.localizedResources,
+		Lang: lang,
+	}
+}
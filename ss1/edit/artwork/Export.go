@@ -0,0 +1,100 @@
+package artwork
+
+import (
+	"image"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+// ExportResult summarizes an export.
+type ExportResult struct {
+	// Written is the number of files that were written.
+	Written int
+	// Failed lists the paths of the entries that could not be exported.
+	Failed []string
+}
+
+// Export writes all the given entries that exist in the resources as image files below the given directory.
+// Bitmaps are written as PNG files, animations as GIF files. Existing files are overwritten.
+// Bitmaps with a private palette are written with that palette, all others with the given one.
+func Export(localizer resource.Localizer, palette bitmap.Palette, entries []Entry, dir string) ExportResult {
+	var result ExportResult
+	for _, entry := range entries {
+		var err error
+		if entry.Animation {
+			err = exportAnimation(localizer, palette, entry, dir)
+		} else {
+			err = exportBitmap(localizer, palette, entry, dir)
+		}
+		if err == errNotExisting {
+			continue
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, entry.Path)
+		} else {
+			result.Written++
+		}
+	}
+	return result
+}
+
+func exportBitmap(localizer resource.Localizer, palette bitmap.Palette, entry Entry, dir string) error {
+	bmp, err := loadBitmap(localizer, entry.Key)
+	if err != nil {
+		return err
+	}
+	if bmp.Palette != nil {
+		palette = *bmp.Palette
+	}
+	imageRect := image.Rect(0, 0, int(bmp.Header.Width), int(bmp.Header.Height))
+	paletted := image.NewPaletted(imageRect, palette.ColorPalette((bmp.Header.Flags&bitmap.FlagTransparent) != 0))
+	copy(paletted.Pix, flatPixels(bmp))
+	writer, err := createFile(dir, entry.Path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = writer.Close() }()
+	return png.Encode(writer, paletted)
+}
+
+func exportAnimation(localizer resource.Localizer, palette bitmap.Palette, entry Entry, dir string) error {
+	anim, err := loadAnimation(localizer, entry.Key)
+	if err != nil {
+		return err
+	}
+	colorPalette := palette.ColorPalette(false)
+	data := gif.GIF{
+		Config: image.Config{
+			Width:      int(anim.anim.Width),
+			Height:     int(anim.anim.Height),
+			ColorModel: colorPalette,
+		},
+		LoopCount: -1,
+	}
+	for index, frame := range anim.frames {
+		frameImg := image.NewPaletted(image.Rect(0, 0, int(frame.Header.Width), int(frame.Header.Height)), colorPalette)
+		copy(frameImg.Pix, frame.Pixels)
+		data.Image = append(data.Image, frameImg)
+		data.Delay = append(data.Delay, anim.delays[index])
+	}
+	writer, err := createFile(dir, entry.Path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = writer.Close() }()
+	return gif.EncodeAll(writer, &data)
+}
+
+func createFile(dir string, path string) (*os.File, error) {
+	filename := filepath.Join(dir, filepath.FromSlash(path))
+	err := os.MkdirAll(filepath.Dir(filename), 0755)
+	if err != nil {
+		return nil, err
+	}
+	return os.Create(filename)
+}
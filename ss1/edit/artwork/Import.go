@@ -0,0 +1,284 @@
+package artwork
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"math"
+	"os"
+	"path/filepath"
+
+	// Register PNG for decoding of bitmaps.
+	_ "image/png"
+
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/serial/rle"
+)
+
+// BitmapChange describes the new data for the block of a bitmap.
+type BitmapChange struct {
+	Entry Entry
+	Data  []byte
+}
+
+// AnimationChange describes the new data of an animation and its frames.
+type AnimationChange struct {
+	Entry    Entry
+	Data     []byte
+	FramesID resource.ID
+	Frames   [][]byte
+}
+
+// ImportResult summarizes the changes found in a directory tree.
+type ImportResult struct {
+	// Bitmaps lists all bitmaps with different content.
+	Bitmaps []BitmapChange
+	// Animations lists all animations with different content.
+	Animations []AnimationChange
+	// Unchanged is the number of files that match the current resources.
+	Unchanged int
+	// Failed lists the paths of files that could not be read.
+	Failed []string
+}
+
+// Import reads the image files of the given entries from below the given directory and returns the
+// changes compared to the current resources. Missing files are ignored.
+//
+// Images that are paletted with the given palette are taken 1:1, others are mapped with the given settings.
+// Bitmaps that already exist keep their 8-bit type, flags, and private palette.
+// Their anchor area is scaled to the new size.
+func Import(localizer resource.Localizer, palette bitmap.Palette, settings bitmap.MapSettings,
+	entries []Entry, dir string) ImportResult {
+	var result ImportResult
+	for _, entry := range entries {
+		filename := filepath.Join(dir, filepath.FromSlash(entry.Path))
+		if _, err := os.Stat(filename); err != nil {
+			continue
+		}
+		var changed bool
+		var err error
+		if entry.Animation {
+			var change AnimationChange
+			change, changed, err = importAnimation(localizer, palette, settings, entry, filename)
+			if changed {
+				result.Animations = append(result.Animations, change)
+			}
+		} else {
+			var change BitmapChange
+			change, changed, err = importBitmap(localizer, palette, settings, entry, filename)
+			if changed {
+				result.Bitmaps = append(result.Bitmaps, change)
+			}
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, entry.Path)
+		} else if !changed {
+			result.Unchanged++
+		}
+	}
+	return result
+}
+
+func importBitmap(localizer resource.Localizer, palette bitmap.Palette, settings bitmap.MapSettings,
+	entry Entry, filename string) (BitmapChange, bool, error) {
+	change := BitmapChange{Entry: entry}
+	img, err := decodeImage(filename)
+	if err != nil {
+		return change, false, err
+	}
+	oldBmp, oldErr := loadBitmap(localizer, entry.Key)
+	if (oldErr == nil) && (oldBmp.Palette != nil) {
+		palette = *oldBmp.Palette
+	}
+	newBmp := mapImage(img, palette, settings)
+	newBmp.Header.Type = entry.Type
+	newBmp.Header.Flags = entry.Flags
+	if oldErr == nil {
+		oldHeader := oldBmp.Header
+		sameSize := (oldHeader.Width == newBmp.Header.Width) && (oldHeader.Height == newBmp.Header.Height)
+		if sameSize && bytes.Equal(flatPixels(oldBmp), newBmp.Pixels) {
+			return change, false, nil
+		}
+		if oldHeader.Type.Is8Bit() {
+			newBmp.Header.Type = oldHeader.Type
+		}
+		newBmp.Header.Flags = oldHeader.Flags
+		newBmp.Header.Area = oldHeader.Area.Scaled(oldHeader.Width, oldHeader.Height, newBmp.Header.Width, newBmp.Header.Height)
+		newBmp.Palette = oldBmp.Palette
+	}
+	newBmp.Header.WidthFactor = bitmap.HighestBitShift(newBmp.Header.Width)
+	newBmp.Header.HeightFactor = bitmap.HighestBitShift(newBmp.Header.Height)
+	newBmp.Header.Stride = uint16(newBmp.Header.Width)
+	change.Data = bitmap.Encode(&newBmp, 0)
+	return change, true, nil
+}
+
+func importAnimation(localizer resource.Localizer, palette bitmap.Palette, settings bitmap.MapSettings,
+	entry Entry, filename string) (AnimationChange, bool, error) {
+	change := AnimationChange{Entry: entry}
+	file, err := os.Open(filename)
+	if err != nil {
+		return change, false, err
+	}
+	defer func() { _ = file.Close() }()
+	data, err := gif.DecodeAll(file)
+	if err != nil {
+		return change, false, err
+	}
+	if len(data.Image) > bitmap.MaxAnimationFrames {
+		return change, false, fmt.Errorf("animation has %d frames, the limit is %d",
+			len(data.Image), bitmap.MaxAnimationFrames)
+	}
+	frames := gifFrames(data, palette, settings)
+
+	old, oldErr := loadAnimation(localizer, entry.Key)
+	if (oldErr == nil) && sameFrames(old, frames, data.Delay) {
+		return change, false, nil
+	}
+
+	anim := bitmap.Animation{
+		Width:      int16(data.Config.Width),
+		Height:     int16(data.Config.Height),
+		ResourceID: entry.FramesID,
+	}
+	if oldErr == nil {
+		anim.ResourceID = old.anim.ResourceID
+		anim.IntroFlag = old.anim.IntroFlag
+	}
+	change.FramesID = anim.ResourceID
+	var prevFrame []byte
+	for index, frame := range frames {
+		anim.Entries = append(anim.Entries, bitmap.AnimationEntry{
+			FirstFrame: byte(index),
+			LastFrame:  byte(index),
+			FrameTime:  int16(data.Delay[index] * 10),
+		})
+		frame.Header.Type = entry.Type
+		frame.Header.Flags = entry.Flags
+		frame.Header.WidthFactor = bitmap.HighestBitShift(frame.Header.Width)
+		frame.Header.HeightFactor = bitmap.HighestBitShift(frame.Header.Height)
+		frame.Header.Area = bitmap.Area{0, 0, anim.Width, anim.Height}
+		frame.Header.Stride = uint16(frame.Header.Width)
+
+		buf := bytes.NewBuffer(nil)
+		_ = binary.Write(buf, binary.LittleEndian, &frame.Header)
+		_ = rle.Compress(buf, frame.Pixels, prevFrame)
+		prevFrame = frame.Pixels
+		change.Frames = append(change.Frames, buf.Bytes())
+	}
+	buf := bytes.NewBuffer(nil)
+	_ = bitmap.WriteAnimation(buf, anim)
+	change.Data = buf.Bytes()
+	return change, true, nil
+}
+
+func decodeImage(filename string) (image.Image, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	img, _, err := image.Decode(file)
+	return img, err
+}
+
+// gifFrames composes the frames of given GIF in full size, as GIF frames may only cover a part of the image.
+func gifFrames(data *gif.GIF, palette bitmap.Palette, settings bitmap.MapSettings) []bitmap.Bitmap {
+	bounds := image.Rect(0, 0, data.Config.Width, data.Config.Height)
+	canvas := image.NewNRGBA(bounds)
+	var frames []bitmap.Bitmap
+	for _, img := range data.Image {
+		if img.Bounds() == bounds {
+			if bmp, isMatching := matchingBitmap(img, palette); isMatching {
+				draw.Draw(canvas, bounds, img, bounds.Min, draw.Src)
+				frames = append(frames, bmp)
+				continue
+			}
+		}
+		draw.Draw(canvas, img.Bounds(), img, img.Bounds().Min, draw.Over)
+		frames = append(frames, mapImage(canvas, palette, settings))
+	}
+	return frames
+}
+
+func sameFrames(old animationFrames, frames []bitmap.Bitmap, delays []int) bool {
+	if len(old.frames) != len(frames) {
+		return false
+	}
+	for index, frame := range frames {
+		oldFrame := old.frames[index]
+		if (oldFrame.Header.Width != frame.Header.Width) || (oldFrame.Header.Height != frame.Header.Height) ||
+			(old.delays[index] != delays[index]) || !bytes.Equal(oldFrame.Pixels, frame.Pixels) {
+			return false
+		}
+	}
+	return true
+}
+
+func mapImage(img image.Image, palette bitmap.Palette, settings bitmap.MapSettings) bitmap.Bitmap {
+	if bmp, isMatching := matchingBitmap(img, palette); isMatching {
+		return bmp
+	}
+	return bitmap.NewBitmapper(&palette).MapWith(img, settings)
+}
+
+// matchingBitmap returns the pixel data of a paletted image if it uses the given palette.
+func matchingBitmap(img image.Image, palette bitmap.Palette) (bitmap.Bitmap, bool) {
+	var bmp bitmap.Bitmap
+	palettedImg, isPaletted := img.(image.PalettedImage)
+	if !isPaletted {
+		return bmp, false
+	}
+	imgPalette, hasPalette := palettedImg.ColorModel().(color.Palette)
+	if !hasPalette || !paletteMatches(imgPalette, palette.ColorPalette(false)) {
+		return bmp, false
+	}
+	bounds := img.Bounds()
+	bmp.Header.Width = int16(math.Min(float64(bounds.Dx()), math.MaxInt16))
+	bmp.Header.Height = int16(math.Min(float64(bounds.Dy()), math.MaxInt16))
+	bmp.Pixels = make([]byte, int(bmp.Header.Width)*int(bmp.Header.Height))
+	for row := 0; row < int(bmp.Header.Height); row++ {
+		for column := 0; column < int(bmp.Header.Width); column++ {
+			bmp.Pixels[row*int(bmp.Header.Width)+column] = palettedImg.ColorIndexAt(bounds.Min.X+column, bounds.Min.Y+row)
+		}
+	}
+	return bmp, true
+}
+
+// paletteMatches compares the colors of the palettes. Fully transparent entries match any color,
+// as exported bitmaps may have their first entry marked as transparent, losing its color.
+func paletteMatches(imgPalette color.Palette, rawPalette color.Palette) bool {
+	if len(imgPalette) > len(rawPalette) {
+		return false
+	}
+	for index, clr := range imgPalette {
+		imgColor := color.NRGBAModel.Convert(clr).(color.NRGBA)
+		if imgColor.A == 0 {
+			continue
+		}
+		rawColor := color.NRGBAModel.Convert(rawPalette[index]).(color.NRGBA)
+		if (imgColor.R != rawColor.R) || (imgColor.G != rawColor.G) || (imgColor.B != rawColor.B) {
+			return false
+		}
+	}
+	return true
+}
+
+// flatPixels returns the pixels of the bitmap without any padding.
+func flatPixels(bmp *bitmap.Bitmap) []byte {
+	width := int(bmp.Header.Width)
+	if int(bmp.Header.Stride) == width {
+		return bmp.Pixels
+	}
+	pixels := make([]byte, width*int(bmp.Header.Height))
+	for row := 0; row < int(bmp.Header.Height); row++ {
+		start := row * int(bmp.Header.Stride)
+		copy(pixels[row*width:], bmp.Pixels[start:start+width])
+	}
+	return pixels
+}
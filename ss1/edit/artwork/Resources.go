@@ -0,0 +1,89 @@
+package artwork
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+// errNotExisting is returned for graphics that are not present in the resources.
+var errNotExisting = errors.New("not existing")
+
+type animationFrames struct {
+	anim   bitmap.Animation
+	frames []*bitmap.Bitmap
+	delays []int
+}
+
+func blockData(localizer resource.Localizer, key resource.Key) ([]byte, error) {
+	view, err := localizer.LocalizedResources(key.Lang).Select(key.ID)
+	if err != nil {
+		return nil, errNotExisting
+	}
+	if (key.Index < 0) || (key.Index >= view.BlockCount()) {
+		return nil, errNotExisting
+	}
+	reader, err := view.Block(key.Index)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errNotExisting
+	}
+	return data, nil
+}
+
+func loadBitmap(localizer resource.Localizer, key resource.Key) (*bitmap.Bitmap, error) {
+	data, err := blockData(localizer, key)
+	if err != nil {
+		return nil, err
+	}
+	return bitmap.Decode(bytes.NewReader(data))
+}
+
+func loadAnimation(localizer resource.Localizer, key resource.Key) (animationFrames, error) {
+	var result animationFrames
+	data, err := blockData(localizer, key)
+	if err != nil {
+		return result, err
+	}
+	result.anim, err = bitmap.ReadAnimation(bytes.NewReader(data))
+	if err != nil {
+		return result, err
+	}
+	var prevFrame []byte
+	frameIndex := 0
+	for _, entry := range result.anim.Entries {
+		for frameIndex <= int(entry.LastFrame) {
+			frameData, err := blockData(localizer, resource.KeyOf(result.anim.ResourceID, key.Lang, frameIndex))
+			if err != nil {
+				return result, err
+			}
+			frame, err := bitmap.DecodeReferenced(bytes.NewReader(frameData), func(width, height int16) ([]byte, error) {
+				buf := make([]byte, int(width)*int(height))
+				if prevFrame != nil {
+					if len(prevFrame) != len(buf) {
+						return nil, errors.New("reference has wrong dimensions")
+					}
+					copy(buf, prevFrame)
+				}
+				return buf, nil
+			})
+			if err != nil {
+				return result, err
+			}
+			prevFrame = frame.Pixels
+			result.frames = append(result.frames, frame)
+			result.delays = append(result.delays, int(entry.FrameTime)/10)
+			frameIndex++
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,4 @@
+// Package artwork provides the exchange of all graphics of a mod with a directory tree of image files.
+// Each graphic has a predictable path, so that whole sets can be edited with external tools and
+// imported back by name.
+package artwork
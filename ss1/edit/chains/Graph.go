@@ -0,0 +1,114 @@
+package chains
+
+import (
+	"sort"
+
+	"github.com/inkyblackness/hacked/ss1/content/text"
+)
+
+// Link describes the relation of a message to its follow-up.
+type Link struct {
+	// From is the index of the message that refers to the follow-up.
+	From int
+	// To is the index of the follow-up.
+	To int
+}
+
+// Chain is a sequence of messages, each referring to the next one.
+type Chain struct {
+	// Messages contains the indices of the messages in order.
+	Messages []int
+	// Dangling is set if the last message refers to a message that does not exist.
+	Dangling bool
+	// Cyclic is set if the last message refers to a message that is already part of the chain.
+	Cyclic bool
+}
+
+// Graph is the result of analyzing all messages.
+type Graph struct {
+	// Chains lists all sequences of messages, starting with messages no other message refers to.
+	// Messages that are only reachable through a cycle start their own chain.
+	Chains []Chain
+	// Dangling lists all references to messages that do not exist.
+	Dangling []Link
+	// Orphans lists all interrupt messages that no other message refers to.
+	Orphans []int
+	// Referrers maps message indices to the indices of messages referring to them.
+	Referrers map[int][]int
+	// Triggers maps message indices to the level triggers that send them.
+	Triggers map[int][]Trigger
+	// MissingTriggered lists all triggers that send a message that does not exist.
+	MissingTriggered []Trigger
+}
+
+// Analyze builds the graph of the given messages, keyed by their message index.
+// The triggers are associated with the messages they send.
+func Analyze(messages map[int]text.ElectronicMessage, triggers []Trigger) Graph {
+	graph := Graph{
+		Referrers: make(map[int][]int),
+		Triggers:  make(map[int][]Trigger),
+	}
+	indices := make([]int, 0, len(messages))
+	for index := range messages {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+
+	for _, index := range indices {
+		next := messages[index].NextMessage
+		if next < 0 {
+			continue
+		}
+		if _, exists := messages[next]; exists {
+			graph.Referrers[next] = append(graph.Referrers[next], index)
+		} else {
+			graph.Dangling = append(graph.Dangling, Link{From: index, To: next})
+		}
+	}
+	for _, trigger := range triggers {
+		graph.Triggers[trigger.Message] = append(graph.Triggers[trigger.Message], trigger)
+		if _, exists := messages[trigger.Message]; !exists {
+			graph.MissingTriggered = append(graph.MissingTriggered, trigger)
+		}
+	}
+
+	visited := make(map[int]bool)
+	follow := func(start int) {
+		var chain Chain
+		inChain := make(map[int]bool)
+		current := start
+		for {
+			chain.Messages = append(chain.Messages, current)
+			inChain[current] = true
+			visited[current] = true
+			next := messages[current].NextMessage
+			if next < 0 {
+				break
+			}
+			if _, exists := messages[next]; !exists {
+				chain.Dangling = true
+				break
+			}
+			if inChain[next] || visited[next] {
+				chain.Cyclic = inChain[next]
+				break
+			}
+			current = next
+		}
+		graph.Chains = append(graph.Chains, chain)
+	}
+	for _, index := range indices {
+		if len(graph.Referrers[index]) == 0 {
+			if messages[index].IsInterrupt {
+				graph.Orphans = append(graph.Orphans, index)
+			}
+			follow(index)
+		}
+	}
+	for _, index := range indices {
+		if !visited[index] {
+			follow(index)
+		}
+	}
+	return graph
+}
@@ -0,0 +1,66 @@
+package chains_test
+
+import (
+	"testing"
+
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/edit/chains"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func message(next int, interrupt bool) text.ElectronicMessage {
+	msg := text.EmptyElectronicMessage()
+	msg.NextMessage = next
+	msg.IsInterrupt = interrupt
+	return msg
+}
+
+func TestAnalyzeFollowsChains(t *testing.T) {
+	graph := chains.Analyze(map[int]text.ElectronicMessage{
+		0: message(2, false),
+		1: message(-1, false),
+		2: message(-1, true),
+	}, nil)
+	assert.Equal(t, []chains.Chain{{Messages: []int{0, 2}}, {Messages: []int{1}}}, graph.Chains)
+	assert.Equal(t, []int{0}, graph.Referrers[2])
+	assert.Empty(t, graph.Dangling)
+	assert.Empty(t, graph.Orphans)
+}
+
+func TestAnalyzeReportsDanglingReferences(t *testing.T) {
+	graph := chains.Analyze(map[int]text.ElectronicMessage{
+		0: message(5, false),
+	}, nil)
+	assert.Equal(t, []chains.Link{{From: 0, To: 5}}, graph.Dangling)
+	assert.Equal(t, []chains.Chain{{Messages: []int{0}, Dangling: true}}, graph.Chains)
+}
+
+func TestAnalyzeReportsOrphanedInterrupts(t *testing.T) {
+	graph := chains.Analyze(map[int]text.ElectronicMessage{
+		0: message(-1, false),
+		1: message(-1, true),
+	}, nil)
+	assert.Equal(t, []int{1}, graph.Orphans)
+}
+
+func TestAnalyzeReportsCycles(t *testing.T) {
+	graph := chains.Analyze(map[int]text.ElectronicMessage{
+		3: message(4, false),
+		4: message(3, true),
+	}, nil)
+	assert.Equal(t, []chains.Chain{{Messages: []int{3, 4}, Cyclic: true}}, graph.Chains)
+	assert.Empty(t, graph.Orphans)
+}
+
+func TestAnalyzeAssociatesTriggers(t *testing.T) {
+	trigger := chains.Trigger{Message: 0, Level: 1, Object: 20}
+	graph := chains.Analyze(map[int]text.ElectronicMessage{0: message(-1, false)}, []chains.Trigger{trigger})
+	assert.Equal(t, []chains.Trigger{trigger}, graph.Triggers[0])
+}
+
+func TestAnalyzeReportsTriggersOfMissingMessages(t *testing.T) {
+	trigger := chains.Trigger{Message: 7, Level: 2, Object: 30}
+	graph := chains.Analyze(map[int]text.ElectronicMessage{0: message(-1, false)}, []chains.Trigger{trigger})
+	assert.Equal(t, []chains.Trigger{trigger}, graph.MissingTriggered)
+}
@@ -0,0 +1,61 @@
+package chains
+
+import (
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+)
+
+// Group describes a consecutive range of messages in the common message table.
+type Group struct {
+	Title string
+	Start resource.ID
+	Count int
+}
+
+// Groups returns the known message groups, in order of their resources.
+func Groups() []Group {
+	groups := []Group{
+		{Title: "Mails", Start: ids.MailsStart},
+		{Title: "Logs", Start: ids.LogsStart},
+		{Title: "Fragments", Start: ids.FragmentsStart},
+	}
+	for index := range groups {
+		info, _ := ids.Info(groups[index].Start)
+		groups[index].Count = info.MaxCount
+	}
+	return groups
+}
+
+// IndexOf returns the message index of the given resource. Returns false if the resource is not a message.
+func IndexOf(id resource.ID) (int, bool) {
+	for _, group := range Groups() {
+		if (id >= group.Start) && (id < group.Start.Plus(group.Count)) {
+			return int(id.Value() - ids.MailsStart.Value()), true
+		}
+	}
+	return -1, false
+}
+
+// IDOf returns the resource of the message with given index. Returns false if the index refers to no message.
+func IDOf(index int) (resource.ID, bool) {
+	if index < 0 {
+		return 0, false
+	}
+	id := ids.MailsStart.Plus(index)
+	_, isMessage := IndexOf(id)
+	return id, isMessage
+}
+
+// GroupOf returns the group of the message with given index.
+func GroupOf(index int) (Group, bool) {
+	id, isMessage := IDOf(index)
+	if !isMessage {
+		return Group{}, false
+	}
+	for _, group := range Groups() {
+		if (id >= group.Start) && (id < group.Start.Plus(group.Count)) {
+			return group, true
+		}
+	}
+	return Group{}, false
+}
@@ -0,0 +1,39 @@
+package chains_test
+
+import (
+	"testing"
+
+	"github.com/inkyblackness/hacked/ss1/edit/chains"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexOfMapsResourcesRelativeToMails(t *testing.T) {
+	tt := []struct {
+		offset    int
+		isMessage bool
+	}{
+		{offset: 0, isMessage: true},
+		{offset: 46, isMessage: true},
+		{offset: 47, isMessage: true},
+		{offset: 0x0A98 - 0x0989, isMessage: true},
+		{offset: 0x0A98 - 0x0989 + 16, isMessage: false},
+	}
+	for _, tc := range tt {
+		index, isMessage := chains.IndexOf(ids.MailsStart.Plus(tc.offset))
+		assert.Equal(t, tc.isMessage, isMessage, "offset %d", tc.offset)
+		if tc.isMessage {
+			assert.Equal(t, tc.offset, index)
+			id, _ := chains.IDOf(index)
+			assert.Equal(t, ids.MailsStart.Plus(tc.offset), id)
+		}
+	}
+}
+
+func TestIDOfRejectsGapsBetweenGroups(t *testing.T) {
+	_, isMessage := chains.IDOf(47 + 136)
+	assert.False(t, isMessage)
+	_, isMessage = chains.IDOf(-1)
+	assert.False(t, isMessage)
+}
@@ -0,0 +1,50 @@
+package chains
+
+import (
+	"github.com/inkyblackness/hacked/ss1/content/archive/level"
+	"github.com/inkyblackness/hacked/ss1/content/archive/level/lvlobj"
+	"github.com/inkyblackness/hacked/ss1/content/interpreters"
+)
+
+// Trigger identifies an object in a level that sends a message.
+type Trigger struct {
+	// Message is the index of the sent message.
+	Message int
+	// Level is the identifier of the level the object is in.
+	Level int
+	// Object is the identifier of the sending object.
+	Object level.ObjectID
+}
+
+// TriggersIn returns all objects of the given level that send a message with the "Receive E-Mail" action.
+// The objects are interpreted with the given interpreters.
+func TriggersIn(lvl *level.Level, interpreters *lvlobj.Interpreters) []Trigger {
+	interpreterFactory := interpreters.ForRealWorld
+	if lvl.IsCyberspace() {
+		interpreterFactory = interpreters.ForCyberspace
+	}
+	var triggers []Trigger
+	lvl.ForEachObject(func(id level.ObjectID, entry level.ObjectMasterEntry) {
+		data := lvl.ObjectClassData(id)
+		if len(data) == 0 {
+			return
+		}
+		for _, message := range sentMessages(interpreterFactory(entry.Triple(), data)) {
+			triggers = append(triggers, Trigger{Message: message, Level: lvl.ID(), Object: id})
+		}
+	})
+	return triggers
+}
+
+func sentMessages(inst *interpreters.Instance) []int {
+	var messages []int
+	for _, key := range inst.ActiveRefinements() {
+		refined := inst.Refined(key)
+		if key == "ReceiveEmail" {
+			messages = append(messages, int(refined.Get("EmailIndex")))
+		} else {
+			messages = append(messages, sentMessages(refined)...)
+		}
+	}
+	return messages
+}
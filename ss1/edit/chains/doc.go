@@ -0,0 +1,5 @@
+// Package chains analyzes the links between electronic messages.
+// Messages refer to their follow-up with NextMessage, and messages marked as interrupt are expected to be
+// such a follow-up. Level triggers send messages with the "Receive E-Mail" action.
+// All messages are identified by their index in the common message table, which starts with the mails.
+package chains
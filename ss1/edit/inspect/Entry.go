@@ -0,0 +1,153 @@
+package inspect
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/resource/lgres"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+)
+
+// Entry describes one resource of a resource file.
+type Entry struct {
+	lgres.EntryInfo
+
+	// BlockCount is the number of blocks of the resource. It is zero if the resource could not be read.
+	BlockCount int
+	// Err is set if the resource could not be read.
+	Err error
+
+	// Known is set if the identifier is part of the known resources.
+	Known bool
+	// Info is the known resource information, valid if Known is set.
+	Info ids.ResourceInfo
+}
+
+// Entries returns the description of all resources of given reader, in order of the file.
+func Entries(reader *lgres.Reader) []Entry {
+	var entries []Entry
+	for _, id := range reader.IDs() {
+		var entry Entry
+		entry.EntryInfo, entry.Err = reader.Entry(id)
+		if entry.Err == nil {
+			var view resource.View
+			view, entry.Err = reader.View(id)
+			if entry.Err == nil {
+				entry.BlockCount = view.BlockCount()
+			}
+		}
+		entry.ID = id
+		entry.Info, entry.Known = ids.Info(id)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// Flags returns a textual description of the storage flags of the resource.
+func (entry Entry) Flags() string {
+	var flags []string
+	if entry.Properties.Compound {
+		flags = append(flags, "compound")
+	}
+	if entry.Properties.Compressed {
+		flags = append(flags, "compressed")
+	}
+	if len(flags) == 0 {
+		return "-"
+	}
+	return strings.Join(flags, ", ")
+}
+
+// Classification returns a textual description of what the resource is known as.
+func (entry Entry) Classification() string {
+	if !entry.Known {
+		return "unknown"
+	}
+	info := entry.Info
+	text := fmt.Sprintf("%v", info.ContentType)
+	if info.List {
+		text += " list"
+	}
+	if count := info.EndID.Value() - info.StartID.Value(); count > 1 {
+		text += fmt.Sprintf(" %d/%d", entry.ID.Value()-info.StartID.Value(), count)
+	}
+	if info.MaxCount > 0 {
+		text += fmt.Sprintf(", max %d", info.MaxCount)
+	}
+	return text + ", " + info.ResFile.For(resource.LangDefault)
+}
+
+// Deviations returns the properties in which the resource differs from the known resource information.
+// Unknown resources have no deviations.
+func (entry Entry) Deviations() []string {
+	if !entry.Known || (entry.Err != nil) {
+		return nil
+	}
+	var result []string
+	if entry.Properties.ContentType != entry.Info.ContentType {
+		result = append(result, fmt.Sprintf("content type %v instead of %v", entry.Properties.ContentType, entry.Info.ContentType))
+	}
+	if entry.Properties.Compound != entry.Info.Compound {
+		result = append(result, fmt.Sprintf("compound %v instead of %v", entry.Properties.Compound, entry.Info.Compound))
+	}
+	if entry.Properties.Compressed != entry.Info.Compressed {
+		result = append(result, fmt.Sprintf("compressed %v instead of %v", entry.Properties.Compressed, entry.Info.Compressed))
+	}
+	if entry.Info.List && (entry.Info.MaxCount > 0) && (entry.BlockCount > entry.Info.MaxCount) {
+		result = append(result, fmt.Sprintf("%d blocks exceed maximum of %d", entry.BlockCount, entry.Info.MaxCount))
+	}
+	return result
+}
+
+// Key returns the resource key the editor uses for the given block of a known resource.
+// The key refers to the first resource of the known group, and the language is derived from the name of
+// the inspected file, considering the given additional languages. Unknown resources are keyed by their own identifier.
+func (entry Entry) Key(filename string, languages resource.LanguageSpecs, block int) resource.Key {
+	if !entry.Known {
+		return resource.KeyOf(entry.ID, resource.LangAny, block)
+	}
+	index := entry.ID.Value() - entry.Info.StartID.Value()
+	if entry.Info.List {
+		index = uint16(block)
+	}
+	return resource.KeyOf(entry.Info.StartID, languageOf(entry.Info.ResFile, filename, languages), int(index))
+}
+
+// BlockKey returns the key of the given block of the resource itself, as is used for raw access.
+// The language is derived from the name of the inspected file, considering the given additional languages.
+// Unknown resources are language agnostic.
+func (entry Entry) BlockKey(filename string, languages resource.LanguageSpecs, block int) resource.Key {
+	if !entry.Known {
+		return resource.KeyOf(entry.ID, resource.LangAny, block)
+	}
+	return resource.KeyOf(entry.ID, languageOf(entry.Info.ResFile, filename, languages), block)
+}
+
+func languageOf(resFile resource.Filename, filename string, languages resource.LanguageSpecs) resource.Language {
+	if _, isAgnostic := resFile.(resource.AnyLanguage); isAgnostic {
+		return resource.LangAny
+	}
+	base := strings.ToLower(filepath.Base(filename))
+	for _, lang := range languages.Languages() {
+		if languages.Filename(resFile, lang) == base {
+			return lang
+		}
+	}
+	return resource.LangDefault
+}
+
+// Block returns the uncompressed data of the identified block.
+func Block(reader *lgres.Reader, id resource.ID, index int) ([]byte, error) {
+	view, err := reader.View(id)
+	if err != nil {
+		return nil, err
+	}
+	blockReader, err := view.Block(index)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(blockReader)
+}
@@ -0,0 +1,137 @@
+package inspect_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/inkyblackness/hacked/ss1/edit/inspect"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/resource/lgres"
+	"github.com/inkyblackness/hacked/ss1/serial"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntriesDescribeResourcesInFileOrder(t *testing.T) {
+	reader := readerOf(t, func(writer *lgres.Writer) {
+		list, _ := writer.CreateCompoundResource(ids.TrapMessageTexts, resource.Text, false)
+		_, _ = list.CreateBlock().Write([]byte{0x41, 0x00})
+		_, _ = list.CreateBlock().Write([]byte{0x42, 0x00})
+		single, _ := writer.CreateResource(resource.ID(0x0001), resource.Bitmap, true)
+		_, _ = single.Write([]byte{0x01, 0x02, 0x03})
+	})
+
+	entries := inspect.Entries(reader)
+
+	require.Equal(t, 2, len(entries), "two entries expected")
+	assert.Equal(t, ids.TrapMessageTexts, entries[0].ID)
+	assert.Equal(t, 2, entries[0].BlockCount)
+	assert.True(t, entries[0].Known, "trap messages should be known")
+	assert.Equal(t, "Text list, max 256, cybstrng.res", entries[0].Classification())
+	assert.Nil(t, entries[0].Err, "no error expected")
+	assert.Equal(t, "compound", entries[0].Flags())
+
+	assert.Equal(t, resource.ID(0x0001), entries[1].ID)
+	assert.Equal(t, 1, entries[1].BlockCount)
+	assert.Equal(t, "compressed", entries[1].Flags())
+	assert.False(t, entries[1].Known, "resource should be unknown")
+	assert.Equal(t, "unknown", entries[1].Classification())
+}
+
+func TestEntryDeviationsListDifferencesToKnownProperties(t *testing.T) {
+	reader := readerOf(t, func(writer *lgres.Writer) {
+		list, _ := writer.CreateCompoundResource(ids.TrapMessageTexts, resource.Text, true)
+		_, _ = list.CreateBlock().Write([]byte{0x00})
+	})
+
+	entries := inspect.Entries(reader)
+
+	require.Equal(t, 1, len(entries), "one entry expected")
+	assert.Equal(t, []string{"compressed true instead of false"}, entries[0].Deviations())
+}
+
+func TestBlockReturnsUncompressedData(t *testing.T) {
+	reader := readerOf(t, func(writer *lgres.Writer) {
+		list, _ := writer.CreateCompoundResource(resource.ID(0x0100), resource.Text, true)
+		_, _ = list.CreateBlock().Write([]byte{0x10, 0x11})
+		_, _ = list.CreateBlock().Write([]byte{0x20, 0x21, 0x22})
+	})
+
+	data, err := inspect.Block(reader, resource.ID(0x0100), 1)
+	require.Nil(t, err, "no error expected")
+	assert.Equal(t, []byte{0x20, 0x21, 0x22}, data)
+
+	_, err = inspect.Block(reader, resource.ID(0x0100), 2)
+	assert.NotNil(t, err, "error expected for wrong block index")
+	_, err = inspect.Block(reader, resource.ID(0x0200), 0)
+	assert.NotNil(t, err, "error expected for unknown resource")
+}
+
+func readerOf(t *testing.T, fill func(writer *lgres.Writer)) *lgres.Reader {
+	t.Helper()
+	store := serial.NewByteStore()
+	writer, err := lgres.NewWriter(store)
+	require.Nil(t, err, "no error expected creating writer")
+	fill(writer)
+	require.Nil(t, writer.Finish(), "no error expected finishing")
+	reader, err := lgres.ReaderFrom(bytes.NewReader(store.Data()))
+	require.Nil(t, err, "no error expected reading")
+	return reader
+}
+
+func TestEntryKeyRefersToKnownGroupInLanguageOfFile(t *testing.T) {
+	reader := readerOf(t, func(writer *lgres.Writer) {
+		list, _ := writer.CreateCompoundResource(ids.TrapMessageTexts, resource.Text, false)
+		_, _ = list.CreateBlock().Write([]byte{0x00})
+		mail, _ := writer.CreateCompoundResource(ids.MailsStart.Plus(5), resource.Text, false)
+		_, _ = mail.CreateBlock().Write([]byte{0x00})
+		unknown, _ := writer.CreateResource(resource.ID(0x0001), resource.Bitmap, false)
+		_, _ = unknown.Write([]byte{0x00})
+	})
+
+	entries := inspect.Entries(reader)
+
+	require.Equal(t, 3, len(entries), "three entries expected")
+	assert.Equal(t, resource.KeyOf(ids.TrapMessageTexts, resource.LangGerman, 3),
+		entries[0].Key("data/GERSTRNG.RES", nil, 3), "list should be keyed by block")
+	assert.Equal(t, resource.KeyOf(ids.MailsStart, resource.LangDefault, 5),
+		entries[1].Key("cybstrng.res", nil, 0), "group should be keyed by offset")
+	assert.Equal(t, resource.KeyOf(resource.ID(0x0001), resource.LangAny, 0),
+		entries[2].Key("unknown.res", nil, 0), "unknown resource should be keyed by itself")
+}
+
+func TestEntryKeyConsidersAdditionalLanguages(t *testing.T) {
+	reader := readerOf(t, func(writer *lgres.Writer) {
+		list, _ := writer.CreateCompoundResource(ids.TrapMessageTexts, resource.Text, false)
+		_, _ = list.CreateBlock().Write([]byte{0x00})
+	})
+	languages := resource.LanguageSpecs{{Name: "Spanish", Code: "es", FilenamePattern: "{name}_es"}}
+
+	entries := inspect.Entries(reader)
+
+	require.Equal(t, 1, len(entries), "one entry expected")
+	assert.Equal(t, resource.KeyOf(ids.TrapMessageTexts, resource.Language(resource.BuiltinLanguageCount), 0),
+		entries[0].Key("cybstrng_es.res", languages, 0))
+	assert.Equal(t, resource.KeyOf(ids.TrapMessageTexts, resource.LangDefault, 0),
+		entries[0].Key("cybstrng_es.res", nil, 0), "unknown language should fall back to default")
+}
+
+func TestEntryBlockKeyRefersToResourceItself(t *testing.T) {
+	reader := readerOf(t, func(writer *lgres.Writer) {
+		mail, _ := writer.CreateCompoundResource(ids.MailsStart.Plus(5), resource.Text, false)
+		_, _ = mail.CreateBlock().Write([]byte{0x00})
+		_, _ = mail.CreateBlock().Write([]byte{0x00})
+		unknown, _ := writer.CreateResource(resource.ID(0x0001), resource.Bitmap, false)
+		_, _ = unknown.Write([]byte{0x00})
+	})
+
+	entries := inspect.Entries(reader)
+
+	require.Equal(t, 2, len(entries), "two entries expected")
+	assert.Equal(t, resource.KeyOf(ids.MailsStart.Plus(5), resource.LangFrench, 1),
+		entries[0].BlockKey("FRNSTRNG.RES", nil, 1))
+	assert.Equal(t, resource.KeyOf(resource.ID(0x0001), resource.LangAny, 0),
+		entries[1].BlockKey("unknown.res", nil, 0))
+}
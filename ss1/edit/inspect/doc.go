@@ -0,0 +1,4 @@
+// Package inspect provides a raw view on the resources of a resource file.
+// The resources are described as they are stored, independent of how the editor would interpret them,
+// and are compared against the known resource information.
+package inspect
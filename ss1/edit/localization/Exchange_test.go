@@ -0,0 +1,171 @@
+package localization_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/edit/localization"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+)
+
+type ExchangeSuite struct {
+	suite.Suite
+
+	cp                 text.Codepage
+	codepages          *text.LanguageCodepages
+	localizedResources resource.LocalizedResourcesList
+
+	units        []localization.Unit
+	importResult localization.ImportResult
+}
+
+func TestExchangeSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeSuite))
+}
+
+func (suite *ExchangeSuite) SetupTest() {
+	suite.cp = text.DefaultCodepage()
+	suite.codepages = text.NewLanguageCodepages(suite.cp)
+	suite.localizedResources = nil
+	suite.units = nil
+	suite.importResult = localization.ImportResult{}
+}
+
+func (suite *ExchangeSuite) TestUnitsContainSourceAndCurrentTarget() {
+	suite.givenResources(resource.LangDefault, suite.storingLines(ids.TrapMessageTexts, "first", "", "third"))
+	suite.givenResources(resource.LangGerman, suite.storingLines(ids.TrapMessageTexts, "erste"))
+	suite.whenCollectingUnits()
+	require.Len(suite.T(), suite.units, 2)
+	assert.Equal(suite.T(), localization.Unit{
+		ID: "0867:0", Note: "Trap Messages #0", Limit: text.LineLimit, Source: "first", Target: "erste"}, suite.units[0])
+	assert.Equal(suite.T(), "0867:2", suite.units[1].ID)
+	assert.Equal(suite.T(), "", suite.units[1].Target)
+}
+
+func (suite *ExchangeSuite) TestUnitsContainPagesAndMessageFields() {
+	suite.givenResources(resource.LangDefault,
+		suite.storingLines(ids.PaperTextsStart.Plus(2), "Some ", "paper", ""),
+		suite.storingMessage(ids.LogsStart.Plus(3), "Title", "Verbose"))
+	suite.whenCollectingUnits()
+	sources := make(map[string]string)
+	for _, unit := range suite.units {
+		sources[unit.ID] = unit.Source
+	}
+	assert.Equal(suite.T(), map[string]string{
+		"003C:2":         "Some paper",
+		"09B8:3:title":   "Title",
+		"09B8:3:verbose": "Verbose",
+	}, sources)
+}
+
+func (suite *ExchangeSuite) TestImportReportsChangedMissingOverLengthAndUnknown() {
+	suite.givenResources(resource.LangDefault, suite.storingLines(ids.WordTexts, "one", "two", "three", "four"))
+	suite.givenResources(resource.LangGerman, suite.storingLines(ids.WordTexts, "eins"))
+	suite.whenImporting(
+		localization.Unit{ID: "0868:0", Target: "eins"},
+		localization.Unit{ID: "0868:1", Target: "zwei"},
+		localization.Unit{ID: "0868:3", Target: strings.Repeat("vier", 20)},
+		localization.Unit{ID: "0868:9", Target: "neun"})
+	assert.Equal(suite.T(), []string{"0868:1"}, suite.importResult.Changed)
+	assert.Equal(suite.T(), 1, suite.importResult.Unchanged)
+	assert.Equal(suite.T(), []string{"0868:2"}, suite.importResult.Missing)
+	assert.Equal(suite.T(), []string{"0868:3"}, suite.importResult.OverLength)
+	assert.Equal(suite.T(), []string{"0868:9"}, suite.importResult.Unknown)
+	assert.Equal(suite.T(), []localization.Change{
+		{ID: ids.WordTexts, Index: 1, Data: [][]byte{suite.cp.Encode("zwei")}},
+	}, suite.importResult.Changes)
+}
+
+func (suite *ExchangeSuite) TestImportMeasuresLengthInEncodedBytes() {
+	suite.givenResources(resource.LangDefault, suite.storingLines(ids.WordTexts, "one", "two"))
+	suite.whenImporting(
+		localization.Unit{ID: "0868:0", Target: strings.Repeat("ä", text.LineLimit)},
+		localization.Unit{ID: "0868:1", Target: strings.Repeat("ä", text.LineLimit+1)})
+	assert.Equal(suite.T(), []string{"0868:0"}, suite.importResult.Changed)
+	assert.Equal(suite.T(), []string{"0868:1"}, suite.importResult.OverLength)
+}
+
+func (suite *ExchangeSuite) TestImportOfNewMessageTakesMetaFromSource() {
+	suite.givenResources(resource.LangDefault, suite.storingMessage(ids.MailsStart, "Title", "Verbose"))
+	suite.whenImporting(localization.Unit{ID: "0989:0:title", Target: "Titel"})
+	require.Len(suite.T(), suite.importResult.Changes, 1)
+	change := suite.importResult.Changes[0]
+	assert.Equal(suite.T(), ids.MailsStart, change.ID)
+	assert.Equal(suite.T(), -1, change.Index)
+	message, err := text.DecodeElectronicMessage(suite.cp, resource.BlocksFrom(change.Data))
+	require.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 0x20, message.NextMessage)
+	assert.Equal(suite.T(), "Titel", message.Title)
+	assert.Equal(suite.T(), "", message.VerboseText)
+}
+
+func (suite *ExchangeSuite) TestImportReportsUnmappableCharactersOfTargetCodepage() {
+	cp852, _ := text.CodepageByName(text.CP852)
+	suite.codepages.Set(resource.LangGerman, cp852)
+	suite.givenResources(resource.LangDefault, suite.storingLines(ids.WordTexts, "one", "two"))
+	suite.whenImporting(
+		localization.Unit{ID: "0868:0", Target: "jeden"},
+		localization.Unit{ID: "0868:1", Target: "два"})
+	assert.Equal(suite.T(), []string{"0868:0"}, suite.importResult.Changed)
+	assert.Equal(suite.T(), []string{"0868:1"}, suite.importResult.Unmappable)
+}
+
+func (suite *ExchangeSuite) givenResources(lang resource.Language, modifiers ...func(*resource.Store)) {
+	var store resource.Store
+	for _, modifier := range modifiers {
+		modifier(&store)
+	}
+	suite.localizedResources = append(suite.localizedResources,
+		resource.LocalizedResources{ID: lang.String(), Language: lang, Viewer: store})
+}
+
+func (suite *ExchangeSuite) whenCollectingUnits() {
+	suite.units = localization.Units(suite, suite.codepages, resource.LangDefault, resource.LangGerman)
+}
+
+func (suite *ExchangeSuite) whenImporting(units ...localization.Unit) {
+	suite.importResult = localization.Import(suite, suite.codepages, resource.LangDefault, resource.LangGerman, units)
+}
+
+func (suite *ExchangeSuite) storingLines(id resource.ID, lines ...string) func(*resource.Store) {
+	return func(store *resource.Store) {
+		_ = store.Put(id, resource.Resource{
+			Properties: resource.Properties{ContentType: resource.Text, Compound: true},
+			Blocks:     resource.BlocksFrom(suite.encoded(lines)),
+		})
+	}
+}
+
+func (suite *ExchangeSuite) storingMessage(id resource.ID, title, verbose string) func(*resource.Store) {
+	message := text.EmptyElectronicMessage()
+	message.NextMessage = 0x20
+	message.Title = title
+	message.VerboseText = verbose
+	return func(store *resource.Store) {
+		_ = store.Put(id, resource.Resource{
+			Properties: resource.Properties{ContentType: resource.Text, Compound: true},
+			Blocks:     resource.BlocksFrom(message.Encode(suite.cp)),
+		})
+	}
+}
+
+func (suite *ExchangeSuite) encoded(lines []string) [][]byte {
+	data := make([][]byte, len(lines))
+	for index, line := range lines {
+		data[index] = suite.cp.Encode(line)
+	}
+	return data
+}
+
+func (suite *ExchangeSuite) LocalizedResources(lang resource.Language) resource.Selector {
+	return resource.Selector{
+		From: suite.localizedResources,
+		Lang: lang,
+	}
+}
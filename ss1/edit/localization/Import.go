@@ -0,0 +1,186 @@
+package localization
+
+import (
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+// Change describes the new content of one text resource.
+type Change struct {
+	// ID is the resource to modify.
+	ID resource.ID
+	// Index is the block to set for list resources. It is -1 for resources that are replaced as a whole.
+	Index int
+	// Data contains the new blocks. For list resources, it has exactly one entry.
+	Data [][]byte
+}
+
+// ImportResult summarizes the changes found in a set of translated units.
+type ImportResult struct {
+	// Changes lists the resource modifications to apply.
+	Changes []Change
+	// Changed lists the IDs of all units with a different translation.
+	Changed []string
+	// Unchanged is the number of translations that match the current texts.
+	Unchanged int
+	// Missing lists the IDs of texts in the source language that have no translation.
+	Missing []string
+	// OverLength lists the IDs of translations that exceed the limit of their text. They are not taken over.
+	OverLength []string
+	// Unmappable lists the IDs of translations with characters that the codepage of the target language
+	// can not represent. They are not taken over.
+	Unmappable []string
+	// Unknown lists the IDs of units that do not refer to a text in the source language.
+	Unknown []string
+}
+
+// Import compares the translations of the given units with the texts of the target language and
+// returns the changes necessary to take them over. Only texts that exist in the source language are considered.
+//
+// Messages that do not exist yet in the target language take their meta information, such as the
+// displayed images, from the source language.
+func Import(localizer resource.Localizer, codepages text.Codepages,
+	sourceLang, targetLang resource.Language, units []Unit) ImportResult {
+	var result ImportResult
+	cp := codepages.ForLanguage(targetLang)
+	translations := make(map[string]string)
+	for _, unit := range units {
+		if len(unit.Target) > 0 {
+			translations[unit.ID] = unit.Target
+		}
+	}
+	targets := make(map[string]string)
+	for _, e := range entriesIn(localizer, codepages, targetLang) {
+		targets[e.id] = e.value
+	}
+	sourceEntries := entriesIn(localizer, codepages, sourceLang)
+	known := make(map[string]bool)
+	for _, e := range sourceEntries {
+		known[e.id] = true
+	}
+	for _, unit := range units {
+		if !known[unit.ID] {
+			result.Unknown = append(result.Unknown, unit.ID)
+		}
+	}
+
+	changes := newTextChanges(localizer, codepages, sourceLang, targetLang)
+	for _, e := range sourceEntries {
+		translation, translated := translations[e.id]
+		switch {
+		case !translated:
+			result.Missing = append(result.Missing, e.id)
+		case e.exceeds(cp, translation):
+			result.OverLength = append(result.OverLength, e.id)
+		case len(text.UnmappableRunes(cp, translation)) > 0:
+			result.Unmappable = append(result.Unmappable, e.id)
+		case translation == targets[e.id]:
+			result.Unchanged++
+		default:
+			result.Changed = append(result.Changed, e.id)
+			changes.set(e, translation)
+		}
+	}
+	result.Changes = changes.result()
+	return result
+}
+
+// textChanges collects the resource modifications for new values of entries.
+type textChanges struct {
+	cp       text.Codepage
+	changes  []Change
+	messages *messageChanges
+}
+
+func newTextChanges(localizer resource.Localizer, codepages text.Codepages,
+	sourceLang, targetLang resource.Language) *textChanges {
+	return &textChanges{
+		cp:       codepages.ForLanguage(targetLang),
+		messages: newMessageChanges(localizer, codepages, sourceLang, targetLang),
+	}
+}
+
+func (changes *textChanges) set(e entry, value string) {
+	switch e.group.kind {
+	case lineText:
+		changes.changes = append(changes.changes, Change{
+			ID:    e.group.id,
+			Index: e.index,
+			Data:  [][]byte{changes.cp.Encode(value)},
+		})
+	case pageText:
+		changes.changes = append(changes.changes, Change{
+			ID:    e.group.id.Plus(e.index),
+			Index: -1,
+			Data:  encodedBlocks(changes.cp, text.Blocked(value)),
+		})
+	case messageText:
+		changes.messages.set(e.group.id.Plus(e.index), e.field, value)
+	}
+}
+
+func (changes *textChanges) result() []Change {
+	return append(changes.changes, changes.messages.changes()...)
+}
+
+func encodedBlocks(cp text.Codepage, lines []string) [][]byte {
+	data := make([][]byte, len(lines))
+	for index, line := range lines {
+		data[index] = cp.Encode(line)
+	}
+	return data
+}
+
+type messageChanges struct {
+	cp         text.Codepage
+	source     *text.ElectronicMessageCache
+	target     *text.ElectronicMessageCache
+	sourceLang resource.Language
+	targetLang resource.Language
+
+	order    []resource.ID
+	messages map[resource.ID]*text.ElectronicMessage
+}
+
+func newMessageChanges(localizer resource.Localizer, codepages text.Codepages,
+	sourceLang, targetLang resource.Language) *messageChanges {
+	return &messageChanges{
+		cp:         codepages.ForLanguage(targetLang),
+		source:     text.NewElectronicMessageCache(codepages, localizer),
+		target:     text.NewElectronicMessageCache(codepages, localizer),
+		sourceLang: sourceLang,
+		targetLang: targetLang,
+		messages:   make(map[resource.ID]*text.ElectronicMessage),
+	}
+}
+
+func (changes *messageChanges) set(id resource.ID, field messageField, value string) {
+	message, existing := changes.messages[id]
+	if !existing {
+		base, err := changes.target.Message(resource.KeyOf(id, changes.targetLang, 0))
+		if err != nil {
+			base, _ = changes.source.Message(resource.KeyOf(id, changes.sourceLang, 0))
+			base.Title = ""
+			base.Sender = ""
+			base.Subject = ""
+			base.VerboseText = ""
+			base.TerseText = ""
+		}
+		message = &base
+		changes.messages[id] = message
+		changes.order = append(changes.order, id)
+	}
+	field.set(message, value)
+}
+
+func (changes *messageChanges) changes() []Change {
+	result := make([]Change, 0, len(changes.order))
+	for _, id := range changes.order {
+		result = append(result, Change{
+			ID:    id,
+			Index: -1,
+			Data:  changes.messages[id].Encode(changes.cp),
+		})
+	}
+	return result
+}
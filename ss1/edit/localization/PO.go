@@ -0,0 +1,179 @@
+package localization
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LoadPO reads the units of a gettext PO file from given reader.
+// The ID of each unit is taken from the message context. Entries marked as fuzzy are considered untranslated.
+func LoadPO(reader io.Reader) ([]Unit, error) {
+	if reader == nil {
+		return nil, errors.New("reader is nil")
+	}
+	var units []Unit
+	var current poEntry
+	var field *string
+	finish := func() error {
+		if current.started {
+			unit, isHeader, err := current.unit()
+			if err != nil {
+				return err
+			}
+			if !isHeader {
+				units = append(units, unit)
+			}
+		}
+		current = poEntry{}
+		field = nil
+		return nil
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, 1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case len(line) == 0:
+			if err := finish(); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, "#,"):
+			if current.hasMsgStr {
+				if err := finish(); err != nil {
+					return nil, err
+				}
+			}
+			current.fuzzy = current.fuzzy || strings.Contains(line, "fuzzy")
+		case strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "\""):
+			if field == nil {
+				return nil, fmt.Errorf("line %d: string without keyword", lineNumber)
+			}
+			value, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+			}
+			*field += value
+		default:
+			keyword, value, err := poKeywordLine(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+			}
+			if ((keyword == "msgctxt") || (keyword == "msgid")) && current.hasMsgStr {
+				if err := finish(); err != nil {
+					return nil, err
+				}
+			}
+			current.started = true
+			switch keyword {
+			case "msgctxt":
+				field = &current.msgctxt
+			case "msgid":
+				field = &current.msgid
+			case "msgstr", "msgstr[0]":
+				current.hasMsgStr = true
+				field = &current.msgstr
+			default:
+				field = &current.ignored
+			}
+			*field += value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	return units, nil
+}
+
+type poEntry struct {
+	started   bool
+	fuzzy     bool
+	hasMsgStr bool
+
+	msgctxt string
+	msgid   string
+	msgstr  string
+	ignored string
+}
+
+func (entry poEntry) unit() (unit Unit, isHeader bool, err error) {
+	if len(entry.msgctxt) == 0 {
+		if len(entry.msgid) == 0 {
+			return Unit{}, true, nil
+		}
+		return Unit{}, false, fmt.Errorf("missing msgctxt for msgid %q", entry.msgid)
+	}
+	unit = Unit{ID: entry.msgctxt, Source: entry.msgid}
+	if !entry.fuzzy {
+		unit.Target = entry.msgstr
+	}
+	return unit, false, nil
+}
+
+func poKeywordLine(line string) (keyword, value string, err error) {
+	separator := strings.Index(line, " ")
+	if separator < 0 {
+		return "", "", fmt.Errorf("invalid line: %v", line)
+	}
+	keyword = line[:separator]
+	value, err = strconv.Unquote(strings.TrimSpace(line[separator:]))
+	return
+}
+
+// SavePO writes the given units as a gettext PO file for the target language, identified by its code.
+// Each unit is written with its ID as message context, its note and limit as comments.
+func SavePO(writer io.Writer, units []Unit, targetCode string) error {
+	if writer == nil {
+		return errors.New("writer is nil")
+	}
+	var builder strings.Builder
+	builder.WriteString("msgid \"\"\n")
+	builder.WriteString("msgstr \"\"\n")
+	builder.WriteString("\"MIME-Version: 1.0\\n\"\n")
+	builder.WriteString("\"Content-Type: text/plain; charset=UTF-8\\n\"\n")
+	builder.WriteString("\"Content-Transfer-Encoding: 8bit\\n\"\n")
+	builder.WriteString("\"Language: " + targetCode + "\\n\"\n")
+	for _, unit := range units {
+		builder.WriteString("\n")
+		if len(unit.Note) > 0 {
+			builder.WriteString("#. " + unit.Note + "\n")
+		}
+		if unit.Limit > 0 {
+			builder.WriteString(fmt.Sprintf("#. Maximum length: %d\n", unit.Limit))
+		}
+		writePOString(&builder, "msgctxt", unit.ID)
+		writePOString(&builder, "msgid", unit.Source)
+		writePOString(&builder, "msgstr", unit.Target)
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+func writePOString(builder *strings.Builder, keyword string, value string) {
+	lines := strings.SplitAfter(value, "\n")
+	if len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= 1 {
+		builder.WriteString(keyword + " " + poQuoted(value) + "\n")
+		return
+	}
+	builder.WriteString(keyword + " \"\"\n")
+	for _, line := range lines {
+		builder.WriteString(poQuoted(line) + "\n")
+	}
+}
+
+var poEscaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n", "\r", "\\r", "\t", "\\t")
+
+func poQuoted(value string) string {
+	return "\"" + poEscaper.Replace(value) + "\""
+}
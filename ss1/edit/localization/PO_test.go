@@ -0,0 +1,61 @@
+package localization_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/edit/localization"
+)
+
+func TestLoadPOReturnsErrorOnNil(t *testing.T) {
+	units, err := localization.LoadPO(nil)
+	assert.NotNil(t, err)
+	assert.Nil(t, units)
+}
+
+func TestLoadPOReturnsUnitsWithContextAsID(t *testing.T) {
+	input := "msgid \"\"\nmsgstr \"\"\n\"Language: de\\n\"\n\n" +
+		"#. Words #1\nmsgctxt \"0868:1\"\nmsgid \"two\"\nmsgstr \"zwei\"\n\n" +
+		"msgctxt \"003C:0\"\nmsgid \"\"\n\"first\\n\"\n\"second\"\nmsgstr \"\"\n\"erste\\n\"\n\"zweite\"\n"
+	units, err := localization.LoadPO(strings.NewReader(input))
+	require.Nil(t, err)
+	assert.Equal(t, []localization.Unit{
+		{ID: "0868:1", Source: "two", Target: "zwei"},
+		{ID: "003C:0", Source: "first\nsecond", Target: "erste\nzweite"},
+	}, units)
+}
+
+func TestLoadPOConsidersFuzzyEntriesUntranslated(t *testing.T) {
+	input := "#, fuzzy\nmsgctxt \"0868:1\"\nmsgid \"two\"\nmsgstr \"zwo\"\n"
+	units, err := localization.LoadPO(strings.NewReader(input))
+	require.Nil(t, err)
+	require.Len(t, units, 1)
+	assert.Equal(t, "", units[0].Target)
+}
+
+func TestLoadPOReturnsErrorOnMissingContext(t *testing.T) {
+	_, err := localization.LoadPO(strings.NewReader("msgid \"two\"\nmsgstr \"zwei\"\n"))
+	assert.NotNil(t, err)
+}
+
+func TestPORoundTrip(t *testing.T) {
+	units := []localization.Unit{
+		{ID: "0868:1", Source: "two \"quoted\"", Target: "zwei"},
+		{ID: "09B8:3:verbose", Source: "line\n\nwith\ttab\n", Target: ""},
+	}
+	buf := bytes.NewBuffer(nil)
+	err := localization.SavePO(buf, []localization.Unit{
+		{ID: units[0].ID, Note: "Words #1", Limit: 79, Source: units[0].Source, Target: units[0].Target},
+		units[1],
+	}, "de")
+	require.Nil(t, err)
+	assert.Contains(t, buf.String(), "\"Language: de\\n\"")
+	assert.Contains(t, buf.String(), "#. Maximum length: 79\n")
+	loaded, err := localization.LoadPO(buf)
+	require.Nil(t, err)
+	assert.Equal(t, units, loaded)
+}
@@ -0,0 +1,78 @@
+package localization
+
+import (
+	"regexp"
+
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+// Match is a text that contains an occurrence of a searched expression.
+type Match struct {
+	// ID identifies the text, in the same form as Unit.ID.
+	ID string
+	// Note describes the origin of the text.
+	Note string
+	// Key refers to the text. Its ID is the first resource of the text type, its index the one of the text.
+	Key resource.Key
+	// Value is the complete text.
+	Value string
+	// Ranges contains the start and end byte offsets of each occurrence within the value.
+	Ranges [][]int
+}
+
+// Search returns all texts of the given language that contain the expression.
+func Search(localizer resource.Localizer, codepages text.Codepages, lang resource.Language, expr *regexp.Regexp) []Match {
+	var matches []Match
+	for _, e := range entriesIn(localizer, codepages, lang) {
+		ranges := expr.FindAllStringIndex(e.value, -1)
+		if len(ranges) == 0 {
+			continue
+		}
+		matches = append(matches, Match{
+			ID:     e.id,
+			Note:   e.note,
+			Key:    resource.KeyOf(e.group.id, lang, e.index),
+			Value:  e.value,
+			Ranges: ranges,
+		})
+	}
+	return matches
+}
+
+// ReplaceResult summarizes the changes of replacing the occurrences of an expression in one language.
+type ReplaceResult struct {
+	// Changes lists the resource modifications to apply.
+	Changes []Change
+	// Replaced lists the IDs of all texts that change.
+	Replaced []string
+	// OverLength lists the IDs of texts that would exceed their limit. They are not changed.
+	OverLength []string
+	// Unmappable lists the IDs of texts that would contain characters the codepage of the language
+	// can not represent. They are not changed.
+	Unmappable []string
+}
+
+// Replace returns the changes necessary to replace all occurrences of the expression in the texts of
+// the given language. The replacement can refer to submatches, as described for regexp.Regexp.Expand().
+func Replace(localizer resource.Localizer, codepages text.Codepages, lang resource.Language,
+	expr *regexp.Regexp, replacement string) ReplaceResult {
+	var result ReplaceResult
+	cp := codepages.ForLanguage(lang)
+	changes := newTextChanges(localizer, codepages, lang, lang)
+	for _, e := range entriesIn(localizer, codepages, lang) {
+		newValue := expr.ReplaceAllString(e.value, replacement)
+		switch {
+		case newValue == e.value:
+		case e.exceeds(cp, newValue):
+			result.OverLength = append(result.OverLength, e.id)
+		case len(text.UnmappableRunes(cp, newValue)) > 0:
+			result.Unmappable = append(result.Unmappable, e.id)
+		default:
+			result.Replaced = append(result.Replaced, e.id)
+			changes.set(e, newValue)
+		}
+	}
+	result.Changes = changes.result()
+	return result
+}
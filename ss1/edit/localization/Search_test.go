@@ -0,0 +1,66 @@
+package localization_test
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/edit/localization"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+)
+
+func (suite *ExchangeSuite) TestSearchReturnsMatchesWithRangesAndKeys() {
+	suite.givenResources(resource.LangDefault,
+		suite.storingLines(ids.WordTexts, "one door", "two", "door and door"),
+		suite.storingMessage(ids.LogsStart.Plus(3), "Title", "The door is open"))
+	matches := localization.Search(suite, suite.codepages, resource.LangDefault, regexp.MustCompile("do+r"))
+	require.Len(suite.T(), matches, 3)
+	assert.Equal(suite.T(), "0868:0", matches[0].ID)
+	assert.Equal(suite.T(), resource.KeyOf(ids.WordTexts, resource.LangDefault, 0), matches[0].Key)
+	assert.Equal(suite.T(), [][]int{{4, 8}}, matches[0].Ranges)
+	assert.Equal(suite.T(), [][]int{{0, 4}, {9, 13}}, matches[1].Ranges)
+	assert.Equal(suite.T(), "09B8:3:verbose", matches[2].ID)
+	assert.Equal(suite.T(), resource.KeyOf(ids.LogsStart, resource.LangDefault, 3), matches[2].Key)
+}
+
+func (suite *ExchangeSuite) TestSearchConsidersOnlyGivenLanguage() {
+	suite.givenResources(resource.LangDefault, suite.storingLines(ids.WordTexts, "door"))
+	suite.givenResources(resource.LangGerman, suite.storingLines(ids.WordTexts, "Tür"))
+	matches := localization.Search(suite, suite.codepages, resource.LangGerman, regexp.MustCompile("door"))
+	assert.Len(suite.T(), matches, 0)
+}
+
+func (suite *ExchangeSuite) TestReplaceReturnsChangesOfAllTextKinds() {
+	suite.givenResources(resource.LangDefault,
+		suite.storingLines(ids.WordTexts, "red door", "blue"),
+		suite.storingLines(ids.PaperTextsStart.Plus(2), "A red ", "paper", ""),
+		suite.storingMessage(ids.LogsStart.Plus(3), "Red", "The red light"))
+	result := localization.Replace(suite, suite.codepages, resource.LangDefault, regexp.MustCompile("(?i)(r)ed"), "${1}ot")
+	assert.Equal(suite.T(), []string{"0868:0", "003C:2", "09B8:3:title", "09B8:3:verbose"}, result.Replaced)
+	require.Len(suite.T(), result.Changes, 3)
+	assert.Equal(suite.T(), localization.Change{
+		ID: ids.WordTexts, Index: 0, Data: [][]byte{suite.cp.Encode("rot door")}}, result.Changes[0])
+	assert.Equal(suite.T(), ids.PaperTextsStart.Plus(2), result.Changes[1].ID)
+	assert.Equal(suite.T(), -1, result.Changes[1].Index)
+	message, err := text.DecodeElectronicMessage(suite.cp, resource.BlocksFrom(result.Changes[2].Data))
+	require.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "Rot", message.Title)
+	assert.Equal(suite.T(), "The rot light", message.VerboseText)
+	assert.Equal(suite.T(), 0x20, message.NextMessage)
+}
+
+func (suite *ExchangeSuite) TestReplaceReportsOverLengthAndUnmappableTexts() {
+	cp852, _ := text.CodepageByName(text.CP852)
+	suite.codepages.Set(resource.LangDefault, cp852)
+	suite.givenResources(resource.LangDefault, suite.storingLines(ids.WordTexts, "short", "word"))
+	result := localization.Replace(suite, suite.codepages, resource.LangDefault, regexp.MustCompile("short"),
+		strings.Repeat("long", 20))
+	assert.Equal(suite.T(), []string{"0868:0"}, result.OverLength)
+	result = localization.Replace(suite, suite.codepages, resource.LangDefault, regexp.MustCompile("word"), "слово")
+	assert.Equal(suite.T(), []string{"0868:1"}, result.Unmappable)
+	assert.Len(suite.T(), result.Changes, 0)
+}
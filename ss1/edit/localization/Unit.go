@@ -0,0 +1,251 @@
+package localization
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/edit"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+)
+
+// Unit is one translatable text.
+type Unit struct {
+	// ID identifies the text. It is composed of the resource ID and the index, and the field for messages.
+	ID string
+	// Note describes the origin of the text for translators.
+	Note string
+	// Limit is the maximum length of the text in bytes. Zero for texts without limit.
+	Limit int
+	// Source is the text in the source language.
+	Source string
+	// Target is the text in the target language. Empty if not translated.
+	Target string
+}
+
+// Units returns all texts that exist in the source language, together with their current translation
+// in the target language.
+func Units(localizer resource.Localizer, codepages text.Codepages, sourceLang, targetLang resource.Language) []Unit {
+	targets := make(map[string]string)
+	for _, entry := range entriesIn(localizer, codepages, targetLang) {
+		targets[entry.id] = entry.value
+	}
+	var units []Unit
+	for _, entry := range entriesIn(localizer, codepages, sourceLang) {
+		units = append(units, Unit{
+			ID:     entry.id,
+			Note:   entry.note,
+			Limit:  entry.limit(),
+			Source: entry.value,
+			Target: targets[entry.id],
+		})
+	}
+	return units
+}
+
+type textKind int
+
+const (
+	lineText textKind = iota
+	pageText
+	messageText
+)
+
+type textGroup struct {
+	id    resource.ID
+	title string
+	kind  textKind
+}
+
+func textGroups() []textGroup {
+	var groups []textGroup
+	for _, info := range edit.KnownTexts() {
+		kind := pageText
+		if resourceInfo, _ := ids.Info(info.ID); resourceInfo.List {
+			kind = lineText
+		}
+		groups = append(groups, textGroup{id: info.ID, title: info.Title, kind: kind})
+	}
+	return append(groups,
+		textGroup{id: ids.ObjectLongNames, title: "Object Long Names", kind: lineText},
+		textGroup{id: ids.ObjectShortNames, title: "Object Short Names", kind: lineText},
+		textGroup{id: ids.TextureNames, title: "Texture Names", kind: lineText},
+		textGroup{id: ids.TextureUsages, title: "Texture Usages", kind: lineText},
+		textGroup{id: ids.MailsStart, title: "Mails", kind: messageText},
+		textGroup{id: ids.LogsStart, title: "Logs", kind: messageText},
+		textGroup{id: ids.FragmentsStart, title: "Fragments", kind: messageText})
+}
+
+type messageField string
+
+const (
+	fieldTitle   messageField = "title"
+	fieldSender  messageField = "sender"
+	fieldSubject messageField = "subject"
+	fieldVerbose messageField = "verbose"
+	fieldTerse   messageField = "terse"
+)
+
+var messageFields = []messageField{fieldTitle, fieldSender, fieldSubject, fieldVerbose, fieldTerse}
+
+func (field messageField) title() string {
+	switch field {
+	case fieldTitle:
+		return "Title"
+	case fieldSender:
+		return "Sender"
+	case fieldSubject:
+		return "Subject"
+	case fieldVerbose:
+		return "Verbose Text"
+	default:
+		return "Terse Text"
+	}
+}
+
+func (field messageField) singleBlock() bool {
+	return (field == fieldTitle) || (field == fieldSender) || (field == fieldSubject)
+}
+
+func (field messageField) get(message text.ElectronicMessage) string {
+	switch field {
+	case fieldTitle:
+		return message.Title
+	case fieldSender:
+		return message.Sender
+	case fieldSubject:
+		return message.Subject
+	case fieldVerbose:
+		return message.VerboseText
+	default:
+		return message.TerseText
+	}
+}
+
+func (field messageField) set(message *text.ElectronicMessage, value string) {
+	switch field {
+	case fieldTitle:
+		message.Title = value
+	case fieldSender:
+		message.Sender = value
+	case fieldSubject:
+		message.Subject = value
+	case fieldVerbose:
+		message.VerboseText = value
+	default:
+		message.TerseText = value
+	}
+}
+
+type entry struct {
+	id    string
+	note  string
+	value string
+
+	group textGroup
+	index int
+	field messageField
+}
+
+func (e entry) singleBlock() bool {
+	return (e.group.kind == lineText) || ((e.group.kind == messageText) && e.field.singleBlock())
+}
+
+func (e entry) limit() int {
+	if e.singleBlock() {
+		return text.LineLimit
+	}
+	return 0
+}
+
+// exceeds returns true if the given value does not fit into the text of this entry.
+// Single-block texts are limited in the number of bytes they are encoded with, excluding the terminator.
+func (e entry) exceeds(cp text.Codepage, value string) bool {
+	if !e.singleBlock() {
+		return false
+	}
+	return (len(cp.Encode(value)) - 1) > text.LineLimit
+}
+
+func entriesIn(localizer resource.Localizer, codepages text.Codepages, lang resource.Language) []entry {
+	var entries []entry
+	selector := localizer.LocalizedResources(lang)
+	pages := text.NewPageCache(codepages, localizer)
+	messages := text.NewElectronicMessageCache(codepages, localizer)
+	for _, group := range textGroups() {
+		switch group.kind {
+		case lineText:
+			for index, value := range linesOf(selector, codepages.ForLanguage(lang), group.id) {
+				entries = appendEntry(entries, entry{
+					id:    fmt.Sprintf("%v:%d", group.id, index),
+					note:  fmt.Sprintf("%s #%d", group.title, index),
+					value: value,
+					group: group,
+					index: index,
+				})
+			}
+		case pageText:
+			info, _ := ids.Info(group.id)
+			for index := 0; index < info.MaxCount; index++ {
+				value, err := pages.Text(resource.KeyOf(group.id, lang, index))
+				if err != nil {
+					continue
+				}
+				entries = appendEntry(entries, entry{
+					id:    fmt.Sprintf("%v:%d", group.id, index),
+					note:  fmt.Sprintf("%s #%d", group.title, index),
+					value: value,
+					group: group,
+					index: index,
+				})
+			}
+		case messageText:
+			info, _ := ids.Info(group.id)
+			for index := 0; index < info.MaxCount; index++ {
+				message, err := messages.Message(resource.KeyOf(group.id, lang, index))
+				if err != nil {
+					continue
+				}
+				for _, field := range messageFields {
+					entries = appendEntry(entries, entry{
+						id:    fmt.Sprintf("%v:%d:%s", group.id, index, field),
+						note:  fmt.Sprintf("%s #%d, %s", group.title, index, field.title()),
+						value: field.get(message),
+						group: group,
+						index: index,
+						field: field,
+					})
+				}
+			}
+		}
+	}
+	return entries
+}
+
+func appendEntry(entries []entry, e entry) []entry {
+	if len(e.value) == 0 {
+		return entries
+	}
+	return append(entries, e)
+}
+
+func linesOf(selector resource.Selector, cp text.Codepage, id resource.ID) []string {
+	view, err := selector.Select(id)
+	if (err != nil) || (view.ContentType() != resource.Text) {
+		return nil
+	}
+	lines := make([]string, view.BlockCount())
+	for index := range lines {
+		reader, err := view.Block(index)
+		if err != nil {
+			continue
+		}
+		raw, err := ioutil.ReadAll(reader)
+		if err != nil {
+			continue
+		}
+		lines[index] = cp.Decode(raw)
+	}
+	return lines
+}
@@ -0,0 +1,115 @@
+package localization
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+const xliffNamespace = "urn:oasis:names:tc:xliff:document:1.2"
+
+type xliffDocument struct {
+	XMLName   xml.Name    `xml:"xliff"`
+	Namespace string      `xml:"xmlns,attr"`
+	Version   string      `xml:"version,attr"`
+	Files     []xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	Original       string           `xml:"original,attr"`
+	SourceLanguage string           `xml:"source-language,attr"`
+	TargetLanguage string           `xml:"target-language,attr,omitempty"`
+	DataType       string           `xml:"datatype,attr"`
+	Units          []xliffTransUnit `xml:"body>trans-unit"`
+}
+
+type xliffTransUnit struct {
+	ID       string       `xml:"id,attr"`
+	Space    string       `xml:"http://www.w3.org/XML/1998/namespace space,attr,omitempty"`
+	MaxWidth int          `xml:"maxwidth,attr,omitempty"`
+	SizeUnit string       `xml:"size-unit,attr,omitempty"`
+	Source   string       `xml:"source"`
+	Target   *xliffTarget `xml:"target"`
+	Note     string       `xml:"note,omitempty"`
+}
+
+type xliffTarget struct {
+	State string `xml:"state,attr,omitempty"`
+	Text  string `xml:",chardata"`
+}
+
+// LoadXLIFF reads the units of an XLIFF 1.2 document from given reader.
+// Targets with the state "needs-translation" are considered untranslated.
+func LoadXLIFF(reader io.Reader) ([]Unit, error) {
+	if reader == nil {
+		return nil, errors.New("reader is nil")
+	}
+	var doc xliffDocument
+	err := xml.NewDecoder(reader).Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+	var units []Unit
+	for _, file := range doc.Files {
+		for _, transUnit := range file.Units {
+			unit := Unit{
+				ID:     transUnit.ID,
+				Note:   transUnit.Note,
+				Limit:  transUnit.MaxWidth,
+				Source: transUnit.Source,
+			}
+			if (transUnit.Target != nil) && (transUnit.Target.State != "needs-translation") {
+				unit.Target = transUnit.Target.Text
+			}
+			units = append(units, unit)
+		}
+	}
+	return units, nil
+}
+
+// SaveXLIFF writes the given units as an XLIFF 1.2 document. The languages are identified by their code.
+// Limits are written as maximum width in bytes. Units without translation have no target.
+func SaveXLIFF(writer io.Writer, units []Unit, sourceCode, targetCode string) error {
+	if writer == nil {
+		return errors.New("writer is nil")
+	}
+	file := xliffFile{
+		Original:       "texts",
+		SourceLanguage: sourceCode,
+		TargetLanguage: targetCode,
+		DataType:       "plaintext",
+	}
+	for _, unit := range units {
+		transUnit := xliffTransUnit{
+			ID:     unit.ID,
+			Space:  "preserve",
+			Source: unit.Source,
+			Note:   unit.Note,
+		}
+		if unit.Limit > 0 {
+			transUnit.MaxWidth = unit.Limit
+			transUnit.SizeUnit = "byte"
+		}
+		if len(unit.Target) > 0 {
+			transUnit.Target = &xliffTarget{Text: unit.Target}
+		}
+		file.Units = append(file.Units, transUnit)
+	}
+	doc := xliffDocument{
+		Namespace: xliffNamespace,
+		Version:   "1.2",
+		Files:     []xliffFile{file},
+	}
+	_, err := io.WriteString(writer, xml.Header)
+	if err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	err = encoder.Encode(&doc)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(writer, "\n")
+	return err
+}
@@ -0,0 +1,46 @@
+package localization_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/edit/localization"
+)
+
+func TestLoadXLIFFReturnsErrorOnNil(t *testing.T) {
+	units, err := localization.LoadXLIFF(nil)
+	assert.NotNil(t, err)
+	assert.Nil(t, units)
+}
+
+func TestLoadXLIFFConsidersTargetsNeedingTranslationUntranslated(t *testing.T) {
+	input := `<xliff version="1.2" xmlns="urn:oasis:names:tc:xliff:document:1.2">
+<file original="texts" source-language="en" target-language="fr" datatype="plaintext"><body>
+<trans-unit id="0868:1"><source>two</source><target state="needs-translation">two</target></trans-unit>
+<trans-unit id="0868:2"><source>three</source><target state="translated">trois</target></trans-unit>
+</body></file></xliff>`
+	units, err := localization.LoadXLIFF(strings.NewReader(input))
+	require.Nil(t, err)
+	assert.Equal(t, []localization.Unit{
+		{ID: "0868:1", Source: "two"},
+		{ID: "0868:2", Source: "three", Target: "trois"},
+	}, units)
+}
+
+func TestXLIFFRoundTrip(t *testing.T) {
+	units := []localization.Unit{
+		{ID: "0868:1", Note: "Words #1", Limit: 79, Source: "two <&>", Target: "zwei"},
+		{ID: "09B8:3:verbose", Note: "Logs #3, Verbose Text", Source: "line\n\n  indented\n"},
+	}
+	buf := bytes.NewBuffer(nil)
+	err := localization.SaveXLIFF(buf, units, "en", "de")
+	require.Nil(t, err)
+	assert.Contains(t, buf.String(), `target-language="de"`)
+	loaded, err := localization.LoadXLIFF(buf)
+	require.Nil(t, err)
+	assert.Equal(t, units, loaded)
+}
@@ -0,0 +1,6 @@
+// Package localization provides the exchange of all translatable texts with common translation formats.
+// The supported formats are gettext PO and XLIFF 1.2. Each text is identified by its resource ID and index,
+// so that translated files can be imported into any language of the same mod.
+//
+// The same set of texts can be searched, and occurrences replaced, with regular expressions.
+package localization
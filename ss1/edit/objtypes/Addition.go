@@ -0,0 +1,151 @@
+package objtypes
+
+import (
+	"io/ioutil"
+
+	"github.com/inkyblackness/hacked/ss1/content/bitmap"
+	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+)
+
+// BitmapsPerType is the number of bitmaps a new type has. These are the icon, the world bitmap, and
+// one frame for the 3D view.
+const BitmapsPerType = 3
+
+// ListChange describes the complete content of a list resource in one language.
+type ListChange struct {
+	Lang resource.Language
+	ID   resource.ID
+	// Data contains all blocks of the list. As the entries of following types are shifted,
+	// the list replaces the one of the world entirely.
+	Data [][]byte
+}
+
+// Addition describes the changes necessary to add a new object type.
+type Addition struct {
+	// Triple identifies the new type.
+	Triple object.Triple
+	// Properties is the extended table of object properties.
+	Properties object.PropertiesTable
+	// Lists contains the extended name texts and bitmaps.
+	Lists []ListChange
+}
+
+// Add returns the changes to append a new, empty type to the given subclass.
+// The new type gets empty names in all given languages that have names, and placeholder bitmaps.
+func Add(localizer resource.Localizer, codepages text.Codepages, languages []resource.Language,
+	table object.PropertiesTable, class object.Class, subclass object.Subclass) (Addition, error) {
+	properties, triple, err := table.WithTypeAdded(class, subclass)
+	if err != nil {
+		return Addition{}, err
+	}
+	addition := Addition{Triple: triple, Properties: properties}
+	nameIndex := properties.TripleIndex(triple)
+	for _, lang := range languages {
+		emptyName := codepages.ForLanguage(lang).Encode("")
+		for _, id := range []resource.ID{ids.ObjectLongNames, ids.ObjectShortNames} {
+			blocks := listBlocks(localizer, lang, id)
+			if len(blocks) == 0 {
+				continue
+			}
+			addition.Lists = append(addition.Lists, ListChange{
+				Lang: lang,
+				ID:   id,
+				Data: inserted(blocks, nameIndex, [][]byte{emptyName}, emptyName),
+			})
+		}
+	}
+	if blocks := listBlocks(localizer, resource.LangAny, ids.ObjectBitmaps); len(blocks) > 0 {
+		placeholder := placeholderBitmap()
+		newBitmaps := make([][]byte, BitmapsPerType)
+		for index := range newBitmaps {
+			newBitmaps[index] = placeholder
+		}
+		addition.Lists = append(addition.Lists, ListChange{
+			Lang: resource.LangAny,
+			ID:   ids.ObjectBitmaps,
+			Data: inserted(blocks, BitmapOffset(properties, triple), newBitmaps, placeholder),
+		})
+	}
+	return addition, nil
+}
+
+// BitmapCount returns the number of bitmaps of a type with given properties.
+// These are the bitmaps every type has, plus the additional frames for the 3D view.
+func BitmapCount(prop *object.Properties) int {
+	return BitmapsPerType + int(prop.Common.Bitmap3D.FrameNumber())
+}
+
+// BitmapOffsets returns the index of the first bitmap of each type of the table within the object bitmaps.
+// The bitmaps of the types follow each other in the order of the table, after one leading bitmap.
+func BitmapOffsets(table object.PropertiesTable) map[object.Triple]int {
+	offsets := make(map[object.Triple]int)
+	offset := 1
+	table.Iterate(func(triple object.Triple, prop *object.Properties) bool {
+		offsets[triple] = offset
+		offset += BitmapCount(prop)
+		return true
+	})
+	return offsets
+}
+
+// BitmapOffset returns the index of the first bitmap of the given type within the object bitmaps.
+// It returns -1 if the type is not part of the table.
+func BitmapOffset(table object.PropertiesTable, triple object.Triple) int {
+	offset, found := BitmapOffsets(table)[triple]
+	if !found {
+		return -1
+	}
+	return offset
+}
+
+// inserted returns a new list with the given blocks inserted at the index.
+// Empty blocks are replaced with the filler, as they would otherwise let the shifted content of the world
+// shine through.
+func inserted(blocks [][]byte, index int, newBlocks [][]byte, filler []byte) [][]byte {
+	for len(blocks) < index {
+		blocks = append(blocks, nil)
+	}
+	result := make([][]byte, 0, len(blocks)+len(newBlocks))
+	result = append(result, blocks[:index]...)
+	result = append(result, newBlocks...)
+	result = append(result, blocks[index:]...)
+	for blockIndex, data := range result {
+		if len(data) == 0 {
+			result[blockIndex] = filler
+		}
+	}
+	return result
+}
+
+func listBlocks(localizer resource.Localizer, lang resource.Language, id resource.ID) [][]byte {
+	view, err := localizer.LocalizedResources(lang).Select(id)
+	if err != nil {
+		return nil
+	}
+	blocks := make([][]byte, view.BlockCount())
+	for index := range blocks {
+		reader, err := view.Block(index)
+		if err != nil {
+			continue
+		}
+		blocks[index], _ = ioutil.ReadAll(reader)
+	}
+	return blocks
+}
+
+func placeholderBitmap() []byte {
+	bmp := bitmap.Bitmap{
+		Header: bitmap.Header{
+			Type:   bitmap.TypeFlat8Bit,
+			Flags:  bitmap.FlagTransparent,
+			Width:  1,
+			Height: 1,
+			Stride: 1,
+		},
+		Pixels: []byte{0x00},
+	}
+	return bitmap.Encode(&bmp, 0)
+}
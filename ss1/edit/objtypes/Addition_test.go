@@ -0,0 +1,149 @@
+package objtypes_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/edit/objtypes"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+)
+
+type AdditionSuite struct {
+	suite.Suite
+
+	cp                 text.Codepage
+	table              object.PropertiesTable
+	localizedResources resource.LocalizedResourcesList
+
+	addition objtypes.Addition
+	err      error
+}
+
+func TestAdditionSuite(t *testing.T) {
+	suite.Run(t, new(AdditionSuite))
+}
+
+func (suite *AdditionSuite) SetupTest() {
+	suite.cp = text.DefaultCodepage()
+	suite.table = object.StandardPropertiesTable()
+	suite.localizedResources = nil
+	suite.addition = objtypes.Addition{}
+	suite.err = nil
+}
+
+func (suite *AdditionSuite) TestAddExtendsPropertiesOfSubclass() {
+	suite.whenAdding(object.Class(0), object.Subclass(1))
+	require.Nil(suite.T(), suite.err)
+	assert.Equal(suite.T(), object.TripleFrom(0, 1, 2), suite.addition.Triple)
+	_, err := suite.addition.Properties.ForObject(suite.addition.Triple)
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *AdditionSuite) TestAddReturnsErrorForInvalidSubclass() {
+	suite.whenAdding(object.Class(0), object.Subclass(40))
+	assert.NotNil(suite.T(), suite.err)
+}
+
+func (suite *AdditionSuite) TestAddInsertsEmptyNameAtLinearIndex() {
+	suite.givenResources(resource.LangDefault, suite.storingList(ids.ObjectLongNames, suite.names(8)...))
+	suite.givenResources(resource.LangGerman, suite.storingList(ids.ObjectShortNames, suite.names(3)...))
+	suite.whenAdding(object.Class(0), object.Subclass(1))
+	require.Nil(suite.T(), suite.err)
+	require.Len(suite.T(), suite.addition.Lists, 2)
+
+	longNames := suite.addition.Lists[0]
+	assert.Equal(suite.T(), resource.LangDefault, longNames.Lang)
+	assert.Equal(suite.T(), ids.ObjectLongNames, longNames.ID)
+	require.Len(suite.T(), longNames.Data, 9)
+	assert.Equal(suite.T(), suite.cp.Encode("name6"), longNames.Data[6])
+	assert.Equal(suite.T(), suite.cp.Encode(""), longNames.Data[7])
+	assert.Equal(suite.T(), suite.cp.Encode("name7"), longNames.Data[8])
+
+	shortNames := suite.addition.Lists[1]
+	assert.Equal(suite.T(), resource.LangGerman, shortNames.Lang)
+	require.Len(suite.T(), shortNames.Data, 8, "short list shall be extended up to the new type")
+	assert.Equal(suite.T(), suite.cp.Encode(""), shortNames.Data[5], "gaps shall be filled")
+	assert.Equal(suite.T(), suite.cp.Encode(""), shortNames.Data[7])
+}
+
+func (suite *AdditionSuite) TestAddInsertsBitmapsAtOffsetOfType() {
+	prop, _ := suite.table.ForObject(object.TripleFrom(0, 0, 0))
+	prop.Common.Bitmap3D = prop.Common.Bitmap3D.WithFrameNumber(2)
+	bitmaps := make([][]byte, 40)
+	for index := range bitmaps {
+		bitmaps[index] = []byte{byte(index)}
+	}
+	suite.givenResources(resource.LangAny, suite.storingBlocks(ids.ObjectBitmaps, bitmaps))
+	suite.whenAdding(object.Class(0), object.Subclass(1))
+	require.Nil(suite.T(), suite.err)
+	require.Len(suite.T(), suite.addition.Lists, 1)
+
+	expectedOffset := 1 + 5 + 4*3 + 2*3
+	assert.Equal(suite.T(), expectedOffset, objtypes.BitmapOffset(suite.addition.Properties, suite.addition.Triple))
+	data := suite.addition.Lists[0].Data
+	require.Len(suite.T(), data, 40+objtypes.BitmapsPerType)
+	assert.Equal(suite.T(), []byte{byte(expectedOffset - 1)}, data[expectedOffset-1])
+	assert.True(suite.T(), len(data[expectedOffset]) > 1, "placeholder expected")
+	assert.Equal(suite.T(), []byte{byte(expectedOffset)}, data[expectedOffset+objtypes.BitmapsPerType])
+}
+
+func (suite *AdditionSuite) TestBitmapOffsetsFollowTheBitmapCountsOfTheTypes() {
+	prop, _ := suite.table.ForObject(object.TripleFrom(0, 0, 0))
+	prop.Common.Bitmap3D = prop.Common.Bitmap3D.WithFrameNumber(2)
+	offsets := objtypes.BitmapOffsets(suite.table)
+	assert.Equal(suite.T(), 1, offsets[object.TripleFrom(0, 0, 0)])
+	assert.Equal(suite.T(), 1+5, offsets[object.TripleFrom(0, 0, 1)])
+	assert.Equal(suite.T(), 5, objtypes.BitmapCount(prop))
+	assert.Equal(suite.T(), -1, objtypes.BitmapOffset(suite.table, object.TripleFrom(15, 0, 0)))
+}
+
+func (suite *AdditionSuite) givenResources(lang resource.Language, modifiers ...func(*resource.Store)) {
+	var store resource.Store
+	for _, modifier := range modifiers {
+		modifier(&store)
+	}
+	suite.localizedResources = append(suite.localizedResources,
+		resource.LocalizedResources{ID: lang.String(), Language: lang, Viewer: store})
+}
+
+func (suite *AdditionSuite) whenAdding(class object.Class, subclass object.Subclass) {
+	suite.addition, suite.err = objtypes.Add(suite, text.NewLanguageCodepages(suite.cp), resource.Languages(), suite.table, class, subclass)
+}
+
+func (suite *AdditionSuite) names(count int) []string {
+	names := make([]string, count)
+	for index := range names {
+		names[index] = "name" + string(rune('0'+index))
+	}
+	return names
+}
+
+func (suite *AdditionSuite) storingList(id resource.ID, lines ...string) func(*resource.Store) {
+	data := make([][]byte, len(lines))
+	for index, line := range lines {
+		data[index] = suite.cp.Encode(line)
+	}
+	return suite.storingBlocks(id, data)
+}
+
+func (suite *AdditionSuite) storingBlocks(id resource.ID, data [][]byte) func(*resource.Store) {
+	return func(store *resource.Store) {
+		_ = store.Put(id, resource.Resource{
+			Properties: resource.Properties{Compound: true},
+			Blocks:     resource.BlocksFrom(data),
+		})
+	}
+}
+
+func (suite *AdditionSuite) LocalizedResources(lang resource.Language) resource.Selector {
+	return resource.Selector{
+		From: suite.localizedResources,
+		Lang: lang,
+	}
+}
@@ -0,0 +1,4 @@
+// Package objtypes extends the catalogue of object types of a mod.
+// Adding a type keeps the object properties, the object name texts, and the object bitmaps consistent,
+// as the latter are indexed by the linear order of all types.
+package objtypes
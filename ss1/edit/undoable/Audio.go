@@ -0,0 +1,50 @@
+package undoable
+
+import (
+	"github.com/inkyblackness/hacked/ss1/content/audio"
+	"github.com/inkyblackness/hacked/ss1/edit/media"
+	"github.com/inkyblackness/hacked/ss1/edit/undoable/cmd"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+// AudioService provides read/write functionality for audio resources with undo capability.
+type AudioService struct {
+	viewer    media.AudioViewerService
+	setter    media.AudioSetterService
+	commander cmd.Commander
+}
+
+// NewAudioService returns a new instance of a service.
+func NewAudioService(viewer media.AudioViewerService, setter media.AudioSetterService, commander cmd.Commander) AudioService {
+	return AudioService{
+		viewer:    viewer,
+		setter:    setter,
+		commander: commander,
+	}
+}
+
+// Sound returns the audio of the identified resource.
+func (service AudioService) Sound(key resource.Key) audio.L8 {
+	return service.viewer.Audio(key)
+}
+
+// RequestSetSound queues the change to update the sound.
+func (service AudioService) RequestSetSound(key resource.Key, sound audio.L8, restoreFunc func()) {
+	isModified := service.viewer.Modified(key)
+	oldSound := service.viewer.Audio(key)
+	c := command{
+		forward: func(modder world.Modder) {
+			service.setter.Set(modder, key, sound)
+		},
+		reverse: func(modder world.Modder) {
+			if isModified {
+				service.setter.Set(modder, key, oldSound)
+			} else {
+				service.setter.Remove(modder, key)
+			}
+		},
+		restore: restoreFunc,
+	}
+	service.commander.Queue(c)
+}
@@ -13,3 +13,12 @@ type Command interface {
 	// environment may not be in the state as before in an error occurred.
 	Undo(modder world.Modder) error
 }
+
+// Merger is implemented by commands that can be combined with the command performed before them.
+// This allows continuous changes, such as dragging a slider, to be undone in one step.
+type Merger interface {
+	// MergedWith returns a command that reverses to the state before the previous command
+	// and performs to the state after this command.
+	// It returns false if the previous command can not be merged.
+	MergedWith(previous Command) (Command, bool)
+}
@@ -20,6 +20,8 @@ type Stack struct {
 
 // Perform executes the given command and puts it on the stack
 // if the command was successful.
+// If the command is a Merger and can be merged with the last performed command,
+// the merged command replaces the last one instead.
 // This function also clears the list of commands to be redone.
 func (stack *Stack) Perform(cmd Command, modder world.Modder) error {
 	stack.lock("Perform")
@@ -29,6 +31,12 @@ func (stack *Stack) Perform(cmd Command, modder world.Modder) error {
 	if err != nil {
 		return err
 	}
+	if merger, isMerger := cmd.(Merger); isMerger && (stack.undoList != nil) && (stack.redoList == nil) {
+		if merged, canMerge := merger.MergedWith(stack.undoList.cmd); canMerge {
+			stack.undoList.cmd = merged
+			return nil
+		}
+	}
 	stack.undoList = &stackEntry{link: stack.undoList, cmd: cmd}
 	stack.redoList = nil
 	return nil
@@ -41,6 +41,19 @@ func (cmd *TestCommand) run() (err error) {
 	return
 }
 
+type MergingTestCommand struct {
+	TestCommand
+
+	mergeable bool
+}
+
+func (command *MergingTestCommand) MergedWith(previous cmd.Command) (cmd.Command, bool) {
+	if !command.mergeable {
+		return nil, false
+	}
+	return cmd.List{previous, command}, true
+}
+
 type StackSuite struct {
 	suite.Suite
 
@@ -176,6 +189,33 @@ func (suite *StackSuite) TestPerformDropsPendingRedoStack() {
 	suite.thenCommandShouldHaveBeenExecutedTimes("cmd1", 1)
 }
 
+func (suite *StackSuite) TestPerformMergesCommandWithPrevious() {
+	suite.givenCommandWasPerformed("cmd1")
+	suite.whenPerforming(suite.aMergingCommand("cmd2", true))
+	suite.whenUndoing()
+	suite.thenCommandShouldHaveBeenReverted("cmd2")
+	suite.thenCommandShouldHaveBeenReverted("cmd1")
+	suite.thenStackShouldNotSupportUndo()
+}
+
+func (suite *StackSuite) TestPerformKeepsCommandSeparateIfNotMergeable() {
+	suite.givenCommandWasPerformed("cmd1")
+	suite.whenPerforming(suite.aMergingCommand("cmd2", false))
+	suite.whenUndoing()
+	suite.thenCommandShouldHaveBeenReverted("cmd2")
+	suite.thenCommandShouldHaveBeenRevertedTimes("cmd1", 0)
+}
+
+func (suite *StackSuite) TestPerformDoesNotMergeWithCommandBeforeUndone() {
+	suite.givenCommandWasPerformed("cmd1")
+	suite.givenCommandWasPerformed("cmd2")
+	suite.givenUndoWasCalledTimes(1)
+	suite.whenPerforming(suite.aMergingCommand("cmd3", true))
+	suite.whenUndoing()
+	suite.thenCommandShouldHaveBeenReverted("cmd3")
+	suite.thenCommandShouldHaveBeenRevertedTimes("cmd1", 0)
+}
+
 func (suite *StackSuite) TestPerformPanicsIfStackIsInUse() {
 	callPerform := func(name string) func() {
 		var times int
@@ -328,6 +368,12 @@ func (suite *StackSuite) aCommandExecuting(name string, task func()) cmd.Command
 	return cmd
 }
 
+func (suite *StackSuite) aMergingCommand(name string, mergeable bool) cmd.Command {
+	cmd := &MergingTestCommand{TestCommand: TestCommand{name: name}, mergeable: mergeable}
+	suite.commands[name] = &cmd.TestCommand
+	return cmd
+}
+
 func (suite *StackSuite) aCommandReturningError() cmd.Command {
 	return suite.aCommandReturning(fmt.Errorf("fail"))
 }
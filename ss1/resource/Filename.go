@@ -12,18 +12,24 @@ type Filename interface {
 }
 
 // I18nFile is for internationalized resource files - i.e., those that store resources per file.
-type I18nFile [LanguageCount]string
+// It lists the filenames of the built-in languages. See LanguageSpecs.Filename() for the filenames of
+// additional languages.
+type I18nFile [BuiltinLanguageCount]string
 
 // For returns the string per language index.
+// Unknown languages return the filename of the default language.
 func (spec I18nFile) For(lang Language) string {
-	return spec[int(lang)]
+	if int(lang) < len(spec) {
+		return spec[int(lang)]
+	}
+	return spec[LangDefault]
 }
 
 // Matches returns true if the given filename matches one of the localized filenames.
 func (spec I18nFile) Matches(filename string) bool {
 	lowercase := strings.ToLower(filename)
-	for _, entry := range spec {
-		if entry == lowercase {
+	for _, lang := range Languages() {
+		if spec.For(lang) == lowercase {
 			return true
 		}
 	}
@@ -1,6 +1,9 @@
 package resource
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Language defines the human language of a resource.
 type Language byte
@@ -15,10 +18,50 @@ const (
 	// LangGerman identifies the German language.
 	LangGerman Language = 2
 
-	// LanguageCount specifies how many languages are supported.
-	LanguageCount = 3
+	// BuiltinLanguageCount specifies how many languages the original game supports.
+	BuiltinLanguageCount = 3
+	// MaxAdditionalLanguages specifies how many languages can be added to the built-in ones.
+	MaxAdditionalLanguages = 32
 )
 
+// LanguageSpec describes an additional language, beyond the ones supported by the original game.
+type LanguageSpec struct {
+	// Name is the human readable name of the language. It must not contain whitespace.
+	Name string
+	// Code is the ISO 639-1 code of the language, such as "es".
+	Code string
+	// FilenamePattern describes the names of the localized resource files. The placeholder "{name}" is
+	// replaced by the name of the respective file of the default language, without extension.
+	FilenamePattern string
+}
+
+// FilenamePlaceholder is the part of a filename pattern that is replaced by the name of the default file.
+const FilenamePlaceholder = "{name}"
+
+// Filename returns the name of the localized file, based on the filename of the default language.
+func (spec LanguageSpec) Filename(defaultFilename string) string {
+	ext := ""
+	if dot := strings.LastIndex(defaultFilename, "."); dot >= 0 {
+		ext = defaultFilename[dot:]
+	}
+	name := strings.ReplaceAll(spec.FilenamePattern, FilenamePlaceholder, strings.TrimSuffix(defaultFilename, ext))
+	if !strings.Contains(name, ".") {
+		name += ext
+	}
+	return strings.ToLower(name)
+}
+
+// Validate returns an error if the specification can not be used.
+func (spec LanguageSpec) Validate() error {
+	if (len(spec.Name) == 0) || (len(strings.Fields(spec.Name)) != 1) {
+		return fmt.Errorf("invalid language name '%v'", spec.Name)
+	}
+	if !strings.Contains(spec.FilenamePattern, FilenamePlaceholder) {
+		return fmt.Errorf("filename pattern '%v' is missing placeholder %v", spec.FilenamePattern, FilenamePlaceholder)
+	}
+	return nil
+}
+
 func (lang Language) String() string {
 	switch lang {
 	case LangAny:
@@ -34,7 +77,24 @@ func (lang Language) String() string {
 	}
 }
 
-// Languages returns a slice of all human languages. Does not include "Any" selector.
+// Code returns the ISO 639-1 code of the language. The default language is considered to be English.
+// Other languages return their lowercase name. See LanguageSpecs.Code() for additional languages.
+func (lang Language) Code() string {
+	switch lang {
+	case LangDefault:
+		return "en"
+	case LangFrench:
+		return "fr"
+	case LangGerman:
+		return "de"
+	default:
+		return strings.ToLower(lang.String())
+	}
+}
+
+// Languages returns a slice of the built-in human languages. See LanguageSpecs.Languages() for a list that
+// includes additional languages.
+// Does not include "Any" selector.
 func Languages() []Language {
 	return []Language{LangDefault, LangFrench, LangGerman}
 }
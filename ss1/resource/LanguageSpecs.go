@@ -0,0 +1,92 @@
+package resource
+
+import "strings"
+
+// LanguageSpecs is a list of additional languages. The first entry describes the language that follows
+// the built-in ones, the further entries get consecutive values. Entries beyond MaxAdditionalLanguages are ignored.
+type LanguageSpecs []LanguageSpec
+
+// Languages returns the built-in human languages, followed by the additional ones.
+// Does not include "Any" selector.
+func (specs LanguageSpecs) Languages() []Language {
+	result := Languages()
+	for index := range specs.limited() {
+		result = append(result, Language(BuiltinLanguageCount+index))
+	}
+	return result
+}
+
+// Spec returns the specification of an additional language.
+// The returned boolean is false if the language is not one of the additional ones.
+func (specs LanguageSpecs) Spec(lang Language) (LanguageSpec, bool) {
+	index := int(lang) - BuiltinLanguageCount
+	limited := specs.limited()
+	if (lang == LangAny) || (index < 0) || (index >= len(limited)) {
+		return LanguageSpec{}, false
+	}
+	return limited[index], true
+}
+
+// Name returns the human readable name of the language.
+func (specs LanguageSpecs) Name(lang Language) string {
+	if spec, isAdditional := specs.Spec(lang); isAdditional {
+		return spec.Name
+	}
+	return lang.String()
+}
+
+// Code returns the ISO 639-1 code of the language.
+// Additional languages without code return their lowercase name.
+func (specs LanguageSpecs) Code(lang Language) string {
+	spec, isAdditional := specs.Spec(lang)
+	if !isAdditional {
+		return lang.Code()
+	}
+	if len(spec.Code) > 0 {
+		return spec.Code
+	}
+	return strings.ToLower(spec.Name)
+}
+
+// Named returns the language with given name. The name is compared without regard to case.
+// The returned boolean is false if no language has the name.
+func (specs LanguageSpecs) Named(name string) (Language, bool) {
+	for _, lang := range specs.Languages() {
+		if strings.EqualFold(specs.Name(lang), name) {
+			return lang, true
+		}
+	}
+	return LangAny, false
+}
+
+// Filename returns the name of the given file for the language.
+// The names of localized files of additional languages are derived from the one of the default language.
+func (specs LanguageSpecs) Filename(file Filename, lang Language) string {
+	if spec, isAdditional := specs.Spec(lang); isAdditional {
+		if localized, isLocalized := file.(I18nFile); isLocalized {
+			return spec.Filename(localized.For(LangDefault))
+		}
+	}
+	return file.For(lang)
+}
+
+// Matches returns true if the given filename is one of the names of the file, in any of the languages.
+func (specs LanguageSpecs) Matches(file Filename, filename string) bool {
+	if file.Matches(filename) {
+		return true
+	}
+	lowercase := strings.ToLower(filename)
+	for _, lang := range specs.Languages() {
+		if specs.Filename(file, lang) == lowercase {
+			return true
+		}
+	}
+	return false
+}
+
+func (specs LanguageSpecs) limited() LanguageSpecs {
+	if len(specs) > MaxAdditionalLanguages {
+		return specs[:MaxAdditionalLanguages]
+	}
+	return specs
+}
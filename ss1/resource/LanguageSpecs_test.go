@@ -0,0 +1,63 @@
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+func someLanguageSpecs() resource.LanguageSpecs {
+	return resource.LanguageSpecs{
+		{Name: "Spanish", Code: "es", FilenamePattern: "{name}_es"},
+		{Name: "Polish", FilenamePattern: "pl{name}.res"},
+	}
+}
+
+func TestLanguageSpecsFollowBuiltinLanguages(t *testing.T) {
+	result := someLanguageSpecs().Languages()
+	assert.Equal(t, []resource.Language{resource.LangDefault, resource.LangFrench, resource.LangGerman, 3, 4}, result)
+	assert.Equal(t, resource.Languages(), resource.LanguageSpecs(nil).Languages())
+}
+
+func TestLanguageSpecsDescribeAdditionalLanguages(t *testing.T) {
+	specs := someLanguageSpecs()
+	assert.Equal(t, "Spanish", specs.Name(3))
+	assert.Equal(t, "es", specs.Code(3))
+	assert.Equal(t, "polish", specs.Code(4))
+	assert.Equal(t, "German", specs.Name(resource.LangGerman))
+	assert.Equal(t, "de", specs.Code(resource.LangGerman))
+	assert.Equal(t, "Unknown05", specs.Name(5))
+	assert.Equal(t, "Unknown03", resource.Language(3).String(), "values shall not describe themselves")
+}
+
+func TestLanguageSpecsNamedFindsLanguagesIgnoringCase(t *testing.T) {
+	specs := someLanguageSpecs()
+	lang, found := specs.Named("polish")
+	assert.True(t, found)
+	assert.Equal(t, resource.Language(4), lang)
+	lang, found = specs.Named("FRENCH")
+	assert.True(t, found)
+	assert.Equal(t, resource.LangFrench, lang)
+	_, found = specs.Named("Klingon")
+	assert.False(t, found)
+}
+
+func TestLanguageSpecsFilenameDerivesLocalizedFilesOnly(t *testing.T) {
+	specs := someLanguageSpecs()
+	localized := resource.I18nFile{"cybstrng.res", "frnstrng.res", "gerstrng.res"}
+	assert.Equal(t, "cybstrng_es.res", specs.Filename(localized, 3))
+	assert.Equal(t, "frnstrng.res", specs.Filename(localized, resource.LangFrench))
+	assert.Equal(t, "cybstrng.res", specs.Filename(localized, 5))
+	assert.Equal(t, "texture.res", specs.Filename(resource.AnyLanguage("texture.res"), 3))
+}
+
+func TestLanguageSpecsMatchesFilenamesOfAllLanguages(t *testing.T) {
+	specs := someLanguageSpecs()
+	localized := resource.I18nFile{"cybstrng.res", "frnstrng.res", "gerstrng.res"}
+	assert.True(t, specs.Matches(localized, "GERSTRNG.RES"))
+	assert.True(t, specs.Matches(localized, "cybstrng_es.res"))
+	assert.False(t, localized.Matches("cybstrng_es.res"), "file alone knows built-in languages only")
+	assert.False(t, specs.Matches(localized, "cybstrng_fi.res"))
+}
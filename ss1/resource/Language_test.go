@@ -56,3 +56,29 @@ func TestLanguages(t *testing.T) {
 	result := resource.Languages()
 	assert.Equal(t, 3, len(result))
 }
+
+func TestLanguageSpecFilename(t *testing.T) {
+	tt := []struct {
+		pattern  string
+		expected string
+	}{
+		{"{name}_es", "cybstrng_es.res"},
+		{"{name}.ES.res", "cybstrng.es.res"},
+		{"spa{name}", "spacybstrng.res"},
+	}
+
+	for _, tc := range tt {
+		td := tc
+		t.Run(td.pattern, func(t *testing.T) {
+			spec := resource.LanguageSpec{Name: "Spanish", FilenamePattern: td.pattern}
+			assert.Equal(t, td.expected, spec.Filename("cybstrng.res"))
+		})
+	}
+}
+
+func TestLanguageSpecValidate(t *testing.T) {
+	assert.Nil(t, resource.LanguageSpec{Name: "Spanish", FilenamePattern: "{name}_es"}.Validate())
+	assert.NotNil(t, resource.LanguageSpec{Name: "", FilenamePattern: "{name}_es"}.Validate(), "empty name")
+	assert.NotNil(t, resource.LanguageSpec{Name: "Brazilian Portuguese", FilenamePattern: "{name}_pt"}.Validate(), "whitespace")
+	assert.NotNil(t, resource.LanguageSpec{Name: "Spanish", FilenamePattern: "spanish.res"}.Validate(), "placeholder")
+}
@@ -3,22 +3,36 @@ package resource
 import "io/ioutil"
 
 // Store holds a set of resources. This set can be modified.
+//
+// Resources can be stored either as copy, or as a reference to a view. Referenced views are only copied
+// into the store once they are retrieved for modification via Resource(). This allows to keep large sets of data
+// in their original location, until they are actually changed.
 type Store struct {
 	ids       []ID
 	resources map[ID]*Resource
+	views     map[ID]View
 }
 
 // IDs returns a list of available IDs this store currently contains.
 func (store Store) IDs() []ID {
-	ids := make([]ID, len(store.resources))
-	for id := range store.resources {
-		ids[store.findIDIndex(id)] = id
+	ids := make([]ID, 0, len(store.resources)+len(store.views))
+	for _, id := range store.ids {
+		if store.contains(id) {
+			ids = append(ids, id)
+		}
 	}
 	return ids
 }
 
 // Resource returns reference to the contained resource for given identifier.
-func (store Store) Resource(id ID) (*Resource, error) {
+// Should the resource only be referenced as a view, it is copied into the store first.
+func (store *Store) Resource(id ID) (*Resource, error) {
+	if view, referenced := store.views[id]; referenced {
+		err := store.Put(id, view)
+		if err != nil {
+			return nil, err
+		}
+	}
 	res, existing := store.resources[id]
 	if !existing {
 		return nil, ErrResourceDoesNotExist(id)
@@ -27,16 +41,29 @@ func (store Store) Resource(id ID) (*Resource, error) {
 }
 
 // View returns a read-only view on the resource for given identifier.
+// Referenced views are returned as they are, without copying them.
 func (store Store) View(id ID) (View, error) {
-	return store.Resource(id)
+	if view, referenced := store.views[id]; referenced {
+		return view, nil
+	}
+	res, existing := store.resources[id]
+	if !existing {
+		return nil, ErrResourceDoesNotExist(id)
+	}
+	return res, nil
+}
+
+// IsReferenced returns true if the identified resource is only referenced and was not yet copied.
+func (store Store) IsReferenced(id ID) bool {
+	_, referenced := store.views[id]
+	return referenced
 }
 
 // Del removes the resource with given identifier from the store.
 func (store *Store) Del(id ID) bool {
-	_, existing := store.resources[id]
-	if existing {
-		delete(store.resources, id)
-	}
+	existing := store.contains(id)
+	delete(store.resources, id)
+	delete(store.views, id)
 	return existing
 }
 
@@ -66,11 +93,33 @@ func (store *Store) Put(id ID, view View) error {
 	if store.resources == nil {
 		store.resources = make(map[ID]*Resource)
 	}
+	delete(store.views, id)
 	store.resources[id] = res
+	store.register(id)
+	return nil
+}
+
+// Reference (re-)assigns an identifier with a view, without copying its data.
+// The view must stay valid for as long as the store references it.
+func (store *Store) Reference(id ID, view View) {
+	if store.views == nil {
+		store.views = make(map[ID]View)
+	}
+	delete(store.resources, id)
+	store.views[id] = view
+	store.register(id)
+}
+
+func (store Store) contains(id ID) bool {
+	_, existing := store.resources[id]
+	_, referenced := store.views[id]
+	return existing || referenced
+}
+
+func (store *Store) register(id ID) {
 	if store.findIDIndex(id) < 0 {
 		store.ids = append(store.ids, id)
 	}
-	return nil
 }
 
 func (store Store) findIDIndex(id ID) int {
@@ -112,6 +112,55 @@ func (suite *StoreSuite) TestPutRestoresIDAtOldPosition() {
 	suite.thenIDsShouldBe([]resource.ID{resource.ID(2), resource.ID(1)})
 }
 
+func (suite *StoreSuite) TestDelOfFirstResourceKeepsOthers() {
+	suite.givenAnInstance()
+	suite.givenStoredResource(resource.ID(2), suite.aResource())
+	suite.givenStoredResource(resource.ID(1), suite.aResource())
+	suite.whenResourceIsDeleted(resource.ID(2))
+	suite.thenIDsShouldBe([]resource.ID{resource.ID(1)})
+}
+
+func (suite *StoreSuite) TestReferencedViewsAreListedInOrder() {
+	suite.givenAnInstance()
+	suite.givenStoredResource(resource.ID(2), suite.aResource())
+	suite.givenReferencedView(resource.ID(3), suite.aResource())
+	suite.givenStoredResource(resource.ID(1), suite.aResource())
+	suite.thenIDsShouldBe([]resource.ID{resource.ID(2), resource.ID(3), resource.ID(1)})
+}
+
+func (suite *StoreSuite) TestViewReturnsReferencedViewWithoutCopy() {
+	suite.givenAnInstance()
+	view := suite.aResource()
+	suite.givenReferencedView(resource.ID(1), view)
+	res, err := suite.store.View(resource.ID(1))
+	require.Nil(suite.T(), err)
+	assert.True(suite.T(), res == resource.View(view), "Referenced view expected")
+	assert.True(suite.T(), suite.store.IsReferenced(resource.ID(1)))
+}
+
+func (suite *StoreSuite) TestResourceCopiesReferencedView() {
+	suite.givenAnInstance()
+	view := suite.aResource()
+	original, _ := view.BlockRaw(0)
+	original = append([]byte{}, original...)
+	suite.givenReferencedView(resource.ID(1), view)
+	res, err := suite.store.Resource(resource.ID(1))
+	require.Nil(suite.T(), err)
+	res.SetBlock(0, []byte{0xFF})
+	assert.False(suite.T(), suite.store.IsReferenced(resource.ID(1)))
+	raw, _ := view.BlockRaw(0)
+	assert.Equal(suite.T(), original, raw, "Original view must not be modified")
+	suite.thenIDsShouldBe([]resource.ID{resource.ID(1)})
+}
+
+func (suite *StoreSuite) TestDelRemovesReferencedView() {
+	suite.givenAnInstance()
+	suite.givenReferencedView(resource.ID(1), suite.aResource())
+	suite.whenResourceIsDeleted(resource.ID(1))
+	suite.thenIDsShouldBeEmpty()
+	suite.thenViewShouldReturnErrorFor(resource.ID(1))
+}
+
 func (suite *StoreSuite) givenAnInstance() {
 	suite.whenInstanceIsCreated()
 }
@@ -121,6 +170,10 @@ func (suite *StoreSuite) givenStoredResource(id resource.ID, res *resource.Resou
 	require.Nil(suite.T(), err, "No error expected storing resource")
 }
 
+func (suite *StoreSuite) givenReferencedView(id resource.ID, view resource.View) {
+	suite.store.Reference(id, view)
+}
+
 func (suite *StoreSuite) givenResourceWasDeleted(id resource.ID) {
 	suite.whenResourceIsDeleted(id)
 }
@@ -64,6 +64,39 @@ func (reader *Reader) IDs() []resource.ID {
 	return ids
 }
 
+// EntryInfo describes a resource as it is listed in the directory of a serialized form.
+type EntryInfo struct {
+	ID         resource.ID
+	Properties resource.Properties
+	// Offset is the position of the resource data within the serialized form.
+	Offset uint32
+	// UnpackedLength is the size of the resource data, including any block table, without compression.
+	UnpackedLength uint32
+	// PackedLength is the size the resource data occupies in the serialized form.
+	PackedLength uint32
+}
+
+// Entry returns the directory information of the specified resource.
+// An error is returned if the ID is not known.
+func (reader *Reader) Entry(id resource.ID) (EntryInfo, error) {
+	resourceStartOffset, entry := reader.findEntry(id.Value())
+	if entry == nil {
+		return EntryInfo{}, resource.ErrResourceDoesNotExist(id)
+	}
+	resourceType := entry.resourceType()
+	return EntryInfo{
+		ID: id,
+		Properties: resource.Properties{
+			Compound:    (resourceType & resourceTypeFlagCompound) != 0,
+			ContentType: resource.ContentType(entry.contentType()),
+			Compressed:  (resourceType & resourceTypeFlagCompressed) != 0,
+		},
+		Offset:         resourceStartOffset,
+		UnpackedLength: entry.unpackedLength(),
+		PackedLength:   entry.packedLength(),
+	}, nil
+}
+
 // View returns a reader prepared to extract data for the specified resource.
 // An error is returned if either the ID is not known, or the resource could not be prepared.
 func (reader *Reader) View(id resource.ID) (retrievedResource resource.View, err error) {
@@ -98,6 +98,34 @@ func TestReaderResourceReturnsResourceWithMetaInformation(t *testing.T) {
 	verifyResource(exampleResourceIDCompoundResourceCompressed, true, resource.ContentType(0x04), true)
 }
 
+func TestReaderEntryReturnsErrorForUnknownID(t *testing.T) {
+	reader, _ := ReaderFrom(bytes.NewReader(emptyResourceFile()))
+	_, err := reader.Entry(resource.ID(0x1111))
+	assert.NotNil(t, err)
+}
+
+func TestReaderEntryReturnsDirectoryInformation(t *testing.T) {
+	reader, _ := ReaderFrom(bytes.NewReader(exampleResourceFile()))
+
+	single, err := reader.Entry(exampleResourceIDSingleBlockResource)
+	require.Nil(t, err, "no error expected")
+	assert.Equal(t, exampleResourceIDSingleBlockResource, single.ID)
+	assert.Equal(t, resource.Properties{ContentType: resource.ContentType(0x01)}, single.Properties)
+	assert.Equal(t, uint32(3), single.UnpackedLength, "unpacked length wrong")
+	assert.Equal(t, uint32(3), single.PackedLength, "packed length wrong")
+
+	compressed, err := reader.Entry(exampleResourceIDSingleBlockResourceCompressed)
+	require.Nil(t, err, "no error expected")
+	assert.Equal(t, resource.Properties{ContentType: resource.ContentType(0x02), Compressed: true}, compressed.Properties)
+	assert.Equal(t, uint32(2), compressed.UnpackedLength, "unpacked length wrong")
+	assert.True(t, compressed.Offset > single.Offset, "offset should be after first resource")
+
+	compound, err := reader.Entry(exampleResourceIDCompoundResource)
+	require.Nil(t, err, "no error expected")
+	assert.Equal(t, resource.Properties{Compound: true, ContentType: resource.ContentType(0x03)}, compound.Properties)
+	assert.Equal(t, compound.UnpackedLength, compound.PackedLength, "uncompressed lengths should be equal")
+}
+
 func TestReaderResourceReturnsSameInstance(t *testing.T) {
 	reader, _ := ReaderFrom(bytes.NewReader(exampleResourceFile()))
 
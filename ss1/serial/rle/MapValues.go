@@ -0,0 +1,68 @@
+package rle
+
+import (
+	"errors"
+)
+
+// MapValues rewrites compressed data so that every written value is passed through the given mapping.
+// Control information is kept as it is, which also keeps skipped areas as they are - relevant for delta data.
+// The rewrite stops after the end marker. The returned length is the number of bytes of the compressed stream,
+// including the end marker. Any data following the stream is not part of the result.
+func MapValues(data []byte, mapping func(byte) byte) ([]byte, int, error) {
+	result := make([]byte, 0, len(data))
+	index := 0
+	errUnexpectedEnd := errors.New("unexpected end of data")
+	copyControl := func(count int) bool {
+		if (index + count) > len(data) {
+			return false
+		}
+		result = append(result, data[index:index+count]...)
+		index += count
+		return true
+	}
+	mapValues := func(count int) bool {
+		if (index + count) > len(data) {
+			return false
+		}
+		for _, value := range data[index : index+count] {
+			result = append(result, mapping(value))
+		}
+		index += count
+		return true
+	}
+
+	for {
+		if index >= len(data) {
+			return nil, 0, errUnexpectedEnd
+		}
+		first := data[index]
+		ok := true
+		switch {
+		case first == 0x00:
+			ok = copyControl(2) && mapValues(1)
+		case first < 0x80:
+			ok = copyControl(1) && mapValues(int(first))
+		case first == 0x80:
+			if !copyControl(3) {
+				return nil, 0, errUnexpectedEnd
+			}
+			control := uint16(data[index-2]) + uint16(data[index-1])<<8
+			switch {
+			case control == 0x0000:
+				return result, index, nil
+			case control < 0x8000:
+			case control < 0xC000:
+				ok = mapValues(int(control & 0x3FFF))
+			case (control & 0xFF00) == 0xC000:
+				return nil, 0, errors.New("undefined case 80 nn C0")
+			default:
+				ok = mapValues(1)
+			}
+		default:
+			ok = copyControl(1)
+		}
+		if !ok {
+			return nil, 0, errUnexpectedEnd
+		}
+	}
+}
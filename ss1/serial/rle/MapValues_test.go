@@ -0,0 +1,59 @@
+package rle_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/serial/rle"
+)
+
+func increment(value byte) byte {
+	return value + 1
+}
+
+func TestMapValuesKeepsControlAndMapsValues(t *testing.T) {
+	data := []byte{
+		0x00, 0x05, 0x10, // constant
+		0x02, 0x20, 0x21, // raw
+		0x83,             // short skip
+		0x80, 0x10, 0x00, // long skip
+		0x80, 0x02, 0x80, 0x30, 0x31, // long raw
+		0x80, 0x04, 0xC1, 0x40, // long constant
+		0x80, 0x00, 0x00, // end
+		0xAA, // trailing data
+	}
+	result, length, err := rle.MapValues(data, increment)
+	require.Nil(t, err)
+	assert.Equal(t, len(data)-1, length)
+	assert.Equal(t, []byte{
+		0x00, 0x05, 0x11,
+		0x02, 0x21, 0x22,
+		0x83,
+		0x80, 0x10, 0x00,
+		0x80, 0x02, 0x80, 0x31, 0x32,
+		0x80, 0x04, 0xC1, 0x41,
+		0x80, 0x00, 0x00,
+	}, result)
+}
+
+func TestMapValuesKeepsSkippedAreasOfDeltas(t *testing.T) {
+	reference := []byte{0x00, 0x00, 0x05, 0x05, 0x05, 0x05, 0x05, 0x00, 0x07}
+	frame := []byte{0x01, 0x00, 0x05, 0x05, 0x05, 0x05, 0x05, 0x02, 0x07}
+	buf := bytes.NewBuffer(nil)
+	require.Nil(t, rle.Compress(buf, frame, reference))
+
+	mapped, _, err := rle.MapValues(buf.Bytes(), increment)
+	require.Nil(t, err)
+	result := []byte{0xF0, 0xF0, 0xF0, 0xF0, 0xF0, 0xF0, 0xF0, 0xF0, 0xF0}
+	require.Nil(t, rle.Decompress(bytes.NewReader(mapped), result))
+	assert.Equal(t, []byte{0x02, 0xF0, 0xF0, 0xF0, 0xF0, 0xF0, 0xF0, 0x03, 0xF0}, result)
+}
+
+func TestMapValuesReturnsErrorForMissingEnd(t *testing.T) {
+	_, _, err := rle.MapValues([]byte{0x02, 0x20}, increment)
+
+	assert.NotNil(t, err)
+}
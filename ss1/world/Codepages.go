@@ -0,0 +1,107 @@
+package world
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+// CodepageAssignment specifies the codepage to use for the texts of a language.
+type CodepageAssignment struct {
+	// Language is the language the codepage is used for.
+	Language resource.Language
+	// Name is either the name of a known codepage, or the filename of a custom mapping table.
+	Name string
+	// Table contains the custom mapping table. It is nil for known codepages.
+	Table []byte
+}
+
+// Codepage returns the codepage described by the assignment.
+func (assignment CodepageAssignment) Codepage() (text.Codepage, error) {
+	if assignment.Table != nil {
+		return text.LoadCodepage(bytes.NewReader(assignment.Table))
+	}
+	cp, known := text.CodepageByName(assignment.Name)
+	if !known {
+		return nil, fmt.Errorf("unknown codepage %v", assignment.Name)
+	}
+	return cp, nil
+}
+
+// CodepageTableLoader returns the content of the custom mapping table with given filename.
+type CodepageTableLoader func(filename string) ([]byte, error)
+
+// LoadCodepageAssignments reads the codepages of languages from given reader.
+// Each line assigns a codepage to a language, with the name of the language and the name of the codepage
+// separated by whitespace. Codepages that are not known by name are custom mapping tables, which are retrieved
+// from the given loader. Empty lines and lines starting with '#' are ignored.
+// Languages are known by name, considering the given additional languages.
+func LoadCodepageAssignments(reader io.Reader, languages resource.LanguageSpecs,
+	tableLoader CodepageTableLoader) ([]CodepageAssignment, error) {
+	if reader == nil {
+		return nil, errors.New("reader is nil")
+	}
+	var assignments []CodepageAssignment
+	assigned := make(map[resource.Language]bool)
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if (len(line) == 0) || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected language and codepage", lineNumber)
+		}
+		lang, known := languages.Named(fields[0])
+		if !known {
+			return nil, fmt.Errorf("line %d: unknown language %v", lineNumber, fields[0])
+		}
+		if assigned[lang] {
+			return nil, fmt.Errorf("line %d: language %v has more than one codepage", lineNumber, fields[0])
+		}
+		assignment := CodepageAssignment{Language: lang, Name: fields[1]}
+		if _, known := text.CodepageByName(assignment.Name); !known {
+			if tableLoader == nil {
+				return nil, fmt.Errorf("line %d: unknown codepage %v", lineNumber, assignment.Name)
+			}
+			table, err := tableLoader(assignment.Name)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+			}
+			assignment.Table = table
+		}
+		if _, err := assignment.Codepage(); err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+		assigned[lang] = true
+		assignments = append(assignments, assignment)
+	}
+	return assignments, scanner.Err()
+}
+
+// SaveCodepageAssignments writes the given assignments in the format LoadCodepageAssignments() reads.
+// Languages are written by name, considering the given additional languages.
+// Assignments of languages that are not known are skipped. Custom mapping tables are not written.
+func SaveCodepageAssignments(writer io.Writer, assignments []CodepageAssignment, languages resource.LanguageSpecs) error {
+	if writer == nil {
+		return errors.New("writer is nil")
+	}
+	var builder strings.Builder
+	builder.WriteString("# language codepage (" + strings.Join(text.CodepageNames(), ", ") + ", or filename of a mapping table)\n")
+	for _, assignment := range assignments {
+		name := languages.Name(assignment.Language)
+		if _, known := languages.Named(name); !known {
+			continue
+		}
+		builder.WriteString(name + " " + assignment.Name + "\n")
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
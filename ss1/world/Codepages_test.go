@@ -0,0 +1,101 @@
+package world_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/content/text"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+func TestLoadCodepageAssignmentsReturnsErrorOnNil(t *testing.T) {
+	assignments, err := world.LoadCodepageAssignments(nil, nil, nil)
+	assert.NotNil(t, err)
+	assert.Nil(t, assignments)
+}
+
+func TestLoadCodepageAssignmentsReadsKnownCodepages(t *testing.T) {
+	assignments, err := world.LoadCodepageAssignments(strings.NewReader("# comment\n\ngerman  cp850\nFrench CP852\n"), nil, nil)
+	require.Nil(t, err)
+	assert.Equal(t, []world.CodepageAssignment{
+		{Language: resource.LangGerman, Name: "cp850"},
+		{Language: resource.LangFrench, Name: "CP852"},
+	}, assignments)
+}
+
+func TestLoadCodepageAssignmentsRetrievesCustomTables(t *testing.T) {
+	var requested string
+	loader := func(filename string) ([]byte, error) {
+		requested = filename
+		return []byte("0x80 0x0106\n"), nil
+	}
+	assignments, err := world.LoadCodepageAssignments(strings.NewReader("Default custom.txt\n"), nil, loader)
+	require.Nil(t, err)
+	assert.Equal(t, "custom.txt", requested)
+	require.Len(t, assignments, 1)
+	cp, err := assignments[0].Codepage()
+	require.Nil(t, err)
+	assert.Equal(t, []byte{0x80, 0x00}, cp.Encode("Ć"))
+}
+
+func TestLoadCodepageAssignmentsReturnsErrorOnInvalidLines(t *testing.T) {
+	failingLoader := func(filename string) ([]byte, error) { return nil, errors.New("not found") }
+	invalidLoader := func(filename string) ([]byte, error) { return []byte("invalid\n"), nil }
+	_, err := world.LoadCodepageAssignments(strings.NewReader("German\n"), nil, nil)
+	assert.NotNil(t, err, "missing field")
+	_, err = world.LoadCodepageAssignments(strings.NewReader("Klingon CP850\n"), nil, nil)
+	assert.NotNil(t, err, "unknown language")
+	_, err = world.LoadCodepageAssignments(strings.NewReader("German CP850\nGerman CP852\n"), nil, nil)
+	assert.NotNil(t, err, "duplicate language")
+	_, err = world.LoadCodepageAssignments(strings.NewReader("German custom.txt\n"), nil, nil)
+	assert.NotNil(t, err, "no loader")
+	_, err = world.LoadCodepageAssignments(strings.NewReader("German custom.txt\n"), nil, failingLoader)
+	assert.NotNil(t, err, "failing loader")
+	_, err = world.LoadCodepageAssignments(strings.NewReader("German custom.txt\n"), nil, invalidLoader)
+	assert.NotNil(t, err, "invalid table")
+}
+
+func TestCodepageAssignmentsRoundTrip(t *testing.T) {
+	assignments := []world.CodepageAssignment{
+		{Language: resource.LangDefault, Name: text.CP437},
+		{Language: resource.LangGerman, Name: text.CP866},
+	}
+	buf := bytes.NewBuffer(nil)
+	err := world.SaveCodepageAssignments(buf, assignments, nil)
+	require.Nil(t, err)
+	loaded, err := world.LoadCodepageAssignments(buf, nil, nil)
+	require.Nil(t, err)
+	assert.Equal(t, assignments, loaded)
+}
+
+func TestCodepageAssignmentsOfAdditionalLanguagesRoundTrip(t *testing.T) {
+	languages := resource.LanguageSpecs{{Name: "Polish", Code: "pl", FilenamePattern: "pl{name}"}}
+	polish := resource.Language(resource.BuiltinLanguageCount)
+	assignments := []world.CodepageAssignment{{Language: polish, Name: text.CP852}}
+	buf := bytes.NewBuffer(nil)
+	err := world.SaveCodepageAssignments(buf, assignments, languages)
+	require.Nil(t, err)
+	assert.Contains(t, buf.String(), "Polish CP852")
+	loaded, err := world.LoadCodepageAssignments(buf, languages, nil)
+	require.Nil(t, err)
+	assert.Equal(t, assignments, loaded)
+	_, err = world.LoadCodepageAssignments(strings.NewReader("Polish CP852\n"), nil, nil)
+	assert.NotNil(t, err, "language unknown without specification")
+}
+
+func TestModProvidesCodepagesOfAssignments(t *testing.T) {
+	var changedIDs []resource.ID
+	mod := world.NewMod(func(modified []resource.ID, failed []resource.ID) { changedIDs = modified }, func() {})
+	mod.SetCodepageAssignments([]world.CodepageAssignment{{Language: resource.LangGerman, Name: text.CP866}})
+
+	assert.Equal(t, []byte{0x86, 0x00}, mod.Codepages().ForLanguage(resource.LangGerman).Encode("Ж"))
+	assert.Equal(t, []byte{0x3F, 0x00}, mod.Codepages().ForLanguage(resource.LangDefault).Encode("Ж"))
+	assert.NotEmpty(t, changedIDs, "text resources should be reported as changed")
+	assert.Contains(t, mod.ModifiedFilenames(), world.CodepagesFilename)
+}
@@ -19,4 +19,23 @@ const (
 
 	// ObjectPropertiesFilename specifies the lowercase name of the file containing object properties.
 	ObjectPropertiesFilename = "objprop.dat"
+
+	// ObjectTypesFilename specifies the lowercase name of the file describing the object types of a mod
+	// that deviate from the standard catalogue.
+	ObjectTypesFilename = "objtypes.txt"
+
+	// LanguagesFilename specifies the lowercase name of the file describing additional languages of a mod.
+	LanguagesFilename = "languages.txt"
+
+	// CodepagesFilename specifies the lowercase name of the file assigning codepages to the languages of a mod.
+	CodepagesFilename = "codepages.txt"
+
+	// BaseFilename specifies the lowercase name of the file recording the data files a mod was built against.
+	BaseFilename = "base.txt"
+
+	// TargetFilename specifies the lowercase name of the file naming the engine profile a mod is made for.
+	TargetFilename = "target.txt"
+
+	// OverlaysFilename specifies the lowercase name of the file containing the user-defined fields of resources.
+	OverlaysFilename = "overlays.txt"
 )
@@ -0,0 +1,71 @@
+package world
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+)
+
+// LoadLanguages reads the specifications of additional languages from given reader.
+// Each line describes one language with its name, its code, and the filename pattern, separated by whitespace.
+// Empty lines and lines starting with '#' are ignored.
+func LoadLanguages(reader io.Reader) ([]resource.LanguageSpec, error) {
+	if reader == nil {
+		return nil, errors.New("reader is nil")
+	}
+	var specs []resource.LanguageSpec
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if (len(line) == 0) || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: expected name, code, and filename pattern", lineNumber)
+		}
+		spec := resource.LanguageSpec{Name: fields[0], Code: fields[1], FilenamePattern: fields[2]}
+		err := spec.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+		specs = append(specs, spec)
+	}
+	if len(specs) > resource.MaxAdditionalLanguages {
+		return nil, fmt.Errorf("too many languages: %d, maximum is %d", len(specs), resource.MaxAdditionalLanguages)
+	}
+	return specs, scanner.Err()
+}
+
+// SaveLanguages writes the given specifications of additional languages in the format LoadLanguages() reads.
+func SaveLanguages(writer io.Writer, specs []resource.LanguageSpec) error {
+	if writer == nil {
+		return errors.New("writer is nil")
+	}
+	var builder strings.Builder
+	builder.WriteString("# name code filename-pattern (" + resource.FilenamePlaceholder + " is the name of the default file)\n")
+	for _, spec := range specs {
+		builder.WriteString(spec.Name + " " + spec.Code + " " + spec.FilenamePattern + "\n")
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// unassignedLanguage is the language of resource files that were localized for an additional language
+// that is no longer known. As it is beyond all possible languages, their resources are not selected.
+const unassignedLanguage = resource.Language(resource.BuiltinLanguageCount + resource.MaxAdditionalLanguages)
+
+// languageOfFile returns the language the resource file with given name contains, according to the
+// given languages. Files that had a language assigned yet are no longer recognized are unassigned.
+func languageOfFile(filename string, previous resource.Language, languages resource.LanguageSpecs) resource.Language {
+	lang := ids.LocalizeFilenameWith(filename, languages)
+	if (lang == resource.LangAny) && (previous != resource.LangAny) {
+		return unassignedLanguage
+	}
+	return lang
+}
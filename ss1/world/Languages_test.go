@@ -0,0 +1,45 @@
+package world_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+func TestLoadLanguagesReturnsErrorOnNil(t *testing.T) {
+	specs, err := world.LoadLanguages(nil)
+	assert.NotNil(t, err)
+	assert.Nil(t, specs)
+}
+
+func TestLoadLanguagesIgnoresCommentsAndEmptyLines(t *testing.T) {
+	specs, err := world.LoadLanguages(strings.NewReader("# comment\n\n  Spanish\tes  {name}_es\n"))
+	require.Nil(t, err)
+	assert.Equal(t, []resource.LanguageSpec{{Name: "Spanish", Code: "es", FilenamePattern: "{name}_es"}}, specs)
+}
+
+func TestLoadLanguagesReturnsErrorOnInvalidLines(t *testing.T) {
+	_, err := world.LoadLanguages(strings.NewReader("Spanish es\n"))
+	assert.NotNil(t, err, "missing field")
+	_, err = world.LoadLanguages(strings.NewReader("Spanish es spanish.res\n"))
+	assert.NotNil(t, err, "missing placeholder")
+}
+
+func TestLanguagesRoundTrip(t *testing.T) {
+	specs := []resource.LanguageSpec{
+		{Name: "Spanish", Code: "es", FilenamePattern: "{name}_es"},
+		{Name: "Russian", Code: "ru", FilenamePattern: "ru{name}.res"},
+	}
+	buf := bytes.NewBuffer(nil)
+	err := world.SaveLanguages(buf, specs)
+	require.Nil(t, err)
+	loaded, err := world.LoadLanguages(buf)
+	require.Nil(t, err)
+	assert.Equal(t, specs, loaded)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/inkyblackness/hacked/ss1/content/object"
 	"github.com/inkyblackness/hacked/ss1/content/texture"
 	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/edition"
 )
 
 // Manifest contains all the data and information of concrete things in a world.
@@ -152,6 +153,27 @@ func (manifest *Manifest) TextureProperties() texture.PropertiesList {
 	return list
 }
 
+// Fingerprint returns the checksums of the files of all entries.
+// Files of later entries take precedence over files with the same name of earlier entries.
+func (manifest *Manifest) Fingerprint() edition.Fingerprint {
+	fp := make(edition.Fingerprint)
+	for _, entry := range manifest.entries {
+		fp = fp.Merge(entry.Fingerprint)
+	}
+	return fp
+}
+
+// relocalize assigns the languages of all resource files anew, based on their filename.
+// Notification of the change is up to the caller.
+func (manifest *Manifest) relocalize(languages resource.LanguageSpecs) {
+	for _, entry := range manifest.entries {
+		for index := range entry.Resources {
+			res := &entry.Resources[index]
+			res.Language = languageOfFile(res.ID, res.Language, languages)
+		}
+	}
+}
+
 func (manifest *Manifest) listIDs(entries ...*ManifestEntry) (ids []resource.ID) {
 	for _, entry := range entries {
 		for _, res := range entry.Resources {
@@ -4,6 +4,7 @@ import (
 	"github.com/inkyblackness/hacked/ss1/content/object"
 	"github.com/inkyblackness/hacked/ss1/content/texture"
 	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/edition"
 )
 
 // ManifestEntry describes a set of localized resources under a collective identifier.
@@ -13,6 +14,9 @@ type ManifestEntry struct {
 
 	ObjectProperties  object.PropertiesTable
 	TextureProperties texture.PropertiesList
+
+	// Fingerprint holds the checksums of the files the entry was loaded from.
+	Fingerprint edition.Fingerprint
 }
 
 // LocalizedResources produces a selector to retrieve resources for a specific language from this entry.
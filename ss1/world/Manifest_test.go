@@ -7,6 +7,7 @@ import (
 
 	"github.com/inkyblackness/hacked/ss1/resource"
 	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/edition"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -316,6 +317,24 @@ func (suite *ManifestSuite) TestModifiedCallbackWithChangedIDsOnMove() {
 	suite.thenModifiedResourcesShouldBe([]int{0x0800})
 }
 
+func (suite *ManifestSuite) TestFingerprintPrefersFilesOfLaterEntries() {
+	first := &world.ManifestEntry{ID: "first", Fingerprint: edition.Fingerprint{
+		"archive.dat": edition.ChecksumOf([]byte{1}),
+		"texture.res": edition.ChecksumOf([]byte{2}),
+	}}
+	second := &world.ManifestEntry{ID: "second", Fingerprint: edition.Fingerprint{
+		"archive.dat": edition.ChecksumOf([]byte{3}),
+	}}
+	err := suite.manifest.InsertEntry(0, first, second)
+	require.Nil(suite.T(), err)
+
+	fp := suite.manifest.Fingerprint()
+	assert.Equal(suite.T(), edition.Fingerprint{
+		"archive.dat": edition.ChecksumOf([]byte{3}),
+		"texture.res": edition.ChecksumOf([]byte{2}),
+	}, fp)
+}
+
 func (suite *ManifestSuite) onManifestModified(modifiedIDs []resource.ID, failedIDs []resource.ID) {
 	suite.lastModifiedIDs = modifiedIDs
 	suite.lastFailedIDs = failedIDs
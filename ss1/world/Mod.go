@@ -4,13 +4,20 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/content/text"
 	"github.com/inkyblackness/hacked/ss1/content/texture"
 	"github.com/inkyblackness/hacked/ss1/resource"
 	"github.com/inkyblackness/hacked/ss1/serial/rle"
+	"github.com/inkyblackness/hacked/ss1/world/edition"
 	"github.com/inkyblackness/hacked/ss1/world/ids"
+	"github.com/inkyblackness/hacked/ss1/world/overlay"
 )
 
 // ModResetCallback is called when the mod was reset.
@@ -29,6 +36,13 @@ type Mod struct {
 	lastChangeTime time.Time
 	changedFiles   map[string]struct{}
 
+	codepages           *text.LanguageCodepages
+	codepageAssignments []CodepageAssignment
+
+	base          edition.Fingerprint
+	targetProfile string
+	overlays      overlay.Set
+
 	data ModData
 }
 
@@ -38,9 +52,11 @@ func NewMod(resourcesChanged resource.ModificationCallback, resetCallback ModRes
 		resourcesChanged: resourcesChanged,
 		resetCallback:    resetCallback,
 		changedFiles:     make(map[string]struct{}),
+		codepages:        text.NewLanguageCodepages(text.DefaultCodepage()),
 	}
 	mod.worldManifest = NewManifest(mod.worldChanged)
 	mod.data.FileChangeCallback = mod.markFileChanged
+	mod.data.ResourceTemplate = mod.worldResourceTemplate
 
 	return mod
 }
@@ -61,11 +77,155 @@ func (mod *Mod) SetPath(p string) {
 	mod.modPath = p
 }
 
+// AdditionalLanguages returns the languages the mod supports beyond the built-in ones.
+func (mod Mod) AdditionalLanguages() resource.LanguageSpecs {
+	return append(resource.LanguageSpecs{}, mod.data.AdditionalLanguages...)
+}
+
+// Languages returns all human languages of the mod, the built-in ones followed by the additional ones.
+func (mod Mod) Languages() []resource.Language {
+	return mod.data.AdditionalLanguages.Languages()
+}
+
+// LanguageName returns the human readable name of the given language, considering the additional languages.
+func (mod Mod) LanguageName(lang resource.Language) string {
+	return mod.data.AdditionalLanguages.Name(lang)
+}
+
+// SetAdditionalLanguages sets the languages the mod supports beyond the built-in ones.
+// The list is stored together with the mod.
+//
+// All loaded resource files of the world and the mod are assigned their language anew.
+// Files of languages that are no longer known are kept, yet are not assigned to any language.
+func (mod *Mod) SetAdditionalLanguages(specs []resource.LanguageSpec) {
+	if len(specs) > resource.MaxAdditionalLanguages {
+		specs = specs[:resource.MaxAdditionalLanguages]
+	}
+	mod.data.AdditionalLanguages = append(resource.LanguageSpecs{}, specs...)
+	mod.markFileChanged(LanguagesFilename)
+
+	var affectedIDs resource.IDMarkerMap
+	for _, loc := range mod.data.LocalizedResources {
+		for _, id := range loc.Store.IDs() {
+			affectedIDs.Add(id)
+		}
+	}
+	for _, id := range mod.worldManifest.listIDs(mod.worldManifest.entries...) {
+		affectedIDs.Add(id)
+	}
+	mod.modifyAndNotify(func() {
+		mod.worldManifest.relocalize(mod.data.AdditionalLanguages)
+		for _, loc := range mod.data.LocalizedResources {
+			loc.Language = languageOfFile(loc.Filename, loc.Language, mod.data.AdditionalLanguages)
+		}
+	}, affectedIDs.ToList())
+}
+
+// Codepages returns the codepages to use for the texts of the mod.
+// The returned instance reflects any later change of the assignments.
+func (mod Mod) Codepages() text.Codepages {
+	return mod.codepages
+}
+
+// CodepageAssignments returns the codepages the mod uses for specific languages.
+func (mod Mod) CodepageAssignments() []CodepageAssignment {
+	return append([]CodepageAssignment{}, mod.codepageAssignments...)
+}
+
+// SetCodepageAssignments registers the codepages the mod uses for specific languages.
+// Languages without assignment use the default codepage. Assignments with invalid codepages are ignored.
+// The assignments are stored together with the mod, including any custom mapping tables.
+func (mod *Mod) SetCodepageAssignments(assignments []CodepageAssignment) {
+	mod.codepageAssignments = append([]CodepageAssignment{}, assignments...)
+	mod.codepages.Clear()
+	for _, assignment := range assignments {
+		cp, err := assignment.Codepage()
+		if err == nil {
+			mod.codepages.Set(assignment.Language, cp)
+		}
+		if assignment.Table != nil {
+			mod.markFileChanged(assignment.Name)
+		}
+	}
+	mod.markFileChanged(CodepagesFilename)
+	mod.resourcesChanged(ids.IDsOf(resource.Text), nil)
+}
+
+// Base returns the fingerprint of the static world data the mod was built against.
+// It is empty if the mod did not record it.
+func (mod Mod) Base() edition.Fingerprint {
+	return mod.base
+}
+
+// SetBase records the fingerprint of the static world data the mod is built against.
+func (mod *Mod) SetBase(fp edition.Fingerprint) {
+	mod.base = fp
+	mod.markFileChanged(BaseFilename)
+}
+
+// BaseDifferences returns the names of files the mod was built against, that are missing or
+// different in the current static world data. It is empty if the mod did not record its base.
+func (mod Mod) BaseDifferences() []string {
+	return mod.base.Differences(mod.worldManifest.Fingerprint())
+}
+
+// TargetProfile returns the key of the engine profile the mod is made for.
+// It is empty if the mod did not specify one.
+func (mod Mod) TargetProfile() string {
+	return mod.targetProfile
+}
+
+// SetTargetProfile specifies the engine profile the mod is made for.
+func (mod *Mod) SetTargetProfile(key string) {
+	mod.targetProfile = key
+	mod.markFileChanged(TargetFilename)
+}
+
+// Overlays returns the user-defined fields of resources.
+func (mod Mod) Overlays() overlay.Set {
+	return mod.overlays
+}
+
+// SetOverlays replaces the user-defined fields of resources.
+func (mod *Mod) SetOverlays(set overlay.Set) {
+	mod.overlays = set
+	mod.markFileChanged(OverlaysFilename)
+}
+
 // ModifiedResources returns the current modification state.
 func (mod Mod) ModifiedResources() []*LocalizedResources {
 	return mod.data.LocalizedResources
 }
 
+// ResourceIDsIn returns the sorted identifiers of all resources that are stored in the given file,
+// considering both the world and the mod, as well as the additional languages.
+func (mod Mod) ResourceIDsIn(file resource.Filename) []resource.ID {
+	var idMarker resource.IDMarkerMap
+	for entryIndex := 0; entryIndex < mod.worldManifest.EntryCount(); entryIndex++ {
+		entry, err := mod.worldManifest.Entry(entryIndex)
+		if err != nil {
+			continue
+		}
+		for _, localized := range entry.Resources {
+			if mod.data.AdditionalLanguages.Matches(file, filepath.Base(localized.ID)) {
+				for _, id := range localized.Viewer.IDs() {
+					idMarker.Add(id)
+				}
+			}
+		}
+	}
+	for _, localized := range mod.data.LocalizedResources {
+		if mod.data.AdditionalLanguages.Matches(file, localized.Filename) {
+			for _, id := range localized.Store.IDs() {
+				idMarker.Add(id)
+			}
+		}
+	}
+	idList := idMarker.ToList()
+	sort.Slice(idList, func(a, b int) bool { return idList[a] < idList[b] })
+	return idList
+}
+
 // ModifiedFilenames returns the list of all filenames suspected of change.
 func (mod Mod) ModifiedFilenames() []string {
 	result := make([]string, 0, len(mod.changedFiles))
@@ -98,18 +258,27 @@ func (mod Mod) ModifiedResource(lang resource.Language, id resource.ID) resource
 	return mod.modifiedResource(lang, id)
 }
 
-func (mod Mod) modifiedResource(lang resource.Language, id resource.ID) *resource.Resource {
+// modifiedResource returns the view on the resource, without copying resources that are only referenced.
+func (mod Mod) modifiedResource(lang resource.Language, id resource.ID) resource.View {
 	for _, entry := range mod.data.LocalizedResources {
 		if entry.Language == lang {
-			res, err := entry.Store.Resource(id)
+			view, err := entry.Store.View(id)
 			if err == nil {
-				return res
+				return view
 			}
 		}
 	}
 	return nil
 }
 
+func blockData(view resource.View, index int) ([]byte, error) {
+	reader, err := view.Block(index)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(reader)
+}
+
 // CreateBlockPatch creates delta information for a block witch static data length.
 // The returned patch structure contains details for both modifying the current state to be equal the new state,
 // as well as the reversal delta. These deltas are calculated using the rle compression package.
@@ -127,7 +296,7 @@ func (mod Mod) CreateBlockPatch(lang resource.Language, id resource.ID, index in
 	if res == nil {
 		return patch, false, errors.New("resource unknown")
 	}
-	oldData, err := res.BlockRaw(index)
+	oldData, err := blockData(res, index)
 	if err != nil {
 		return patch, false, err
 	}
@@ -162,8 +331,8 @@ func (mod Mod) ModifiedBlock(lang resource.Language, id resource.ID, index int)
 	if res == nil {
 		return
 	}
-	raw, _ := res.BlockRaw(index)
-	return mod.blockCopy(raw)
+	data, _ = blockData(res, index)
+	return data
 }
 
 // ModifiedBlocks returns all blocks of the modified resource.
@@ -174,25 +343,18 @@ func (mod Mod) ModifiedBlocks(lang resource.Language, id resource.ID) [][]byte {
 	}
 	data := make([][]byte, res.BlockCount())
 	for index := 0; index < res.BlockCount(); index++ {
-		raw, _ := res.BlockRaw(index)
-		data[index] = mod.blockCopy(raw)
+		data[index], _ = blockData(res, index)
 	}
 	return data
 }
 
-func (mod Mod) blockCopy(data []byte) []byte {
-	result := make([]byte, len(data))
-	copy(result, data)
-	return result
-}
-
 // Filter returns a list of resources that match the given parameters.
 func (mod Mod) Filter(lang resource.Language, id resource.ID) resource.List {
 	list := mod.worldManifest.Filter(lang, id)
 	if res := mod.modifiedResource(resource.LangAny, id); res != nil {
 		list = list.With(res)
 	}
-	for _, worldLang := range resource.Languages() {
+	for _, worldLang := range mod.Languages() {
 		if worldLang.Includes(lang) {
 			if res := mod.modifiedResource(lang, id); res != nil {
 				list = list.With(res)
@@ -290,6 +452,34 @@ func (mod *Mod) Reset(newResources []*LocalizedResources, objectProperties objec
 	mod.resourcesChanged(modifiedIDs.ToList(), nil)
 }
 
+// worldResourceTemplate looks up the resource in the world, starting with the latest entry.
+// This allows modification of resources that are loaded, yet not described by the known identifiers,
+// keeping them in the file they came from.
+func (mod *Mod) worldResourceTemplate(lang resource.Language, id resource.ID) (string, resource.Properties, bool) {
+	for entryIndex := mod.worldManifest.EntryCount() - 1; entryIndex >= 0; entryIndex-- {
+		entry, err := mod.worldManifest.Entry(entryIndex)
+		if err != nil {
+			continue
+		}
+		for _, localized := range entry.Resources {
+			if localized.Language != lang {
+				continue
+			}
+			view, err := localized.Viewer.View(id)
+			if err != nil {
+				continue
+			}
+			properties := resource.Properties{
+				Compound:    view.Compound(),
+				ContentType: view.ContentType(),
+				Compressed:  view.Compressed(),
+			}
+			return strings.ToLower(filepath.Base(localized.ID)), properties, true
+		}
+	}
+	return "", resource.Properties{}, false
+}
+
 func (mod *Mod) markFileChanged(filename string) {
 	mod.changedFiles[filename] = struct{}{}
 	mod.lastChangeTime = time.Now()
@@ -304,7 +494,7 @@ func (mod *Mod) FixListResources() {
 	mod.Modify(func(modder Modder) {
 		for _, localized := range mod.data.LocalizedResources {
 			for _, id := range localized.Store.IDs() {
-				res, _ := localized.Store.Resource(id)
+				res, _ := localized.Store.View(id)
 				info, known := ids.Info(id)
 				if known && info.List {
 					baseCount := res.BlockCount()
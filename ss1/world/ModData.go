@@ -17,9 +17,18 @@ type LocalizedResources struct {
 	Store    resource.Store
 }
 
+// ResourceTemplateFunc returns the filename and properties of an existing resource.
+// It is used to create resources that are not described by the known identifiers.
+type ResourceTemplateFunc func(lang resource.Language, id resource.ID) (filename string, properties resource.Properties, found bool)
+
 // ModData contains the core information about a mod.
 type ModData struct {
 	FileChangeCallback func(string)
+	ResourceTemplate   ResourceTemplateFunc
+
+	// AdditionalLanguages are the languages beyond the built-in ones. They determine the filenames of
+	// new resources in these languages.
+	AdditionalLanguages resource.LanguageSpecs
 
 	LocalizedResources []*LocalizedResources
 	ObjectProperties   object.PropertiesTable
@@ -86,6 +95,13 @@ func (data *ModData) SetObjectProperties(triple object.Triple, properties object
 	data.notifyFileChanged(ObjectPropertiesFilename)
 }
 
+// SetObjectPropertiesTable replaces the complete table of object properties.
+func (data *ModData) SetObjectPropertiesTable(table object.PropertiesTable) {
+	data.ObjectProperties = table.Clone()
+	data.notifyFileChanged(ObjectPropertiesFilename)
+	data.notifyFileChanged(ObjectTypesFilename)
+}
+
 func (data *ModData) ensureResource(lang resource.Language, id resource.ID) (*LocalizedResources, *resource.Resource) {
 	for _, loc := range data.LocalizedResources {
 		if loc.Language == lang {
@@ -109,7 +125,14 @@ func (data *ModData) newResource(lang resource.Language, id resource.ID) (*Local
 		compound = info.Compound
 		contentType = info.ContentType
 		compressed = info.Compressed
-		filename = info.ResFile.For(lang)
+		filename = data.AdditionalLanguages.Filename(info.ResFile, lang)
+	} else if data.ResourceTemplate != nil {
+		if templateFilename, properties, found := data.ResourceTemplate(lang, id); found {
+			compound = properties.Compound
+			contentType = properties.ContentType
+			compressed = properties.Compressed
+			filename = templateFilename
+		}
 	}
 
 	loc := data.ensureStore(lang, filename)
@@ -72,3 +72,10 @@ func (trans *ModTransaction) SetObjectProperties(triple object.Triple, propertie
 		modder.SetObjectProperties(triple, properties)
 	})
 }
+
+// SetObjectPropertiesTable replaces the complete table of object properties.
+func (trans *ModTransaction) SetObjectPropertiesTable(table object.PropertiesTable) {
+	trans.actions = append(trans.actions, func(modder Modder) {
+		modder.SetObjectPropertiesTable(table)
+	})
+}
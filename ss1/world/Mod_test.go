@@ -6,8 +6,11 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/inkyblackness/hacked/ss1/content/object"
 	"github.com/inkyblackness/hacked/ss1/resource"
 	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/edition"
+	"github.com/inkyblackness/hacked/ss1/world/overlay"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -60,6 +63,31 @@ func (suite *ModSuite) TestResourcesCanBeModified() {
 	suite.thenResourceBlockShouldBe(resource.LangAny, 0x0800, 0, []byte{0xBB})
 }
 
+func (suite *ModSuite) TestUnknownResourcesTakeOverPropertiesFromTheWorld() {
+	suite.givenWorldHas(
+		suite.someLocalizedResourcesIn("splash.res", resource.LangAny,
+			suite.storingWith(0x0800, resource.Properties{ContentType: resource.Bitmap, Compressed: true}, [][]byte{{0xAA}})))
+	suite.whenModifyingBy(func(modder world.Modder) {
+		modder.SetResourceBlock(resource.LangAny, 0x0800, 0, []byte{0xBB})
+	})
+	suite.thenResourceMetaShouldBe(resource.LangAny, 0x0800, false, resource.Bitmap, true)
+	suite.thenModifiedFileShouldBe(resource.LangAny, 0x0800, "splash.res")
+}
+
+func (suite *ModSuite) TestResourceIDsInReturnsIDsOfFileFromWorldAndMod() {
+	suite.givenWorldHas(
+		suite.someLocalizedResourcesIn("splash.res", resource.LangAny,
+			suite.storing(0x0802, [][]byte{{0xAA}}),
+			suite.storing(0x0800, [][]byte{{0xAA}})),
+		suite.someLocalizedResourcesIn("other.res", resource.LangAny,
+			suite.storing(0x0900, [][]byte{{0xAA}})))
+	suite.givenModifiedBy(func(modder world.Modder) {
+		modder.SetResourceBlock(resource.LangAny, 0x0800, 1, []byte{0xBB})
+	})
+
+	assert.Equal(suite.T(), []resource.ID{0x0800, 0x0802}, suite.mod.ResourceIDsIn(resource.AnyLanguage("splash.res")))
+}
+
 func (suite *ModSuite) TestResourcesCanBeExtended() {
 	suite.givenWorldHas(
 		suite.someLocalizedResources(resource.LangAny,
@@ -146,6 +174,76 @@ func (suite *ModSuite) TestModifiedBlocksCanBeRetrievedBulk() {
 	assert.Equal(suite.T(), [][]byte{{0xBB}, {0xCC}}, suite.mod.ModifiedBlocks(resource.LangAny, 0x0800))
 }
 
+func (suite *ModSuite) TestBaseDifferencesListsFilesNotMatchingTheWorld() {
+	err := suite.mod.World().InsertEntry(0, &world.ManifestEntry{ID: "base", Fingerprint: edition.Fingerprint{
+		"archive.dat": edition.ChecksumOf([]byte{1}),
+		"texture.res": edition.ChecksumOf([]byte{2}),
+	}})
+	require.Nil(suite.T(), err)
+	assert.Empty(suite.T(), suite.mod.BaseDifferences(), "no differences without recorded base")
+
+	suite.mod.SetBase(edition.Fingerprint{
+		"archive.dat": edition.ChecksumOf([]byte{3}),
+		"texture.res": edition.ChecksumOf([]byte{2}),
+		"citalog.res": edition.ChecksumOf([]byte{4}),
+	})
+	assert.Equal(suite.T(), []string{"archive.dat", "citalog.res"}, suite.mod.BaseDifferences())
+	assert.Contains(suite.T(), suite.mod.ModifiedFilenames(), world.BaseFilename)
+}
+
+func (suite *ModSuite) TestSetOverlaysMarksFileChanged() {
+	set := overlay.Set{}.With(overlay.KeyOfID(resource.ID(0x0FA1)), overlay.Field{Offset: 0, Length: 4, Name: "unknown", Type: overlay.Int32})
+
+	suite.mod.SetOverlays(set)
+
+	assert.Equal(suite.T(), set, suite.mod.Overlays())
+	assert.Contains(suite.T(), suite.mod.ModifiedFilenames(), world.OverlaysFilename)
+}
+
+func (suite *ModSuite) TestSetObjectPropertiesTableReplacesCatalogue() {
+	suite.mod.Reset(nil, object.StandardPropertiesTable(), nil)
+	extended, triple, err := suite.mod.ObjectProperties().WithTypeAdded(object.Class(0), object.Subclass(1))
+	require.Nil(suite.T(), err)
+
+	suite.whenModifyingBy(func(modder world.Modder) {
+		modder.SetObjectPropertiesTable(extended)
+	})
+
+	_, err = suite.mod.ObjectProperties().ForObject(triple)
+	assert.Nil(suite.T(), err, "new type should be available")
+	assert.Contains(suite.T(), suite.mod.ModifiedFilenames(), world.ObjectPropertiesFilename)
+	assert.Contains(suite.T(), suite.mod.ModifiedFilenames(), world.ObjectTypesFilename)
+}
+
+func (suite *ModSuite) TestAdditionalLanguagesAreKeptWithTheMod() {
+	specs := resource.LanguageSpecs{{Name: "Spanish", Code: "es", FilenamePattern: "{name}_es"}}
+
+	suite.mod.SetAdditionalLanguages(specs)
+
+	assert.Equal(suite.T(), specs, suite.mod.AdditionalLanguages())
+	assert.Equal(suite.T(), "Spanish", suite.mod.LanguageName(resource.Language(resource.BuiltinLanguageCount)))
+	assert.Equal(suite.T(), append(resource.Languages(), resource.Language(resource.BuiltinLanguageCount)),
+		suite.mod.Languages())
+	assert.Contains(suite.T(), suite.mod.ModifiedFilenames(), world.LanguagesFilename)
+}
+
+func (suite *ModSuite) TestSetAdditionalLanguagesRelocalizesLoadedResources() {
+	spanish := resource.Language(resource.BuiltinLanguageCount)
+	suite.givenWorldHas(
+		suite.someLocalizedResourcesIn("cybstrng_es.res", resource.LangAny,
+			suite.storing(0x0867, [][]byte{{0xAA}})))
+
+	suite.mod.SetAdditionalLanguages([]resource.LanguageSpec{{Name: "Spanish", Code: "es", FilenamePattern: "{name}_es"}})
+	suite.thenResourceBlockShouldBe(spanish, 0x0867, 0, []byte{0xAA})
+	_, err := suite.mod.LocalizedResources(resource.LangDefault).Select(0x0867)
+	assert.NotNil(suite.T(), err, "resource should no longer be available for the default language")
+	assert.Contains(suite.T(), suite.lastModifiedIDs, resource.ID(0x0867))
+
+	suite.mod.SetAdditionalLanguages(nil)
+	_, err = suite.mod.LocalizedResources(resource.LangDefault).Select(0x0867)
+	assert.NotNil(suite.T(), err, "resource of removed language should not be available")
+}
+
 func (suite *ModSuite) givenWorldHas(res ...resource.LocalizedResources) {
 	suite.whenWorldIsExtendedWith(res...)
 	suite.lastModifiedIDs = nil
@@ -214,6 +312,16 @@ func (suite *ModSuite) thenModifiedResourcesShouldBe(expected ...int) {
 	assert.Equal(suite.T(), identified, suite.lastModifiedIDs, "Modified IDs don't match")
 }
 
+func (suite *ModSuite) thenModifiedFileShouldBe(lang resource.Language, id int, expected string) {
+	for _, loc := range suite.mod.ModifiedResources() {
+		if _, err := loc.Store.Resource(resource.ID(id)); (loc.Language == lang) && (err == nil) {
+			assert.Equal(suite.T(), expected, loc.Filename, "Filename does not match")
+			return
+		}
+	}
+	assert.Fail(suite.T(), "Resource not modified")
+}
+
 func (suite *ModSuite) sortIDs(ids []resource.ID) {
 	sort.Slice(ids, func(a, b int) bool { return ids[a] < ids[b] })
 }
@@ -230,6 +338,13 @@ func (suite *ModSuite) someLocalizedResources(lang resource.Language, modifiers
 	}
 }
 
+func (suite *ModSuite) someLocalizedResourcesIn(filename string, lang resource.Language,
+	modifiers ...func(*resource.Store)) resource.LocalizedResources {
+	res := suite.someLocalizedResources(lang, modifiers...)
+	res.ID = filename
+	return res
+}
+
 func (suite *ModSuite) anEntryWithResources(id string, res ...resource.LocalizedResources) *world.ManifestEntry {
 	return &world.ManifestEntry{
 		ID:        id,
@@ -244,3 +359,12 @@ func (suite *ModSuite) storing(id int, data [][]byte) func(*resource.Store) {
 		})
 	}
 }
+
+func (suite *ModSuite) storingWith(id int, properties resource.Properties, data [][]byte) func(*resource.Store) {
+	return func(store *resource.Store) {
+		_ = store.Put(resource.ID(id), resource.Resource{
+			Properties: properties,
+			Blocks:     resource.BlocksFrom(data),
+		})
+	}
+}
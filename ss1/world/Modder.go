@@ -38,4 +38,8 @@ type Modder interface {
 
 	// SetObjectProperties updates the properties of a specific object.
 	SetObjectProperties(triple object.Triple, properties object.Properties)
+
+	// SetObjectPropertiesTable replaces the complete table of object properties.
+	// This allows changing the catalogue of object types, such as adding types to a subclass.
+	SetObjectPropertiesTable(table object.PropertiesTable)
 }
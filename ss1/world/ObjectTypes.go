@@ -0,0 +1,107 @@
+package world
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/inkyblackness/hacked/ss1/content/object"
+)
+
+// LoadObjectTypes reads the catalogue of object types from given reader.
+// Each line names a class, a subclass, and the number of types in this subclass, separated by whitespace.
+// Subclasses that are not listed keep their standard number of types. A subclass can only have more types
+// than the standard catalogue, never fewer.
+// Empty lines and lines starting with '#' are ignored.
+func LoadObjectTypes(reader io.Reader) (object.Descriptors, error) {
+	if reader == nil {
+		return nil, errors.New("reader is nil")
+	}
+	standard := object.StandardDescriptors()
+	desc := standard.Clone()
+	listed := make(map[[2]int]bool)
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if (len(line) == 0) || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: expected class, subclass, and type count", lineNumber)
+		}
+		var values [3]int
+		for index, field := range fields {
+			value, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+			}
+			values[index] = value
+		}
+		class, subclass, count := values[0], values[1], values[2]
+		if (class < 0) || (class >= len(desc)) {
+			return nil, fmt.Errorf("line %d: invalid class %d", lineNumber, class)
+		}
+		if (subclass < 0) || (subclass >= len(desc[class].Subclasses)) {
+			return nil, fmt.Errorf("line %d: invalid subclass %d", lineNumber, subclass)
+		}
+		key := [2]int{class, subclass}
+		if listed[key] {
+			return nil, fmt.Errorf("line %d: subclass %d/%d listed more than once", lineNumber, class, subclass)
+		}
+		listed[key] = true
+		minimum := standard[class].Subclasses[subclass].TypeCount
+		if (count < minimum) || (count > object.MaxTypesPerSubclass) {
+			return nil, fmt.Errorf("line %d: type count %d out of range [%d, %d]",
+				lineNumber, count, minimum, object.MaxTypesPerSubclass)
+		}
+		desc[class].Subclasses[subclass].TypeCount = count
+	}
+	return desc, scanner.Err()
+}
+
+// SaveObjectTypes writes the type counts of the given descriptors in the format LoadObjectTypes() reads.
+// Only subclasses that deviate from the standard catalogue are written.
+func SaveObjectTypes(writer io.Writer, desc object.Descriptors) error {
+	if writer == nil {
+		return errors.New("writer is nil")
+	}
+	var builder strings.Builder
+	builder.WriteString("# class subclass type-count (for subclasses that deviate from the standard)\n")
+	for _, deviation := range objectTypeDeviations(desc) {
+		builder.WriteString(fmt.Sprintf("%d %d %d\n", deviation.class, deviation.subclass, deviation.count))
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// HasStandardObjectTypes returns true if the given descriptors have the type counts of the standard catalogue.
+func HasStandardObjectTypes(desc object.Descriptors) bool {
+	return len(objectTypeDeviations(desc)) == 0
+}
+
+type objectTypeDeviation struct {
+	class    int
+	subclass int
+	count    int
+}
+
+func objectTypeDeviations(desc object.Descriptors) []objectTypeDeviation {
+	var deviations []objectTypeDeviation
+	standard := object.StandardDescriptors()
+	for class, classDesc := range desc {
+		for subclass, subclassDesc := range classDesc.Subclasses {
+			standardCount := 0
+			if (class < len(standard)) && (subclass < len(standard[class].Subclasses)) {
+				standardCount = standard[class].Subclasses[subclass].TypeCount
+			}
+			if subclassDesc.TypeCount != standardCount {
+				deviations = append(deviations, objectTypeDeviation{class: class, subclass: subclass, count: subclassDesc.TypeCount})
+			}
+		}
+	}
+	return deviations
+}
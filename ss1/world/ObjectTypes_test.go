@@ -0,0 +1,65 @@
+package world_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+func TestLoadObjectTypesReturnsErrorOnNil(t *testing.T) {
+	desc, err := world.LoadObjectTypes(nil)
+	assert.NotNil(t, err)
+	assert.Nil(t, desc)
+}
+
+func TestLoadObjectTypesReturnsStandardForEmptyFile(t *testing.T) {
+	desc, err := world.LoadObjectTypes(strings.NewReader("# comment\n\n"))
+	require.Nil(t, err)
+	assert.Equal(t, object.StandardDescriptors(), desc)
+	assert.True(t, world.HasStandardObjectTypes(desc))
+}
+
+func TestLoadObjectTypesExtendsListedSubclasses(t *testing.T) {
+	desc, err := world.LoadObjectTypes(strings.NewReader("  1\t2 5\n"))
+	require.Nil(t, err)
+	assert.Equal(t, 5, desc[1].Subclasses[2].TypeCount)
+	assert.Equal(t, 1, desc[1].Subclasses[2].SpecificDataSize)
+	assert.False(t, world.HasStandardObjectTypes(desc))
+}
+
+func TestLoadObjectTypesReturnsErrorOnInvalidLines(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+	}{
+		{"missing field", "1 2\n"},
+		{"no number", "1 two 5\n"},
+		{"invalid class", "20 0 5\n"},
+		{"invalid subclass", "1 40 5\n"},
+		{"fewer than standard", "1 2 1\n"},
+		{"more than maximum", "1 2 257\n"},
+		{"listed twice", "1 2 5\n1 2 6\n"},
+	}
+	for _, tc := range tt {
+		_, err := world.LoadObjectTypes(strings.NewReader(tc.input))
+		assert.NotNil(t, err, "error expected for "+tc.name)
+	}
+}
+
+func TestObjectTypesRoundTrip(t *testing.T) {
+	desc := object.StandardDescriptors()
+	desc[0].Subclasses[1].TypeCount = 4
+	desc[14].Subclasses[4].TypeCount = 256
+	buf := bytes.NewBuffer(nil)
+	err := world.SaveObjectTypes(buf, desc)
+	require.Nil(t, err)
+	loaded, err := world.LoadObjectTypes(buf)
+	require.Nil(t, err)
+	assert.Equal(t, desc, loaded)
+}
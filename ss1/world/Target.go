@@ -0,0 +1,34 @@
+package world
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+)
+
+// LoadTargetProfile reads the key of the engine profile from given reader.
+// The key is the first line that is neither empty nor starts with '#'.
+func LoadTargetProfile(reader io.Reader) (string, error) {
+	if reader == nil {
+		return "", errors.New("reader is nil")
+	}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if (len(line) == 0) || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return line, nil
+	}
+	return "", scanner.Err()
+}
+
+// SaveTargetProfile writes the key of the engine profile in the format LoadTargetProfile() reads.
+func SaveTargetProfile(writer io.Writer, key string) error {
+	if writer == nil {
+		return errors.New("writer is nil")
+	}
+	_, err := io.WriteString(writer, "# engine profile the mod is made for\n"+key+"\n")
+	return err
+}
@@ -0,0 +1,32 @@
+package world_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+func TestLoadTargetProfileReturnsErrorOnNil(t *testing.T) {
+	_, err := world.LoadTargetProfile(nil)
+	assert.NotNil(t, err)
+}
+
+func TestLoadTargetProfileIgnoresCommentsAndEmptyLines(t *testing.T) {
+	key, err := world.LoadTargetProfile(strings.NewReader("# comment\n\n  sourceport \nignored\n"))
+	require.Nil(t, err)
+	assert.Equal(t, "sourceport", key)
+}
+
+func TestTargetProfileRoundTrip(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	err := world.SaveTargetProfile(buf, "vanilla")
+	require.Nil(t, err)
+	key, err := world.LoadTargetProfile(buf)
+	require.Nil(t, err)
+	assert.Equal(t, "vanilla", key)
+}
@@ -0,0 +1,183 @@
+package edition
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Known describes a file that belongs to an edition.
+type Known struct {
+	Checksum Checksum
+	Filename string
+	Edition  string
+}
+
+// Database is a list of files the user recorded for named editions.
+// The same file can be listed for more than one edition, and an edition can have several variants of a file.
+type Database struct {
+	entries []Known
+}
+
+// LoadDatabase reads a database from given reader.
+// Each line contains the hexadecimal checksum, the filename, and the name of the edition, separated by whitespace.
+// The name of the edition is the remainder of the line and may contain whitespace itself.
+// Empty lines and lines starting with '#' are ignored.
+func LoadDatabase(reader io.Reader) (*Database, error) {
+	if reader == nil {
+		return nil, errors.New("reader is nil")
+	}
+	var db Database
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if (len(line) == 0) || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("line %d: expected checksum, filename, and edition", lineNumber)
+		}
+		sum, err := ParseChecksum(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+		db.Add(Known{Checksum: sum, Filename: fields[1], Edition: strings.Join(fields[2:], " ")})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &db, nil
+}
+
+// SaveDatabase writes the database in the format LoadDatabase() reads.
+func SaveDatabase(writer io.Writer, db *Database) error {
+	if writer == nil {
+		return errors.New("writer is nil")
+	}
+	var builder strings.Builder
+	builder.WriteString("# checksum filename edition\n")
+	for _, entry := range db.entries {
+		builder.WriteString(entry.Checksum.String() + " " + entry.Filename + " " + entry.Edition + "\n")
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// Add registers a known file. The filename is stored in lowercase. Duplicate entries are ignored.
+func (db *Database) Add(entry Known) {
+	entry.Filename = strings.ToLower(entry.Filename)
+	for _, existing := range db.entries {
+		if existing == entry {
+			return
+		}
+	}
+	db.entries = append(db.entries, entry)
+}
+
+// Record registers all files of the given fingerprint for the named edition.
+func (db *Database) Record(edition string, fp Fingerprint) {
+	for _, name := range fp.Filenames() {
+		db.Add(Known{Checksum: fp[name], Filename: name, Edition: edition})
+	}
+}
+
+// Editions returns the names of all editions in the database, in order of their first appearance.
+func (db *Database) Editions() []string {
+	var names []string
+	listed := make(map[string]bool)
+	for _, entry := range db.entries {
+		if !listed[entry.Edition] {
+			listed[entry.Edition] = true
+			names = append(names, entry.Edition)
+		}
+	}
+	return names
+}
+
+// Detection is the result of comparing a fingerprint against a database.
+type Detection struct {
+	// Editions lists the editions all recognized files belong to.
+	// It is empty if no file was recognized, or if the files belong to different editions.
+	Editions []string
+	// Recognized lists the files that are known in the database.
+	Recognized []string
+	// Unrecognized lists the files that are not known in the database.
+	Unrecognized []string
+}
+
+// Known returns true if all recognized files belong to at least one common edition.
+func (detection Detection) Known() bool {
+	return len(detection.Editions) > 0
+}
+
+// Mixed returns true if files were recognized, yet they belong to different editions.
+func (detection Detection) Mixed() bool {
+	return (len(detection.Recognized) > 0) && (len(detection.Editions) == 0)
+}
+
+// String returns a short description of the detection.
+func (detection Detection) String() string {
+	switch {
+	case detection.Known() && (len(detection.Unrecognized) > 0):
+		return fmt.Sprintf("%s (%d unrecognized file(s))", strings.Join(detection.Editions, " / "), len(detection.Unrecognized))
+	case detection.Known():
+		return strings.Join(detection.Editions, " / ")
+	case detection.Mixed():
+		return "Mixed recorded data"
+	default:
+		return "Unrecorded data"
+	}
+}
+
+// Detect determines the edition the given fingerprint belongs to.
+func (db *Database) Detect(fp Fingerprint) Detection {
+	var detection Detection
+	var candidates []string
+	for _, name := range fp.Filenames() {
+		editions := db.editionsOf(name, fp[name])
+		if len(editions) == 0 {
+			detection.Unrecognized = append(detection.Unrecognized, name)
+			continue
+		}
+		if len(detection.Recognized) == 0 {
+			candidates = editions
+		} else {
+			candidates = intersection(candidates, editions)
+		}
+		detection.Recognized = append(detection.Recognized, name)
+	}
+	for _, edition := range db.Editions() {
+		for _, candidate := range candidates {
+			if candidate == edition {
+				detection.Editions = append(detection.Editions, edition)
+			}
+		}
+	}
+	return detection
+}
+
+func (db *Database) editionsOf(filename string, sum Checksum) []string {
+	var editions []string
+	for _, entry := range db.entries {
+		if (entry.Filename == filename) && (entry.Checksum == sum) {
+			editions = append(editions, entry.Edition)
+		}
+	}
+	return editions
+}
+
+func intersection(a, b []string) []string {
+	var result []string
+	for _, first := range a {
+		for _, second := range b {
+			if first == second {
+				result = append(result, first)
+				break
+			}
+		}
+	}
+	return result
+}
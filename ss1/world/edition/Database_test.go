@@ -0,0 +1,96 @@
+package edition_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/world/edition"
+)
+
+func aDatabase() *edition.Database {
+	var db edition.Database
+	db.Record("Floppy", edition.Fingerprint{
+		"archive.dat": edition.ChecksumOf([]byte{1}),
+		"texture.res": edition.ChecksumOf([]byte{2}),
+	})
+	db.Record("CD", edition.Fingerprint{
+		"archive.dat": edition.ChecksumOf([]byte{3}),
+		"texture.res": edition.ChecksumOf([]byte{2}),
+		"citalog.res": edition.ChecksumOf([]byte{4}),
+	})
+	db.Add(edition.Known{Checksum: edition.ChecksumOf([]byte{5}), Filename: "ARCHIVE.DAT", Edition: "CD"})
+	return &db
+}
+
+func TestDatabaseEditionsAreInOrderOfAppearance(t *testing.T) {
+	assert.Equal(t, []string{"Floppy", "CD"}, aDatabase().Editions())
+}
+
+func TestDetectFindsCommonEdition(t *testing.T) {
+	detection := aDatabase().Detect(edition.Fingerprint{
+		"archive.dat": edition.ChecksumOf([]byte{3}),
+		"texture.res": edition.ChecksumOf([]byte{2}),
+	})
+	assert.Equal(t, []string{"CD"}, detection.Editions)
+	assert.True(t, detection.Known())
+	assert.Equal(t, "CD", detection.String())
+}
+
+func TestDetectConsidersVariantsOfFiles(t *testing.T) {
+	detection := aDatabase().Detect(edition.Fingerprint{"archive.dat": edition.ChecksumOf([]byte{5})})
+	assert.Equal(t, []string{"CD"}, detection.Editions)
+}
+
+func TestDetectListsAllEditionsSharingTheFiles(t *testing.T) {
+	detection := aDatabase().Detect(edition.Fingerprint{"texture.res": edition.ChecksumOf([]byte{2})})
+	assert.Equal(t, []string{"Floppy", "CD"}, detection.Editions)
+}
+
+func TestDetectReportsUnrecognizedFiles(t *testing.T) {
+	detection := aDatabase().Detect(edition.Fingerprint{
+		"archive.dat": edition.ChecksumOf([]byte{1}),
+		"objprop.dat": edition.ChecksumOf([]byte{9}),
+	})
+	assert.Equal(t, []string{"Floppy"}, detection.Editions)
+	assert.Equal(t, []string{"archive.dat"}, detection.Recognized)
+	assert.Equal(t, []string{"objprop.dat"}, detection.Unrecognized)
+}
+
+func TestDetectReportsMixedEditions(t *testing.T) {
+	detection := aDatabase().Detect(edition.Fingerprint{
+		"archive.dat": edition.ChecksumOf([]byte{1}),
+		"citalog.res": edition.ChecksumOf([]byte{4}),
+	})
+	assert.False(t, detection.Known())
+	assert.True(t, detection.Mixed())
+}
+
+func TestDetectReportsUnknownEdition(t *testing.T) {
+	detection := aDatabase().Detect(edition.Fingerprint{"archive.dat": edition.ChecksumOf([]byte{9})})
+	assert.False(t, detection.Known())
+	assert.False(t, detection.Mixed())
+	assert.Equal(t, "Unrecorded data", detection.String())
+}
+
+func TestLoadDatabaseReturnsErrorOnInvalidLines(t *testing.T) {
+	_, err := edition.LoadDatabase(nil)
+	assert.NotNil(t, err, "nil reader")
+	_, err = edition.LoadDatabase(strings.NewReader(edition.ChecksumOf(nil).String() + " archive.dat\n"))
+	assert.NotNil(t, err, "missing edition")
+}
+
+func TestDatabaseRoundTripKeepsEditionNamesWithSpaces(t *testing.T) {
+	var db edition.Database
+	db.Record("CD 1.1", edition.Fingerprint{"archive.dat": edition.ChecksumOf([]byte{1})})
+	buf := bytes.NewBuffer(nil)
+	err := edition.SaveDatabase(buf, &db)
+	require.Nil(t, err)
+	loaded, err := edition.LoadDatabase(buf)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"CD 1.1"}, loaded.Editions())
+	assert.Equal(t, []string{"CD 1.1"}, loaded.Detect(edition.Fingerprint{"archive.dat": edition.ChecksumOf([]byte{1})}).Editions)
+}
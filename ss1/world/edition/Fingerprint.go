@@ -0,0 +1,139 @@
+package edition
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Checksum is the SHA-256 hash of a file.
+type Checksum [sha256.Size]byte
+
+// ChecksumOf returns the checksum of given data.
+func ChecksumOf(data []byte) Checksum {
+	return sha256.Sum256(data)
+}
+
+// ChecksumFrom returns the checksum of all the data of given reader.
+func ChecksumFrom(reader io.Reader) (Checksum, error) {
+	var sum Checksum
+	hash := sha256.New()
+	_, err := io.Copy(hash, reader)
+	if err != nil {
+		return sum, err
+	}
+	copy(sum[:], hash.Sum(nil))
+	return sum, nil
+}
+
+// ParseChecksum returns the checksum from its hexadecimal representation.
+func ParseChecksum(text string) (Checksum, error) {
+	var sum Checksum
+	decoded, err := hex.DecodeString(text)
+	if err != nil {
+		return sum, err
+	}
+	if len(decoded) != len(sum) {
+		return sum, fmt.Errorf("checksum has wrong length: %d", len(decoded))
+	}
+	copy(sum[:], decoded)
+	return sum, nil
+}
+
+// String returns the hexadecimal representation of the checksum.
+func (sum Checksum) String() string {
+	return hex.EncodeToString(sum[:])
+}
+
+// Fingerprint maps the lowercase names of data files to their checksums.
+type Fingerprint map[string]Checksum
+
+// Add registers the checksum of given file data. Any previous checksum for the same filename is replaced.
+func (fp Fingerprint) Add(filename string, data []byte) {
+	fp[strings.ToLower(filename)] = ChecksumOf(data)
+}
+
+// Filenames returns the sorted list of all files in the fingerprint.
+func (fp Fingerprint) Filenames() []string {
+	names := make([]string, 0, len(fp))
+	for name := range fp {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Merge returns a new fingerprint with the entries of this and the others.
+// Later fingerprints take precedence, in the same way later entries of a manifest do.
+func (fp Fingerprint) Merge(others ...Fingerprint) Fingerprint {
+	merged := make(Fingerprint)
+	for _, source := range append([]Fingerprint{fp}, others...) {
+		for name, sum := range source {
+			merged[name] = sum
+		}
+	}
+	return merged
+}
+
+// Differences returns the sorted names of files of this fingerprint that are missing
+// in the other, or have a different checksum there.
+func (fp Fingerprint) Differences(other Fingerprint) []string {
+	var names []string
+	for _, name := range fp.Filenames() {
+		otherSum, existing := other[name]
+		if !existing || (otherSum != fp[name]) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// LoadFingerprint reads a fingerprint from given reader.
+// Each line contains the hexadecimal checksum and the filename, separated by whitespace.
+// Empty lines and lines starting with '#' are ignored.
+func LoadFingerprint(reader io.Reader) (Fingerprint, error) {
+	if reader == nil {
+		return nil, errors.New("reader is nil")
+	}
+	fp := make(Fingerprint)
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if (len(line) == 0) || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected checksum and filename", lineNumber)
+		}
+		sum, err := ParseChecksum(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+		fp[strings.ToLower(fields[1])] = sum
+	}
+	return fp, scanner.Err()
+}
+
+// SaveFingerprint writes the fingerprint in the format LoadFingerprint() reads.
+// The given description is written as a comment on top.
+func SaveFingerprint(writer io.Writer, fp Fingerprint, description string) error {
+	if writer == nil {
+		return errors.New("writer is nil")
+	}
+	var builder strings.Builder
+	builder.WriteString("# checksum filename\n")
+	if len(description) > 0 {
+		builder.WriteString("# " + description + "\n")
+	}
+	for _, name := range fp.Filenames() {
+		builder.WriteString(fp[name].String() + " " + name + "\n")
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
@@ -0,0 +1,75 @@
+package edition_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/world/edition"
+)
+
+func TestFingerprintAddUsesLowercaseNames(t *testing.T) {
+	fp := make(edition.Fingerprint)
+	fp.Add("ARCHIVE.DAT", []byte{0x01})
+	assert.Equal(t, []string{"archive.dat"}, fp.Filenames())
+	assert.Equal(t, edition.ChecksumOf([]byte{0x01}), fp["archive.dat"])
+}
+
+func TestChecksumFromIsEqualToChecksumOf(t *testing.T) {
+	data := []byte{0x01, 0x02, 0x03}
+	sum, err := edition.ChecksumFrom(bytes.NewReader(data))
+	require.Nil(t, err)
+	assert.Equal(t, edition.ChecksumOf(data), sum)
+}
+
+func TestFingerprintMergePrefersLaterEntries(t *testing.T) {
+	first := edition.Fingerprint{"a.res": edition.ChecksumOf([]byte{1}), "b.res": edition.ChecksumOf([]byte{2})}
+	second := edition.Fingerprint{"b.res": edition.ChecksumOf([]byte{3})}
+	merged := first.Merge(second)
+	assert.Equal(t, edition.ChecksumOf([]byte{1}), merged["a.res"])
+	assert.Equal(t, edition.ChecksumOf([]byte{3}), merged["b.res"])
+	assert.Equal(t, edition.ChecksumOf([]byte{2}), first["b.res"], "original must not be modified")
+}
+
+func TestFingerprintDifferencesListsMissingAndChangedFiles(t *testing.T) {
+	recorded := edition.Fingerprint{
+		"a.res": edition.ChecksumOf([]byte{1}),
+		"b.res": edition.ChecksumOf([]byte{2}),
+		"c.res": edition.ChecksumOf([]byte{3}),
+	}
+	current := edition.Fingerprint{
+		"a.res": edition.ChecksumOf([]byte{1}),
+		"b.res": edition.ChecksumOf([]byte{4}),
+		"d.res": edition.ChecksumOf([]byte{5}),
+	}
+	assert.Equal(t, []string{"b.res", "c.res"}, recorded.Differences(current))
+}
+
+func TestLoadFingerprintReturnsErrorOnNil(t *testing.T) {
+	_, err := edition.LoadFingerprint(nil)
+	assert.NotNil(t, err)
+}
+
+func TestLoadFingerprintReturnsErrorOnInvalidLines(t *testing.T) {
+	_, err := edition.LoadFingerprint(strings.NewReader("archive.dat\n"))
+	assert.NotNil(t, err, "missing field")
+	_, err = edition.LoadFingerprint(strings.NewReader("0102 archive.dat\n"))
+	assert.NotNil(t, err, "short checksum")
+	_, err = edition.LoadFingerprint(strings.NewReader(strings.Repeat("x", 64) + " archive.dat\n"))
+	assert.NotNil(t, err, "no hex")
+}
+
+func TestFingerprintRoundTrip(t *testing.T) {
+	fp := make(edition.Fingerprint)
+	fp.Add("archive.dat", []byte{0x01, 0x02})
+	fp.Add("texture.res", []byte{0x03})
+	buf := bytes.NewBuffer(nil)
+	err := edition.SaveFingerprint(buf, fp, "CD")
+	require.Nil(t, err)
+	loaded, err := edition.LoadFingerprint(buf)
+	require.Nil(t, err)
+	assert.Equal(t, fp, loaded)
+}
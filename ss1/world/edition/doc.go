@@ -0,0 +1,8 @@
+// Package edition records and compares the fingerprints of the game data, such as of the floppy or the CD release.
+//
+// A Fingerprint records the checksums of the data files of a set of static world data.
+// A Database lists the checksums of files the user recorded under the name of an edition, and recognizes
+// these files in later fingerprints. There is no built-in list of original releases.
+// Mods record the fingerprint of the data they were built against, so that it is possible to tell whether they
+// are used with the same data.
+package edition
@@ -7,22 +7,22 @@ import (
 )
 
 // CybStrng contains all strings.
-var CybStrng = resource.I18nFile([resource.LanguageCount]string{"cybstrng.res", "frnstrng.res", "gerstrng.res"})
+var CybStrng = resource.I18nFile([resource.BuiltinLanguageCount]string{"cybstrng.res", "frnstrng.res", "gerstrng.res"})
 
 // MfdArt contains all MFD graphics.
-var MfdArt = resource.I18nFile([resource.LanguageCount]string{"mfdart.res", "mfdfrn.res", "mfdger.res"})
+var MfdArt = resource.I18nFile([resource.BuiltinLanguageCount]string{"mfdart.res", "mfdfrn.res", "mfdger.res"})
 
 // CitALog contains all log audio.
-var CitALog = resource.I18nFile([resource.LanguageCount]string{"citalog.res", "frnalog.res", "geralog.res"})
+var CitALog = resource.I18nFile([resource.BuiltinLanguageCount]string{"citalog.res", "frnalog.res", "geralog.res"})
 
 // CitBark contains all bark audio.
-var CitBark = resource.I18nFile([resource.LanguageCount]string{"citbark.res", "frnbark.res", "gerbark.res"})
+var CitBark = resource.I18nFile([resource.BuiltinLanguageCount]string{"citbark.res", "frnbark.res", "gerbark.res"})
 
 // LowIntr contains the low-res intro video.
-var LowIntr = resource.I18nFile([resource.LanguageCount]string{"lowintr.res", "lofrintr.res", "logeintr.res"})
+var LowIntr = resource.I18nFile([resource.BuiltinLanguageCount]string{"lowintr.res", "lofrintr.res", "logeintr.res"})
 
 // SvgaIntr contains the high-res intro video.
-var SvgaIntr = resource.I18nFile([resource.LanguageCount]string{"svgaintr.res", "svfrintr.res", "svgeintr.res"})
+var SvgaIntr = resource.I18nFile([resource.BuiltinLanguageCount]string{"svgaintr.res", "svfrintr.res", "svgeintr.res"})
 
 // Archive contains the game world.
 var Archive = resource.AnyLanguage("archive.dat")
@@ -111,7 +111,27 @@ func LocalizedFiles() []resource.Filename {
 	return []resource.Filename{CybStrng, MfdArt, CitALog, CitBark, LowIntr, SvgaIntr}
 }
 
+// LocalizeFilenameWith returns the language that the resource file would typically contain, considering
+// the given additional languages beyond the built-in ones.
+func LocalizeFilenameWith(filename string, languages []resource.LanguageSpec) resource.Language {
+	lowercase := strings.ToLower(filename)
+	for _, file := range LocalizedFiles() {
+		for lang := resource.LangDefault; lang < resource.BuiltinLanguageCount; lang++ {
+			if file.For(lang) == lowercase {
+				return lang
+			}
+		}
+		for index, spec := range languages {
+			if spec.Filename(file.For(resource.LangDefault)) == lowercase {
+				return resource.Language(resource.BuiltinLanguageCount + index)
+			}
+		}
+	}
+	return resource.LangAny
+}
+
 // LocalizeFilename returns the language that the resource file would typically contain.
+// Only built-in languages are recognized, see LocalizeFilenameWith for additional ones.
 func LocalizeFilename(filename string) resource.Language {
 	all := LocalizedFiles()
 	lowercase := strings.ToLower(filename)
@@ -71,3 +71,27 @@ func TestLocalizedResources(t *testing.T) {
 		assert.Equal(t, tc.expected, result, "Wrong language for <"+tc.filename+">")
 	}
 }
+
+func TestLocalizedResourcesOfAdditionalLanguages(t *testing.T) {
+	languages := resource.LanguageSpecs{{Name: "Spanish", Code: "es", FilenamePattern: "{name}_es"}}
+
+	spanish := resource.Language(resource.BuiltinLanguageCount)
+	assert.Equal(t, spanish, ids.LocalizeFilenameWith("cybstrng_es.res", languages))
+	assert.Equal(t, spanish, ids.LocalizeFilenameWith("MFDART_ES.RES", languages))
+	assert.Equal(t, resource.LangAny, ids.LocalizeFilenameWith("texture_es.res", languages))
+	assert.Equal(t, resource.LangAny, ids.LocalizeFilename("cybstrng_es.res"))
+	assert.Equal(t, "citalog_es.res", languages.Filename(ids.CitALog, spanish))
+	assert.True(t, languages.Matches(ids.CitBark, "citbark_es.res"))
+}
+
+func TestLocalizeFilenameWithConsidersGivenLanguagesOnly(t *testing.T) {
+	languages := []resource.LanguageSpec{
+		{Name: "Spanish", Code: "es", FilenamePattern: "{name}_es"},
+		{Name: "Polish", Code: "pl", FilenamePattern: "pl_{name}"},
+	}
+
+	assert.Equal(t, resource.LangGerman, ids.LocalizeFilenameWith("gerstrng.res", languages))
+	assert.Equal(t, resource.Language(resource.BuiltinLanguageCount+1), ids.LocalizeFilenameWith("PL_MFDART.RES", languages))
+	assert.Equal(t, resource.LangAny, ids.LocalizeFilenameWith("cybstrng_es.res", nil))
+	assert.Equal(t, resource.LangAny, ids.LocalizeFilenameWith("texture_es.res", languages))
+}
@@ -66,6 +66,14 @@ const (
 // Sounds
 const (
 	TrapMessagesAudioStart resource.ID = 0x0C1C
+	SoundEffectsAudioStart resource.ID = 0x00C9
+)
+
+// Movies
+const (
+	MovieIntro resource.ID = 0x0BD6
+	MovieDeath resource.ID = 0x0BD7
+	MovieEnd   resource.ID = 0x0BD8
 )
 
 // Archives
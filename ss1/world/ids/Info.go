@@ -36,6 +36,17 @@ func Info(id resource.ID) (ResourceInfo, bool) {
 	return info, existing
 }
 
+// IDsOf returns the identifiers of all known resources that have the given content type.
+func IDsOf(contentType resource.ContentType) []resource.ID {
+	var result []resource.ID
+	for id, info := range infoByID {
+		if info.ContentType == contentType {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
 func init() {
 	register := func(info ResourceInfo) {
 		count := info.EndID.Value() - info.StartID.Value()
@@ -130,6 +141,7 @@ var infoList = []ResourceInfo{
 
 	{TrapMessageTexts, TrapMessageTexts.Plus(1), resource.Text, true, false, true, 256, CybStrng},
 	{TrapMessagesAudioStart, TrapMessagesAudioStart.Plus(256), resource.Movie, false, false, false, 256, CitBark},
+	{SoundEffectsAudioStart, SoundEffectsAudioStart.Plus(114), resource.Sound, false, false, false, 114, DigiFX},
 
 	{WordTexts, WordTexts.Plus(1), resource.Text, true, false, true, 512, CybStrng},
 	{PanelNameTexts, PanelNameTexts.Plus(1), resource.Text, true, false, true, 256, CybStrng},
@@ -146,6 +158,10 @@ var infoList = []ResourceInfo{
 	{MailsAudioStart, MailsAudioStart.Plus(47), resource.Movie, false, false, false, 47, CitALog},
 	{LogsAudioStart, LogsAudioStart.Plus(224), resource.Movie, false, false, false, 224, CitALog},
 
+	{MovieIntro, MovieIntro.Plus(1), resource.Movie, false, false, false, 1, SvgaIntr},
+	{MovieDeath, MovieDeath.Plus(1), resource.Movie, false, false, false, 1, SvgaDeth},
+	{MovieEnd, MovieEnd.Plus(1), resource.Movie, false, false, false, 1, SvgaEnd},
+
 	{ObjectLongNames, ObjectLongNames.Plus(1), resource.Text, true, false, true, 0, CybStrng},
 
 	{ArchiveName, ArchiveName.Plus(1), resource.Archive, false, false, false, 1, Archive},
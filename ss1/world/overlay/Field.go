@@ -0,0 +1,74 @@
+package overlay
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Field names a range of bytes within a resource block.
+type Field struct {
+	// Offset is the position of the first byte within the block.
+	Offset int
+	// Length is the number of bytes of the field. Should it be a multiple of the element size of the type,
+	// the field is an array of values.
+	Length int
+	// Name is the free text describing the field.
+	Name string
+	// Type describes how to interpret the bytes.
+	Type FieldType
+}
+
+// Validate returns an error if the field is not well-formed.
+func (field Field) Validate() error {
+	if field.Offset < 0 {
+		return errors.New("offset must not be negative")
+	}
+	if field.Length <= 0 {
+		return errors.New("length must be positive")
+	}
+	if (field.Length % field.Type.ElementSize()) != 0 {
+		return fmt.Errorf("length must be a multiple of %d for %v", field.Type.ElementSize(), field.Type)
+	}
+	if len(strings.TrimSpace(field.Name)) == 0 {
+		return errors.New("name must not be empty")
+	}
+	if strings.ContainsAny(field.Name, "\r\n") {
+		return errors.New("name must be a single line")
+	}
+	return nil
+}
+
+// End returns the offset of the first byte after the field.
+func (field Field) End() int {
+	return field.Offset + field.Length
+}
+
+// Covers returns true if the given offset is within the field.
+func (field Field) Covers(offset int) bool {
+	return (offset >= field.Offset) && (offset < field.End())
+}
+
+// Format returns the interpretation of the field within the given block data.
+// Fields that exceed the data are reported as such.
+func (field Field) Format(data []byte) string {
+	if field.End() > len(data) {
+		return "(beyond end of data)"
+	}
+	fieldData := data[field.Offset:field.End()]
+	if field.Type == Text {
+		if end := strings.IndexByte(string(fieldData), 0); end >= 0 {
+			fieldData = fieldData[:end]
+		}
+		return fmt.Sprintf("%q", string(fieldData))
+	}
+	size := field.Type.ElementSize()
+	values := make([]string, 0, len(fieldData)/size)
+	for start := 0; start+size <= len(fieldData); start += size {
+		values = append(values, field.Type.format(fieldData[start:start+size]))
+	}
+	if (field.Type == Bytes) || (len(values) == 1) {
+		return strings.Join(values, " ")
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
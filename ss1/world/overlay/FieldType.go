@@ -0,0 +1,93 @@
+package overlay
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// FieldType describes how the bytes of a field are interpreted.
+type FieldType int
+
+// Field types
+const (
+	Bytes  FieldType = 0
+	Uint8  FieldType = 1
+	Int8   FieldType = 2
+	Uint16 FieldType = 3
+	Int16  FieldType = 4
+	Uint32 FieldType = 5
+	Int32  FieldType = 6
+	Fixed  FieldType = 7
+	Text   FieldType = 8
+)
+
+var fieldTypeNames = map[FieldType]string{
+	Bytes:  "bytes",
+	Uint8:  "uint8",
+	Int8:   "int8",
+	Uint16: "uint16",
+	Int16:  "int16",
+	Uint32: "uint32",
+	Int32:  "int32",
+	Fixed:  "fixed",
+	Text:   "text",
+}
+
+// FieldTypes returns all known field types.
+func FieldTypes() []FieldType {
+	return []FieldType{Bytes, Uint8, Int8, Uint16, Int16, Uint32, Int32, Fixed, Text}
+}
+
+// FieldTypeByName returns the field type with given name.
+func FieldTypeByName(name string) (FieldType, bool) {
+	lowercase := strings.ToLower(name)
+	for fieldType, typeName := range fieldTypeNames {
+		if typeName == lowercase {
+			return fieldType, true
+		}
+	}
+	return Bytes, false
+}
+
+// String returns the textual representation of the type.
+func (fieldType FieldType) String() string {
+	if name, known := fieldTypeNames[fieldType]; known {
+		return name
+	}
+	return fmt.Sprintf("Unknown%d", int(fieldType))
+}
+
+// ElementSize returns the amount of bytes one value of the type occupies.
+// Bytes and texts have an element size of one.
+func (fieldType FieldType) ElementSize() int {
+	switch fieldType {
+	case Uint16, Int16:
+		return 2
+	case Uint32, Int32, Fixed:
+		return 4
+	default:
+		return 1
+	}
+}
+
+func (fieldType FieldType) format(data []byte) string {
+	switch fieldType {
+	case Uint8:
+		return fmt.Sprintf("%d", data[0])
+	case Int8:
+		return fmt.Sprintf("%d", int8(data[0]))
+	case Uint16:
+		return fmt.Sprintf("%d", binary.LittleEndian.Uint16(data))
+	case Int16:
+		return fmt.Sprintf("%d", int16(binary.LittleEndian.Uint16(data)))
+	case Uint32:
+		return fmt.Sprintf("%d", binary.LittleEndian.Uint32(data))
+	case Int32:
+		return fmt.Sprintf("%d", int32(binary.LittleEndian.Uint32(data)))
+	case Fixed:
+		return fmt.Sprintf("%.3f", float64(int32(binary.LittleEndian.Uint32(data)))/65536.0)
+	default:
+		return fmt.Sprintf("%02X", data[0])
+	}
+}
@@ -0,0 +1,68 @@
+package overlay_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inkyblackness/hacked/ss1/world/overlay"
+)
+
+func TestFieldValidate(t *testing.T) {
+	tt := []struct {
+		field   overlay.Field
+		isValid bool
+	}{
+		{overlay.Field{Offset: 0, Length: 1, Name: "a", Type: overlay.Uint8}, true},
+		{overlay.Field{Offset: 2, Length: 8, Name: "a", Type: overlay.Int32}, true},
+		{overlay.Field{Offset: -1, Length: 1, Name: "a", Type: overlay.Uint8}, false},
+		{overlay.Field{Offset: 0, Length: 0, Name: "a", Type: overlay.Bytes}, false},
+		{overlay.Field{Offset: 0, Length: 3, Name: "a", Type: overlay.Uint16}, false},
+		{overlay.Field{Offset: 0, Length: 1, Name: " ", Type: overlay.Uint8}, false},
+		{overlay.Field{Offset: 0, Length: 1, Name: "a\nb", Type: overlay.Uint8}, false},
+	}
+	for index, tc := range tt {
+		err := tc.field.Validate()
+		assert.Equal(t, tc.isValid, err == nil, "case %d: %v", index, err)
+	}
+}
+
+func TestFieldFormat(t *testing.T) {
+	data := []byte{0xFF, 0xFE, 0x00, 0x80, 0x01, 0x00, 0x41, 0x42, 0x00, 0x43}
+	tt := []struct {
+		field    overlay.Field
+		expected string
+	}{
+		{overlay.Field{Offset: 0, Length: 1, Type: overlay.Uint8}, "255"},
+		{overlay.Field{Offset: 0, Length: 1, Type: overlay.Int8}, "-1"},
+		{overlay.Field{Offset: 0, Length: 2, Type: overlay.Uint16}, "65279"},
+		{overlay.Field{Offset: 0, Length: 2, Type: overlay.Int16}, "-257"},
+		{overlay.Field{Offset: 2, Length: 4, Type: overlay.Uint32}, "98304"},
+		{overlay.Field{Offset: 2, Length: 4, Type: overlay.Fixed}, "1.500"},
+		{overlay.Field{Offset: 0, Length: 4, Type: overlay.Int16}, "[-257, -32768]"},
+		{overlay.Field{Offset: 0, Length: 3, Type: overlay.Bytes}, "FF FE 00"},
+		{overlay.Field{Offset: 6, Length: 4, Type: overlay.Text}, "\"AB\""},
+		{overlay.Field{Offset: 8, Length: 4, Type: overlay.Uint32}, "(beyond end of data)"},
+	}
+	for _, tc := range tt {
+		assert.Equal(t, tc.expected, tc.field.Format(data), "format of %v wrong", tc.field)
+	}
+}
+
+func TestFieldCovers(t *testing.T) {
+	field := overlay.Field{Offset: 4, Length: 2}
+	assert.False(t, field.Covers(3))
+	assert.True(t, field.Covers(4))
+	assert.True(t, field.Covers(5))
+	assert.False(t, field.Covers(6))
+}
+
+func TestFieldTypeByName(t *testing.T) {
+	for _, fieldType := range overlay.FieldTypes() {
+		found, known := overlay.FieldTypeByName(fieldType.String())
+		assert.True(t, known, "type %v should be found", fieldType)
+		assert.Equal(t, fieldType, found)
+	}
+	_, known := overlay.FieldTypeByName("float128")
+	assert.False(t, known)
+}
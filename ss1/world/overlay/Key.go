@@ -0,0 +1,125 @@
+package overlay
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/inkyblackness/hacked/ss1/content/archive"
+	"github.com/inkyblackness/hacked/ss1/content/archive/level/lvlids"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+)
+
+// levelKeyPrefix starts the textual representation of keys for level resources.
+const levelKeyPrefix = "L"
+
+// Key specifies the resources a list of fields applies to.
+// A key covers either a range of resource identifiers, or the resource at the same offset within the resources
+// of every level.
+type Key struct {
+	first resource.ID
+	last  resource.ID
+	level bool
+}
+
+// KeyOfID returns a key for the single identified resource.
+func KeyOfID(id resource.ID) Key {
+	return Key{first: id, last: id}
+}
+
+// KeyOfRange returns a key for all resources from first to last, inclusive.
+func KeyOfRange(first, last resource.ID) Key {
+	if last < first {
+		first, last = last, first
+	}
+	return Key{first: first, last: last}
+}
+
+// KeyOfLevelResource returns a key for the resource at given offset within the resources of every level.
+func KeyOfLevelResource(offset int) Key {
+	return Key{first: resource.ID(offset), last: resource.ID(offset), level: true}
+}
+
+// LevelOffsetOf returns the offset of given resource within the resources of its level.
+// The returned boolean is false if the resource does not belong to a level.
+func LevelOffsetOf(id resource.ID) (int, bool) {
+	if id < ids.LevelResourcesStart {
+		return 0, false
+	}
+	relative := int(id - ids.LevelResourcesStart)
+	if relative >= archive.MaxLevels*lvlids.PerLevel {
+		return 0, false
+	}
+	return relative % lvlids.PerLevel, true
+}
+
+// Range returns the first and last identifier of the resources the key covers.
+// It is only meaningful for keys that are not for level resources.
+func (key Key) Range() (first, last resource.ID) {
+	return key.first, key.last
+}
+
+// LevelOffset returns the offset within the resources of a level, if the key is for level resources.
+func (key Key) LevelOffset() (int, bool) {
+	return int(key.first), key.level
+}
+
+// Covers returns true if the key applies to the identified resource.
+func (key Key) Covers(id resource.ID) bool {
+	if key.level {
+		offset, isLevelResource := LevelOffsetOf(id)
+		return isLevelResource && (offset == int(key.first))
+	}
+	return (id >= key.first) && (id <= key.last)
+}
+
+// String returns the textual representation of the key, as used by Save() and Load().
+// Single resources are written in hexadecimal form, ranges as "first-last", and level resources
+// as their decimal offset with the prefix "L".
+func (key Key) String() string {
+	if key.level {
+		return fmt.Sprintf("%s%d", levelKeyPrefix, int(key.first))
+	}
+	if key.first == key.last {
+		return key.first.String()
+	}
+	return key.first.String() + "-" + key.last.String()
+}
+
+// ParseKey returns the key from its textual representation.
+func ParseKey(text string) (Key, error) {
+	if strings.HasPrefix(text, levelKeyPrefix) {
+		offset, err := strconv.Atoi(text[len(levelKeyPrefix):])
+		if err != nil {
+			return Key{}, err
+		}
+		if (offset < 0) || (offset >= lvlids.PerLevel) {
+			return Key{}, fmt.Errorf("level resource offset %d out of range", offset)
+		}
+		return KeyOfLevelResource(offset), nil
+	}
+	parts := strings.SplitN(text, "-", 2)
+	first, err := strconv.ParseUint(parts[0], 16, 16)
+	if err != nil {
+		return Key{}, err
+	}
+	if len(parts) == 1 {
+		return KeyOfID(resource.ID(first)), nil
+	}
+	last, err := strconv.ParseUint(parts[1], 16, 16)
+	if err != nil {
+		return Key{}, err
+	}
+	return KeyOfRange(resource.ID(first), resource.ID(last)), nil
+}
+
+func (key Key) less(other Key) bool {
+	if key.level != other.level {
+		return !key.level
+	}
+	if key.first != other.first {
+		return key.first < other.first
+	}
+	return key.last < other.last
+}
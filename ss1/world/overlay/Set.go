@@ -0,0 +1,149 @@
+package overlay
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+)
+
+// Set is a collection of fields per key. The fields apply to all blocks of the covered resources, in all languages.
+// A set is not modified after creation; the modifying functions return a new set.
+type Set map[Key][]Field
+
+// Keys returns all keys that have fields. Keys of resource identifiers come first, in ascending order,
+// followed by those of level resources.
+func (set Set) Keys() []Key {
+	keys := make([]Key, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(a, b int) bool { return keys[a].less(keys[b]) })
+	return keys
+}
+
+// KeysFor returns the keys that cover the identified resource, in the order of Keys().
+func (set Set) KeysFor(id resource.ID) []Key {
+	var keys []Key
+	for _, key := range set.Keys() {
+		if key.Covers(id) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Fields returns the fields of all keys that cover the identified resource, ordered by offset.
+func (set Set) Fields(id resource.ID) []Field {
+	var fields []Field
+	for _, key := range set.KeysFor(id) {
+		fields = append(fields, set[key]...)
+	}
+	sort.SliceStable(fields, func(a, b int) bool { return fields[a].Offset < fields[b].Offset })
+	return fields
+}
+
+// FieldsOf returns the fields registered for exactly the given key, ordered by offset.
+func (set Set) FieldsOf(key Key) []Field {
+	return set[key]
+}
+
+// With returns a new set that additionally contains given field for the key.
+func (set Set) With(key Key, field Field) Set {
+	result := set.copy()
+	fields := append(append([]Field{}, set[key]...), field)
+	sort.SliceStable(fields, func(a, b int) bool { return fields[a].Offset < fields[b].Offset })
+	result[key] = fields
+	return result
+}
+
+// Without returns a new set that no longer contains the field at given index of the key.
+func (set Set) Without(key Key, index int) Set {
+	result := set.copy()
+	fields := set[key]
+	if (index < 0) || (index >= len(fields)) {
+		return result
+	}
+	remaining := append(append([]Field{}, fields[:index]...), fields[index+1:]...)
+	if len(remaining) > 0 {
+		result[key] = remaining
+	} else {
+		delete(result, key)
+	}
+	return result
+}
+
+func (set Set) copy() Set {
+	result := make(Set)
+	for key, fields := range set {
+		result[key] = fields
+	}
+	return result
+}
+
+// Load reads a set from given reader.
+// Each line describes one field with key, offset, length, type, and name - separated by whitespace.
+// See Key.String() for the format of the key. Empty lines and lines starting with '#' are ignored.
+func Load(reader io.Reader) (Set, error) {
+	if reader == nil {
+		return nil, errors.New("reader is nil")
+	}
+	set := make(Set)
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if (len(line) == 0) || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.Fields(line)
+		if len(parts) < 5 {
+			return nil, fmt.Errorf("line %d: expected key, offset, length, type, and name", lineNumber)
+		}
+		key, err := ParseKey(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid key: %v", lineNumber, err)
+		}
+		var field Field
+		field.Offset, err = strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid offset: %v", lineNumber, err)
+		}
+		field.Length, err = strconv.Atoi(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid length: %v", lineNumber, err)
+		}
+		var known bool
+		field.Type, known = FieldTypeByName(parts[3])
+		if !known {
+			return nil, fmt.Errorf("line %d: unknown type %q", lineNumber, parts[3])
+		}
+		field.Name = strings.Join(parts[4:], " ")
+		err = field.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+		set = set.With(key, field)
+	}
+	return set, scanner.Err()
+}
+
+// Save writes the set in the format Load() reads.
+func Save(writer io.Writer, set Set) error {
+	if writer == nil {
+		return errors.New("writer is nil")
+	}
+	var builder strings.Builder
+	builder.WriteString("# key offset length type name\n")
+	for _, key := range set.Keys() {
+		for _, field := range set[key] {
+			builder.WriteString(fmt.Sprintf("%v %d %d %v %s\n", key, field.Offset, field.Length, field.Type, field.Name))
+		}
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
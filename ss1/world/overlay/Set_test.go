@@ -0,0 +1,118 @@
+package overlay_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/overlay"
+)
+
+func TestSetWithKeepsFieldsOrderedByOffset(t *testing.T) {
+	var set overlay.Set
+	set = set.With(overlay.KeyOfID(resource.ID(0x1000)), overlay.Field{Offset: 4, Length: 1, Name: "b"})
+	set = set.With(overlay.KeyOfID(resource.ID(0x1000)), overlay.Field{Offset: 0, Length: 1, Name: "a"})
+
+	fields := set.Fields(resource.ID(0x1000))
+	require.Equal(t, 2, len(fields))
+	assert.Equal(t, "a", fields[0].Name)
+	assert.Equal(t, "b", fields[1].Name)
+}
+
+func TestSetModificationsDoNotChangeOriginal(t *testing.T) {
+	original := overlay.Set{}.With(overlay.KeyOfID(resource.ID(0x1000)), overlay.Field{Offset: 0, Length: 1, Name: "a"})
+
+	added := original.With(overlay.KeyOfID(resource.ID(0x1000)), overlay.Field{Offset: 1, Length: 1, Name: "b"})
+	removed := original.Without(overlay.KeyOfID(resource.ID(0x1000)), 0)
+
+	assert.Equal(t, 1, len(original.Fields(resource.ID(0x1000))), "original should be unchanged")
+	assert.Equal(t, 2, len(added.Fields(resource.ID(0x1000))))
+	assert.Equal(t, 0, len(removed.Keys()), "empty keys should be removed")
+}
+
+func TestLoadReturnsErrorOnNil(t *testing.T) {
+	_, err := overlay.Load(nil)
+	assert.NotNil(t, err)
+}
+
+func TestLoadReportsInvalidLines(t *testing.T) {
+	tt := []string{
+		"0FA1 0 1 uint8",
+		"XYZ 0 1 uint8 name",
+		"0FA1 a 1 uint8 name",
+		"0FA1 0 b uint8 name",
+		"0FA1 0 1 float name",
+		"0FA1 0 3 uint16 name",
+		"0FA1-XYZ 0 1 uint8 name",
+		"L100 0 1 uint8 name",
+	}
+	for _, line := range tt {
+		_, err := overlay.Load(strings.NewReader(line))
+		assert.NotNil(t, err, "error expected for %q", line)
+	}
+}
+
+func TestSetRoundTrip(t *testing.T) {
+	set := overlay.Set{}.
+		With(overlay.KeyOfID(resource.ID(0x0FA1)), overlay.Field{Offset: 12, Length: 4, Name: "level  flags", Type: overlay.Int32}).
+		With(overlay.KeyOfID(resource.ID(0x0FA1)), overlay.Field{Offset: 0, Length: 8, Name: "unknown header", Type: overlay.Bytes}).
+		With(overlay.KeyOfID(resource.ID(0x0028)), overlay.Field{Offset: 2, Length: 6, Name: "title", Type: overlay.Text})
+
+	buf := bytes.NewBuffer(nil)
+	err := overlay.Save(buf, set)
+	require.Nil(t, err)
+	loaded, err := overlay.Load(buf)
+	require.Nil(t, err)
+
+	assert.Equal(t, []overlay.Key{overlay.KeyOfID(resource.ID(0x0028)), overlay.KeyOfID(resource.ID(0x0FA1))}, loaded.Keys())
+	assert.Equal(t, set.Fields(resource.ID(0x0028)), loaded.Fields(resource.ID(0x0028)))
+	fields := loaded.Fields(resource.ID(0x0FA1))
+	require.Equal(t, 2, len(fields))
+	assert.Equal(t, "unknown header", fields[0].Name)
+	assert.Equal(t, "level flags", fields[1].Name, "whitespace within names is normalized")
+}
+
+func TestFieldsIncludeThoseOfRangesAndLevelResources(t *testing.T) {
+	levelTileMap := resource.ID(4000 + 2*100 + 5)
+	set := overlay.Set{}.
+		With(overlay.KeyOfID(levelTileMap), overlay.Field{Offset: 4, Length: 1, Name: "exact"}).
+		With(overlay.KeyOfRange(levelTileMap.Plus(-1), levelTileMap.Plus(1)), overlay.Field{Offset: 2, Length: 1, Name: "range"}).
+		With(overlay.KeyOfLevelResource(5), overlay.Field{Offset: 0, Length: 1, Name: "level"})
+
+	fields := set.Fields(levelTileMap)
+	require.Equal(t, 3, len(fields))
+	assert.Equal(t, "level", fields[0].Name)
+	assert.Equal(t, "range", fields[1].Name)
+	assert.Equal(t, "exact", fields[2].Name)
+
+	assert.Equal(t, 1, len(set.Fields(levelTileMap.Plus(1))), "only range key expected")
+	assert.Equal(t, 1, len(set.Fields(resource.ID(4000+14*100+5))), "level key expected for other level")
+	assert.Equal(t, 0, len(set.Fields(resource.ID(4000+16*100+5))), "no level beyond the maximum")
+}
+
+func TestLevelOffsetOf(t *testing.T) {
+	offset, isLevelResource := overlay.LevelOffsetOf(resource.ID(4000 + 3*100 + 7))
+	assert.True(t, isLevelResource)
+	assert.Equal(t, 7, offset)
+	_, isLevelResource = overlay.LevelOffsetOf(resource.ID(0x0028))
+	assert.False(t, isLevelResource)
+}
+
+func TestKeyRoundTrip(t *testing.T) {
+	keys := []overlay.Key{
+		overlay.KeyOfID(resource.ID(0x0FA1)),
+		overlay.KeyOfRange(resource.ID(0x0FA1), resource.ID(0x0FB0)),
+		overlay.KeyOfLevelResource(5),
+	}
+	for _, key := range keys {
+		parsed, err := overlay.ParseKey(key.String())
+		require.Nil(t, err, "no error expected for %v", key)
+		assert.Equal(t, key, parsed)
+	}
+	assert.Equal(t, "0FA1-0FB0", keys[1].String())
+	assert.Equal(t, "L5", keys[2].String())
+}
@@ -0,0 +1,7 @@
+// Package overlay describes user-defined fields on top of raw resource data.
+// Overlays are the notes of reverse-engineering structures the editor does not know in detail yet.
+// They are stored with a mod, next to the data they describe.
+//
+// Fields are registered per Key, which covers a single resource, a range of resources, or the resource
+// at the same offset within the resources of every level.
+package overlay
@@ -0,0 +1,127 @@
+package patch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/resource/lgres"
+	"github.com/inkyblackness/hacked/ss1/serial"
+	"github.com/inkyblackness/hacked/ss1/serial/rle"
+	"github.com/inkyblackness/hacked/ss1/world/edition"
+)
+
+// VerifyBase returns an error if the given base does not match the one the patch was created from.
+// A nil base is expected for new files.
+func (file File) VerifyBase(base []byte) error {
+	if !file.HasBase {
+		if base != nil {
+			return fmt.Errorf("%s: file is not expected to exist", file.Name)
+		}
+		return nil
+	}
+	if base == nil {
+		return fmt.Errorf("%s: base file is missing", file.Name)
+	}
+	if edition.ChecksumOf(base) != file.BaseChecksum {
+		return fmt.Errorf("%s: base file has a different checksum, the patch is for another edition", file.Name)
+	}
+	return nil
+}
+
+// Apply returns the patched version of the given base.
+// Both the base and the result are verified against their checksums.
+func (file File) Apply(base []byte) ([]byte, error) {
+	err := file.VerifyBase(base)
+	if err != nil {
+		return nil, err
+	}
+	var result []byte
+	if file.Kind == ResourceFile {
+		result, err = file.applyResources(base)
+	} else {
+		result, err = file.Raw.applyTo(base)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", file.Name, err)
+	}
+	if edition.ChecksumOf(result) != file.ResultChecksum {
+		return nil, fmt.Errorf("%s: patched file has an unexpected checksum", file.Name)
+	}
+	return result, nil
+}
+
+func (delta Delta) applyTo(base []byte) ([]byte, error) {
+	if delta.Length < 0 {
+		return nil, errors.New("invalid length")
+	}
+	result := make([]byte, delta.Length)
+	copy(result, base)
+	err := rle.Decompress(bytes.NewReader(delta.Data), result)
+	return result, err
+}
+
+func (file File) applyResources(base []byte) ([]byte, error) {
+	baseViewer, err := resourcesOf(base)
+	if err != nil {
+		return nil, err
+	}
+	var store resource.Store
+	removed := make(map[resource.ID]bool)
+	for _, id := range file.Removed {
+		removed[id] = true
+	}
+	changed := make(map[resource.ID]ResourceDelta)
+	for _, entry := range file.Resources {
+		changed[entry.ID] = entry
+	}
+	ids := baseViewer.IDs()
+	for _, entry := range file.Resources {
+		if _, err := baseViewer.View(entry.ID); err != nil {
+			ids = append(ids, entry.ID)
+		}
+	}
+	for _, id := range sortedIDs(ids) {
+		if removed[id] {
+			continue
+		}
+		baseView, baseErr := baseViewer.View(id)
+		entry, isChanged := changed[id]
+		if !isChanged {
+			store.Reference(id, baseView)
+			continue
+		}
+		var baseBlocks [][]byte
+		if baseErr == nil {
+			baseBlocks, err = blocksOf(baseView)
+			if err != nil {
+				return nil, err
+			}
+		}
+		resultBlocks := make([][]byte, len(entry.Blocks))
+		for index, delta := range entry.Blocks {
+			var baseData []byte
+			if index < len(baseBlocks) {
+				baseData = baseBlocks[index]
+			}
+			resultBlocks[index], err = delta.applyTo(baseData)
+			if err != nil {
+				return nil, fmt.Errorf("resource %v block %d: %v", id, index, err)
+			}
+		}
+		err = store.Put(id, resource.Resource{
+			Properties: entry.Properties,
+			Blocks:     resource.BlocksFrom(resultBlocks),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	buffer := serial.NewByteStore()
+	err = lgres.Write(buffer, store)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Data(), nil
+}
@@ -0,0 +1,182 @@
+package patch
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/serial"
+)
+
+const (
+	formatVersion = 1
+	// maxDataLength limits the size of any data entry, protecting against corrupt patches.
+	maxDataLength = 64 * 1024 * 1024
+)
+
+var magic = [7]byte{'H', 'K', 'P', 'A', 'T', 'C', 'H'}
+
+// Encode writes the given patch to the writer.
+func Encode(writer io.Writer, patch Patch) error {
+	if writer == nil {
+		return errors.New("writer is nil")
+	}
+	coder := serial.NewEncoder(writer)
+	coder.Code(magic)
+	coder.Code(byte(formatVersion))
+	coder.Code(uint32(len(patch.Files)))
+	for _, file := range patch.Files {
+		name := []byte(file.Name)
+		coder.Code(uint16(len(name)))
+		coder.Code(name)
+		coder.Code(byte(file.Kind))
+		coder.Code(boolByte(file.HasBase))
+		coder.Code(file.BaseChecksum)
+		coder.Code(file.ResultChecksum)
+		if file.Kind == ResourceFile {
+			coder.Code(uint32(len(file.Removed)))
+			for _, id := range file.Removed {
+				coder.Code(id.Value())
+			}
+			coder.Code(uint32(len(file.Resources)))
+			for _, entry := range file.Resources {
+				coder.Code(entry.ID.Value())
+				coder.Code(boolByte(entry.Properties.Compound))
+				coder.Code(byte(entry.Properties.ContentType))
+				coder.Code(boolByte(entry.Properties.Compressed))
+				coder.Code(uint32(len(entry.Blocks)))
+				for _, delta := range entry.Blocks {
+					encodeDelta(coder, delta)
+				}
+			}
+		} else {
+			encodeDelta(coder, file.Raw)
+		}
+	}
+	return coder.FirstError()
+}
+
+func encodeDelta(coder serial.Coder, delta Delta) {
+	coder.Code(uint32(delta.Length))
+	coder.Code(uint32(len(delta.Data)))
+	coder.Code(delta.Data)
+}
+
+func boolByte(value bool) byte {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// Decode reads a patch from the given reader.
+func Decode(reader io.Reader) (Patch, error) {
+	var patch Patch
+	if reader == nil {
+		return patch, errors.New("reader is nil")
+	}
+	coder := &decoder{Decoder: serial.NewDecoder(reader)}
+	var fileMagic [7]byte
+	var version byte
+	coder.Code(&fileMagic)
+	coder.Code(&version)
+	if coder.FirstError() != nil {
+		return patch, coder.FirstError()
+	}
+	if fileMagic != magic {
+		return patch, errors.New("not a patch file")
+	}
+	if version != formatVersion {
+		return patch, fmt.Errorf("unsupported patch version %d", version)
+	}
+	fileCount := coder.count()
+	for fileIndex := 0; (fileIndex < fileCount) && (coder.FirstError() == nil); fileIndex++ {
+		var file File
+		var nameLength uint16
+		coder.Code(&nameLength)
+		name := make([]byte, nameLength)
+		coder.Code(name)
+		file.Name = string(name)
+		var kind, hasBase byte
+		coder.Code(&kind)
+		coder.Code(&hasBase)
+		file.Kind = FileKind(kind)
+		file.HasBase = hasBase != 0
+		coder.Code(&file.BaseChecksum)
+		coder.Code(&file.ResultChecksum)
+		switch file.Kind {
+		case ResourceFile:
+			removedCount := coder.count()
+			for index := 0; (index < removedCount) && (coder.FirstError() == nil); index++ {
+				var id uint16
+				coder.Code(&id)
+				file.Removed = append(file.Removed, resource.ID(id))
+			}
+			resourceCount := coder.count()
+			for index := 0; (index < resourceCount) && (coder.FirstError() == nil); index++ {
+				var id uint16
+				var compound, contentType, compressed byte
+				coder.Code(&id)
+				coder.Code(&compound)
+				coder.Code(&contentType)
+				coder.Code(&compressed)
+				entry := ResourceDelta{
+					ID: resource.ID(id),
+					Properties: resource.Properties{
+						Compound:    compound != 0,
+						ContentType: resource.ContentType(contentType),
+						Compressed:  compressed != 0,
+					},
+				}
+				blockCount := coder.count()
+				for blockIndex := 0; (blockIndex < blockCount) && (coder.FirstError() == nil); blockIndex++ {
+					entry.Blocks = append(entry.Blocks, coder.delta())
+				}
+				file.Resources = append(file.Resources, entry)
+			}
+		case RawFile:
+			file.Raw = coder.delta()
+		default:
+			return patch, fmt.Errorf("%s: unknown file kind %d", file.Name, kind)
+		}
+		patch.Files = append(patch.Files, file)
+	}
+	return patch, coder.FirstError()
+}
+
+// decoder extends the serial decoder with checks of the counts of entries.
+type decoder struct {
+	*serial.Decoder
+	err error
+}
+
+func (coder *decoder) FirstError() error {
+	if coder.err != nil {
+		return coder.err
+	}
+	return coder.Decoder.FirstError()
+}
+
+func (coder *decoder) Code(value interface{}) {
+	if coder.err == nil {
+		coder.Decoder.Code(value)
+	}
+}
+
+func (coder *decoder) count() int {
+	var count uint32
+	coder.Code(&count)
+	if count > maxDataLength {
+		coder.err = errors.New("patch data too large")
+		return 0
+	}
+	return int(count)
+}
+
+func (coder *decoder) delta() Delta {
+	length := coder.count()
+	data := make([]byte, coder.count())
+	coder.Code(data)
+	return Delta{Length: length, Data: data}
+}
@@ -0,0 +1,162 @@
+package patch
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/resource/lgres"
+	"github.com/inkyblackness/hacked/ss1/serial/rle"
+	"github.com/inkyblackness/hacked/ss1/world/edition"
+)
+
+func isResourceFilename(filename string) bool {
+	return strings.ToLower(filepath.Ext(filename)) == ".res"
+}
+
+// CreateFile returns the differences of a file to its base. A nil base is used for new files.
+// The returned file is verified by applying it to the base.
+func CreateFile(name string, base []byte, result []byte) (File, error) {
+	file := File{
+		Name:    strings.ToLower(name),
+		Kind:    KindOf(name),
+		HasBase: base != nil,
+	}
+	if file.HasBase {
+		file.BaseChecksum = edition.ChecksumOf(base)
+	}
+	if file.Kind == ResourceFile {
+		err := file.diffResources(base, result)
+		if err != nil {
+			return file, fmt.Errorf("%s: %v", name, err)
+		}
+		result, err = file.applyResources(base)
+		if err != nil {
+			return file, fmt.Errorf("%s: %v", name, err)
+		}
+	} else {
+		delta, err := deltaOf(base, result)
+		if err != nil {
+			return file, fmt.Errorf("%s: %v", name, err)
+		}
+		file.Raw = delta
+	}
+	file.ResultChecksum = edition.ChecksumOf(result)
+	return file, nil
+}
+
+func deltaOf(base []byte, result []byte) (Delta, error) {
+	buf := bytes.NewBuffer(nil)
+	err := rle.Compress(buf, result, base)
+	return Delta{Length: len(result), Data: buf.Bytes()}, err
+}
+
+func resourcesOf(data []byte) (resource.Viewer, error) {
+	if data == nil {
+		return resource.Store{}, nil
+	}
+	return lgres.ReaderFrom(bytes.NewReader(data))
+}
+
+func blocksOf(view resource.View) ([][]byte, error) {
+	blocks := make([][]byte, view.BlockCount())
+	for index := range blocks {
+		reader, err := view.Block(index)
+		if err != nil {
+			return nil, err
+		}
+		blocks[index], err = ioutil.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return blocks, nil
+}
+
+func propertiesOf(view resource.View) resource.Properties {
+	return resource.Properties{
+		Compound:    view.Compound(),
+		ContentType: view.ContentType(),
+		Compressed:  view.Compressed(),
+	}
+}
+
+func (file *File) diffResources(base []byte, result []byte) error {
+	baseViewer, err := resourcesOf(base)
+	if err != nil {
+		return err
+	}
+	resultViewer, err := resourcesOf(result)
+	if err != nil {
+		return err
+	}
+	resultIDs := make(map[resource.ID]bool)
+	for _, id := range resultViewer.IDs() {
+		resultIDs[id] = true
+	}
+	for _, id := range sortedIDs(baseViewer.IDs()) {
+		if !resultIDs[id] {
+			file.Removed = append(file.Removed, id)
+		}
+	}
+
+	for _, id := range sortedIDs(resultViewer.IDs()) {
+		resultView, err := resultViewer.View(id)
+		if err != nil {
+			return err
+		}
+		resultBlocks, err := blocksOf(resultView)
+		if err != nil {
+			return err
+		}
+		var baseBlocks [][]byte
+		baseProperties := resource.Properties{}
+		baseView, baseErr := baseViewer.View(id)
+		if baseErr == nil {
+			baseBlocks, err = blocksOf(baseView)
+			if err != nil {
+				return err
+			}
+			baseProperties = propertiesOf(baseView)
+		}
+		if (baseErr == nil) && (baseProperties == propertiesOf(resultView)) && equalBlocks(baseBlocks, resultBlocks) {
+			continue
+		}
+		entry := ResourceDelta{ID: id, Properties: propertiesOf(resultView)}
+		for index, blockData := range resultBlocks {
+			var baseData []byte
+			if index < len(baseBlocks) {
+				baseData = baseBlocks[index]
+			}
+			delta, err := deltaOf(baseData, blockData)
+			if err != nil {
+				return err
+			}
+			entry.Blocks = append(entry.Blocks, delta)
+		}
+		file.Resources = append(file.Resources, entry)
+	}
+	return nil
+}
+
+func sortedIDs(ids []resource.ID) []resource.ID {
+	sorted := append([]resource.ID{}, ids...)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a] < sorted[b] })
+	return sorted
+}
+
+func equalBlocks(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for index := range a {
+		if !bytes.Equal(a[index], b[index]) {
+			return false
+		}
+	}
+	return true
+}
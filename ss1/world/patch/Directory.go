@@ -0,0 +1,133 @@
+package patch
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FindFile returns the path of the file with given name in the directory, ignoring the case of the name.
+// Returns an empty string if there is no such file.
+func FindFile(dir string, name string) string {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, info := range infos {
+		if !info.IsDir() && strings.EqualFold(info.Name(), name) {
+			return filepath.Join(dir, info.Name())
+		}
+	}
+	return ""
+}
+
+// baseFile returns the content of the named file from the last base directory that contains it.
+// Returns nil if none contains it.
+func baseFile(baseDirs []string, name string) ([]byte, error) {
+	for index := len(baseDirs) - 1; index >= 0; index-- {
+		path := FindFile(baseDirs[index], name)
+		if len(path) > 0 {
+			return ioutil.ReadFile(path)
+		}
+	}
+	return nil, nil
+}
+
+// CreateFromDirectories creates a patch for all files of the mod directory, relative to the base directories.
+// Should several base directories contain the same file, the one of the last directory is used.
+func CreateFromDirectories(baseDirs []string, modDir string) (Patch, error) {
+	var patch Patch
+	infos, err := ioutil.ReadDir(modDir)
+	if err != nil {
+		return patch, err
+	}
+	sort.Slice(infos, func(a, b int) bool { return strings.ToLower(infos[a].Name()) < strings.ToLower(infos[b].Name()) })
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		result, err := ioutil.ReadFile(filepath.Join(modDir, info.Name()))
+		if err != nil {
+			return patch, err
+		}
+		base, err := baseFile(baseDirs, info.Name())
+		if err != nil {
+			return patch, err
+		}
+		file, err := CreateFile(info.Name(), base, result)
+		if err != nil {
+			return patch, err
+		}
+		patch.Files = append(patch.Files, file)
+	}
+	return patch, nil
+}
+
+// ApplyToDirectory applies the patch to the files of the base directories and writes the results into the output
+// directory. All base files are verified before anything is written.
+// Should several base directories contain the same file, the one of the last directory is used.
+func ApplyToDirectory(patch Patch, baseDirs []string, outDir string) error {
+	results := make([][]byte, len(patch.Files))
+	for index, file := range patch.Files {
+		if (file.Name != filepath.Base(file.Name)) || strings.HasPrefix(file.Name, ".") {
+			return fmt.Errorf("%s: invalid file name", file.Name)
+		}
+		base, err := baseFile(baseDirs, file.Name)
+		if err != nil {
+			return err
+		}
+		results[index], err = file.Apply(base)
+		if err != nil {
+			return err
+		}
+	}
+	err := os.MkdirAll(outDir, 0755)
+	if err != nil {
+		return err
+	}
+	for index, file := range patch.Files {
+		err = ioutil.WriteFile(filepath.Join(outDir, file.Name), results[index], 0644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Verify checks whether the patch can be applied to the base directories, without applying it.
+func Verify(patch Patch, baseDirs []string) error {
+	for _, file := range patch.Files {
+		base, err := baseFile(baseDirs, file.Name)
+		if err != nil {
+			return err
+		}
+		err = file.VerifyBase(base)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveFile writes the encoded patch into the named file.
+func SaveFile(filename string, patch Patch) error {
+	buffer := bytes.NewBuffer(nil)
+	err := Encode(buffer, patch)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, buffer.Bytes(), 0644)
+}
+
+// LoadFile reads a patch from the named file.
+func LoadFile(filename string) (Patch, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return Patch{}, err
+	}
+	return Decode(bytes.NewReader(data))
+}
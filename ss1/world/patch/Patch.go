@@ -0,0 +1,68 @@
+package patch
+
+import (
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world/edition"
+)
+
+// FileKind specifies how the differences of a file are stored.
+type FileKind byte
+
+// FileKind constants
+const (
+	// RawFile differences are stored for the file as a whole.
+	RawFile FileKind = 0
+	// ResourceFile differences are stored per resource and block.
+	ResourceFile FileKind = 1
+)
+
+// Delta describes the content of a block of data, relative to its base.
+type Delta struct {
+	// Length is the length of the resulting data.
+	Length int
+	// Data is the rle delta to the base.
+	Data []byte
+}
+
+// ResourceDelta describes a resource that is changed or added.
+type ResourceDelta struct {
+	ID         resource.ID
+	Properties resource.Properties
+	// Blocks describe all blocks of the resulting resource, each relative to the base block of the same index.
+	Blocks []Delta
+}
+
+// File describes the differences of one file.
+type File struct {
+	// Name is the lowercase name of the file.
+	Name string
+	// Kind specifies how the differences are stored.
+	Kind FileKind
+
+	// HasBase is false for files that are new, without a base file.
+	HasBase bool
+	// BaseChecksum is the checksum of the base file the patch was created from.
+	BaseChecksum edition.Checksum
+	// ResultChecksum is the checksum of the file after the patch was applied.
+	ResultChecksum edition.Checksum
+
+	// Raw contains the differences of raw files.
+	Raw Delta
+	// Resources contains the changed and added resources of resource files.
+	Resources []ResourceDelta
+	// Removed contains the identifiers of removed resources of resource files.
+	Removed []resource.ID
+}
+
+// Patch is a set of file differences.
+type Patch struct {
+	Files []File
+}
+
+// KindOf returns the kind of file differences to use for the file with given name.
+func KindOf(filename string) FileKind {
+	if isResourceFilename(filename) {
+		return ResourceFile
+	}
+	return RawFile
+}
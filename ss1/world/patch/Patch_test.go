@@ -0,0 +1,172 @@
+package patch_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/resource/lgres"
+	"github.com/inkyblackness/hacked/ss1/serial"
+	"github.com/inkyblackness/hacked/ss1/world/patch"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func resourceFile(t *testing.T, resources map[resource.ID][][]byte) []byte {
+	var store resource.Store
+	for _, id := range []resource.ID{0x0100, 0x0200, 0x0300} {
+		blocks, existing := resources[id]
+		if !existing {
+			continue
+		}
+		err := store.Put(id, resource.Resource{
+			Properties: resource.Properties{Compound: true, ContentType: resource.Text},
+			Blocks:     resource.BlocksFrom(blocks),
+		})
+		require.Nil(t, err)
+	}
+	buffer := serial.NewByteStore()
+	require.Nil(t, lgres.Write(buffer, store))
+	return buffer.Data()
+}
+
+func blocksIn(t *testing.T, data []byte, id resource.ID) [][]byte {
+	reader, err := lgres.ReaderFrom(bytes.NewReader(data))
+	require.Nil(t, err)
+	view, err := reader.View(id)
+	require.Nil(t, err, "resource %v expected", id)
+	var blocks [][]byte
+	for index := 0; index < view.BlockCount(); index++ {
+		blockReader, err := view.Block(index)
+		require.Nil(t, err)
+		block, err := ioutil.ReadAll(blockReader)
+		require.Nil(t, err)
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func TestRawFileRoundTrip(t *testing.T) {
+	base := []byte{1, 2, 3, 4, 5, 6}
+	result := []byte{1, 2, 9, 4, 5, 6, 7, 8}
+	file, err := patch.CreateFile("OBJPROP.DAT", base, result)
+	require.Nil(t, err)
+	assert.Equal(t, "objprop.dat", file.Name)
+	assert.Equal(t, patch.RawFile, file.Kind)
+
+	patched, err := file.Apply(base)
+	require.Nil(t, err)
+	assert.Equal(t, result, patched)
+}
+
+func TestNewFilesHaveNoBase(t *testing.T) {
+	result := []byte("english eng\n")
+	file, err := patch.CreateFile("languages.txt", nil, result)
+	require.Nil(t, err)
+	assert.False(t, file.HasBase)
+
+	patched, err := file.Apply(nil)
+	require.Nil(t, err)
+	assert.Equal(t, result, patched)
+	_, err = file.Apply([]byte{})
+	assert.NotNil(t, err, "existing file should be rejected")
+}
+
+func TestApplyRejectsDifferentBase(t *testing.T) {
+	file, err := patch.CreateFile("textprop.dat", []byte{1, 2, 3}, []byte{1, 2, 4})
+	require.Nil(t, err)
+	_, err = file.Apply([]byte{1, 2, 5})
+	assert.NotNil(t, err)
+	_, err = file.Apply(nil)
+	assert.NotNil(t, err)
+}
+
+func TestResourceFileStoresOnlyChangedResources(t *testing.T) {
+	base := resourceFile(t, map[resource.ID][][]byte{
+		0x0100: {{1, 2, 3}, {4, 5}},
+		0x0200: {{6}},
+		0x0300: {{7, 8}},
+	})
+	result := resourceFile(t, map[resource.ID][][]byte{
+		0x0100: {{1, 2, 3}, {4, 9}, {10}},
+		0x0200: {{6}},
+	})
+	file, err := patch.CreateFile("cybstrng.res", base, result)
+	require.Nil(t, err)
+	assert.Equal(t, patch.ResourceFile, file.Kind)
+	assert.Equal(t, []resource.ID{0x0300}, file.Removed)
+	require.Equal(t, 1, len(file.Resources))
+	assert.Equal(t, resource.ID(0x0100), file.Resources[0].ID)
+
+	patched, err := file.Apply(base)
+	require.Nil(t, err)
+	assert.Equal(t, [][]byte{{1, 2, 3}, {4, 9}, {10}}, blocksIn(t, patched, 0x0100))
+	assert.Equal(t, [][]byte{{6}}, blocksIn(t, patched, 0x0200))
+	reader, err := lgres.ReaderFrom(bytes.NewReader(patched))
+	require.Nil(t, err)
+	assert.Equal(t, 2, len(reader.IDs()))
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	base := resourceFile(t, map[resource.ID][][]byte{0x0100: {{1, 2, 3}}})
+	result := resourceFile(t, map[resource.ID][][]byte{0x0100: {{1, 2, 4}}, 0x0200: {{5}}})
+	resFile, err := patch.CreateFile("gamescr.res", base, result)
+	require.Nil(t, err)
+	rawFile, err := patch.CreateFile("objprop.dat", []byte{1}, []byte{2, 3})
+	require.Nil(t, err)
+	original := patch.Patch{Files: []patch.File{resFile, rawFile}}
+
+	buf := bytes.NewBuffer(nil)
+	require.Nil(t, patch.Encode(buf, original))
+	decoded, err := patch.Decode(bytes.NewReader(buf.Bytes()))
+	require.Nil(t, err)
+	assert.Equal(t, original, decoded)
+}
+
+func TestDecodeRejectsOtherData(t *testing.T) {
+	_, err := patch.Decode(bytes.NewReader([]byte("LG Res File v2\r\n")))
+	assert.NotNil(t, err)
+}
+
+func TestDirectoryRoundTrip(t *testing.T) {
+	root, err := ioutil.TempDir("", "patch")
+	require.Nil(t, err)
+	defer func() { _ = os.RemoveAll(root) }()
+	baseDir := filepath.Join(root, "base")
+	modDir := filepath.Join(root, "mod")
+	outDir := filepath.Join(root, "out")
+	require.Nil(t, os.MkdirAll(baseDir, 0755))
+	require.Nil(t, os.MkdirAll(modDir, 0755))
+	require.Nil(t, ioutil.WriteFile(filepath.Join(baseDir, "OBJPROP.DAT"), []byte{1, 2, 3}, 0644))
+	require.Nil(t, ioutil.WriteFile(filepath.Join(modDir, "objprop.dat"), []byte{1, 5, 3}, 0644))
+
+	created, err := patch.CreateFromDirectories([]string{baseDir}, modDir)
+	require.Nil(t, err)
+	require.Nil(t, patch.Verify(created, []string{baseDir}))
+	require.Nil(t, patch.ApplyToDirectory(created, []string{baseDir}, outDir))
+	data, err := ioutil.ReadFile(filepath.Join(outDir, "objprop.dat"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte{1, 5, 3}, data)
+
+	require.Nil(t, ioutil.WriteFile(filepath.Join(baseDir, "OBJPROP.DAT"), []byte{1, 2, 4}, 0644))
+	assert.NotNil(t, patch.Verify(created, []string{baseDir}))
+}
+
+func TestFileRoundTrip(t *testing.T) {
+	root, err := ioutil.TempDir("", "patch")
+	require.Nil(t, err)
+	defer func() { _ = os.RemoveAll(root) }()
+	filename := filepath.Join(root, "test.hkpatch")
+	file, err := patch.CreateFile("objprop.dat", []byte{1, 2, 3}, []byte{1, 5, 3})
+	require.Nil(t, err)
+	original := patch.Patch{Files: []patch.File{file}}
+
+	require.Nil(t, patch.SaveFile(filename, original))
+	loaded, err := patch.LoadFile(filename)
+	require.Nil(t, err)
+	assert.Equal(t, original, loaded)
+}
@@ -0,0 +1,8 @@
+// Package patch provides a distribution format for mods that only contains the differences to the data files
+// of a specific edition of the game.
+//
+// Resource files are compared per resource and block, all other files, such as the object and texture properties,
+// are compared as a whole. All differences are stored as deltas of the rle package.
+// Checksums of the base files and the results are part of the patch, so that a patch is only applied to the files
+// it was created from, and a successful application is verified.
+package patch
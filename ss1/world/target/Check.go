@@ -0,0 +1,198 @@
+package target
+
+import (
+	"fmt"
+
+	"github.com/inkyblackness/hacked/ss1/content/archive/level"
+	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+)
+
+// Severity classifies a violation.
+type Severity int
+
+// Severity values.
+const (
+	// Warning marks violations the engine tolerates, yet that will not work as intended.
+	Warning Severity = iota
+	// Error marks violations that will break the engine, or make it behave wrongly.
+	Error
+)
+
+// String returns the textual representation.
+func (severity Severity) String() string {
+	if severity == Error {
+		return "Error"
+	}
+	return "Warning"
+}
+
+// Violation describes one problem of a mod regarding a profile.
+type Violation struct {
+	Severity Severity
+	// Area is a short description of where the problem is.
+	Area    string
+	Message string
+}
+
+// Level is the part of a level that is checked.
+type Level interface {
+	ID() int
+	Size() (x, y int, z level.HeightShift)
+	IsCyberspace() bool
+	TextureAtlas() level.TextureAtlas
+	ObjectLimit() level.ObjectID
+	ObjectClassStats(class object.Class) (active, limit int)
+}
+
+// Subject collects the properties of a mod that are checked.
+type Subject struct {
+	// Resources are those of the mod itself.
+	Resources []*world.LocalizedResources
+	// TextureCount is the amount of texture properties.
+	TextureCount int
+	// AdditionalLanguages is the amount of languages beyond the built-in ones.
+	AdditionalLanguages int
+	// ObjectTypes describes the object types of the mod. It is not checked if nil.
+	ObjectTypes object.Descriptors
+	// Levels are all levels of the archive, as seen through the mod.
+	Levels []Level
+}
+
+// SubjectOf returns the subject for given mod and its levels.
+func SubjectOf(mod *world.Mod, levels []*level.Level) Subject {
+	subject := Subject{
+		Resources:           mod.ModifiedResources(),
+		TextureCount:        len(mod.TextureProperties()),
+		AdditionalLanguages: len(mod.AdditionalLanguages()),
+		ObjectTypes:         mod.ObjectProperties().Descriptors(),
+	}
+	for _, lvl := range levels {
+		subject.Levels = append(subject.Levels, lvl)
+	}
+	return subject
+}
+
+// Check returns all violations of the subject regarding given profile.
+func Check(profile Profile, subject Subject) []Violation {
+	var violations []Violation
+	report := func(severity Severity, area string, format string, a ...interface{}) {
+		violations = append(violations, Violation{Severity: severity, Area: area, Message: fmt.Sprintf(format, a...)})
+	}
+
+	for _, localized := range subject.Resources {
+		for _, id := range localized.Store.IDs() {
+			info, known := ids.Info(id)
+			if !known || !info.List || (info.MaxCount == 0) {
+				continue
+			}
+			res, err := localized.Store.View(id)
+			if err != nil {
+				continue
+			}
+			if res.BlockCount() > info.MaxCount {
+				report(Error, localized.Filename, "Resource %v has %d entries, the limit is %d",
+					id, res.BlockCount(), info.MaxCount)
+			}
+			if profile.PaddedLists && (res.BlockCount() < info.MaxCount) {
+				report(Warning, localized.Filename,
+					"Resource %v has %d of %d entries, the engine takes the missing ones from lower mods",
+					id, res.BlockCount(), info.MaxCount)
+			}
+		}
+	}
+	if subject.TextureCount > profile.MaxTextures {
+		report(Error, "Textures", "There are %d textures, the limit is %d", subject.TextureCount, profile.MaxTextures)
+	}
+	if (subject.AdditionalLanguages > 0) && !profile.AdditionalLanguages {
+		report(Warning, "Languages", "Additional languages are not supported and will be ignored")
+	}
+	if !profile.AdditionalObjectTypes {
+		violations = append(violations, checkObjectTypes(subject.ObjectTypes)...)
+	}
+	for _, lvl := range subject.Levels {
+		violations = append(violations, checkLevel(profile, lvl)...)
+	}
+	return violations
+}
+
+func checkObjectTypes(desc object.Descriptors) []Violation {
+	var violations []Violation
+	report := func(format string, a ...interface{}) {
+		violations = append(violations, Violation{Severity: Error, Area: "Object types", Message: fmt.Sprintf(format, a...)})
+	}
+	standard := object.StandardDescriptors()
+	for class, classDesc := range desc {
+		for subclass, subclassDesc := range classDesc.Subclasses {
+			supported := 0
+			if (class < len(standard)) && (subclass < len(standard[class].Subclasses)) {
+				supported = standard[class].Subclasses[subclass].TypeCount
+			}
+			if subclassDesc.TypeCount != supported {
+				report("Subclass %v/%d has %d types, the engine supports %d",
+					object.Class(class), subclass, subclassDesc.TypeCount, supported)
+			}
+		}
+	}
+	return violations
+}
+
+func checkLevel(profile Profile, lvl Level) []Violation {
+	var violations []Violation
+	area := fmt.Sprintf("Level %d", lvl.ID())
+	report := func(severity Severity, format string, a ...interface{}) {
+		violations = append(violations, Violation{Severity: severity, Area: area, Message: fmt.Sprintf(format, a...)})
+	}
+	if x, y, _ := lvl.Size(); (x == 0) || (y == 0) {
+		return nil
+	}
+
+	flagged := lvl.IsCyberspace()
+	if profile.IsCyberspace(lvl.ID(), flagged) != flagged {
+		report(Error, "Level is %s, yet the engine treats it as %s", realmName(flagged), realmName(!flagged))
+	}
+
+	atlas := lvl.TextureAtlas()
+	if len(atlas) > profile.MaxTextureAtlasSize {
+		report(Error, "Texture atlas has %d entries, the limit is %d", len(atlas), profile.MaxTextureAtlasSize)
+	}
+	for index, textureIndex := range atlas {
+		if int(textureIndex) >= profile.MaxTextures {
+			report(Error, "Texture atlas entry %d refers to texture %d, the limit is %d",
+				index, textureIndex, profile.MaxTextures)
+		}
+	}
+
+	if objects := int(lvl.ObjectLimit()) + 1; objects > profile.MaxObjects {
+		report(Error, "Object table has %d entries, the limit is %d", objects, profile.MaxObjects)
+	}
+	for class := object.Class(0); class < object.ClassCount; class++ {
+		active, limit := lvl.ObjectClassStats(class)
+		if limit+1 > profile.MaxClassObjects[class] {
+			report(Error, "Table of class %v has %d entries, the limit is %d", class, limit+1, profile.MaxClassObjects[class])
+		}
+		if (limit > 0) && (active >= limit) {
+			report(Warning, "Table of class %v is full, the engine can not create further objects of it", class)
+		}
+	}
+	return violations
+}
+
+func realmName(cyberspace bool) string {
+	if cyberspace {
+		return "cyberspace"
+	}
+	return "real world"
+}
+
+// Blocking returns the violations of given list that have the severity Error.
+func Blocking(violations []Violation) []Violation {
+	var result []Violation
+	for _, violation := range violations {
+		if violation.Severity == Error {
+			result = append(result, violation)
+		}
+	}
+	return result
+}
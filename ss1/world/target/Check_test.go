@@ -0,0 +1,157 @@
+package target_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/inkyblackness/hacked/ss1/content/archive/level"
+	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/resource"
+	"github.com/inkyblackness/hacked/ss1/world"
+	"github.com/inkyblackness/hacked/ss1/world/ids"
+	"github.com/inkyblackness/hacked/ss1/world/target"
+)
+
+type testingLevel struct {
+	id         int
+	size       int
+	cyberspace bool
+	atlas      level.TextureAtlas
+	objects    int
+	classStats map[object.Class][2]int
+}
+
+func aLevel(id int) *testingLevel {
+	lvl := &testingLevel{
+		id:         id,
+		size:       64,
+		cyberspace: world.IsConsideredCyberspaceByDefault(id),
+		atlas:      make(level.TextureAtlas, level.DefaultTextureAtlasSize),
+		objects:    level.DefaultObjectMasterEntryCount,
+		classStats: make(map[object.Class][2]int),
+	}
+	for class := object.Class(0); class < object.ClassCount; class++ {
+		lvl.classStats[class] = [2]int{0, level.ObjectClassInfoFor(class).EntryCount - 1}
+	}
+	return lvl
+}
+
+func (lvl *testingLevel) ID() int {
+	return lvl.id
+}
+
+func (lvl *testingLevel) Size() (x, y int, z level.HeightShift) {
+	return lvl.size, lvl.size, 0
+}
+
+func (lvl *testingLevel) IsCyberspace() bool {
+	return lvl.cyberspace
+}
+
+func (lvl *testingLevel) TextureAtlas() level.TextureAtlas {
+	return lvl.atlas
+}
+
+func (lvl *testingLevel) ObjectLimit() level.ObjectID {
+	return level.ObjectID(lvl.objects - 1)
+}
+
+func (lvl *testingLevel) ObjectClassStats(class object.Class) (active, limit int) {
+	stats := lvl.classStats[class]
+	return stats[0], stats[1]
+}
+
+func TestCheckOfDefaultLevelsHasNoViolations(t *testing.T) {
+	subject := target.Subject{Levels: []target.Level{aLevel(1), aLevel(10)}}
+	assert.Empty(t, target.Check(target.VanillaDOS, subject))
+	assert.Empty(t, target.Check(target.SourcePort, subject))
+}
+
+func TestCheckIgnoresEmptyLevels(t *testing.T) {
+	lvl := aLevel(15)
+	lvl.size = 0
+	lvl.cyberspace = false
+	assert.Empty(t, target.Check(target.VanillaDOS, target.Subject{Levels: []target.Level{lvl}}))
+}
+
+func TestCheckReportsCyberspaceMismatchForVanillaOnly(t *testing.T) {
+	lvl := aLevel(11)
+	lvl.cyberspace = true
+	subject := target.Subject{Levels: []target.Level{lvl}}
+	violations := target.Check(target.VanillaDOS, subject)
+	require.Len(t, violations, 1)
+	assert.Equal(t, target.Error, violations[0].Severity)
+	assert.Equal(t, "Level 11", violations[0].Area)
+	assert.Empty(t, target.Check(target.SourcePort, subject))
+}
+
+func TestCheckReportsTexturesBeyondLimit(t *testing.T) {
+	lvl := aLevel(1)
+	lvl.atlas[3] = level.TextureIndex(world.MaxWorldTextures)
+	subject := target.Subject{TextureCount: world.MaxWorldTextures + 1, Levels: []target.Level{lvl}}
+	violations := target.Check(target.VanillaDOS, subject)
+	assert.Len(t, target.Blocking(violations), 2)
+}
+
+func TestCheckReportsObjectTables(t *testing.T) {
+	lvl := aLevel(1)
+	lvl.objects = level.DefaultObjectMasterEntryCount + 1
+	lvl.classStats[object.ClassGun] = [2]int{15, 15}
+	violations := target.Check(target.VanillaDOS, target.Subject{Levels: []target.Level{lvl}})
+	require.Len(t, violations, 2)
+	assert.Equal(t, target.Error, violations[0].Severity, "table size")
+	assert.Equal(t, target.Warning, violations[1].Severity, "full class")
+}
+
+func textureNamesWith(t *testing.T, count int) *world.LocalizedResources {
+	t.Helper()
+	localized := &world.LocalizedResources{Filename: "cybstrng.res", Language: resource.LangDefault}
+	err := localized.Store.Put(ids.TextureNames, resource.Resource{
+		Properties: resource.Properties{Compound: true, ContentType: resource.Text},
+		Blocks:     resource.BlocksFrom(make([][]byte, count)),
+	})
+	require.Nil(t, err)
+	return localized
+}
+
+func TestCheckReportsListsBeyondLimit(t *testing.T) {
+	info, _ := ids.Info(ids.TextureNames)
+	localized := textureNamesWith(t, info.MaxCount+1)
+	violations := target.Check(target.SourcePort, target.Subject{Resources: []*world.LocalizedResources{localized}})
+	require.Len(t, violations, 1)
+	assert.Equal(t, "cybstrng.res", violations[0].Area)
+}
+
+func TestCheckWarnsAboutShortListsForPaddedListsOnly(t *testing.T) {
+	info, _ := ids.Info(ids.TextureNames)
+	subject := target.Subject{Resources: []*world.LocalizedResources{textureNamesWith(t, info.MaxCount-1)}}
+	violations := target.Check(target.SourcePort, subject)
+	require.Len(t, violations, 1)
+	assert.Equal(t, target.Warning, violations[0].Severity)
+	assert.Equal(t, "cybstrng.res", violations[0].Area)
+	assert.Empty(t, target.Check(target.VanillaDOS, subject))
+
+	subject.Resources = []*world.LocalizedResources{textureNamesWith(t, info.MaxCount)}
+	assert.Empty(t, target.Check(target.SourcePort, subject))
+}
+
+func TestCheckWarnsAboutAdditionalLanguagesForVanillaOnly(t *testing.T) {
+	subject := target.Subject{AdditionalLanguages: 1}
+	violations := target.Check(target.VanillaDOS, subject)
+	require.Len(t, violations, 1)
+	assert.Equal(t, target.Warning, violations[0].Severity)
+	assert.Empty(t, target.Check(target.SourcePort, subject))
+}
+
+func TestCheckReportsAdditionalObjectTypesForVanillaOnly(t *testing.T) {
+	subject := target.Subject{ObjectTypes: object.StandardDescriptors()}
+	assert.Empty(t, target.Check(target.VanillaDOS, subject))
+
+	subject.ObjectTypes[object.ClassGun].Subclasses[0].TypeCount++
+	violations := target.Check(target.VanillaDOS, subject)
+	require.Len(t, violations, 1)
+	assert.Equal(t, target.Error, violations[0].Severity)
+	assert.Empty(t, target.Check(target.SourcePort, subject))
+}
@@ -0,0 +1,95 @@
+package target
+
+import (
+	"github.com/inkyblackness/hacked/ss1/content/archive/level"
+	"github.com/inkyblackness/hacked/ss1/content/object"
+	"github.com/inkyblackness/hacked/ss1/world"
+)
+
+// Profile describes the limits and behaviour of an engine.
+type Profile struct {
+	// Key identifies the profile when stored with a mod.
+	Key string
+	// Title is the human readable name of the profile.
+	Title string
+
+	// MaxTextures is the amount of world textures the engine supports.
+	MaxTextures int
+	// MaxTextureAtlasSize is the amount of textures a single level can use.
+	MaxTextureAtlasSize int
+	// MaxObjects is the size of the object master table of a level, including the list head.
+	MaxObjects int
+	// MaxClassObjects is the size of the object class tables of a level, including the list heads.
+	MaxClassObjects [object.ClassCount]int
+
+	// HardcodedCyberspace is set if the engine ignores the cyberspace flag of levels and
+	// determines cyberspace by the level identifier instead.
+	HardcodedCyberspace bool
+	// AdditionalLanguages is set if the engine supports languages beyond the built-in ones.
+	AdditionalLanguages bool
+	// AdditionalObjectTypes is set if the engine supports object types beyond the standard ones.
+	AdditionalObjectTypes bool
+	// PaddedLists is set if resources with lists need to provide all their entries.
+	// Such engines layer mods and take any empty or missing entry from a lower mod.
+	PaddedLists bool
+}
+
+// IsCyberspace returns whether the engine treats the given level as cyberspace.
+func (profile Profile) IsCyberspace(levelID int, flagged bool) bool {
+	if profile.HardcodedCyberspace {
+		return world.IsConsideredCyberspaceByDefault(levelID)
+	}
+	return flagged
+}
+
+func vanillaClassObjects() [object.ClassCount]int {
+	var sizes [object.ClassCount]int
+	for class := object.Class(0); class < object.ClassCount; class++ {
+		sizes[class] = level.ObjectClassInfoFor(class).EntryCount
+	}
+	return sizes
+}
+
+// VanillaDOS is the profile of the original DOS engine.
+var VanillaDOS = withOriginalTables(Profile{
+	Key:   "vanilla",
+	Title: "Vanilla DOS",
+
+	HardcodedCyberspace: true,
+})
+
+// SourcePort is the profile of the engines based on the released source code.
+// They keep the table sizes of the original engine, yet consider the cyberspace flag of levels,
+// support additional languages and object types, and layer mods on top of each other.
+var SourcePort = withOriginalTables(Profile{
+	Key:   "sourceport",
+	Title: "Source Port",
+
+	AdditionalLanguages:   true,
+	AdditionalObjectTypes: true,
+	PaddedLists:           true,
+})
+
+// withOriginalTables returns the given profile with the limits of the original engine.
+func withOriginalTables(profile Profile) Profile {
+	profile.MaxTextures = world.MaxWorldTextures
+	profile.MaxTextureAtlasSize = level.DefaultTextureAtlasSize
+	profile.MaxObjects = level.DefaultObjectMasterEntryCount
+	profile.MaxClassObjects = vanillaClassObjects()
+	return profile
+}
+
+// Profiles returns all known profiles. The first one is the default.
+func Profiles() []Profile {
+	return []Profile{VanillaDOS, SourcePort}
+}
+
+// ProfileByKey returns the profile with given key. Unknown keys return the default profile and false.
+func ProfileByKey(key string) (Profile, bool) {
+	for _, profile := range Profiles() {
+		if profile.Key == key {
+			return profile, true
+		}
+	}
+	return Profiles()[0], false
+}
@@ -0,0 +1,49 @@
+package target_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inkyblackness/hacked/ss1/world/target"
+)
+
+func TestProfileByKeyReturnsKnownProfiles(t *testing.T) {
+	for _, profile := range target.Profiles() {
+		found, known := target.ProfileByKey(profile.Key)
+		assert.True(t, known, "profile "+profile.Key+" should be known")
+		assert.Equal(t, profile.Title, found.Title)
+	}
+}
+
+func TestProfileByKeyReturnsDefaultForUnknownKey(t *testing.T) {
+	found, known := target.ProfileByKey("unknown")
+	assert.False(t, known)
+	assert.Equal(t, target.Profiles()[0].Key, found.Key)
+}
+
+func TestVanillaProfileIgnoresCyberspaceFlag(t *testing.T) {
+	assert.True(t, target.VanillaDOS.IsCyberspace(10, false))
+	assert.False(t, target.VanillaDOS.IsCyberspace(1, true))
+}
+
+func TestSourcePortProfileConsidersCyberspaceFlag(t *testing.T) {
+	assert.False(t, target.SourcePort.IsCyberspace(10, false))
+	assert.True(t, target.SourcePort.IsCyberspace(1, true))
+}
+
+func TestSourcePortProfileRequiresPaddedLists(t *testing.T) {
+	assert.True(t, target.SourcePort.PaddedLists)
+	assert.False(t, target.VanillaDOS.PaddedLists)
+}
+
+func TestSourcePortProfileSupportsAdditionalObjectTypes(t *testing.T) {
+	assert.True(t, target.SourcePort.AdditionalObjectTypes)
+	assert.False(t, target.VanillaDOS.AdditionalObjectTypes)
+}
+
+func TestProfilesShareTheTablesOfTheOriginalEngine(t *testing.T) {
+	assert.Equal(t, target.VanillaDOS.MaxClassObjects, target.SourcePort.MaxClassObjects)
+	assert.Equal(t, target.VanillaDOS.MaxObjects, target.SourcePort.MaxObjects)
+	assert.Equal(t, target.VanillaDOS.MaxTextures, target.SourcePort.MaxTextures)
+}
@@ -0,0 +1,6 @@
+// Package target describes the engines a mod can be made for, and checks a mod against their limits.
+//
+// The original DOS engine has fixed limits for tables and resources, and hardcoded behaviour for specific levels.
+// Source ports lift some of these, and add features of their own, such as additional languages.
+// A Profile encodes these differences, and Check lists the violations of a mod for a profile.
+package target
@@ -15,6 +15,8 @@ type ContextParameters struct {
 	FontFile string
 	// FontSize is the requested size of the font. Defaults to 12.
 	FontSize float32
+	// FontGlyphs lists characters the font from FontFile shall provide in addition to the default ones.
+	FontGlyphs []rune
 }
 
 // BitmapTextureQuery resolves the texture and the palette to be used for a bitmap.
@@ -31,7 +33,12 @@ type Context struct {
 	mouseButtonWasDown [3]bool
 	mouseButtonIsDown  [3]bool
 
-	fontTexture            uint32
+	fontFile    string
+	fontSize    float32
+	fontGlyphs  map[rune]bool
+	font        imgui.Font
+	fontTexture uint32
+
 	shaderHandle           uint32
 	attribLocationType     int32
 	attribLocationTex      int32
@@ -110,10 +117,16 @@ func (context *Context) NewFrame() {
 	}
 
 	imgui.NewFrame()
+	if context.font != imgui.DefaultFont {
+		imgui.PushFont(context.font)
+	}
 }
 
 // Render must be called at the end of rendering.
 func (context *Context) Render(bitmapTextureQuery BitmapTextureQuery) {
+	if context.font != imgui.DefaultFont {
+		imgui.PopFont()
+	}
 	imgui.Render()
 	context.renderDrawData(imgui.RenderedDrawData(), bitmapTextureQuery)
 }
@@ -150,6 +163,10 @@ void main()
 		vec4 pixel = texture(Texture, Frag_UV.st);
 		Out_Color = Frag_Color * texture(Palette, vec2(pixel.r, 0.5));
 	}
+	else if (ImageType == 2)
+	{
+		Out_Color = Frag_Color * texture(Texture, Frag_UV.st);
+	}
 	else
 	{
 		Out_Color = vec4(Frag_Color.rgb, Frag_Color.a * texture( Texture, Frag_UV.st).r);
@@ -177,18 +194,73 @@ void main()
 }
 
 func (context *Context) createFontsTexture(gl opengl.OpenGL, param ContextParameters) error {
-	io := imgui.CurrentIO()
-	fontAtlas := io.Fonts()
-	if len(param.FontFile) > 0 {
-		fontSize := float32(16.0)
-		if param.FontSize > 0.0 {
-			fontSize = param.FontSize
+	context.fontFile = param.FontFile
+	context.fontSize = float32(16.0)
+	if param.FontSize > 0.0 {
+		context.fontSize = param.FontSize
+	}
+	context.fontGlyphs = make(map[rune]bool)
+	for _, r := range param.FontGlyphs {
+		context.fontGlyphs[r] = true
+	}
+	if len(context.fontFile) > 0 {
+		err := context.loadFont()
+		if err != nil {
+			return err
 		}
-		font := fontAtlas.AddFontFromFileTTF(param.FontFile, fontSize)
-		if font == imgui.DefaultFont {
-			return fmt.Errorf("could not load font <%s>", param.FontFile)
+	}
+	context.uploadFontsTexture(gl)
+	return nil
+}
+
+// AddFontGlyphs extends the font from the FontFile by the given characters.
+// The font is only loaded again if it does not yet provide all of them.
+// This function must not be called between NewFrame() and Render().
+func (context *Context) AddFontGlyphs(glyphs []rune) error {
+	if len(context.fontFile) == 0 {
+		return nil
+	}
+	added := false
+	for _, r := range glyphs {
+		if !context.fontGlyphs[r] {
+			context.fontGlyphs[r] = true
+			added = true
 		}
 	}
+	if !added {
+		return nil
+	}
+	err := context.loadFont()
+	if err != nil {
+		return err
+	}
+	gl := context.window.OpenGL()
+	gl.DeleteTextures([]uint32{context.fontTexture})
+	context.uploadFontsTexture(gl)
+	return nil
+}
+
+func (context *Context) loadFont() error {
+	fontAtlas := imgui.CurrentIO().Fonts()
+	var glyphs imgui.GlyphRangesBuilder
+	glyphs.AddExisting(fontAtlas.GlyphRangesDefault())
+	for r := range context.fontGlyphs {
+		glyphs.Add(r, r)
+	}
+	glyphRanges := glyphs.Build()
+	defer glyphRanges.Free()
+	font := fontAtlas.AddFontFromFileTTFV(context.fontFile, context.fontSize, imgui.DefaultFontConfig, glyphRanges.GlyphRanges)
+	if font == imgui.DefaultFont {
+		return fmt.Errorf("could not load font <%s>", context.fontFile)
+	}
+	// The atlas is built while the glyph ranges are still valid.
+	_ = fontAtlas.TextureDataAlpha8()
+	context.font = font
+	return nil
+}
+
+func (context *Context) uploadFontsTexture(gl opengl.OpenGL) {
+	fontAtlas := imgui.CurrentIO().Fonts()
 	image := fontAtlas.TextureDataAlpha8()
 
 	context.fontTexture = gl.GenTextures(1)[0]
@@ -199,10 +271,9 @@ func (context *Context) createFontsTexture(gl opengl.OpenGL, param ContextParame
 	gl.TexImage2D(opengl.TEXTURE_2D, 0, opengl.RED, int32(image.Width), int32(image.Height),
 		0, opengl.RED, opengl.UNSIGNED_BYTE, image.Pixels)
 
-	io.Fonts().SetTextureID(TextureIDForSimpleTexture(context.fontTexture))
+	fontAtlas.SetTextureID(TextureIDForSimpleTexture(context.fontTexture))
 
 	gl.BindTexture(opengl.TEXTURE_2D, 0)
-	return nil
 }
 
 func (context *Context) destroyDeviceObjects(gl opengl.OpenGL) {
@@ -330,7 +401,7 @@ func (context *Context) renderDrawData(drawData imgui.DrawData, bitmapTextureQue
 				imageType := ImageTypeFromID(textureID)
 				gl.Uniform1i(context.attribLocationType, int32(imageType))
 				switch imageType {
-				case ImageTypeSimpleTexture:
+				case ImageTypeSimpleTexture, ImageTypeRGBATexture:
 					gl.ActiveTexture(opengl.TEXTURE0 + uint32(0))
 					gl.BindTexture(opengl.TEXTURE_2D, uint32(textureID))
 				case ImageTypeBitmapTexture:
@@ -10,6 +10,8 @@ const (
 	ImageTypeSimpleTexture ImageType = 0
 	// ImageTypeBitmapTexture identifies bitmap textures.
 	ImageTypeBitmapTexture ImageType = 1
+	// ImageTypeRGBATexture identifies textures with full color that use the image key as OpenGL texture handle.
+	ImageTypeRGBATexture ImageType = 2
 )
 
 // TextureIDForSimpleTexture returns a TextureID with ImageTypeSimpleTexture.
@@ -17,6 +19,11 @@ func TextureIDForSimpleTexture(handle uint32) imgui.TextureID {
 	return imgui.TextureID(ImageTypeSimpleTexture)<<56 | imgui.TextureID(handle)
 }
 
+// TextureIDForRGBATexture returns a TextureID with ImageTypeRGBATexture.
+func TextureIDForRGBATexture(handle uint32) imgui.TextureID {
+	return imgui.TextureID(ImageTypeRGBATexture)<<56 | imgui.TextureID(handle)
+}
+
 // ImageTypeFromID returns the image type the given texture ID specifies.
 func ImageTypeFromID(id imgui.TextureID) ImageType {
 	return ImageType(id >> 56)
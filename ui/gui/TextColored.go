@@ -0,0 +1,10 @@
+package gui
+
+import "github.com/inkyblackness/imgui-go"
+
+// TextColored renders the given text in the given color.
+func TextColored(text string, color imgui.Vec4) {
+	imgui.PushStyleColor(imgui.StyleColorText, color)
+	imgui.Text(text)
+	imgui.PopStyleColor()
+}